@@ -0,0 +1,252 @@
+// Package alert checks a set of conditions at a regular interval and sends
+// notifications when they fire, so that problems with our node are found
+// without polling faraday. Conditions are checked for each node that faraday
+// is connected to, and can alert when:
+//   - A channel's peer has been offline for longer than a threshold
+//   - A channel is flagged by threshold close recommendations
+//   - The node's revenue over the last day is below a minimum
+//   - faraday's connection to lnd is lost
+//
+// Each alert is identified by its condition, node and subject, and alerts
+// with the same identity are not sent again until a cooldown period has
+// elapsed, so that conditions which persist do not flood the recipient.
+package alert
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Alert is a notification that a condition has fired.
+type Alert struct {
+	// Condition is the name of the condition that fired.
+	Condition string `json:"condition"`
+
+	// Node is the name of the node that the condition fired for, which is
+	// empty if faraday is connected to a single unnamed node.
+	Node string `json:"node,omitempty"`
+
+	// Subject identifies what the alert is about within the node, for
+	// example a channel outpoint.
+	Subject string `json:"subject"`
+
+	// Message is a human readable description of the alert.
+	Message string `json:"message"`
+
+	// Value is the value that caused the condition to fire.
+	Value float64 `json:"value"`
+
+	// Timestamp is the unix time that the condition fired at.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// key returns the identity of an alert, which is used to de-duplicate
+// alerts.
+func (a *Alert) key() string {
+	return fmt.Sprintf("%v/%v/%v", a.Condition, a.Node, a.Subject)
+}
+
+// Condition is a condition which is checked for alerts.
+type Condition struct {
+	// Name is the name of the condition.
+	Name string
+
+	// Node is the name of the node that the condition checks.
+	Node string
+
+	// Check returns an alert for each subject that the condition currently
+	// fires for. Alerts only need to set their subject, message and
+	// value; the remaining fields are set from the condition. The context
+	// provided is cancelled when the check times out, so that a hung call
+	// to lnd does not block our other checks.
+	Check func(ctx context.Context, now time.Time) ([]*Alert, error)
+}
+
+// Notifier sends an alert to its recipient.
+type Notifier func(alert *Alert) error
+
+// Config provides the conditions and notifiers used by the alert engine.
+type Config struct {
+	// Conditions is the set of conditions that we check.
+	Conditions []*Condition
+
+	// Notifiers is the set of notifiers that each alert is sent to.
+	Notifiers []Notifier
+
+	// Interval is the interval at which we check our conditions.
+	Interval time.Duration
+
+	// CheckTimeout is the maximum amount of time that each condition's
+	// check may take. If it is zero, our interval is used, and if both
+	// are zero checks do not time out.
+	CheckTimeout time.Duration
+
+	// Cooldown is the minimum amount of time between notifications for
+	// alerts with the same condition, node and subject.
+	Cooldown time.Duration
+
+	// Now returns the current time.
+	Now func() time.Time
+}
+
+// Engine checks a set of conditions at a regular interval and sends alerts
+// for the conditions that fire.
+type Engine struct {
+	cfg *Config
+
+	// sent maps the identity of alerts to the last time they were sent.
+	// It is only accessed by the engine's goroutine.
+	sent map[string]time.Time
+
+	// ctx is cancelled when the engine is stopped, so that checks which
+	// are in progress exit.
+	ctx    context.Context
+	cancel func()
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewEngine creates a new alert engine. Note that the engine returned does
+// not check conditions until it is started using Start().
+func NewEngine(cfg *Config) *Engine {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Engine{
+		cfg:    cfg,
+		sent:   make(map[string]time.Time),
+		ctx:    ctx,
+		cancel: cancel,
+		quit:   make(chan struct{}),
+	}
+}
+
+// Start starts checking our conditions.
+func (e *Engine) Start() {
+	log.Infof("Starting alert engine with %v conditions, checking "+
+		"every: %v", len(e.cfg.Conditions), e.cfg.Interval)
+
+	e.wg.Add(1)
+	go e.run()
+}
+
+// Stop stops the engine and waits for it to exit.
+func (e *Engine) Stop() {
+	e.cancel()
+	close(e.quit)
+	e.wg.Wait()
+}
+
+// run checks our conditions on startup, and then at each interval until the
+// engine is stopped.
+func (e *Engine) run() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		e.check()
+
+		select {
+		case <-ticker.C:
+
+		case <-e.quit:
+			return
+		}
+	}
+}
+
+// check checks each of our conditions, and sends the alerts that fire unless
+// they were sent within our cooldown period. It returns the alerts that were
+// sent.
+func (e *Engine) check() []*Alert {
+	now := e.cfg.Now()
+
+	// Forget alerts that were sent before our cooldown period, so that
+	// our set of sent alerts does not grow without bound.
+	for key, sent := range e.sent {
+		if now.Sub(sent) >= e.cfg.Cooldown {
+			delete(e.sent, key)
+		}
+	}
+
+	timeout := e.cfg.CheckTimeout
+	if timeout == 0 {
+		timeout = e.cfg.Interval
+	}
+
+	var sent []*Alert
+	for _, condition := range e.cfg.Conditions {
+		// We log errors rather than exiting, because lnd may be
+		// temporarily unavailable. Each check has its own timeout so
+		// that a hung lnd call does not prevent us from checking our
+		// other conditions, including our lnd connection.
+		ctx, cancel := e.checkContext(timeout)
+
+		alerts, err := condition.Check(ctx, now)
+		cancel()
+		if err != nil {
+			log.Errorf("could not check condition: %v for node: "+
+				"%v: %v", condition.Name, condition.Node, err)
+
+			continue
+		}
+
+		for _, alert := range alerts {
+			alert.Condition = condition.Name
+			alert.Node = condition.Node
+			alert.Timestamp = now.Unix()
+
+			key := alert.key()
+			if _, ok := e.sent[key]; ok {
+				log.Tracef("Alert: %v in cooldown", key)
+				continue
+			}
+
+			if !e.notify(alert) {
+				continue
+			}
+
+			e.sent[key] = now
+			sent = append(sent, alert)
+		}
+	}
+
+	return sent
+}
+
+// checkContext returns the context that a condition is checked with, which
+// times out after the period provided unless it is zero.
+func (e *Engine) checkContext(timeout time.Duration) (context.Context,
+	func()) {
+
+	if timeout == 0 {
+		return context.WithCancel(e.ctx)
+	}
+
+	return context.WithTimeout(e.ctx, timeout)
+}
+
+// notify sends an alert to each of our notifiers. It returns true if any
+// notifier sent the alert successfully, so that alerts which could not be
+// sent at all are retried at our next check.
+func (e *Engine) notify(alert *Alert) bool {
+	log.Infof("Alert: %v", alert.Message)
+
+	var notified bool
+	for _, notifier := range e.cfg.Notifiers {
+		if err := notifier(alert); err != nil {
+			log.Errorf("could not send alert: %v: %v",
+				alert.key(), err)
+
+			continue
+		}
+
+		notified = true
+	}
+
+	return notified
+}
@@ -0,0 +1,183 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestEngineCheck tests de-duplication and cooldown of alerts.
+func TestEngineCheck(t *testing.T) {
+	var (
+		start    = time.Unix(100000, 0)
+		now      = start
+		cooldown = time.Hour
+
+		// firing is the set of subjects that our condition currently
+		// fires for.
+		firing []string
+
+		// notifyErr is the error that our notifier returns.
+		notifyErr error
+
+		// notified is the set of alerts our notifier received.
+		notified []*Alert
+	)
+
+	engine := NewEngine(&Config{
+		Conditions: []*Condition{
+			{
+				Name: "test",
+				Node: "alice",
+				Check: func(_ context.Context, _ time.Time) (
+					[]*Alert, error) {
+
+					var alerts []*Alert
+					for _, subject := range firing {
+						alerts = append(alerts, &Alert{
+							Subject: subject,
+						})
+					}
+
+					return alerts, nil
+				},
+			},
+			{
+				Name: "failing",
+				Check: func(_ context.Context, _ time.Time) (
+					[]*Alert, error) {
+
+					return nil, errors.New("check failed")
+				},
+			},
+		},
+		Notifiers: []Notifier{
+			func(alert *Alert) error {
+				if notifyErr != nil {
+					return notifyErr
+				}
+
+				notified = append(notified, alert)
+				return nil
+			},
+		},
+		Cooldown: cooldown,
+		Now: func() time.Time {
+			return now
+		},
+	})
+
+	tests := []struct {
+		name      string
+		offset    time.Duration
+		firing    []string
+		notifyErr error
+		expected  []string
+	}{
+		{
+			name:   "nothing firing",
+			offset: 0,
+		},
+		{
+			name:     "alerts sent",
+			offset:   time.Minute,
+			firing:   []string{"a", "b"},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:   "duplicates in cooldown",
+			offset: time.Minute * 2,
+			firing: []string{"a", "b", "b"},
+		},
+		{
+			name:      "failed notifications retried",
+			offset:    time.Minute * 3,
+			firing:    []string{"c"},
+			notifyErr: errors.New("webhook down"),
+		},
+		{
+			name:     "new alert sent once",
+			offset:   time.Minute * 4,
+			firing:   []string{"a", "c", "c"},
+			expected: []string{"c"},
+		},
+		{
+			name:     "cooldown elapsed",
+			offset:   time.Minute + cooldown,
+			firing:   []string{"a", "b", "c"},
+			expected: []string{"a", "b"},
+		},
+	}
+
+	// Our tests share an engine, so they are run in order.
+	for _, test := range tests {
+		now = start.Add(test.offset)
+		firing = test.firing
+		notifyErr = test.notifyErr
+		notified = nil
+
+		sent := engine.check()
+
+		if len(sent) != len(test.expected) ||
+			len(notified) != len(test.expected) {
+
+			t.Fatalf("%v: expected: %v alerts, got: %v sent, %v "+
+				"notified", test.name, len(test.expected),
+				len(sent), len(notified))
+		}
+
+		for i, subject := range test.expected {
+			alert := notified[i]
+			if alert.Subject != subject {
+				t.Fatalf("%v: expected alert: %v, got: %v",
+					test.name, subject, alert.Subject)
+			}
+
+			if alert.Condition != "test" || alert.Node != "alice" ||
+				alert.Timestamp != now.Unix() {
+
+				t.Fatalf("%v: alert fields not set: %+v",
+					test.name, alert)
+			}
+		}
+	}
+}
+
+// TestEngineCheckTimeout tests that a check which does not return is timed
+// out, and that our remaining conditions are still checked.
+func TestEngineCheckTimeout(t *testing.T) {
+	engine := NewEngine(&Config{
+		Conditions: []*Condition{
+			{
+				Name: "hung",
+				Check: func(ctx context.Context, _ time.Time) (
+					[]*Alert, error) {
+
+					<-ctx.Done()
+					return nil, ctx.Err()
+				},
+			},
+			{
+				Name: "firing",
+				Check: func(_ context.Context, _ time.Time) (
+					[]*Alert, error) {
+
+					return []*Alert{{Subject: "a"}}, nil
+				},
+			},
+		},
+		Notifiers: []Notifier{
+			func(_ *Alert) error {
+				return nil
+			},
+		},
+		CheckTimeout: time.Millisecond * 10,
+		Now:          time.Now,
+	})
+
+	sent := engine.check()
+	if len(sent) != 1 || sent[0].Condition != "firing" {
+		t.Fatalf("expected firing alert, got: %v", sent)
+	}
+}
@@ -0,0 +1,293 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/faraday/recommend"
+	"github.com/lightninglabs/faraday/supervisor"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// The names of the conditions that alerts can be configured for.
+const (
+	PeerOfflineCondition   = "peer_offline"
+	ThresholdCondition     = "threshold"
+	DailyRevenueCondition  = "daily_revenue"
+	LndConnectionCondition = "lnd_connection"
+)
+
+// revenuePeriod is the period that daily revenue alerts check revenue over.
+const revenuePeriod = time.Hour * 24
+
+// ErrInvalidSpec is returned when an alert spec cannot be parsed.
+var ErrInvalidSpec = errors.New("invalid alert")
+
+// Spec describes a condition that we alert on, before it is created for a
+// node.
+type Spec struct {
+	// Condition is the name of the condition.
+	Condition string
+
+	// Duration is the amount of time a peer must be offline for peer
+	// offline alerts, and the amount of time lnd must be unavailable for
+	// lnd connection alerts.
+	Duration time.Duration
+
+	// Metric is the name of the metric that threshold alerts are based
+	// on.
+	Metric string
+
+	// Threshold is the value that a channel's metric must be below for
+	// threshold alerts.
+	Threshold float64
+
+	// MinRevenue is the minimum revenue that the node must earn over a day
+	// for daily revenue alerts.
+	MinRevenue btcutil.Amount
+}
+
+// ParseSpec parses an alert spec, expressed as one of:
+//   - peer_offline=<duration>
+//   - threshold=<metric>:<value>
+//   - daily_revenue=<minimum revenue in satoshis>
+//   - lnd_connection[=<duration>]
+func ParseSpec(spec string) (*Spec, error) {
+	parts := strings.SplitN(spec, "=", 2)
+
+	parsed := &Spec{
+		Condition: parts[0],
+	}
+
+	var value string
+	if len(parts) == 2 {
+		value = parts[1]
+	}
+
+	if value == "" && parsed.Condition != LndConnectionCondition {
+		return nil, fmt.Errorf("%w: %v requires a value",
+			ErrInvalidSpec, spec)
+	}
+
+	var err error
+	switch parsed.Condition {
+	case PeerOfflineCondition:
+		parsed.Duration, err = parseDuration(value)
+
+	case LndConnectionCondition:
+		if value != "" {
+			parsed.Duration, err = parseDuration(value)
+		}
+
+	case ThresholdCondition:
+		metricParts := strings.SplitN(value, ":", 2)
+		if len(metricParts) != 2 {
+			return nil, fmt.Errorf("%w: %v should be in the form "+
+				"threshold=<metric>:<value>", ErrInvalidSpec,
+				spec)
+		}
+
+		parsed.Metric = metricParts[0]
+		if _, err := recommend.GetMetric(parsed.Metric); err != nil {
+			return nil, err
+		}
+
+		parsed.Threshold, err = strconv.ParseFloat(metricParts[1], 64)
+
+	case DailyRevenueCondition:
+		var sats int64
+		sats, err = strconv.ParseInt(value, 10, 64)
+		if err == nil && sats < 0 {
+			err = errors.New("revenue must not be negative")
+		}
+		parsed.MinRevenue = btcutil.Amount(sats)
+
+	default:
+		return nil, fmt.Errorf("%w: unknown condition: %v",
+			ErrInvalidSpec, parsed.Condition)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v: %v", ErrInvalidSpec, spec, err)
+	}
+
+	return parsed, nil
+}
+
+// parseDuration parses a non-negative duration.
+func parseDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if duration < 0 {
+		return 0, errors.New("duration must not be negative")
+	}
+
+	return duration, nil
+}
+
+// PeerOffline returns a condition which fires for each channel whose peer
+// has been offline for at least the threshold provided. It takes a function
+// which returns our open channels, and a function which returns the amount
+// of time that a channel's peer has currently been offline for.
+func PeerOffline(threshold time.Duration,
+	channels func(ctx context.Context) ([]*lnrpc.Channel, error),
+	offline func(chanPoint string) (time.Duration, error)) *Condition {
+
+	return &Condition{
+		Name: PeerOfflineCondition,
+		Check: func(ctx context.Context, _ time.Time) ([]*Alert,
+			error) {
+
+			openChannels, err := channels(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			var alerts []*Alert
+			for _, channel := range openChannels {
+				offlineFor, err := offline(channel.ChannelPoint)
+				if err != nil {
+					return nil, err
+				}
+
+				if offlineFor == 0 || offlineFor < threshold {
+					continue
+				}
+
+				alerts = append(alerts, &Alert{
+					Subject: channel.ChannelPoint,
+					Message: fmt.Sprintf("peer %v of "+
+						"channel %v has been offline "+
+						"for %v", channel.RemotePubkey,
+						channel.ChannelPoint,
+						offlineFor),
+					Value: offlineFor.Seconds(),
+				})
+			}
+
+			return alerts, nil
+		},
+	}
+}
+
+// Threshold returns a condition which fires for each channel that is flagged
+// by threshold close recommendations for the metric provided. It takes a
+// function which returns the channels that are below the threshold, mapped
+// to their value for the metric.
+func Threshold(metric string, threshold float64,
+	flagged func(ctx context.Context) (map[string]float64,
+		error)) *Condition {
+
+	return &Condition{
+		Name: ThresholdCondition,
+		Check: func(ctx context.Context, _ time.Time) ([]*Alert,
+			error) {
+
+			channels, err := flagged(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			// Sort our channels so that alerts are sent in a
+			// consistent order.
+			chanPoints := make([]string, 0, len(channels))
+			for chanPoint := range channels {
+				chanPoints = append(chanPoints, chanPoint)
+			}
+			sort.Strings(chanPoints)
+
+			alerts := make([]*Alert, 0, len(channels))
+			for _, chanPoint := range chanPoints {
+				value := channels[chanPoint]
+				alerts = append(alerts, &Alert{
+					Subject: chanPoint,
+					Message: fmt.Sprintf("channel %v has "+
+						"%v: %v, below threshold: %v",
+						chanPoint, metric, value,
+						threshold),
+					Value: value,
+				})
+			}
+
+			return alerts, nil
+		},
+	}
+}
+
+// DailyRevenue returns a condition which fires when the fees that our node
+// earned over the last day are below the minimum provided. It takes a
+// function which returns the fees earned over a period.
+func DailyRevenue(minRevenue lnwire.MilliSatoshi,
+	fees func(ctx context.Context, start, end time.Time) (
+		lnwire.MilliSatoshi, error)) *Condition {
+
+	return &Condition{
+		Name: DailyRevenueCondition,
+		Check: func(ctx context.Context, now time.Time) ([]*Alert,
+			error) {
+
+			earned, err := fees(ctx, now.Add(-revenuePeriod), now)
+			if err != nil {
+				return nil, err
+			}
+
+			if earned >= minRevenue {
+				return nil, nil
+			}
+
+			return []*Alert{{
+				Subject: "revenue",
+				Message: fmt.Sprintf("earned %v over the last "+
+					"day, below minimum: %v", earned,
+					minRevenue),
+				Value: float64(earned),
+			}}, nil
+		},
+	}
+}
+
+// LndConnection returns a condition which fires when our connection to lnd
+// has not been ready for at least the grace period provided. It takes a
+// function which returns the status of our connection.
+func LndConnection(grace time.Duration,
+	status func() supervisor.Status) *Condition {
+
+	return &Condition{
+		Name: LndConnectionCondition,
+		Check: func(_ context.Context, now time.Time) ([]*Alert,
+			error) {
+
+			current := status()
+			if current.State == supervisor.StateReady {
+				return nil, nil
+			}
+
+			unavailable := now.Sub(current.Since)
+			if unavailable < grace {
+				return nil, nil
+			}
+
+			message := fmt.Sprintf("lnd connection %v for %v",
+				current.State, unavailable)
+			if current.Err != nil {
+				message = fmt.Sprintf("%v: %v", message,
+					current.Err)
+			}
+
+			return []*Alert{{
+				Subject: "lnd",
+				Message: message,
+				Value:   unavailable.Seconds(),
+			}}, nil
+		},
+	}
+}
@@ -0,0 +1,250 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/faraday/recommend"
+	"github.com/lightninglabs/faraday/supervisor"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestParseSpec tests parsing of alert specs.
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		expected    *Spec
+		expectedErr error
+	}{
+		{
+			name: "peer offline",
+			spec: "peer_offline=6h",
+			expected: &Spec{
+				Condition: PeerOfflineCondition,
+				Duration:  time.Hour * 6,
+			},
+		},
+		{
+			name:        "peer offline without duration",
+			spec:        "peer_offline",
+			expectedErr: ErrInvalidSpec,
+		},
+		{
+			name:        "negative duration",
+			spec:        "peer_offline=-1h",
+			expectedErr: ErrInvalidSpec,
+		},
+		{
+			name: "threshold",
+			spec: "threshold=uptime:0.8",
+			expected: &Spec{
+				Condition: ThresholdCondition,
+				Metric:    recommend.UptimeMetric,
+				Threshold: 0.8,
+			},
+		},
+		{
+			name:        "threshold without value",
+			spec:        "threshold=uptime",
+			expectedErr: ErrInvalidSpec,
+		},
+		{
+			name:        "threshold unknown metric",
+			spec:        "threshold=unknown:1",
+			expectedErr: recommend.ErrUnknownMetric,
+		},
+		{
+			name: "daily revenue",
+			spec: "daily_revenue=1000",
+			expected: &Spec{
+				Condition:  DailyRevenueCondition,
+				MinRevenue: 1000,
+			},
+		},
+		{
+			name:        "invalid revenue",
+			spec:        "daily_revenue=lots",
+			expectedErr: ErrInvalidSpec,
+		},
+		{
+			name: "lnd connection",
+			spec: "lnd_connection",
+			expected: &Spec{
+				Condition: LndConnectionCondition,
+			},
+		},
+		{
+			name: "lnd connection grace period",
+			spec: "lnd_connection=5m",
+			expected: &Spec{
+				Condition: LndConnectionCondition,
+				Duration:  time.Minute * 5,
+			},
+		},
+		{
+			name:        "unknown condition",
+			spec:        "unknown=1",
+			expectedErr: ErrInvalidSpec,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec, err := ParseSpec(test.spec)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected: %v, got: %v",
+					test.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(test.expected, spec) {
+				t.Fatalf("expected: %+v, got: %+v",
+					test.expected, spec)
+			}
+		})
+	}
+}
+
+// subjects returns the subjects of a set of alerts.
+func subjects(alerts []*Alert) []string {
+	var subjects []string
+	for _, alert := range alerts {
+		subjects = append(subjects, alert.Subject)
+	}
+
+	return subjects
+}
+
+// TestConditions tests checking of each of our conditions.
+func TestConditions(t *testing.T) {
+	now := time.Unix(100000, 0)
+
+	channels := func(_ context.Context) ([]*lnrpc.Channel, error) {
+		return []*lnrpc.Channel{
+			{ChannelPoint: "a:0"},
+			{ChannelPoint: "a:1"},
+			{ChannelPoint: "a:2"},
+		}, nil
+	}
+
+	offline := map[string]time.Duration{
+		"a:1": time.Hour,
+		"a:2": time.Hour * 7,
+	}
+
+	status := func(state supervisor.State,
+		since time.Duration) func() supervisor.Status {
+
+		return func() supervisor.Status {
+			return supervisor.Status{
+				State: state,
+				Since: now.Add(-since),
+				Err:   errors.New("connection refused"),
+			}
+		}
+	}
+
+	fees := func(_ context.Context, start, end time.Time) (
+		lnwire.MilliSatoshi, error) {
+
+		if end.Sub(start) != revenuePeriod {
+			return 0, errors.New("unexpected period")
+		}
+
+		return 5000, nil
+	}
+
+	tests := []struct {
+		name      string
+		condition *Condition
+		expected  []string
+	}{
+		{
+			name: "peer offline",
+			condition: PeerOffline(
+				time.Hour*6, channels,
+				func(chanPoint string) (time.Duration, error) {
+					return offline[chanPoint], nil
+				},
+			),
+			expected: []string{"a:2"},
+		},
+		{
+			name: "threshold",
+			condition: Threshold(
+				recommend.UptimeMetric, 0.8,
+				func(_ context.Context) (map[string]float64,
+					error) {
+
+					return map[string]float64{
+						"a:2": 0.5,
+						"a:1": 0.1,
+					}, nil
+				},
+			),
+			expected: []string{"a:1", "a:2"},
+		},
+		{
+			name:      "daily revenue above minimum",
+			condition: DailyRevenue(5000, fees),
+		},
+		{
+			name:      "daily revenue below minimum",
+			condition: DailyRevenue(5001, fees),
+			expected:  []string{"revenue"},
+		},
+		{
+			name: "lnd ready",
+			condition: LndConnection(
+				0, status(supervisor.StateReady, time.Hour),
+			),
+		},
+		{
+			name: "lnd unavailable in grace period",
+			condition: LndConnection(
+				time.Minute*5, status(
+					supervisor.StateDisconnected,
+					time.Minute,
+				),
+			),
+		},
+		{
+			name: "lnd unavailable",
+			condition: LndConnection(
+				time.Minute*5, status(
+					supervisor.StateDisconnected,
+					time.Minute*10,
+				),
+			),
+			expected: []string{"lnd"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			alerts, err := test.condition.Check(
+				context.Background(), now,
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(test.expected, subjects(alerts)) {
+				t.Fatalf("expected: %v, got: %v",
+					test.expected, subjects(alerts))
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"time"
+)
+
+// Webhook returns a notifier which POSTs each alert to a http endpoint as a
+// json object. Responses with a status code outside of the 2xx range are
+// treated as failures.
+func Webhook(endpoint string, timeout time.Duration) Notifier {
+	client := &http.Client{
+		Timeout: timeout,
+	}
+
+	return func(alert *Alert) error {
+		body, err := json.Marshal(alert)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Post(
+			endpoint, "application/json", bytes.NewReader(body),
+		)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook returned: %v", resp.Status)
+		}
+
+		return nil
+	}
+}
+
+// Command returns a notifier which runs a local command for each alert, with
+// the alert provided as a json object on its standard input. Commands which
+// exit with a non-zero status, or do not complete within the timeout
+// provided, are treated as failures.
+func Command(path string, timeout time.Duration) Notifier {
+	return func(alert *Alert) error {
+		body, err := json.Marshal(alert)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(
+			context.Background(), timeout,
+		)
+		defer cancel()
+
+		cmd := exec.CommandContext(ctx, path)
+		cmd.Stdin = bytes.NewReader(body)
+
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("alert command failed: %v: %s", err,
+				output)
+		}
+
+		return nil
+	}
+}
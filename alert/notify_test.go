@@ -0,0 +1,111 @@
+package alert
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testAlert is the alert that our notifier tests send.
+var testAlert = &Alert{
+	Condition: PeerOfflineCondition,
+	Node:      "alice",
+	Subject:   "a:1",
+	Message:   "peer offline",
+	Value:     3600,
+	Timestamp: 100000,
+}
+
+// TestWebhook tests posting alerts to a webhook.
+func TestWebhook(t *testing.T) {
+	var received *Alert
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				http.Error(w, "post required",
+					http.StatusMethodNotAllowed)
+				return
+			}
+
+			received = &Alert{}
+			err := json.NewDecoder(r.Body).Decode(received)
+			if err != nil {
+				http.Error(w, err.Error(),
+					http.StatusBadRequest)
+				return
+			}
+
+			if received.Node == "fail" {
+				http.Error(w, "failed",
+					http.StatusInternalServerError)
+			}
+		},
+	))
+	defer server.Close()
+
+	notify := Webhook(server.URL, time.Second*5)
+
+	if err := notify(testAlert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(testAlert, received) {
+		t.Fatalf("expected: %+v, got: %+v", testAlert, received)
+	}
+
+	failing := *testAlert
+	failing.Node = "fail"
+	if err := notify(&failing); err == nil {
+		t.Fatalf("expected error for failed response")
+	}
+}
+
+// TestCommand tests running a local command for alerts.
+func TestCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "alert")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Write a script which saves the alert it receives to a file.
+	output := filepath.Join(dir, "alert.json")
+	script := filepath.Join(dir, "notify.sh")
+	contents := "#!/bin/sh\ncat > " + output + "\n"
+
+	err = ioutil.WriteFile(script, []byte(contents), 0700)
+	if err != nil {
+		t.Fatalf("could not write script: %v", err)
+	}
+
+	if err := Command(script, time.Second*5)(testAlert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatalf("could not read output: %v", err)
+	}
+
+	received := &Alert{}
+	if err := json.Unmarshal(b, received); err != nil {
+		t.Fatalf("could not decode output: %v", err)
+	}
+
+	if !reflect.DeepEqual(testAlert, received) {
+		t.Fatalf("expected: %+v, got: %+v", testAlert, received)
+	}
+
+	// A command which exits with a non-zero status fails.
+	err = Command("false", time.Second*5)(testAlert)
+	if err == nil {
+		t.Fatalf("expected error for failed command")
+	}
+}
@@ -0,0 +1,164 @@
+package faraday
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/faraday/alert"
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// defaultAlertTimeout is the amount of time we allow webhooks and alert
+// commands to complete.
+const defaultAlertTimeout = time.Second * 30
+
+// liveNode is a lnd node that faraday is connected to. It includes the
+// functions that alerts use to check the node, in addition to those that our
+// rpc server uses.
+type liveNode struct {
+	*frdrpc.Node
+
+	// peerOffline returns the amount of time that a channel's peer has
+	// currently been offline for.
+	peerOffline func(chanPoint string) (time.Duration, error)
+}
+
+// startAlerts creates the alert conditions in our config for each of our
+// nodes, and starts an alert engine which checks them. Threshold and revenue
+// conditions are checked with our rpc server's handlers, so that alerts use
+// the same calculations as requests. It returns a function which stops the
+// engine.
+func startAlerts(config *config, nodes []*liveNode,
+	server *frdrpc.RPCServer) func() {
+
+	var notifiers []alert.Notifier
+	if config.AlertWebhook != "" {
+		notifiers = append(notifiers, alert.Webhook(
+			config.AlertWebhook, defaultAlertTimeout,
+		))
+	}
+
+	if config.AlertCommand != "" {
+		notifiers = append(notifiers, alert.Command(
+			config.AlertCommand, defaultAlertTimeout,
+		))
+	}
+
+	var conditions []*alert.Condition
+	for _, node := range nodes {
+		for _, spec := range config.alerts {
+			condition := alertCondition(
+				config, spec, node, server,
+			)
+			condition.Node = node.Name
+
+			conditions = append(conditions, condition)
+		}
+	}
+
+	engine := alert.NewEngine(&alert.Config{
+		Conditions: conditions,
+		Notifiers:  notifiers,
+		Interval:   config.AlertInterval,
+		Cooldown:   config.AlertCooldown,
+		Now:        time.Now,
+	})
+	engine.Start()
+
+	return engine.Stop
+}
+
+// alertCondition creates the condition described by an alert spec for a
+// node.
+func alertCondition(config *config, spec *alert.Spec, node *liveNode,
+	server *frdrpc.RPCServer) *alert.Condition {
+
+	switch spec.Condition {
+	case alert.PeerOfflineCondition:
+		channels := func(ctx context.Context) ([]*lnrpc.Channel,
+			error) {
+
+			resp, err := node.LightningClient.ListChannels(
+				ctx, &lnrpc.ListChannelsRequest{},
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return resp.Channels, nil
+		}
+
+		return alert.PeerOffline(
+			spec.Duration, channels, node.peerOffline,
+		)
+
+	case alert.ThresholdCondition:
+		// The rpc server does not default the minimum monitored
+		// period, so we set it from our config.
+		req := &frdrpc.ThresholdRecommendationsRequest{
+			RecRequest: &frdrpc.CloseRecommendationRequest{
+				MinimumMonitored: int64(
+					config.MinimumMonitored.Seconds(),
+				),
+				MetricName: spec.Metric,
+				Node:       node.Name,
+			},
+			ThresholdValue: float32(spec.Threshold),
+		}
+
+		flagged := func(ctx context.Context) (map[string]float64,
+			error) {
+
+			resp, err := server.ThresholdRecommendations(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			channels := make(map[string]float64)
+			for _, rec := range resp.Recommendations {
+				if rec.RecommendClose {
+					channels[rec.ChanPoint] =
+						float64(rec.Value)
+				}
+			}
+
+			return channels, nil
+		}
+
+		return alert.Threshold(spec.Metric, spec.Threshold, flagged)
+
+	case alert.DailyRevenueCondition:
+		fees := func(ctx context.Context, start, end time.Time) (
+			lnwire.MilliSatoshi, error) {
+
+			resp, err := server.RevenueReport(
+				ctx, &frdrpc.RevenueReportRequest{
+					StartTime: uint64(start.Unix()),
+					EndTime:   uint64(end.Unix()),
+					Node:      node.Name,
+				},
+			)
+			if err != nil {
+				return 0, err
+			}
+
+			return lnwire.MilliSatoshi(resp.TotalFeesMsat), nil
+		}
+
+		return alert.DailyRevenue(
+			lnwire.NewMSatFromSatoshis(spec.MinRevenue), fees,
+		)
+
+	case alert.LndConnectionCondition:
+		return alert.LndConnection(spec.Duration, node.Status)
+
+	default:
+		// Specs are validated when our config is parsed, so we
+		// should not reach this point.
+		panic(fmt.Sprintf("unknown alert condition: %v",
+			spec.Condition))
+	}
+}
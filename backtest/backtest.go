@@ -0,0 +1,511 @@
+// Package backtest replays forwarding history and channel snapshots up to a
+// date in the past to assess how a close recommendation strategy would have
+// performed. Each strategy is run against the set of channels that were open
+// at the date provided, using only the forwards that had occurred by then.
+// The revenue that flagged and kept channels earned after that date is then
+// used to assess whether the strategy made good calls.
+//
+// Peer uptime is not available for past dates, so each channel's current
+// ratio of uptime to time monitored is applied to the period it was open for
+// at the backtest date. Channels that we have no uptime information for are
+// treated as unmonitored. Balance history is not available for past dates,
+// so metrics based on it cannot be backtested.
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/faraday/insights"
+	"github.com/lightninglabs/faraday/recommend"
+	"github.com/lightninglabs/faraday/revenue"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// ErrNoStrategies is returned when a backtest is requested without
+	// any strategies.
+	ErrNoStrategies = errors.New("at least one strategy required for " +
+		"backtest")
+
+	// ErrInvalidPeriod is returned when the date that we backtest from is
+	// not before the end of the backtest period.
+	ErrInvalidPeriod = errors.New("backtest date must be before end time")
+
+	// ErrUnknownStrategy is returned when a strategy with an unknown type
+	// is provided.
+	ErrUnknownStrategy = errors.New("unknown strategy type")
+
+	// ErrUnsupportedMetric is returned when a strategy uses a metric that
+	// we cannot reconstruct for past dates.
+	ErrUnsupportedMetric = errors.New("metric cannot be backtested")
+
+	// unsupportedMetrics is the set of metrics that depend on data that
+	// we do not have for past dates.
+	unsupportedMetrics = map[string]bool{
+		recommend.BalanceMetric: true,
+	}
+)
+
+// Channel is a snapshot of a channel that we have had open at some point.
+type Channel struct {
+	// ChannelPoint is the outpoint of the channel's funding transaction.
+	ChannelPoint string
+
+	// ChannelID is the short channel ID of the channel, which contains the
+	// height that the channel was opened at.
+	ChannelID uint64
+
+	// Capacity is the total capacity of the channel.
+	Capacity btcutil.Amount
+
+	// CloseHeight is the height that the channel was closed at. This
+	// value is zero if the channel is still open.
+	CloseHeight uint32
+
+	// Private indicates whether the channel is private.
+	Private bool
+
+	// UptimeKnown indicates whether we have uptime information for the
+	// channel's peer.
+	UptimeKnown bool
+
+	// UptimeRatio is the ratio of the time that the channel's peer has
+	// been online to the time it has been monitored for. This value is
+	// only set if UptimeKnown is true.
+	UptimeRatio float64
+}
+
+// openHeight returns the height at which the channel was opened.
+func (c *Channel) openHeight() uint32 {
+	return lnwire.NewShortChanIDFromInt(c.ChannelID).BlockHeight
+}
+
+// openAt returns a boolean indicating whether the channel was open at the
+// height provided.
+func (c *Channel) openAt(height uint32) bool {
+	if c.openHeight() > height {
+		return false
+	}
+
+	return c.CloseHeight == 0 || c.CloseHeight > height
+}
+
+// closedAt returns a boolean indicating whether the channel had been closed
+// by the height provided.
+func (c *Channel) closedAt(height uint32) bool {
+	return c.CloseHeight != 0 && c.CloseHeight <= height
+}
+
+// ChainTime provides an estimate of the mapping between block heights and
+// times, based on a known height and time and an expected block interval.
+type ChainTime struct {
+	// Height is a known block height.
+	Height uint32
+
+	// Time is the time at which our known block height was reached.
+	Time time.Time
+
+	// BlockInterval is the expected time between blocks.
+	BlockInterval time.Duration
+}
+
+// HeightAt returns the estimated block height at the time provided.
+func (c *ChainTime) HeightAt(t time.Time) uint32 {
+	blocks := int64(t.Sub(c.Time) / c.BlockInterval)
+
+	height := int64(c.Height) + blocks
+	if height < 0 {
+		return 0
+	}
+
+	return uint32(height)
+}
+
+// TimeAt returns the estimated time at which the height provided was reached.
+func (c *ChainTime) TimeAt(height uint32) time.Time {
+	blocks := int64(height) - int64(c.Height)
+	return c.Time.Add(time.Duration(blocks) * c.BlockInterval)
+}
+
+// Config provides the functions and parameters required to run a backtest.
+type Config struct {
+	// Channels returns a snapshot of all of our open and closed channels.
+	Channels func() ([]*Channel, error)
+
+	// ForwardingHistory returns paginated forwarding history results
+	// for the period [start, end], expressed in unix seconds.
+	ForwardingHistory func(start, end uint64, offset,
+		maxEvents uint32) ([]*lnrpc.ForwardingEvent, uint32, error)
+
+	// Chain is used to estimate block heights for past dates.
+	Chain *ChainTime
+}
+
+// StrategyType indicates the type of close recommendations a strategy uses.
+type StrategyType int
+
+const (
+	// OutlierStrategy recommends closing channels that are lower
+	// outliers for the strategy's metric.
+	OutlierStrategy StrategyType = iota
+
+	// ThresholdStrategy recommends closing channels that are below a
+	// threshold for the strategy's metric.
+	ThresholdStrategy
+)
+
+// Strategy describes a close recommendation strategy to backtest.
+type Strategy struct {
+	// Name is a label for the strategy.
+	Name string
+
+	// Type is the type of close recommendations to use.
+	Type StrategyType
+
+	// Metric is the metric that recommendations are based on.
+	Metric string
+
+	// Value is the outlier multiplier for outlier strategies, or the
+	// threshold for threshold strategies.
+	Value float64
+}
+
+// Request contains the parameters for a backtest.
+type Request struct {
+	// Strategies is the set of strategies to backtest.
+	Strategies []*Strategy
+
+	// AsOf is the date at which we run our strategies. Only forwards that
+	// happened before this date are used to produce recommendations.
+	AsOf time.Time
+
+	// EndTime is the end of the period that we assess our strategies'
+	// recommendations over.
+	EndTime time.Time
+
+	// MinimumMonitored is the minimum amount of time that a channel must
+	// have been open for at our backtest date to be considered for close.
+	// Channels that we have no uptime information for are not considered
+	// if this value is set.
+	MinimumMonitored time.Duration
+
+	// OpportunityCostRate is the annual rate of return used to calculate
+	// the opportunity cost of channel capital for strategies that use the
+	// net value metric.
+	OpportunityCostRate float64
+}
+
+// Result contains the outcome of backtesting a single strategy.
+type Result struct {
+	// Strategy is the strategy that was backtested.
+	Strategy *Strategy
+
+	// TotalChannels is the number of channels that were open at the
+	// backtest date.
+	TotalChannels int
+
+	// ConsideredChannels is the number of channels that were eligible for
+	// close at the backtest date.
+	ConsideredChannels int
+
+	// FlaggedChannels is the number of channels that the strategy
+	// recommended closing.
+	FlaggedChannels int
+
+	// KeptChannels is the number of channels that the strategy did not
+	// recommend closing.
+	KeptChannels int
+
+	// FlaggedFees is the total fees that flagged channels earned after
+	// the backtest date.
+	FlaggedFees lnwire.MilliSatoshi
+
+	// KeptFees is the total fees that kept channels earned after the
+	// backtest date.
+	KeptFees lnwire.MilliSatoshi
+
+	// FlaggedIdle is the number of flagged channels that earned no fees
+	// after the backtest date.
+	FlaggedIdle int
+
+	// KeptIdle is the number of kept channels that earned no fees after
+	// the backtest date.
+	KeptIdle int
+}
+
+// MeanFlaggedFees returns the average fees earned by flagged channels after
+// the backtest date.
+func (r *Result) MeanFlaggedFees() float64 {
+	if r.FlaggedChannels == 0 {
+		return 0
+	}
+
+	return float64(r.FlaggedFees) / float64(r.FlaggedChannels)
+}
+
+// MeanKeptFees returns the average fees earned by kept channels after the
+// backtest date.
+func (r *Result) MeanKeptFees() float64 {
+	if r.KeptChannels == 0 {
+		return 0
+	}
+
+	return float64(r.KeptFees) / float64(r.KeptChannels)
+}
+
+// Report contains the results of a backtest for a set of strategies.
+type Report struct {
+	// AsOf is the date that strategies were run at.
+	AsOf time.Time
+
+	// EndTime is the end of the period that recommendations were
+	// assessed over.
+	EndTime time.Time
+
+	// Results contains a result for each strategy, in the order that the
+	// strategies were provided.
+	Results []*Result
+}
+
+// Run backtests each of the strategies in the request and returns a report
+// comparing their performance.
+func Run(cfg *Config, req *Request) (*Report, error) {
+	if len(req.Strategies) == 0 {
+		return nil, ErrNoStrategies
+	}
+
+	if !req.AsOf.Before(req.EndTime) {
+		return nil, ErrInvalidPeriod
+	}
+
+	for _, strategy := range req.Strategies {
+		if unsupportedMetrics[strategy.Metric] {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedMetric,
+				strategy.Metric)
+		}
+	}
+
+	channels, err := cfg.Channels()
+	if err != nil {
+		return nil, err
+	}
+
+	height := cfg.Chain.HeightAt(req.AsOf)
+
+	// Create rpc channels for all of our channels so that forwards can be
+	// attributed to any of them, and a set of rpc channels that were open
+	// at the backtest date. Channels that had already closed by our
+	// backtest date are only used to attribute forwards, so we create
+	// close summaries for them rather than giving them uptime that they
+	// did not have at the backtest date.
+	var (
+		allChannels, openChannels []*lnrpc.Channel
+		closedChannels            []*lnrpc.ChannelCloseSummary
+	)
+	for _, channel := range channels {
+		if channel.closedAt(height) {
+			closedChannels = append(
+				closedChannels, &lnrpc.ChannelCloseSummary{
+					ChannelPoint: channel.ChannelPoint,
+					ChanId:       channel.ChannelID,
+					CloseHeight:  channel.CloseHeight,
+				},
+			)
+
+			continue
+		}
+
+		rpcChannel := getRPCChannel(cfg.Chain, channel, req.AsOf)
+		allChannels = append(allChannels, rpcChannel)
+
+		if channel.openAt(height) {
+			openChannels = append(openChannels, rpcChannel)
+		}
+	}
+
+	// Get the revenue our channels produced before our backtest date and
+	// produce the channel insights that our strategies would have had.
+	// Forwarding history queries include both their start and end time,
+	// so we end this period a second before our backtest date so that
+	// forwards at the backtest date are only counted after it.
+	before, err := getRevenue(
+		cfg, allChannels, closedChannels, 0,
+		req.AsOf.Add(-time.Second),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	channelInsights, err := insights.GetChannels(&insights.Config{
+		OpenChannels: func() ([]*lnrpc.Channel, error) {
+			return openChannels, nil
+		},
+		CurrentHeight: func() (uint32, error) {
+			return height, nil
+		},
+		RevenueReport: before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the revenue that our channels produced after the backtest date
+	// so that we can assess the recommendations.
+	after, err := getRevenue(
+		cfg, allChannels, closedChannels, uint64(req.AsOf.Unix()),
+		req.EndTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		AsOf:    req.AsOf,
+		EndTime: req.EndTime,
+		Results: make([]*Result, 0, len(req.Strategies)),
+	}
+
+	for _, strategy := range req.Strategies {
+		result, err := runStrategy(strategy, channelInsights, after, req)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
+
+// runStrategy gets recommendations for a strategy using the set of insights
+// provided and assesses them against the revenue report provided.
+func runStrategy(strategy *Strategy, channels []*insights.ChannelInfo,
+	after *revenue.Report, req *Request) (*Result, error) {
+
+	recCfg := &recommend.CloseRecommendationConfig{
+		ChannelInsights: func() ([]*insights.ChannelInfo, error) {
+			return channels, nil
+		},
+		Metric:              strategy.Metric,
+		MinimumMonitored:    req.MinimumMonitored,
+		OpportunityCostRate: req.OpportunityCostRate,
+	}
+
+	var (
+		recs *recommend.Report
+		err  error
+	)
+
+	switch strategy.Type {
+	case OutlierStrategy:
+		recs, err = recommend.OutlierRecommendations(
+			recCfg, strategy.Value,
+		)
+
+	case ThresholdStrategy:
+		recs, err = recommend.ThresholdRecommendations(
+			recCfg, strategy.Value,
+		)
+
+	default:
+		return nil, ErrUnknownStrategy
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Strategy:           strategy,
+		TotalChannels:      recs.TotalChannels,
+		ConsideredChannels: recs.ConsideredChannels,
+	}
+
+	for chanPoint, rec := range recs.Recommendations {
+		fees := feesEarned(after, chanPoint)
+
+		if rec.RecommendClose {
+			result.FlaggedChannels++
+			result.FlaggedFees += fees
+			if fees == 0 {
+				result.FlaggedIdle++
+			}
+
+			continue
+		}
+
+		result.KeptChannels++
+		result.KeptFees += fees
+		if fees == 0 {
+			result.KeptIdle++
+		}
+	}
+
+	log.Debugf("strategy %v flagged %v/%v channels", strategy.Name,
+		result.FlaggedChannels, result.ConsideredChannels)
+
+	return result, nil
+}
+
+// getRPCChannel creates a rpc channel for a channel snapshot as it would have
+// been at the date provided. We do not have uptime information for the past,
+// so we apply the channel's uptime ratio to the period that it had been open
+// for at the date provided. Channels without uptime information are given no
+// lifetime, so that they are reported as unmonitored.
+func getRPCChannel(chain *ChainTime, channel *Channel,
+	asOf time.Time) *lnrpc.Channel {
+
+	var lifetime time.Duration
+	openTime := chain.TimeAt(channel.openHeight())
+	if channel.UptimeKnown && asOf.After(openTime) {
+		lifetime = asOf.Sub(openTime)
+	}
+
+	uptime := time.Duration(float64(lifetime) * channel.UptimeRatio)
+
+	return &lnrpc.Channel{
+		ChannelPoint: channel.ChannelPoint,
+		ChanId:       channel.ChannelID,
+		Capacity:     int64(channel.Capacity),
+		Private:      channel.Private,
+		Lifetime:     int64(lifetime.Seconds()),
+		Uptime:       int64(uptime.Seconds()),
+	}
+}
+
+// getRevenue produces a revenue report for the period provided.
+func getRevenue(cfg *Config, channels []*lnrpc.Channel,
+	closed []*lnrpc.ChannelCloseSummary, start uint64,
+	end time.Time) (*revenue.Report, error) {
+
+	return revenue.GetRevenueReport(&revenue.Config{
+		ListChannels: func() ([]*lnrpc.Channel, error) {
+			return channels, nil
+		},
+		ClosedChannels: func() ([]*lnrpc.ChannelCloseSummary, error) {
+			return closed, nil
+		},
+		ForwardingHistory: func(offset, maxEvents uint32) (
+			[]*lnrpc.ForwardingEvent, uint32, error) {
+
+			return cfg.ForwardingHistory(
+				start, uint64(end.Unix()), offset, maxEvents,
+			)
+		},
+	})
+}
+
+// feesEarned returns the fees earned by a channel in a revenue report. Fees
+// are split evenly between the incoming and outgoing channel, so that they
+// are not double counted.
+func feesEarned(report *revenue.Report, chanPoint string) lnwire.MilliSatoshi {
+	var fees lnwire.MilliSatoshi
+	for _, rev := range report.ChannelPairs[chanPoint] {
+		fees += (rev.FeesOutgoing + rev.FeesIncoming) / 2
+	}
+
+	return fees
+}
@@ -0,0 +1,303 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/faraday/recommend"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestChainTime tests estimation of heights and times.
+func TestChainTime(t *testing.T) {
+	chain := &ChainTime{
+		Height:        1000,
+		Time:          time.Unix(1000000, 0),
+		BlockInterval: time.Minute * 10,
+	}
+
+	tests := []struct {
+		name   string
+		height uint32
+		time   time.Time
+	}{
+		{
+			name:   "known height",
+			height: 1000,
+			time:   time.Unix(1000000, 0),
+		},
+		{
+			name:   "past height",
+			height: 990,
+			time:   time.Unix(1000000-6000, 0),
+		},
+		{
+			name:   "future height",
+			height: 1010,
+			time:   time.Unix(1000000+6000, 0),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			height := chain.HeightAt(test.time)
+			if height != test.height {
+				t.Fatalf("expected height: %v, got: %v",
+					test.height, height)
+			}
+
+			blockTime := chain.TimeAt(test.height)
+			if !blockTime.Equal(test.time) {
+				t.Fatalf("expected time: %v, got: %v",
+					test.time, blockTime)
+			}
+		})
+	}
+}
+
+// TestRun tests backtesting of strategies against a set of channels and
+// forwards.
+func TestRun(t *testing.T) {
+	chain := &ChainTime{
+		Height:        1000,
+		Time:          time.Unix(1000000, 0),
+		BlockInterval: time.Minute * 10,
+	}
+
+	// newChannel creates a channel snapshot for a channel opened at the
+	// height provided. The channel's funding output index is used as its
+	// transaction index so that each channel has a unique short channel
+	// id.
+	newChannel := func(index, openHeight, closeHeight uint32,
+		uptime float64) *Channel {
+
+		return &Channel{
+			ChannelPoint: fmt.Sprintf("a:%v", index),
+			ChannelID: lnwire.ShortChannelID{
+				BlockHeight: openHeight,
+				TxIndex:     index,
+			}.ToUint64(),
+			CloseHeight: closeHeight,
+			UptimeKnown: true,
+			UptimeRatio: uptime,
+		}
+	}
+
+	var (
+		asOf = chain.TimeAt(900)
+
+		chan1 = newChannel(1, 100, 0, 1)
+		chan2 = newChannel(2, 100, 0, 0.2)
+		chan3 = newChannel(3, 100, 0, 0.1)
+
+		channels = []*Channel{
+			chan1, chan2, chan3,
+			// A channel that closed before the backtest date.
+			newChannel(4, 100, 500, 0.1),
+			// A channel that opened after the backtest date.
+			newChannel(5, 950, 0, 0.1),
+			// A channel that we have no uptime information for.
+			{
+				ChannelPoint: "a:6",
+				ChannelID: lnwire.ShortChannelID{
+					BlockHeight: 100,
+					TxIndex:     6,
+				}.ToUint64(),
+			},
+		}
+
+		// forwards contains a forward at our backtest date from
+		// channel 3 to channel 1, which should only be counted after
+		// the backtest date, and a forward after our backtest date
+		// from channel 2 to channel 1.
+		forwards = []*lnrpc.ForwardingEvent{
+			{
+				Timestamp:  uint64(asOf.Unix()),
+				ChanIdIn:   chan3.ChannelID,
+				ChanIdOut:  chan1.ChannelID,
+				AmtInMsat:  2200,
+				AmtOutMsat: 2000,
+			},
+			{
+				Timestamp:  uint64(asOf.Unix() + 100),
+				ChanIdIn:   chan2.ChannelID,
+				ChanIdOut:  chan1.ChannelID,
+				AmtInMsat:  1100,
+				AmtOutMsat: 1000,
+			},
+		}
+
+		uptimeStrategy = &Strategy{
+			Name:   "uptime",
+			Type:   ThresholdStrategy,
+			Metric: recommend.UptimeMetric,
+			Value:  0.5,
+		}
+
+		revenueStrategy = &Strategy{
+			Name:   "revenue",
+			Type:   ThresholdStrategy,
+			Metric: recommend.RevenueMetric,
+			Value:  0.001,
+		}
+
+		balanceStrategy = &Strategy{
+			Name:   "balance",
+			Type:   ThresholdStrategy,
+			Metric: recommend.BalanceMetric,
+			Value:  0.5,
+		}
+	)
+
+	tests := []struct {
+		name           string
+		request        *Request
+		expectedReport *Report
+		expectedErr    error
+	}{
+		{
+			name: "no strategies",
+			request: &Request{
+				AsOf:    asOf,
+				EndTime: chain.Time,
+			},
+			expectedErr: ErrNoStrategies,
+		},
+		{
+			name: "invalid period",
+			request: &Request{
+				Strategies: []*Strategy{uptimeStrategy},
+				AsOf:       chain.Time,
+				EndTime:    asOf,
+			},
+			expectedErr: ErrInvalidPeriod,
+		},
+		{
+			name: "unknown strategy",
+			request: &Request{
+				Strategies: []*Strategy{
+					{Type: 3},
+				},
+				AsOf:             asOf,
+				EndTime:          chain.Time,
+				MinimumMonitored: time.Hour,
+			},
+			expectedErr: ErrUnknownStrategy,
+		},
+		{
+			name: "unsupported metric",
+			request: &Request{
+				Strategies: []*Strategy{
+					uptimeStrategy, balanceStrategy,
+				},
+				AsOf:    asOf,
+				EndTime: chain.Time,
+			},
+			expectedErr: ErrUnsupportedMetric,
+		},
+		{
+			name: "uptime threshold",
+			request: &Request{
+				Strategies:       []*Strategy{uptimeStrategy},
+				AsOf:             asOf,
+				EndTime:          chain.Time,
+				MinimumMonitored: time.Hour,
+			},
+			expectedReport: &Report{
+				AsOf:    asOf,
+				EndTime: chain.Time,
+				Results: []*Result{
+					{
+						Strategy:           uptimeStrategy,
+						TotalChannels:      4,
+						ConsideredChannels: 3,
+						FlaggedChannels:    2,
+						KeptChannels:       1,
+						FlaggedFees:        150,
+						KeptFees:           150,
+						FlaggedIdle:        0,
+						KeptIdle:           0,
+					},
+				},
+			},
+		},
+		{
+			name: "revenue threshold",
+			request: &Request{
+				Strategies:       []*Strategy{revenueStrategy},
+				AsOf:             asOf,
+				EndTime:          chain.Time,
+				MinimumMonitored: time.Hour,
+			},
+			expectedReport: &Report{
+				AsOf:    asOf,
+				EndTime: chain.Time,
+				Results: []*Result{
+					{
+						Strategy:           revenueStrategy,
+						TotalChannels:      4,
+						ConsideredChannels: 3,
+						FlaggedChannels:    3,
+						KeptChannels:       0,
+						FlaggedFees:        300,
+						KeptFees:           0,
+						FlaggedIdle:        0,
+						KeptIdle:           0,
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &Config{
+				Channels: func() ([]*Channel, error) {
+					return channels, nil
+				},
+				ForwardingHistory: func(start, end uint64,
+					offset, maxEvents uint32) (
+					[]*lnrpc.ForwardingEvent, uint32,
+					error) {
+
+					var events []*lnrpc.ForwardingEvent
+					for _, fwd := range forwards {
+						if fwd.Timestamp < start ||
+							fwd.Timestamp > end {
+
+							continue
+						}
+
+						events = append(events, fwd)
+					}
+
+					return events, 0, nil
+				},
+				Chain: chain,
+			}
+
+			report, err := Run(cfg, test.request)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected: %v, got: %v",
+					test.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(test.expectedReport, report) {
+				t.Fatalf("expected: %+v, got: %+v",
+					test.expectedReport, report)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package closer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileAuditor appends audit entries to a file, with each entry written as a
+// single line of json.
+type FileAuditor struct {
+	file *os.File
+	mtx  sync.Mutex
+}
+
+// NewFileAuditor opens the audit log at the path provided, creating it and
+// its parent directories if they do not exist.
+func NewFileAuditor(path string) (*FileAuditor, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(
+		path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileAuditor{
+		file: file,
+	}, nil
+}
+
+// Audit appends an entry to the audit log.
+func (f *FileAuditor) Audit(entry *AuditEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if _, err := f.file.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	return f.file.Sync()
+}
+
+// Close closes the audit log file.
+func (f *FileAuditor) Close() error {
+	return f.file.Close()
+}
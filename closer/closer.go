@@ -0,0 +1,335 @@
+// Package closer closes a set of channels that have been selected for closure,
+// typically from a close recommendation report. Before any channel is closed,
+// it is checked against our current set of open and pending channels so that
+// stale recommendations do not result in unexpected closes.
+//
+// Channels are closed cooperatively when their peer is online. Channels with
+// offline peers are skipped unless force closes are explicitly allowed. No
+// channels are closed unless the request is confirmed; unconfirmed requests
+// return the set of actions that would be taken.
+package closer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+var (
+	// ErrNoChannels is returned when a close request does not contain any
+	// channels.
+	ErrNoChannels = errors.New("at least one channel required for close")
+
+	// ErrFeeAndTarget is returned when a close request sets both a target
+	// confirmation and a fee rate.
+	ErrFeeAndTarget = errors.New("only one of target confirmations and " +
+		"fee rate may be set")
+
+	// ErrAuditUnavailable is returned when a confirmed close request is
+	// made without an audit log to record closes in.
+	ErrAuditUnavailable = errors.New("channels cannot be closed without " +
+		"an audit log")
+)
+
+// Action describes the action taken for a channel that was requested for
+// close.
+type Action int
+
+const (
+	// ActionSkip indicates that the channel was not closed. The result's
+	// reason will indicate why the channel was skipped.
+	ActionSkip Action = iota
+
+	// ActionCooperative indicates that the channel was cooperatively
+	// closed with its online peer.
+	ActionCooperative
+
+	// ActionForce indicates that the channel was force closed because its
+	// peer was offline.
+	ActionForce
+)
+
+// String returns the string representation of an action.
+func (a Action) String() string {
+	switch a {
+	case ActionSkip:
+		return "skip"
+
+	case ActionCooperative:
+		return "cooperative"
+
+	case ActionForce:
+		return "force"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Config provides the functions and parameters required to close channels.
+type Config struct {
+	// OpenChannels is a function which returns all of our currently open,
+	// public and private channels.
+	OpenChannels func() ([]*lnrpc.Channel, error)
+
+	// PendingChannels is a function which returns the channel points of
+	// all of our channels that are pending open or close.
+	PendingChannels func() ([]string, error)
+
+	// CloseChannel closes the channel with the channel point provided and
+	// returns the txid of the closing transaction.
+	CloseChannel func(chanPoint string, force bool, targetConf int32,
+		satPerByte int64) (string, error)
+
+	// Audit records the result of each close that was attempted. This
+	// function is only called for confirmed requests. If it is not set,
+	// confirmed requests are rejected.
+	Audit func(*AuditEntry) error
+
+	// Node is the name of the node that channels are closed for, which is
+	// recorded in our audit entries. It may be empty if we are only
+	// connected to a single node.
+	Node string
+}
+
+// Request contains the set of channels that should be closed and the
+// parameters that should be used to close them.
+type Request struct {
+	// ChannelPoints is the set of channels that we want to close.
+	ChannelPoints []string
+
+	// TargetConf is the number of blocks that the closing transaction
+	// should confirm within. This value may not be set if a fee rate is
+	// set.
+	TargetConf int32
+
+	// SatPerByte is the fee rate that should be used for the closing
+	// transaction. This value may not be set if a target conf is set.
+	SatPerByte int64
+
+	// AllowForce indicates whether we may force close channels whose peer
+	// is offline. If this value is false, these channels are skipped.
+	AllowForce bool
+
+	// Confirm must be set for any closes to be executed. If it is false,
+	// the results returned will contain the actions that would be taken,
+	// but no channels will be closed.
+	Confirm bool
+}
+
+// Result describes the outcome of a close attempt for a single channel.
+type Result struct {
+	// ChannelPoint is the channel that the result is for.
+	ChannelPoint string
+
+	// Action is the action that was (or would be) taken for the channel.
+	Action Action
+
+	// Reason provides the reason that a channel was skipped.
+	Reason string
+
+	// ClosingTxid is the txid of the closing transaction. This value is
+	// only set when a close was executed successfully.
+	ClosingTxid string
+}
+
+// AuditEntry is recorded for each channel close that is attempted.
+type AuditEntry struct {
+	// Timestamp is the time that the close was attempted.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Node is the name of the node that the channel belongs to, if we are
+	// connected to more than one node.
+	Node string `json:"node,omitempty"`
+
+	// ChannelPoint is the channel that we attempted to close.
+	ChannelPoint string `json:"chan_point"`
+
+	// Action is the action that was taken for the channel.
+	Action string `json:"action"`
+
+	// Reason is the reason a channel was skipped, or the error returned
+	// by our close attempt.
+	Reason string `json:"reason,omitempty"`
+
+	// ClosingTxid is the txid of the closing transaction, if successful.
+	ClosingTxid string `json:"closing_txid,omitempty"`
+
+	// TargetConf is the confirmation target set for the close.
+	TargetConf int32 `json:"target_conf,omitempty"`
+
+	// SatPerByte is the fee rate set for the close.
+	SatPerByte int64 `json:"sat_per_byte,omitempty"`
+}
+
+// CloseChannels checks each channel in the request against our current set of
+// open and pending channels and closes the channels that are eligible for
+// closure. If the request is not confirmed, the actions that would be taken
+// are returned without closing any channels.
+func CloseChannels(cfg *Config, req *Request) ([]*Result, error) {
+	if len(req.ChannelPoints) == 0 {
+		return nil, ErrNoChannels
+	}
+
+	if req.TargetConf != 0 && req.SatPerByte != 0 {
+		return nil, ErrFeeAndTarget
+	}
+
+	// We do not close channels unless we can record the closes.
+	if req.Confirm && cfg.Audit == nil {
+		return nil, ErrAuditUnavailable
+	}
+
+	openChannels, err := cfg.OpenChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	pendingChannels, err := cfg.PendingChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a map of open channel points to channels and a set of pending
+	// channel points so that we can lookup each channel requested.
+	open := make(map[string]*lnrpc.Channel, len(openChannels))
+	for _, channel := range openChannels {
+		open[channel.ChannelPoint] = channel
+	}
+
+	pending := make(map[string]bool, len(pendingChannels))
+	for _, chanPoint := range pendingChannels {
+		pending[chanPoint] = true
+	}
+
+	results := make([]*Result, 0, len(req.ChannelPoints))
+	seen := make(map[string]bool, len(req.ChannelPoints))
+
+	// auditErr is set if we fail to record a close, after which we do not
+	// close any further channels.
+	var auditErr error
+
+	for _, chanPoint := range req.ChannelPoints {
+		// Skip over duplicates so that we do not try to close a
+		// channel twice.
+		if seen[chanPoint] {
+			continue
+		}
+		seen[chanPoint] = true
+
+		result := getAction(
+			chanPoint, open[chanPoint], pending[chanPoint],
+			req.AllowForce,
+		)
+		results = append(results, result)
+
+		// If the request is not confirmed, we just return the action
+		// that would be taken for the channel.
+		if !req.Confirm {
+			continue
+		}
+
+		// If we could not record an earlier close, we skip the
+		// remaining channels so that we do not make closes that are
+		// not audited.
+		if auditErr != nil && result.Action != ActionSkip {
+			result.Action = ActionSkip
+			result.Reason = fmt.Sprintf("audit failed: %v",
+				auditErr)
+
+			continue
+		}
+
+		if result.Action != ActionSkip {
+			result.ClosingTxid, err = cfg.CloseChannel(
+				chanPoint, result.Action == ActionForce,
+				req.TargetConf, req.SatPerByte,
+			)
+
+			// If we could not close the channel, we do not fail
+			// the whole request because other closes may already
+			// have been executed. Instead, we skip the channel and
+			// report the error as our reason.
+			if err != nil {
+				log.Errorf("could not close channel: %v: %v",
+					chanPoint, err)
+
+				result.Action = ActionSkip
+				result.Reason = fmt.Sprintf("close failed: %v",
+					err)
+			}
+		}
+
+		err := cfg.Audit(&AuditEntry{
+			Timestamp:    time.Now(),
+			Node:         cfg.Node,
+			ChannelPoint: chanPoint,
+			Action:       result.Action.String(),
+			Reason:       result.Reason,
+			ClosingTxid:  result.ClosingTxid,
+			TargetConf:   req.TargetConf,
+			SatPerByte:   req.SatPerByte,
+		})
+
+		// If we could not record the close, we do not fail the request
+		// because the channel may already have been closed, and the
+		// caller needs its closing txid. Instead, we add the failure to
+		// the channel's result.
+		if err != nil {
+			log.Errorf("could not audit close of channel: %v: %v",
+				chanPoint, err)
+
+			auditErr = err
+			if result.Reason != "" {
+				result.Reason += ", "
+			}
+			result.Reason += fmt.Sprintf("audit failed: %v", err)
+		}
+	}
+
+	return results, nil
+}
+
+// getAction returns the action that should be taken for a channel. It takes
+// the channel that is currently open with the channel point provided, which
+// will be nil if the channel is not open, a boolean indicating whether the
+// channel is pending and a boolean indicating whether force closes are
+// permitted.
+func getAction(chanPoint string, channel *lnrpc.Channel, pending,
+	allowForce bool) *Result {
+
+	result := &Result{
+		ChannelPoint: chanPoint,
+		Action:       ActionSkip,
+	}
+
+	switch {
+	// If the channel is pending open or close, we skip it. We check this
+	// first because channels that are pending close will not be in our
+	// set of open channels.
+	case pending:
+		result.Reason = "channel is pending"
+
+	// If the channel is not open, it may have been closed since the
+	// recommendation was produced.
+	case channel == nil:
+		result.Reason = "channel is not open"
+
+	// If the channel is active, the peer is online so we can close
+	// cooperatively.
+	case channel.Active:
+		result.Action = ActionCooperative
+
+	// If the peer is offline, we may only force close the channel if it is
+	// explicitly allowed.
+	case allowForce:
+		result.Action = ActionForce
+
+	default:
+		result.Reason = "peer is offline and force close not allowed"
+	}
+
+	return result
+}
@@ -0,0 +1,259 @@
+package closer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// TestCloseChannels tests checking of channels before close and the actions
+// that are taken for each channel.
+func TestCloseChannels(t *testing.T) {
+	var (
+		// testErr is an error returned by the mock to simulate rpc
+		// failures.
+		testErr = errors.New("error thrown by mock")
+
+		activeChan = &lnrpc.Channel{
+			ChannelPoint: "a:1",
+			Active:       true,
+		}
+
+		inactiveChan = &lnrpc.Channel{
+			ChannelPoint: "a:2",
+			Active:       false,
+		}
+
+		pendingChan = "a:3"
+	)
+
+	tests := []struct {
+		name            string
+		request         *Request
+		closeErr        error
+		auditErr        error
+		expectedResults []*Result
+		expectedCloses  int
+		expectedAudits  int
+		noAudit         bool
+		expectErr       error
+	}{
+		{
+			name:      "no channels",
+			request:   &Request{},
+			expectErr: ErrNoChannels,
+		},
+		{
+			name: "fee rate and target set",
+			request: &Request{
+				ChannelPoints: []string{activeChan.ChannelPoint},
+				TargetConf:    6,
+				SatPerByte:    10,
+			},
+			expectErr: ErrFeeAndTarget,
+		},
+		{
+			name: "not confirmed",
+			request: &Request{
+				ChannelPoints: []string{
+					activeChan.ChannelPoint,
+					inactiveChan.ChannelPoint,
+				},
+			},
+			expectedResults: []*Result{
+				{
+					ChannelPoint: activeChan.ChannelPoint,
+					Action:       ActionCooperative,
+				},
+				{
+					ChannelPoint: inactiveChan.ChannelPoint,
+					Action:       ActionSkip,
+					Reason: "peer is offline and force " +
+						"close not allowed",
+				},
+			},
+		},
+		{
+			name: "confirmed with force and duplicates",
+			request: &Request{
+				ChannelPoints: []string{
+					activeChan.ChannelPoint,
+					inactiveChan.ChannelPoint,
+					pendingChan,
+					"a:4",
+					activeChan.ChannelPoint,
+				},
+				AllowForce: true,
+				Confirm:    true,
+			},
+			expectedResults: []*Result{
+				{
+					ChannelPoint: activeChan.ChannelPoint,
+					Action:       ActionCooperative,
+					ClosingTxid:  "txid",
+				},
+				{
+					ChannelPoint: inactiveChan.ChannelPoint,
+					Action:       ActionForce,
+					ClosingTxid:  "txid",
+				},
+				{
+					ChannelPoint: pendingChan,
+					Action:       ActionSkip,
+					Reason:       "channel is pending",
+				},
+				{
+					ChannelPoint: "a:4",
+					Action:       ActionSkip,
+					Reason:       "channel is not open",
+				},
+			},
+			expectedCloses: 2,
+			expectedAudits: 4,
+		},
+		{
+			name: "close fails",
+			request: &Request{
+				ChannelPoints: []string{
+					activeChan.ChannelPoint,
+				},
+				Confirm: true,
+			},
+			closeErr: testErr,
+			expectedResults: []*Result{
+				{
+					ChannelPoint: activeChan.ChannelPoint,
+					Action:       ActionSkip,
+					Reason: "close failed: error " +
+						"thrown by mock",
+				},
+			},
+			expectedCloses: 1,
+			expectedAudits: 1,
+		},
+		{
+			name: "audit fails",
+			request: &Request{
+				ChannelPoints: []string{
+					activeChan.ChannelPoint,
+					inactiveChan.ChannelPoint,
+				},
+				AllowForce: true,
+				Confirm:    true,
+			},
+			auditErr: testErr,
+			expectedResults: []*Result{
+				{
+					ChannelPoint: activeChan.ChannelPoint,
+					Action:       ActionCooperative,
+					Reason: "audit failed: error " +
+						"thrown by mock",
+					ClosingTxid: "txid",
+				},
+				{
+					ChannelPoint: inactiveChan.ChannelPoint,
+					Action:       ActionSkip,
+					Reason: "audit failed: error " +
+						"thrown by mock",
+				},
+			},
+			expectedCloses: 1,
+			expectedAudits: 1,
+		},
+		{
+			name: "not confirmed without audit",
+			request: &Request{
+				ChannelPoints: []string{
+					activeChan.ChannelPoint,
+				},
+			},
+			noAudit: true,
+			expectedResults: []*Result{
+				{
+					ChannelPoint: activeChan.ChannelPoint,
+					Action:       ActionCooperative,
+				},
+			},
+		},
+		{
+			name: "confirmed without audit",
+			request: &Request{
+				ChannelPoints: []string{
+					activeChan.ChannelPoint,
+				},
+				Confirm: true,
+			},
+			noAudit:   true,
+			expectErr: ErrAuditUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var closes, audits int
+
+			cfg := &Config{
+				OpenChannels: func() ([]*lnrpc.Channel, error) {
+					return []*lnrpc.Channel{
+						activeChan, inactiveChan,
+					}, nil
+				},
+				PendingChannels: func() ([]string, error) {
+					return []string{pendingChan}, nil
+				},
+				CloseChannel: func(_ string, _ bool, _ int32,
+					_ int64) (string, error) {
+
+					closes++
+					if test.closeErr != nil {
+						return "", test.closeErr
+					}
+
+					return "txid", nil
+				},
+				Audit: func(entry *AuditEntry) error {
+					audits++
+
+					if entry.Node != "alice" {
+						t.Errorf("expected node alice, "+
+							"got: %v", entry.Node)
+					}
+
+					return test.auditErr
+				},
+				Node: "alice",
+			}
+
+			if test.noAudit {
+				cfg.Audit = nil
+			}
+
+			results, err := CloseChannels(cfg, test.request)
+			if err != test.expectErr {
+				t.Fatalf("expected: %v, got: %v",
+					test.expectErr, err)
+			}
+
+			if !reflect.DeepEqual(test.expectedResults, results) {
+				t.Fatalf("expected: %v, got: %v",
+					test.expectedResults, results)
+			}
+
+			if closes != test.expectedCloses {
+				t.Fatalf("expected: %v closes, got: %v",
+					test.expectedCloses, closes)
+			}
+
+			if audits != test.expectedAudits {
+				t.Fatalf("expected: %v audits, got: %v",
+					test.expectedAudits, audits)
+			}
+		})
+	}
+}
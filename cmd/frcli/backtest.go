@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/urfave/cli"
+)
+
+var backtestCommand = cli.Command{
+	Name:     "backtest",
+	Category: "recommendations",
+	Usage: "Assess close recommendation strategies by running them " +
+		"at a date in the past and comparing what flagged and kept " +
+		"channels earned afterwards.",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "strategy",
+			Usage: "A strategy to backtest, expressed as " +
+				"type:metric:value where type is outlier or " +
+				"threshold, metric is the name of any metric " +
+				"listed by the metrics command, and value is " +
+				"the outlier multiplier or threshold. " +
+				"Multiple strategies can be " +
+				"specified using a comma separated list in " +
+				"braces --strategy={strategy, strategy}",
+		},
+		cli.Int64Flag{
+			Name: "as_of",
+			Usage: "The unix timestamp in seconds at which " +
+				"strategies should be run.",
+		},
+		cli.Int64Flag{
+			Name: "end_time",
+			Usage: "(optional) The unix timestamp in seconds " +
+				"until which recommendations should be " +
+				"assessed. If not set, the present is used.",
+		},
+		monitoredFlag,
+		costRateFlag,
+	},
+	Action: queryBacktest,
+}
+
+func queryBacktest(ctx *cli.Context) error {
+	client, cleanup := getClient(ctx)
+	defer cleanup()
+
+	if !ctx.IsSet("as_of") {
+		return fmt.Errorf("as_of required")
+	}
+
+	req := &frdrpc.BacktestRequest{
+		AsOf:             uint64(ctx.Int64("as_of")),
+		EndTime:          uint64(ctx.Int64("end_time")),
+		MinimumMonitored: ctx.Int64("min_monitored"),
+		Node:             ctx.GlobalString("node"),
+		OpportunityCostRate: float32(
+			ctx.Float64("cost_rate"),
+		),
+	}
+
+	for _, s := range ctx.StringSlice("strategy") {
+		strategy, err := parseStrategy(s)
+		if err != nil {
+			return err
+		}
+
+		req.Strategies = append(req.Strategies, strategy)
+	}
+
+	if len(req.Strategies) == 0 {
+		return fmt.Errorf("at least one strategy required")
+	}
+
+	rpcCtx := context.Background()
+	resp, err := client.Backtest(rpcCtx, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+// parseStrategy parses a strategy expressed as type:metric:value.
+func parseStrategy(s string) (*frdrpc.BacktestStrategy, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("strategy: %v should be expressed as "+
+			"type:metric:value", s)
+	}
+
+	strategy := &frdrpc.BacktestStrategy{
+		Name: s,
+	}
+
+	switch parts[0] {
+	case "outlier":
+		strategy.Type = frdrpc.BacktestStrategy_OUTLIER
+
+	case "threshold":
+		strategy.Type = frdrpc.BacktestStrategy_THRESHOLD
+
+	default:
+		return nil, fmt.Errorf("unknown strategy type: %v", parts[0])
+	}
+
+	if parts[1] == "" {
+		return nil, fmt.Errorf("strategy: %v requires a metric", s)
+	}
+	strategy.MetricName = parts[1]
+
+	value, err := strconv.ParseFloat(parts[2], 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %v", parts[2])
+	}
+	strategy.Value = float32(value)
+
+	return strategy, nil
+}
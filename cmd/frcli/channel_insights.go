@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"io"
 
 	"github.com/lightninglabs/faraday/frdrpc"
 	"github.com/urfave/cli"
@@ -12,6 +13,23 @@ var channelInsightsCommand = cli.Command{
 	Category: "insights",
 	Usage: "List currently open channel with routing and " +
 		"uptime information.",
+	Flags: []cli.Flag{
+		lookbackFlag,
+		halfLifeFlag,
+		cli.BoolFlag{
+			Name: "follow",
+			Usage: "keep the command running and print updated " +
+				"insights when channel events or forwards " +
+				"change them",
+		},
+		cli.Uint64Flag{
+			Name: "interval",
+			Usage: "when following, the optional interval in " +
+				"seconds at which insights are refreshed " +
+				"regardless of events, which must be at " +
+				"least 60 seconds",
+		},
+	},
 	Action: queryChannelInsights,
 }
 
@@ -30,17 +48,60 @@ func queryChannelInsights(ctx *cli.Context) error {
 	client, cleanup := getClient(ctx)
 	defer cleanup()
 
+	req := &frdrpc.ChannelInsightsRequest{
+		LookbackSeconds:      uint64(ctx.Int64("lookback")),
+		DecayHalfLifeSeconds: uint64(ctx.Int64("half_life")),
+		Node:                 ctx.GlobalString("node"),
+	}
+
 	rpcCtx := context.Background()
-	resp, err := client.ChannelInsights(
-		rpcCtx, &frdrpc.ChannelInsightsRequest{},
+
+	if !ctx.Bool("follow") {
+		resp, err := client.ChannelInsights(rpcCtx, req)
+		if err != nil {
+			return err
+		}
+
+		printInsights(resp)
+
+		return nil
+	}
+
+	stream, err := client.SubscribeChannelInsights(
+		rpcCtx, &frdrpc.SubscribeChannelInsightsRequest{
+			Request:         req,
+			IntervalSeconds: ctx.Uint64("interval"),
+		},
 	)
 	if err != nil {
 		return err
 	}
 
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		printInsights(resp)
+	}
+}
+
+// printInsights prints a channel insights response with additional
+// information calculated from each insight.
+func printInsights(resp *frdrpc.ChannelInsightsResponse) {
 	insights := make([]insightsResp, len(resp.ChannelInsights))
 	for i, channel := range resp.ChannelInsights {
-		confirmations := float64(channel.Confirmations)
+		// Scale our values by the number of blocks that the channel's
+		// revenue was calculated over, falling back to its
+		// confirmations for servers that do not provide it.
+		confirmations := channel.RevenueBlocks
+		if confirmations == 0 {
+			confirmations = float64(channel.Confirmations)
+		}
 
 		insight := insightsResp{
 			ChannelInsight: channel,
@@ -71,6 +132,4 @@ func queryChannelInsights(ctx *cli.Context) error {
 	}
 
 	printJSON(insights)
-
-	return nil
 }
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/lightninglabs/protobuf-hex-display/jsonpb"
+	"github.com/urfave/cli"
+)
+
+var closeChannelsCommand = cli.Command{
+	Name:     "close",
+	Category: "recommendations",
+	Usage: "Close a set of channels, checking that each channel is " +
+		"still open and not pending before closing it.",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "chan_points",
+			Usage: "(optional) A set of channels to close. A " +
+				"single channel can be set directly using " +
+				"--chan_points=txid:outpoint, multiple " +
+				"channels should be specified using a comma " +
+				"separated list in braces " +
+				"--chan_points={chan, chan}",
+		},
+		cli.StringFlag{
+			Name: "from_report",
+			Usage: "(optional) Path to a json report produced " +
+				"by the outliers or threshold commands. All " +
+				"channels that are recommended for close in " +
+				"the report will be closed.",
+		},
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "(optional) The number of blocks that the " +
+				"closing transactions should confirm within.",
+		},
+		cli.Int64Flag{
+			Name: "sat_per_byte",
+			Usage: "(optional) The fee rate in satoshis per byte " +
+				"to use for the closing transactions.",
+		},
+		cli.BoolFlag{
+			Name: "force",
+			Usage: "Allow force closes for channels whose peer " +
+				"is offline. If not set, these channels will " +
+				"be skipped.",
+		},
+	},
+	Action: closeChannels,
+}
+
+func closeChannels(ctx *cli.Context) error {
+	client, cleanup := getClient(ctx)
+	defer cleanup()
+
+	req := &frdrpc.CloseChannelsRequest{
+		ChanPoints: ctx.StringSlice("chan_points"),
+		TargetConf: int32(ctx.Int64("conf_target")),
+		SatPerByte: ctx.Int64("sat_per_byte"),
+		AllowForce: ctx.Bool("force"),
+		Node:       ctx.GlobalString("node"),
+	}
+
+	// If a recommendation report was provided, add all of the channels
+	// that it recommends closing to our request.
+	if ctx.IsSet("from_report") {
+		chanPoints, err := readRecommendedCloses(
+			ctx.String("from_report"),
+		)
+		if err != nil {
+			return err
+		}
+
+		req.ChanPoints = append(req.ChanPoints, chanPoints...)
+	}
+
+	if len(req.ChanPoints) == 0 {
+		return fmt.Errorf("chan_points or from_report required")
+	}
+
+	// First, we make our request without confirmation so that the user
+	// can see the actions that will be taken.
+	rpcCtx := context.Background()
+	dryRun, err := client.CloseChannels(rpcCtx, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(dryRun)
+
+	if !promptForConfirmation("Close channels as listed above? " +
+		"Type 'yes' to confirm: ") {
+
+		fmt.Println("Aborted, no channels closed.")
+		return nil
+	}
+
+	req.Confirm = true
+	resp, err := client.CloseChannels(rpcCtx, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+// readRecommendedCloses reads a close recommendations response from the file
+// provided and returns the channel points of all channels that are
+// recommended for close.
+func readRecommendedCloses(path string) ([]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &frdrpc.CloseRecommendationsResponse{}
+	if err := jsonpb.UnmarshalString(string(b), report); err != nil {
+		return nil, fmt.Errorf("could not parse report: %v", err)
+	}
+
+	var chanPoints []string
+	for _, rec := range report.Recommendations {
+		if rec.RecommendClose {
+			chanPoints = append(chanPoints, rec.ChanPoint)
+		}
+	}
+
+	return chanPoints, nil
+}
+
+// promptForConfirmation prints the message provided and returns true if the
+// user responds with "yes".
+func promptForConfirmation(msg string) bool {
+	fmt.Print(msg)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	return strings.ToLower(strings.TrimSpace(answer)) == "yes"
+}
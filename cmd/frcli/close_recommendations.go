@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/lightninglabs/faraday/recommend"
 	"github.com/urfave/cli"
 )
 
@@ -21,8 +24,52 @@ var (
 		Value: int64(defaultMinMonitored.Seconds()),
 	}
 
+	// lookbackFlag is common to recommendation and insights requests.
+	lookbackFlag = cli.Int64Flag{
+		Name: "lookback",
+		Usage: "(optional) period of time in seconds, counting back " +
+			"from the present, that revenue and volume should be " +
+			"calculated over. If not set, revenue and volume are " +
+			"calculated over the lifetime of each channel.",
+	}
+
+	// halfLifeFlag is common to recommendation and insights requests.
+	halfLifeFlag = cli.Int64Flag{
+		Name: "half_life",
+		Usage: "(optional) half-life in seconds used to weight " +
+			"forwards by their age, so that recent forwards " +
+			"count more than older ones. If not set, all " +
+			"forwards are weighted equally.",
+	}
+
+	// costRateFlag is common to recommendation and backtest requests.
+	costRateFlag = cli.Float64Flag{
+		Name: "cost_rate",
+		Usage: "(optional) annual rate of return that could be " +
+			"earned on capital elsewhere, expressed as a " +
+			"fraction (eg, 0.05 for 5%), used to calculate the " +
+			"opportunity cost of channel capital for net value " +
+			"recommendations. If not set, the rate faraday is " +
+			"configured with is used.",
+	}
+
+	// metricFlag is common to recommendation requests.
+	metricFlag = cli.StringFlag{
+		Name: "metric",
+		Usage: "name of the metric to get recommendations based on, " +
+			"which may be any metric listed by the metrics " +
+			"command.",
+	}
+
 	// Flags required for threshold close recommendations.
 	thresholdFlags = []cli.Flag{
+		metricFlag,
+		cli.Float64Flag{
+			Name: "threshold",
+			Usage: "threshold value for the metric set with " +
+				"--metric, beneath which channels will be " +
+				"identified for close.",
+		},
 		cli.Float64Flag{
 			Name: "uptime",
 			Usage: "Ratio of uptime to time monitored, expressed" +
@@ -52,11 +99,31 @@ var (
 				"confirmation beneath which channels will be " +
 				"identified for close",
 		},
+		cli.Float64Flag{
+			Name: "net_value",
+			Usage: "threshold net value (in msat) per " +
+				"confirmation, which is revenue less the " +
+				"opportunity cost of the channel's capital, " +
+				"beneath which channels will be identified " +
+				"for close. Set to 0 to identify channels " +
+				"with negative net value.",
+		},
+		cli.Float64Flag{
+			Name: "balance",
+			Usage: "threshold ratio of time that the channel's " +
+				"balance was not one-sided to time sampled, " +
+				"expressed in [0;1], beneath which channels " +
+				"will be identified for close.",
+		},
 		monitoredFlag,
+		lookbackFlag,
+		halfLifeFlag,
+		costRateFlag,
 	}
 
 	// Flags required for outlier close recommendations.
 	outlierFlags = []cli.Flag{
+		metricFlag,
 		cli.StringFlag{
 			Name: "outlier_mult",
 			Usage: "(optional with outlier strategy) Number of " +
@@ -91,7 +158,22 @@ var (
 			Usage: "get recommendations based on the " +
 				"channel's total volume per confirmation",
 		},
+		cli.BoolFlag{
+			Name: "net_value",
+			Usage: "get recommendations based on the " +
+				"channel's revenue less the opportunity cost " +
+				"of its capital per confirmation",
+		},
+		cli.BoolFlag{
+			Name: "balance",
+			Usage: "get recommendations based on the ratio of " +
+				"time that the channel's balance was not " +
+				"one-sided",
+		},
 		monitoredFlag,
+		lookbackFlag,
+		halfLifeFlag,
+		costRateFlag,
 	}
 )
 
@@ -113,11 +195,28 @@ func queryThresholdRecommendations(ctx *cli.Context) error {
 	req := &frdrpc.ThresholdRecommendationsRequest{
 		RecRequest: &frdrpc.CloseRecommendationRequest{
 			MinimumMonitored: ctx.Int64("min_monitored"),
+			LookbackSeconds:  uint64(ctx.Int64("lookback")),
+			DecayHalfLifeSeconds: uint64(
+				ctx.Int64("half_life"),
+			),
+			Node: ctx.GlobalString("node"),
+			OpportunityCostRate: float32(
+				ctx.Float64("cost_rate"),
+			),
 		},
 	}
 
-	// Set threshold and metric based on uptime/revenue flags.
+	// Set threshold and metric based on the metric name provided, or the
+	// flags for our original set of metrics.
 	switch {
+	case ctx.IsSet("metric"):
+		if !ctx.IsSet("threshold") {
+			return fmt.Errorf("threshold required for metric")
+		}
+
+		req.ThresholdValue = float32(ctx.Float64("threshold"))
+		req.RecRequest.MetricName = ctx.String("metric")
+
 	case ctx.IsSet("uptime"):
 		req.ThresholdValue = float32(ctx.Float64("uptime"))
 		req.RecRequest.Metric = frdrpc.CloseRecommendationRequest_UPTIME
@@ -138,6 +237,14 @@ func queryThresholdRecommendations(ctx *cli.Context) error {
 		req.ThresholdValue = float32(ctx.Float64("volume"))
 		req.RecRequest.Metric = frdrpc.CloseRecommendationRequest_TOTAL_VOLUME
 
+	case ctx.IsSet("net_value"):
+		req.ThresholdValue = float32(ctx.Float64("net_value"))
+		req.RecRequest.Metric = frdrpc.CloseRecommendationRequest_NET_VALUE
+
+	case ctx.IsSet("balance"):
+		req.ThresholdValue = float32(ctx.Float64("balance"))
+		req.RecRequest.Metric = frdrpc.CloseRecommendationRequest_BALANCE
+
 	default:
 		return fmt.Errorf("threshold required")
 	}
@@ -173,6 +280,14 @@ func queryOutlierRecommendations(ctx *cli.Context) error {
 	req := &frdrpc.OutlierRecommendationsRequest{
 		RecRequest: &frdrpc.CloseRecommendationRequest{
 			MinimumMonitored: ctx.Int64("min_monitored"),
+			LookbackSeconds:  uint64(ctx.Int64("lookback")),
+			DecayHalfLifeSeconds: uint64(
+				ctx.Int64("half_life"),
+			),
+			Node: ctx.GlobalString("node"),
+			OpportunityCostRate: float32(
+				ctx.Float64("cost_rate"),
+			),
 		},
 		OutlierMultiplier: float32(defaultOutlierMultiplier),
 	}
@@ -182,8 +297,12 @@ func queryOutlierRecommendations(ctx *cli.Context) error {
 		req.OutlierMultiplier = float32(ctx.Float64("outlier_mult"))
 	}
 
-	// Set metric based on uptime or revenue flags.
+	// Set metric based on the metric name provided, or the flags for our
+	// original set of metrics.
 	switch {
+	case ctx.IsSet("metric"):
+		req.RecRequest.MetricName = ctx.String("metric")
+
 	case ctx.IsSet("uptime"):
 		req.RecRequest.Metric = frdrpc.CloseRecommendationRequest_UPTIME
 
@@ -199,9 +318,15 @@ func queryOutlierRecommendations(ctx *cli.Context) error {
 	case ctx.IsSet("volume"):
 		req.RecRequest.Metric = frdrpc.CloseRecommendationRequest_TOTAL_VOLUME
 
+	case ctx.IsSet("net_value"):
+		req.RecRequest.Metric = frdrpc.CloseRecommendationRequest_NET_VALUE
+
+	case ctx.IsSet("balance"):
+		req.RecRequest.Metric = frdrpc.CloseRecommendationRequest_BALANCE
+
 	default:
-		return fmt.Errorf("uptime, revenue or volume realted flag " +
-			"required")
+		return fmt.Errorf("metric, uptime, revenue or volume " +
+			"related flag required")
 	}
 
 	rpcCtx := context.Background()
@@ -214,3 +339,75 @@ func queryOutlierRecommendations(ctx *cli.Context) error {
 
 	return nil
 }
+
+var ruleRecommendationCommand = cli.Command{
+	Name:     "rules",
+	Category: "recommendations",
+	Usage: "Get close recommendations for currently open channels " +
+		"based on whether they match a set of rules.",
+	Description: "Rules are expressions which are checked against " +
+		"each channel's insights, such as uptime_ratio < 0.8 && " +
+		"fees_per_conf < 10 && monitored > 60d. Channels are " +
+		"recommended for close if any rule matches them, and the " +
+		"first rule that matches is reported.\n\n" +
+		"Rules may use the following variables: " +
+		strings.Join(ruleVariableNames(), ", ") + ".",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "rule",
+			Usage: "(optional) a rule expressed as " +
+				"[name:]expression. May be specified " +
+				"multiple times. If not set, the rules " +
+				"faraday is configured with are used.",
+		},
+		monitoredFlag,
+		lookbackFlag,
+		halfLifeFlag,
+		costRateFlag,
+	},
+	Action: queryRuleRecommendations,
+}
+
+// ruleVariableNames returns the sorted names of the variables that close
+// recommendation rules can use.
+func ruleVariableNames() []string {
+	variables := recommend.RuleVariables()
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func queryRuleRecommendations(ctx *cli.Context) error {
+	client, cleanup := getClient(ctx)
+	defer cleanup()
+
+	req := &frdrpc.RuleRecommendationsRequest{
+		RecRequest: &frdrpc.CloseRecommendationRequest{
+			MinimumMonitored: ctx.Int64("min_monitored"),
+			LookbackSeconds:  uint64(ctx.Int64("lookback")),
+			DecayHalfLifeSeconds: uint64(
+				ctx.Int64("half_life"),
+			),
+			Node: ctx.GlobalString("node"),
+			OpportunityCostRate: float32(
+				ctx.Float64("cost_rate"),
+			),
+		},
+		Rules: ctx.StringSlice("rule"),
+	}
+
+	rpcCtx := context.Background()
+	recs, err := client.RuleRecommendations(rpcCtx, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(recs)
+
+	return nil
+}
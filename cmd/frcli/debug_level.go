@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/urfave/cli"
+)
+
+var debugLevelCommand = cli.Command{
+	Name:     "debuglevel",
+	Category: "daemon",
+	Usage:    "Set the debug level of faraday's subsystems.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "show",
+			Usage: "list the subsystems that faraday logs for",
+		},
+		cli.StringFlag{
+			Name: "level",
+			Usage: "the level to set for all subsystems, or a " +
+				"comma separated list of " +
+				"<subsystem>=<level> pairs",
+		},
+	},
+	Action: setDebugLevel,
+}
+
+func setDebugLevel(ctx *cli.Context) error {
+	client, cleanup := getClient(ctx)
+	defer cleanup()
+
+	req := &frdrpc.DebugLevelRequest{
+		Show:      ctx.Bool("show"),
+		LevelSpec: ctx.String("level"),
+	}
+
+	if !req.Show && req.LevelSpec == "" {
+		return fmt.Errorf("level or show required")
+	}
+
+	rpcCtx := context.Background()
+	resp, err := client.DebugLevel(rpcCtx, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/urfave/cli"
+)
+
+var fleetReportCommand = cli.Command{
+	Name:     "fleet",
+	Category: "insights",
+	Usage: "Get channel insights and totals for each of the lnd nodes " +
+		"that faraday is connected to.",
+	Flags: []cli.Flag{
+		lookbackFlag,
+		halfLifeFlag,
+	},
+	Action: queryFleetReport,
+}
+
+func queryFleetReport(ctx *cli.Context) error {
+	client, cleanup := getClient(ctx)
+	defer cleanup()
+
+	req := &frdrpc.FleetReportRequest{
+		LookbackSeconds:      uint64(ctx.Int64("lookback")),
+		DecayHalfLifeSeconds: uint64(ctx.Int64("half_life")),
+	}
+
+	rpcCtx := context.Background()
+	resp, err := client.FleetReport(rpcCtx, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
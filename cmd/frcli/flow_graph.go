@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/urfave/cli"
+)
+
+var flowGraphCommand = cli.Command{
+	Name:     "flowgraph",
+	Category: "insights",
+	Usage: "Export a graph of the flow of forwards between channels " +
+		"or peers.",
+	Description: "Exports a directed graph of the forwards that our " +
+		"node made, with an edge from the channel that each forward " +
+		"arrived on to the channel that it left on. The graph can " +
+		"be output as json or in Graphviz's DOT language, which can " +
+		"be rendered using eg: frcli flowgraph --format=dot | dot " +
+		"-Tsvg > flows.svg",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "start_time",
+			Usage: "(optional) The unix timestamp in seconds " +
+				"from which the graph should be generated. " +
+				"If not set, the graph will include all " +
+				"forwards up to the end time.",
+		},
+		cli.Int64Flag{
+			Name: "end_time",
+			Usage: "(optional) The unix timestamp in seconds " +
+				"until which the graph should be generated. " +
+				"If not set, the graph will be produced " +
+				"until the present.",
+		},
+		cli.StringFlag{
+			Name: "weight",
+			Usage: "(optional) The value that edges are weighted " +
+				"by, either volume or fees.",
+			Value: "volume",
+		},
+		cli.StringFlag{
+			Name: "label",
+			Usage: "(optional) The value that nodes are labeled " +
+				"with, either alias or peer.",
+			Value: "alias",
+		},
+		cli.BoolFlag{
+			Name: "merge_peers",
+			Usage: "(optional) Represent all of our channels " +
+				"with a peer as a single node.",
+		},
+		cli.StringFlag{
+			Name: "format",
+			Usage: "(optional) The format to output the graph " +
+				"in, either json or dot.",
+			Value: "json",
+		},
+	},
+	Action: queryFlowGraph,
+}
+
+func queryFlowGraph(ctx *cli.Context) error {
+	client, cleanup := getClient(ctx)
+	defer cleanup()
+
+	weightName := strings.ToUpper(ctx.String("weight"))
+	weight, ok := frdrpc.FlowGraphRequest_Weight_value[weightName]
+	if !ok {
+		return fmt.Errorf("unknown weight: %v", ctx.String("weight"))
+	}
+
+	labelName := strings.ToUpper(ctx.String("label"))
+	label, ok := frdrpc.FlowGraphRequest_Label_value[labelName]
+	if !ok {
+		return fmt.Errorf("unknown label: %v", ctx.String("label"))
+	}
+
+	formatName := strings.ToUpper(ctx.String("format"))
+	format, ok := frdrpc.FlowGraphRequest_Format_value[formatName]
+	if !ok {
+		return fmt.Errorf("unknown format: %v", ctx.String("format"))
+	}
+
+	req := &frdrpc.FlowGraphRequest{
+		StartTime:  uint64(ctx.Int64("start_time")),
+		EndTime:    uint64(ctx.Int64("end_time")),
+		Node:       ctx.GlobalString("node"),
+		Weight:     frdrpc.FlowGraphRequest_Weight(weight),
+		Label:      frdrpc.FlowGraphRequest_Label(label),
+		MergePeers: ctx.Bool("merge_peers"),
+		Format:     frdrpc.FlowGraphRequest_Format(format),
+	}
+
+	rpcCtx := context.Background()
+	resp, err := client.FlowGraph(rpcCtx, req)
+	if err != nil {
+		return err
+	}
+
+	// Output the graph as is if it was requested in the DOT language, so
+	// that it can be piped directly into Graphviz.
+	if req.Format == frdrpc.FlowGraphRequest_DOT {
+		fmt.Print(resp.Dot)
+		return nil
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/urfave/cli"
+)
+
+var getInfoCommand = cli.Command{
+	Name:     "getinfo",
+	Category: "daemon",
+	Usage: "Get faraday's version, the lnd nodes it is connected to " +
+		"and the features that it has enabled.",
+	Action: queryGetInfo,
+}
+
+func queryGetInfo(ctx *cli.Context) error {
+	client, cleanup := getClient(ctx)
+	defer cleanup()
+
+	rpcCtx := context.Background()
+	resp, err := client.GetInfo(rpcCtx, &frdrpc.GetInfoRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
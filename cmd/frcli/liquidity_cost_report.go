@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/urfave/cli"
+)
+
+var liquidityCostReportCommand = cli.Command{
+	Name:     "liquiditycost",
+	Category: "insights",
+	Usage: "Get a report of the cost of loop swaps, attributed to " +
+		"the channels they rebalanced.",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "start_time",
+			Usage: "(optional) The unix timestamp in seconds " +
+				"from which the report should be generated. " +
+				"If not set, the report will include all " +
+				"swaps up to the end time.",
+		},
+		cli.Int64Flag{
+			Name: "end_time",
+			Usage: "(optional) The unix timestamp in seconds " +
+				"until which the report should be generated. " +
+				"If not set, the report will be produced " +
+				"until the present.",
+		},
+	},
+	Action: queryLiquidityCostReport,
+}
+
+func queryLiquidityCostReport(ctx *cli.Context) error {
+	client, cleanup := getClient(ctx)
+	defer cleanup()
+
+	req := &frdrpc.LiquidityCostReportRequest{
+		StartTime: uint64(ctx.Int64("start_time")),
+		EndTime:   uint64(ctx.Int64("end_time")),
+		Node:      ctx.GlobalString("node"),
+	}
+
+	rpcCtx := context.Background()
+	resp, err := client.LiquidityCostReport(rpcCtx, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
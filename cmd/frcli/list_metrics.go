@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/urfave/cli"
+)
+
+var listMetricsCommand = cli.Command{
+	Name:     "metrics",
+	Category: "recommendations",
+	Usage: "List the metrics that close recommendations can be " +
+		"based on.",
+	Action: queryListMetrics,
+}
+
+func queryListMetrics(ctx *cli.Context) error {
+	client, cleanup := getClient(ctx)
+	defer cleanup()
+
+	rpcCtx := context.Background()
+	resp, err := client.ListMetrics(rpcCtx, &frdrpc.ListMetricsRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -23,12 +23,36 @@ func main() {
 			Value: defaultRPCHostPort,
 			Usage: "host:port of faraday",
 		},
+		cli.StringFlag{
+			Name: "offlinedir",
+			Usage: "(optional) directory containing " +
+				"getinfo.json, listchannels.json, " +
+				"closedchannels.json and fwdinghistory*.json " +
+				"exported with lncli. If set, commands are " +
+				"run against these files rather than faraday",
+		},
+		cli.StringFlag{
+			Name: "node",
+			Usage: "(optional) the name of the lnd node that " +
+				"commands are run against, if faraday is " +
+				"connected to multiple nodes",
+		},
 	}
 	app.Commands = []cli.Command{
 		thresholdRecommendationCommand,
 		outlierRecommendationCommand,
+		ruleRecommendationCommand,
+		listMetricsCommand,
 		revenueReportCommand,
+		liquidityCostReportCommand,
+		flowGraphCommand,
 		channelInsightsCommand,
+		closeChannelsCommand,
+		backtestCommand,
+		fleetReportCommand,
+		debugLevelCommand,
+		statusCommand,
+		getInfoCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
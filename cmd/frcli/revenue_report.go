@@ -38,6 +38,21 @@ var revenueReportCommand = cli.Command{
 				"If not set, the report will be produced " +
 				"until the present.",
 		},
+		cli.StringFlag{
+			Name: "fiat",
+			Usage: "(optional) A fiat currency code, eg USD or " +
+				"EUR, to value fees in. Each forward's fees " +
+				"are valued at the price when it happened. " +
+				"Requires faraday to be configured with a " +
+				"fiat price source.",
+		},
+		cli.Int64Flag{
+			Name: "period_seconds",
+			Usage: "(optional) A period length in seconds to " +
+				"split the report's fees and volume into, " +
+				"eg 86400 for daily totals. Requires a " +
+				"start time.",
+		},
 	},
 	Action: queryRevenueReport,
 }
@@ -49,8 +64,11 @@ func queryRevenueReport(ctx *cli.Context) error {
 	// Set start and end times from user specified values, defaulting
 	// to zero if they are not set.
 	req := &frdrpc.RevenueReportRequest{
-		StartTime: uint64(ctx.Int64("start_time")),
-		EndTime:   uint64(ctx.Int64("end_time")),
+		StartTime:     uint64(ctx.Int64("start_time")),
+		EndTime:       uint64(ctx.Int64("end_time")),
+		Node:          ctx.GlobalString("node"),
+		FiatCurrency:  ctx.String("fiat"),
+		PeriodSeconds: uint64(ctx.Int64("period_seconds")),
 	}
 
 	if ctx.IsSet("chan_points") {
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/urfave/cli"
+)
+
+var statusCommand = cli.Command{
+	Name:     "status",
+	Category: "daemon",
+	Usage:    "Get the status of faraday's connection to each lnd node.",
+	Action:   queryStatus,
+}
+
+func queryStatus(ctx *cli.Context) error {
+	client, cleanup := getClient(ctx)
+	defer cleanup()
+
+	rpcCtx := context.Background()
+	resp, err := client.Status(rpcCtx, &frdrpc.StatusRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
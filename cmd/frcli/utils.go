@@ -7,19 +7,32 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
+	"github.com/btcsuite/btclog"
+	"github.com/lightninglabs/faraday"
 	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/lightninglabs/faraday/offline"
 	"github.com/lightninglabs/protobuf-hex-display/jsonpb"
 	"github.com/lightninglabs/protobuf-hex-display/proto"
 	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/urfave/cli"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 var (
 	// maxMsgRecvSize is the largest message our client will receive. We
 	// set this to 200MiB atm.
 	maxMsgRecvSize = grpc.MaxCallRecvMsgSize(1 * 1024 * 1024 * 200)
+
+	// offlineBufSize is the size of the in-memory buffer used to connect
+	// to our in-process server in offline mode.
+	offlineBufSize = 1024 * 1024
+
+	// versionCheckTimeout is the amount of time we allow for the daemon
+	// to return its version.
+	versionCheckTimeout = time.Second * 5
 )
 
 // fatal logs and error and exits.
@@ -28,6 +41,12 @@ func fatal(err error) {
 	os.Exit(1)
 }
 
+// warn prints a warning to stderr, so that it does not interfere with our
+// output.
+func warn(err error) {
+	_, _ = fmt.Fprintf(os.Stderr, "[frcli] warning: %v\n", err)
+}
+
 // printRespJSON prints a proto message as json.
 func printRespJSON(resp proto.Message) {
 	jsonMarshaler := &jsonpb.Marshaler{
@@ -57,8 +76,14 @@ func printJSON(resp interface{}) {
 	_, _ = out.WriteTo(os.Stdout)
 }
 
-// getClient returns a faraday client.
+// getClient returns a faraday client. If an offline directory is set, the
+// client queries an in-process server which analyses the offline files
+// rather than connecting to faraday.
 func getClient(ctx *cli.Context) (frdrpc.FaradayServerClient, func()) {
+	if ctx.GlobalString("offlinedir") != "" {
+		return getOfflineClient(ctx.GlobalString("offlinedir"))
+	}
+
 	conn := getClientConn(ctx)
 
 	cleanUp := func() {
@@ -67,6 +92,91 @@ func getClient(ctx *cli.Context) (frdrpc.FaradayServerClient, func()) {
 		}
 	}
 
+	client := frdrpc.NewFaradayServerClient(conn)
+	checkVersion(client)
+
+	return client, cleanUp
+}
+
+// checkVersion gets the faraday daemon's version and prints a warning if it is
+// not compatible with our version. We do not fail if the version cannot be
+// obtained, so that commands can still be run against older daemons.
+func checkVersion(client frdrpc.FaradayServerClient) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(), versionCheckTimeout,
+	)
+	defer cancel()
+
+	info, err := client.GetInfo(ctx, &frdrpc.GetInfoRequest{})
+	if err != nil {
+		warn(fmt.Errorf("could not check faraday version: %v", err))
+		return
+	}
+
+	if err := faraday.CheckCompatible(info.Version); err != nil {
+		warn(fmt.Errorf("faraday daemon may not be compatible with "+
+			"frcli: %v", err))
+	}
+}
+
+// getOfflineClient starts a faraday rpc server which runs against the lncli
+// exports in the directory provided on an in-memory listener, and returns a
+// client connected to it.
+func getOfflineClient(dir string) (frdrpc.FaradayServerClient, func()) {
+	files, err := offline.FilesFromDir(dir)
+	if err != nil {
+		fatal(fmt.Errorf("cannot find offline files: %v", err))
+	}
+
+	snapshot, err := offline.Load(files)
+	if err != nil {
+		fatal(fmt.Errorf("cannot load offline files: %v", err))
+	}
+
+	// Disable logging for our in-process server so that it does not
+	// interfere with our output.
+	frdrpc.UseLogger(btclog.Disabled)
+	offline.UseLogger(btclog.Disabled)
+
+	listener := bufconn.Listen(offlineBufSize)
+
+	server := frdrpc.NewRPCServer(&frdrpc.Config{
+		LightningClient: snapshot.Client(),
+		RPCListener:     listener,
+		Version:         faraday.SemanticVersion(),
+		Commit:          faraday.Commit,
+		StartTime:       time.Now(),
+		Offline:         true,
+		Now:             snapshot.Now,
+	})
+	if err := server.Start(); err != nil {
+		fatal(err)
+	}
+
+	dialer := func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}
+
+	conn, err := grpc.Dial(
+		"offline", grpc.WithContextDialer(dialer),
+		grpc.WithDefaultCallOptions(maxMsgRecvSize),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		fatal(fmt.Errorf("unable to connect to offline server: %v",
+			err))
+	}
+
+	cleanUp := func() {
+		if err := conn.Close(); err != nil {
+			fatal(err)
+		}
+
+		if err := server.Stop(); err != nil {
+			fatal(err)
+		}
+	}
+
 	return frdrpc.NewFaradayServerClient(conn), cleanUp
 }
 
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/faraday/simulator"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/urfave/cli"
+)
+
+var generateCommand = cli.Command{
+	Name: "generate",
+	Usage: "Simulate a routing node and save its channels and " +
+		"forwarding history as a replayable fixture.",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "channel",
+			Usage: "A channel to simulate, expressed as " +
+				"capacity:uptime[:in_weight[:out_weight" +
+				"[:fee_ppm]]] where capacity is in satoshis, " +
+				"uptime is the fraction of time the peer is " +
+				"online, the weights set the channel's share " +
+				"of incoming and outgoing forwards (default " +
+				"1) and fee_ppm is the proportional fee we " +
+				"charge. Multiple channels can be specified " +
+				"using a comma separated list in braces " +
+				"--channel={channel, channel}",
+		},
+		cli.DurationFlag{
+			Name:  "duration",
+			Value: time.Hour * 24 * 30,
+			Usage: "The period of time to simulate.",
+		},
+		cli.Float64Flag{
+			Name:  "forwards_per_day",
+			Value: 100,
+			Usage: "The mean number of forwards attempted per day.",
+		},
+		cli.Int64Flag{
+			Name:  "min_forward",
+			Value: 1000,
+			Usage: "The smallest forward amount in satoshis.",
+		},
+		cli.Int64Flag{
+			Name:  "max_forward",
+			Value: 500000,
+			Usage: "The largest forward amount in satoshis.",
+		},
+		cli.DurationFlag{
+			Name:  "mean_offline",
+			Value: time.Hour * 6,
+			Usage: "The mean duration of a peer's outages.",
+		},
+		cli.Int64Flag{
+			Name:  "seed",
+			Usage: "The seed for the simulation's randomness.",
+		},
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "The path to write the fixture to.",
+		},
+	},
+	Action: generate,
+}
+
+func generate(ctx *cli.Context) error {
+	output := ctx.String("output")
+	if output == "" {
+		return fmt.Errorf("output path required")
+	}
+
+	duration := ctx.Duration("duration")
+
+	// We end our simulation at the present so that the forwards it
+	// produces fall within the default periods used by faraday.
+	cfg := &simulator.Config{
+		Start:          time.Now().Add(duration * -1),
+		Duration:       duration,
+		ForwardsPerDay: ctx.Float64("forwards_per_day"),
+		MinForward: lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(ctx.Int64("min_forward")),
+		),
+		MaxForward: lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(ctx.Int64("max_forward")),
+		),
+		Seed: ctx.Int64("seed"),
+	}
+
+	for _, c := range ctx.StringSlice("channel") {
+		spec, err := parseChannel(c, ctx.Duration("mean_offline"))
+		if err != nil {
+			return err
+		}
+
+		cfg.Channels = append(cfg.Channels, spec)
+	}
+
+	fixture, summary, err := simulator.Simulate(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := fixture.Save(output); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(summary, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+// parseChannel parses a channel expressed as
+// capacity:uptime[:in_weight[:out_weight[:fee_ppm]]]. Channels start with
+// their capacity split evenly between us and our peer.
+func parseChannel(c string, meanOffline time.Duration) (
+	*simulator.ChannelSpec, error) {
+
+	parts := strings.Split(strings.TrimSpace(c), ":")
+	if len(parts) < 2 || len(parts) > 5 {
+		return nil, fmt.Errorf("channel: %v should be expressed as "+
+			"capacity:uptime[:in_weight[:out_weight[:fee_ppm]]]",
+			c)
+	}
+
+	capacity, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity: %v", parts[0])
+	}
+
+	// Parse our float values, defaulting our flow weights to 1.
+	floats := []float64{0, 1, 1}
+	for i, part := range parts[1:] {
+		if i >= len(floats) {
+			break
+		}
+
+		floats[i], err = strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value: %v", part)
+		}
+	}
+
+	var feeRate uint64
+	if len(parts) == 5 {
+		feeRate, err = strconv.ParseUint(parts[4], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee rate: %v",
+				parts[4])
+		}
+	}
+
+	return &simulator.ChannelSpec{
+		Capacity:    btcutil.Amount(capacity),
+		LocalRatio:  0.5,
+		UptimeRatio: floats[0],
+		MeanOffline: meanOffline,
+		InWeight:    floats[1],
+		OutWeight:   floats[2],
+		FeeRatePPM:  uint32(feeRate),
+	}, nil
+}
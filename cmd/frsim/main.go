@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lightninglabs/faraday"
+	"github.com/urfave/cli"
+)
+
+// fatal logs and error and exits.
+func fatal(err error) {
+	_, _ = fmt.Fprintf(os.Stderr, "[frsim] %v\n", err)
+	os.Exit(1)
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "frsim"
+	app.Usage = "simulate a routing node to try out faraday's " +
+		"recommendations"
+	app.Version = faraday.Version()
+	app.Commands = []cli.Command{
+		generateCommand,
+		serveCommand,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fatal(err)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/faraday"
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/lightninglabs/faraday/simulator"
+	"github.com/lightningnetwork/lnd/signal"
+	"github.com/urfave/cli"
+)
+
+var serveCommand = cli.Command{
+	Name: "serve",
+	Usage: "Serve faraday's rpc server using a simulated node's " +
+		"fixture in place of lnd, so that frcli can be used to " +
+		"query it.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "fixture",
+			Usage: "The path to a fixture created by generate.",
+		},
+		cli.StringFlag{
+			Name:  "rpclisten",
+			Value: "localhost:8466",
+			Usage: "The host:port to serve rpc requests on.",
+		},
+	},
+	Action: serve,
+}
+
+func serve(ctx *cli.Context) error {
+	path := ctx.String("fixture")
+	if path == "" {
+		return fmt.Errorf("fixture path required")
+	}
+
+	fixture, err := simulator.LoadFixture(path)
+	if err != nil {
+		return err
+	}
+
+	server := frdrpc.NewRPCServer(&frdrpc.Config{
+		LightningClient: fixture.Client(),
+		RPCListen:       ctx.String("rpclisten"),
+		Version:         faraday.SemanticVersion(),
+		Commit:          faraday.Commit,
+		StartTime:       time.Now(),
+	})
+
+	if err := server.Start(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Serving simulated node on %v, use frcli "+
+		"--rpcserver=%v to query it\n", ctx.String("rpclisten"),
+		ctx.String("rpclisten"))
+
+	<-signal.ShutdownChannel()
+
+	return server.Stop()
+}
@@ -2,9 +2,21 @@ package faraday
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/btcsuite/btcutil"
 	"github.com/jessevdk/go-flags"
+	"github.com/lightninglabs/faraday/alert"
+	"github.com/lightninglabs/faraday/recommend"
+	"github.com/lightninglabs/faraday/rules"
+	"github.com/lightninglabs/faraday/uptime"
 	"github.com/lightningnetwork/lnd/build"
 )
 
@@ -16,9 +28,66 @@ const (
 	defaultMinimumMonitor = time.Hour * 24 * 7 * 4 // four weeks in hours
 	defaultDebugLevel     = "info"
 	defaultRPCListen      = "localhost:8465"
+	defaultConfigFilename = "faraday.conf"
+	defaultLogDirname     = "logs"
+	defaultLogFilename    = "faraday.log"
+	defaultMaxLogFiles    = 3
+	defaultMaxLogFileSize = 10
+	defaultCloseAuditFile = "close_audit.log"
+	defaultUptimeDBFile   = "uptime.db"
+	defaultUptimePoll     = time.Minute
+	defaultFiatTimeout    = time.Second * 30
+	defaultAlertInterval  = time.Minute * 5
+	defaultAlertCooldown  = time.Hour * 6
+	defaultReportDirname  = "reports"
+	defaultReportKeep     = 10
+
+	// defaultLndCheckInterval is the interval at which we check lnd's
+	// state while it can be reached.
+	defaultLndCheckInterval = time.Second * 15
+
+	// defaultLndCheckTimeout is the amount of time we allow lnd to
+	// respond to each state check.
+	defaultLndCheckTimeout = time.Second * 10
+
+	// defaultLndMinBackoff is the time we wait before reconnecting to lnd
+	// after it cannot be reached. It is doubled for each failed attempt,
+	// up to defaultLndMaxBackoff.
+	defaultLndMinBackoff = time.Second
+	defaultLndMaxBackoff = time.Minute * 2
+)
+
+var (
+	// defaultFaradayDir is the default directory that faraday stores its
+	// files in. Files which are specific to a network are stored in a
+	// subdirectory named after the network, so the layout is:
+	//
+	//	faraday.conf
+	//	logs/<network>/faraday.log
+	//	<network>/uptime.db
+	//	<network>/close_audit.log
+	//	<network>/reports/<report>/<report>_<timestamp>.<format>
+	//
+	// TLS certs and macaroons are not part of this layout. Faraday's rpc
+	// server does not use TLS or macaroons, so it has none of its own,
+	// and the certs and macaroons that it uses to connect to lnd and loopd
+	// are generated by those daemons and read from their directories.
+	defaultFaradayDir = btcutil.AppDataDir("faraday", false)
+
+	// defaultConfigFile is the default path for faraday's config file.
+	defaultConfigFile = filepath.Join(
+		defaultFaradayDir, defaultConfigFilename,
+	)
 )
 
 type config struct {
+	// FaradayDir is the base directory that faraday's config file and
+	// network directories are stored in.
+	FaradayDir string `long:"faradaydir" description:"The base directory that contains faraday's config file and a data directory for each network."`
+
+	// ConfigFile is the path to faraday's config file.
+	ConfigFile string `long:"configfile" description:"Path to faraday's config file, which contains options in the same form as the command line flags. Command line flags override values in the file."`
+
 	// RPCServer is host:port that lnd's RPC server is listening on.
 	RPCServer string `long:"rpcserver" description:"host:port that LND is listening for RPC connections on"`
 
@@ -28,6 +97,9 @@ type config struct {
 	// MacaroonFile is the file name of the macaroon to use.
 	MacaroonFile string `long:"macaroonfile" description:"Macaroon file to use."`
 
+	// NoMacaroons connects to lnd without macaroon credentials.
+	NoMacaroons bool `long:"nomacaroons" description:"Connect to lnd without a macaroon. This should only be set for lnd nodes that run with --no-macaroons, otherwise a missing macaroon is an error."`
+
 	// TLSCertPath is the path to the tls cert that faraday should use.
 	TLSCertPath string `long:"tlscertpath" description:"Path to TLS cert"`
 
@@ -43,6 +115,9 @@ type config struct {
 	// MinimumMonitored is the minimum amount of time that a channel must be monitored for before we consider it for termination.
 	MinimumMonitored time.Duration `long:"min_monitored" description:"The minimum amount of time that a channel must be monitored for before recommending termination. Valid time units are {s, m, h}."`
 
+	// OpportunityCostRate is the default annual rate of return used to calculate the opportunity cost of channel capital.
+	OpportunityCostRate float64 `long:"opportunity_cost_rate" description:"The annual rate of return that could be earned on capital elsewhere, expressed as a fraction (eg, 0.05 for 5%). Used to calculate the net value of channels when requests do not provide a rate."`
+
 	// network is a string containing the network we're running on.
 	network string
 
@@ -50,51 +125,614 @@ type config struct {
 	// for all subsystems the same or individual level by subsystem.
 	DebugLevel string `long:"debuglevel" description:"Debug level for faraday and its subsystems."`
 
+	// LogDir is the directory that faraday writes its log files to. If it
+	// is not set, logs are written to the network's directory in the logs
+	// directory.
+	LogDir string `long:"logdir" description:"Directory to log output to. Defaults to logs/<network> in faradaydir."`
+
+	// MaxLogFiles is the maximum number of rotated log files to keep.
+	MaxLogFiles int `long:"maxlogfiles" description:"Maximum number of rotated log files to keep (0 for no rotation)"`
+
+	// MaxLogFileSize is the size, in MB, at which a log file is rotated.
+	MaxLogFileSize int `long:"maxlogfilesize" description:"Maximum log file size in MB before it is rotated"`
+
 	// RPCListen is the listen address for the faraday rpc server.
 	RPCListen string `long:"rpclisten" description:"Address to listen on for gRPC clients"`
+
+	// CloseAuditLog is the path to the file that channel closes initiated
+	// by faraday are recorded in. If it is not set, the audit log is
+	// stored in the network directory.
+	CloseAuditLog string `long:"closeauditlog" description:"Path to the audit log that channel closes are recorded in. Defaults to the network directory in faradaydir."`
+
+	// AllowClose enables the CloseChannels rpc. It is off by default
+	// because faraday's rpc server is not authenticated, so any process
+	// that can reach it could close our channels.
+	AllowClose bool `long:"allowclose" description:"Allow channels to be closed over faraday's rpc server. Faraday's rpc server is not authenticated, so this should only be set if rpclisten cannot be reached by untrusted processes."`
+
+	// UptimeDB is the path to the database that faraday records peer
+	// uptime in. If it is not set, the database is stored in the network
+	// directory.
+	UptimeDB string `long:"uptimedb" description:"Path to the database that peer uptime history is recorded in. Defaults to the network directory in faradaydir."`
+
+	// UptimePollInterval is the interval at which peer uptime is sampled.
+	UptimePollInterval time.Duration `long:"uptimepoll" description:"The interval at which peer online status is sampled. Valid time units are {s, m, h}."`
+
+	// OneSidedThreshold is the proportion of a channel's balance that may be on its less funded side for the balance to be considered one-sided.
+	OneSidedThreshold float64 `long:"onesided_threshold" description:"The proportion of a channel's balance, expressed in [0;0.5), that may be on its less funded side for the channel to be considered one-sided when its balance is sampled."`
+
+	// OfflineDir is an optional directory containing lncli exports of a
+	// node. If it is set, faraday runs against the exports rather than
+	// connecting to lnd.
+	OfflineDir string `long:"offlinedir" description:"Directory containing getinfo.json, listchannels.json, closedchannels.json and fwdinghistory*.json exported with lncli. If set, faraday analyses these files rather than connecting to lnd."`
+
+	// Nodes is an optional set of named lnd nodes that faraday connects
+	// to, in the form name=alice,rpcserver=host:port. If it is not set,
+	// faraday connects to a single node using the top level connection
+	// options.
+	Nodes []string `long:"node" description:"A named lnd node to connect to, in the form name=<name>,rpcserver=<host:port>[,tlscertpath=<path>][,macaroondir=<dir>][,macaroonfile=<file>][,nomacaroons=<bool>][,loopserver=<host:port>][,looptlscertpath=<path>][,loopmacaroonpath=<path>]. Options that are not set default to the top level connection options, except for loop options which are set per node. May be specified multiple times; requests are served by the first node unless they name another."`
+
+	// nodes is the set of lnd nodes that faraday connects to, parsed from
+	// Nodes or the top level connection options.
+	nodes []*nodeConfig
+
+	// Rules is the set of close recommendation rules used by rule based recommendations when requests do not provide their own.
+	Rules []string `long:"rule" description:"A close recommendation rule, in the form [name:]expression, eg flaky:uptime_ratio < 0.8 && monitored > 60d. May be specified multiple times."`
+
+	// rules is the set of close recommendation rules parsed from Rules.
+	rules []*rules.Rule
+
+	// FiatPriceFile is an optional file containing historical bitcoin
+	// prices which are used to value fees in fiat currencies.
+	FiatPriceFile string `long:"fiatpricefile" description:"Path to a .csv file with rows of timestamp,currency,price or a .json file with a list of {timestamp, currency, price} objects, containing historical bitcoin prices used to value fees in fiat. Timestamps are unix seconds, or RFC3339 in csv files."`
+
+	// FiatPriceURL is an optional http endpoint which is queried for
+	// historical bitcoin prices which are used to value fees in fiat
+	// currencies.
+	FiatPriceURL string `long:"fiatpriceurl" description:"A http endpoint that is queried for historical bitcoin prices used to value fees in fiat. It is sent GET requests with currency, start and end query parameters and should respond with a json list of {timestamp, currency, price} objects."`
+
+	// FiatPriceTimeout is the timeout for queries to FiatPriceURL.
+	FiatPriceTimeout time.Duration `long:"fiatpricetimeout" description:"The timeout for queries to the fiat price endpoint. Valid time units are {s, m, h}."`
+
+	// LoopServer is an optional host:port that loopd's RPC server is
+	// listening on. If it is set, the cost of loop swaps is accounted
+	// for in channel insights.
+	LoopServer string `long:"loopserver" description:"host:port that loopd is listening for RPC connections on. If set, the cost of loop swaps is included in liquidity cost reports and channel insights. Only used when a single node is configured; named nodes set loopserver in their --node option."`
+
+	// LoopTLSCertPath is the path to loopd's tls cert.
+	LoopTLSCertPath string `long:"looptlscertpath" description:"Path to loopd's TLS cert. Defaults to tls.cert in loop's network directory, and loopd is connected to without TLS if the default cert does not exist."`
+
+	// LoopMacaroonPath is the path to loopd's macaroon.
+	LoopMacaroonPath string `long:"loopmacaroonpath" description:"Path to loopd's macaroon. Defaults to loop.macaroon in loop's network directory, and no macaroon is used if the default macaroon does not exist."`
+
+	// Alerts is the set of conditions that faraday alerts on, which are
+	// checked for each node.
+	Alerts []string `long:"alert" description:"A condition to alert on, checked for each node: peer_offline=<duration>, threshold=<metric>:<value>, daily_revenue=<sats> or lnd_connection[=<duration>]. May be specified multiple times; requires alertwebhook or alertcommand."`
+
+	// alerts is the set of alert specs parsed from Alerts.
+	alerts []*alert.Spec
+
+	// AlertWebhook is an optional http endpoint that alerts are POSTed
+	// to.
+	AlertWebhook string `long:"alertwebhook" description:"A http endpoint that alerts are POSTed to as json objects."`
+
+	// AlertCommand is an optional local command that is run for each
+	// alert.
+	AlertCommand string `long:"alertcommand" description:"Path to a command that is run for each alert, with the alert provided as a json object on its standard input."`
+
+	// AlertInterval is the interval at which alert conditions are
+	// checked.
+	AlertInterval time.Duration `long:"alertinterval" description:"The interval at which alert conditions are checked. Valid time units are {s, m, h}."`
+
+	// AlertCooldown is the minimum amount of time between repeated
+	// alerts for the same condition, node and subject.
+	AlertCooldown time.Duration `long:"alertcooldown" description:"The minimum amount of time before an alert for the same condition, node and subject is sent again. Valid time units are {s, m, h}."`
+
+	// ReportFile is an optional json file containing reports that are
+	// generated on a schedule.
+	ReportFile string `long:"reportfile" description:"Path to a .json file with a list of {name, schedule, report, format, keep, lookback, request} objects describing reports that are generated on a cron-like schedule."`
+
+	// reports is the set of scheduled reports read from ReportFile.
+	reports []*scheduledReport
+
+	// ReportDir is the directory that scheduled reports are written to.
+	// If it is not set, reports are written to the network directory.
+	ReportDir string `long:"reportdir" description:"Directory that scheduled reports are written to. Defaults to reports in the network directory in faradaydir."`
+
+	// ReportKeep is the default number of runs of each scheduled report
+	// that are kept.
+	ReportKeep int `long:"reportkeep" description:"The number of runs of each scheduled report to keep, for reports that do not set their own (0 to keep all runs)."`
+
+	// DashboardListen is an optional listen address for faraday's web
+	// dashboard. The dashboard is disabled if it is not set.
+	DashboardListen string `long:"dashboardlisten" description:"Address to serve faraday's web dashboard on, eg localhost:8466. The dashboard is not authenticated, so it should only be served on a local or otherwise trusted address. Disabled if not set."`
+}
+
+// nodeConfig contains the options required to connect to a single lnd node.
+type nodeConfig struct {
+	// name is the name that requests use to select the node.
+	name string
+
+	// rpcServer is host:port that the node's RPC server is listening on.
+	rpcServer string
+
+	// tlsCertPath is the path to the node's tls cert.
+	tlsCertPath string
+
+	// macaroonDir is the directory containing the node's macaroons.
+	macaroonDir string
+
+	// macaroonFile is the file name of the macaroon to use.
+	macaroonFile string
+
+	// noMacaroons indicates that we connect to the node without macaroon
+	// credentials.
+	noMacaroons bool
+
+	// loopServer is the optional host:port that the loopd instance that
+	// manages the node's liquidity is listening on.
+	loopServer string
+
+	// loopTLSCertPath is the path to loopd's tls cert.
+	loopTLSCertPath string
+
+	// loopMacaroonPath is the path to loopd's macaroon.
+	loopMacaroonPath string
+}
+
+// parseNode parses a node in the form name=alice,rpcserver=host:port. Options
+// that are not set are copied from the top level connection options in our
+// config, except for loopd options which are specific to each node.
+func (c *config) parseNode(value string) (*nodeConfig, error) {
+	node := &nodeConfig{
+		rpcServer:    c.RPCServer,
+		tlsCertPath:  c.TLSCertPath,
+		macaroonDir:  c.MacaroonDir,
+		macaroonFile: c.MacaroonFile,
+		noMacaroons:  c.NoMacaroons,
+	}
+
+	for _, option := range strings.Split(value, ",") {
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("node option: %v should be "+
+				"in the form key=value", option)
+		}
+
+		switch parts[0] {
+		case "name":
+			node.name = parts[1]
+
+		case "rpcserver":
+			node.rpcServer = parts[1]
+
+		case "tlscertpath":
+			node.tlsCertPath = cleanAndExpandPath(parts[1])
+
+		case "macaroondir":
+			node.macaroonDir = cleanAndExpandPath(parts[1])
+
+		case "macaroonfile":
+			node.macaroonFile = parts[1]
+
+		case "nomacaroons":
+			noMacaroons, err := strconv.ParseBool(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid nomacaroons "+
+					"value: %v", parts[1])
+			}
+			node.noMacaroons = noMacaroons
+
+		case "loopserver":
+			node.loopServer = parts[1]
+
+		case "looptlscertpath":
+			node.loopTLSCertPath = cleanAndExpandPath(parts[1])
+
+		case "loopmacaroonpath":
+			node.loopMacaroonPath = cleanAndExpandPath(parts[1])
+
+		default:
+			return nil, fmt.Errorf("unknown node option: %v",
+				parts[0])
+		}
+	}
+
+	if node.name == "" {
+		return nil, fmt.Errorf("node: %v has no name", value)
+	}
+
+	return node, nil
+}
+
+// parseNodes sets the nodes that faraday connects to. If no named nodes are
+// set, a single unnamed node is created from the top level connection
+// options.
+func (c *config) parseNodes() error {
+	if len(c.Nodes) == 0 {
+		c.nodes = []*nodeConfig{{
+			rpcServer:        c.RPCServer,
+			tlsCertPath:      c.TLSCertPath,
+			macaroonDir:      c.MacaroonDir,
+			macaroonFile:     c.MacaroonFile,
+			noMacaroons:      c.NoMacaroons,
+			loopServer:       c.LoopServer,
+			loopTLSCertPath:  c.LoopTLSCertPath,
+			loopMacaroonPath: c.LoopMacaroonPath,
+		}}
+
+		return nil
+	}
+
+	if c.LoopServer != "" {
+		return fmt.Errorf("loopserver cannot be used with named " +
+			"nodes, set loopserver in each node's options instead")
+	}
+
+	names := make(map[string]bool, len(c.Nodes))
+	for _, value := range c.Nodes {
+		node, err := c.parseNode(value)
+		if err != nil {
+			return err
+		}
+
+		if names[node.name] {
+			return fmt.Errorf("node name: %v used more than once",
+				node.name)
+		}
+		names[node.name] = true
+
+		c.nodes = append(c.nodes, node)
+	}
+
+	return nil
+}
+
+// nodeUptimeDB returns the path to the uptime database for the node with the
+// name provided. Unnamed nodes use the uptime database path as is, named
+// nodes have their name appended to its file name.
+func nodeUptimeDB(path, name string) string {
+	if name == "" {
+		return path
+	}
+
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%v_%v%v", strings.TrimSuffix(path, ext), name, ext)
+}
+
+// defaultConfig returns a config with default values set.
+func defaultConfig() *config {
+	return &config{
+		FaradayDir:         defaultFaradayDir,
+		ConfigFile:         defaultConfigFile,
+		RPCServer:          defaultRPCHostPort,
+		network:            defaultNetwork,
+		MacaroonFile:       defaultMacaroon,
+		MinimumMonitored:   defaultMinimumMonitor,
+		DebugLevel:         defaultDebugLevel,
+		MaxLogFiles:        defaultMaxLogFiles,
+		MaxLogFileSize:     defaultMaxLogFileSize,
+		RPCListen:          defaultRPCListen,
+		UptimePollInterval: defaultUptimePoll,
+		OneSidedThreshold:  uptime.DefaultOneSidedThreshold,
+		FiatPriceTimeout:   defaultFiatTimeout,
+		AlertInterval:      defaultAlertInterval,
+		AlertCooldown:      defaultAlertCooldown,
+		ReportKeep:         defaultReportKeep,
+	}
 }
 
-// loadConfig starts with a skeleton default config, and reads in user provided
-// configuration from the command line. It does not provide a full set of
-// defaults or validate user input because validation and sensible default
-// setting are performed by the lndclient package.
+// loadConfig reads faraday's config from its config file and the command
+// line, validates it, starts writing logs to file and sets the debug levels
+// it specifies. The log writer must be closed on shutdown.
 func loadConfig() (*config, error) {
-	// Start with a default config.
-	config := &config{
-		RPCServer:        defaultRPCHostPort,
-		network:          defaultNetwork,
-		MacaroonFile:     defaultMacaroon,
-		MinimumMonitored: defaultMinimumMonitor,
-		DebugLevel:       defaultDebugLevel,
-		RPCListen:        defaultRPCListen,
-	}
-
-	// Parse command line options to obtain user specified values.
-	if _, err := flags.Parse(config); err != nil {
+	config, err := parseConfig(os.Args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	err = logWriter.InitLogRotator(
+		filepath.Join(config.LogDir, defaultLogFilename),
+		config.MaxLogFileSize, config.MaxLogFiles,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("log rotation setup failed: %v", err)
+	}
+
+	if err := build.ParseAndSetDebugLevels(config.DebugLevel, logWriter); err != nil {
 		return nil, err
 	}
 
+	return config, nil
+}
+
+// parseConfig starts with a default config, reads in values from the config
+// file and then overrides them with the command line arguments provided.
+// The resulting config is validated, and defaults which depend on other
+// values are set.
+func parseConfig(args []string) (*config, error) {
+	// Pre-parse the command line options to pick up an alternative config
+	// file or faraday directory.
+	preCfg := defaultConfig()
+	if _, err := flags.NewParser(preCfg, flags.Default).ParseArgs(args); err != nil {
+		return nil, err
+	}
+
+	// If the user has changed their faraday directory but not their config
+	// file, we look for the config file in their faraday directory.
+	configFile := cleanAndExpandPath(preCfg.ConfigFile)
+	faradayDir := cleanAndExpandPath(preCfg.FaradayDir)
+	if faradayDir != defaultFaradayDir && configFile == defaultConfigFile {
+		configFile = filepath.Join(faradayDir, defaultConfigFilename)
+	}
+
+	// Read values from the config file. A missing config file is only an
+	// error if the user explicitly set its path.
+	config := defaultConfig()
+	parser := flags.NewParser(config, flags.Default)
+	err := flags.NewIniParser(parser).ParseFile(configFile)
+	if err != nil {
+		explicit := preCfg.ConfigFile != defaultConfigFile
+		if !os.IsNotExist(err) || explicit {
+			return nil, fmt.Errorf("could not read config file "+
+				"%v: %v", configFile, err)
+		}
+	}
+
+	// Parse the command line options again so that they take precedence
+	// over values in the config file.
+	if _, err := parser.ParseArgs(args); err != nil {
+		return nil, err
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// validate checks the values in our config, expands paths and sets the
+// defaults for files that are stored in the network directory.
+func (c *config) validate() error {
 	var netCount int
-	if config.TestNet {
-		config.network = "testnet"
+	if c.TestNet {
+		c.network = "testnet"
 		netCount++
 	}
-	if config.Regtest {
-		config.network = "regtest"
+	if c.Regtest {
+		c.network = "regtest"
 		netCount++
 	}
-	if config.Simnet {
-		config.network = "simnet"
+	if c.Simnet {
+		c.network = "simnet"
 		netCount++
 	}
 
 	if netCount > 1 {
-		return nil, fmt.Errorf("do not specify more than one network flag")
+		return fmt.Errorf("do not specify more than one network flag")
 	}
 
-	if err := build.ParseAndSetDebugLevels(config.DebugLevel, logWriter); err != nil {
-		return nil, err
+	if c.MinimumMonitored < 0 {
+		return fmt.Errorf("min_monitored must not be negative")
 	}
 
-	return config, nil
+	if c.OpportunityCostRate < 0 {
+		return fmt.Errorf("opportunity_cost_rate must not be negative")
+	}
+
+	if c.UptimePollInterval <= 0 {
+		return fmt.Errorf("uptimepoll must be positive")
+	}
+
+	if c.OneSidedThreshold < 0 || c.OneSidedThreshold >= 0.5 {
+		return fmt.Errorf("onesided_threshold must be in [0;0.5)")
+	}
+
+	if c.MaxLogFiles < 0 {
+		return fmt.Errorf("maxlogfiles must not be negative")
+	}
+
+	if c.MaxLogFileSize <= 0 {
+		return fmt.Errorf("maxlogfilesize must be positive")
+	}
+
+	if _, err := net.ResolveTCPAddr("tcp", c.RPCListen); err != nil {
+		return fmt.Errorf("invalid rpclisten address: %v", err)
+	}
+
+	if c.DashboardListen != "" {
+		_, err := net.ResolveTCPAddr("tcp", c.DashboardListen)
+		if err != nil {
+			return fmt.Errorf("invalid dashboardlisten address: %v",
+				err)
+		}
+	}
+
+	if c.RPCServer == "" {
+		return fmt.Errorf("rpcserver must be set")
+	}
+
+	// Expand all of our paths, and place files that have not been set
+	// in the network directory.
+	c.FaradayDir = cleanAndExpandPath(c.FaradayDir)
+	c.ConfigFile = cleanAndExpandPath(c.ConfigFile)
+	c.TLSCertPath = cleanAndExpandPath(c.TLSCertPath)
+	c.MacaroonDir = cleanAndExpandPath(c.MacaroonDir)
+	c.OfflineDir = cleanAndExpandPath(c.OfflineDir)
+	c.FiatPriceFile = cleanAndExpandPath(c.FiatPriceFile)
+	c.LoopTLSCertPath = cleanAndExpandPath(c.LoopTLSCertPath)
+	c.LoopMacaroonPath = cleanAndExpandPath(c.LoopMacaroonPath)
+	c.AlertCommand = cleanAndExpandPath(c.AlertCommand)
+	c.ReportFile = cleanAndExpandPath(c.ReportFile)
+
+	networkDir := filepath.Join(c.FaradayDir, c.network)
+
+	if c.LogDir == "" {
+		c.LogDir = filepath.Join(
+			c.FaradayDir, defaultLogDirname, c.network,
+		)
+	}
+	c.LogDir = cleanAndExpandPath(c.LogDir)
+
+	if c.UptimeDB == "" {
+		c.UptimeDB = filepath.Join(networkDir, defaultUptimeDBFile)
+	}
+	c.UptimeDB = cleanAndExpandPath(c.UptimeDB)
+
+	if c.CloseAuditLog == "" {
+		c.CloseAuditLog = filepath.Join(
+			networkDir, defaultCloseAuditFile,
+		)
+	}
+	c.CloseAuditLog = cleanAndExpandPath(c.CloseAuditLog)
+
+	if c.ReportDir == "" {
+		c.ReportDir = filepath.Join(networkDir, defaultReportDirname)
+	}
+	c.ReportDir = cleanAndExpandPath(c.ReportDir)
+
+	if c.OfflineDir != "" {
+		info, err := os.Stat(c.OfflineDir)
+		if err != nil {
+			return fmt.Errorf("invalid offlinedir: %v", err)
+		}
+
+		if !info.IsDir() {
+			return fmt.Errorf("offlinedir: %v is not a directory",
+				c.OfflineDir)
+		}
+	}
+
+	if c.FiatPriceFile != "" && c.FiatPriceURL != "" {
+		return fmt.Errorf("fiatpricefile and fiatpriceurl cannot " +
+			"both be set")
+	}
+
+	if c.FiatPriceURL != "" {
+		priceURL, err := url.Parse(c.FiatPriceURL)
+		if err != nil {
+			return fmt.Errorf("invalid fiatpriceurl: %v", err)
+		}
+
+		if priceURL.Scheme != "http" && priceURL.Scheme != "https" {
+			return fmt.Errorf("fiatpriceurl must be a http or " +
+				"https url")
+		}
+	}
+
+	if c.FiatPriceTimeout <= 0 {
+		return fmt.Errorf("fiatpricetimeout must be positive")
+	}
+
+	// Parse our rules so that invalid rules are reported on startup,
+	// rather than when they are used.
+	closeRules, err := recommend.ParseRules(c.Rules)
+	if err != nil {
+		return err
+	}
+	c.rules = closeRules
+
+	if err := c.parseAlerts(); err != nil {
+		return err
+	}
+
+	if err := c.parseReports(); err != nil {
+		return err
+	}
+
+	return c.parseNodes()
+}
+
+// parseAlerts parses and validates our alert options.
+func (c *config) parseAlerts() error {
+	if c.AlertInterval <= 0 {
+		return fmt.Errorf("alertinterval must be positive")
+	}
+
+	if c.AlertCooldown < 0 {
+		return fmt.Errorf("alertcooldown must not be negative")
+	}
+
+	if c.AlertWebhook != "" {
+		webhookURL, err := url.Parse(c.AlertWebhook)
+		if err != nil {
+			return fmt.Errorf("invalid alertwebhook: %v", err)
+		}
+
+		if webhookURL.Scheme != "http" && webhookURL.Scheme != "https" {
+			return fmt.Errorf("alertwebhook must be a http or " +
+				"https url")
+		}
+	}
+
+	for _, value := range c.Alerts {
+		spec, err := alert.ParseSpec(value)
+		if err != nil {
+			return err
+		}
+
+		c.alerts = append(c.alerts, spec)
+	}
+
+	if len(c.alerts) == 0 {
+		return nil
+	}
+
+	if c.AlertWebhook == "" && c.AlertCommand == "" {
+		return fmt.Errorf("alerts require alertwebhook or " +
+			"alertcommand to be set")
+	}
+
+	if c.OfflineDir != "" {
+		return fmt.Errorf("alerts cannot be used with offlinedir")
+	}
+
+	return nil
+}
+
+// parseReports reads our scheduled reports from our report file, if it is
+// set, so that invalid reports are reported on startup.
+func (c *config) parseReports() error {
+	if c.ReportKeep < 0 {
+		return fmt.Errorf("reportkeep must not be negative")
+	}
+
+	if c.ReportFile == "" {
+		return nil
+	}
+
+	if c.OfflineDir != "" {
+		return fmt.Errorf("reportfile cannot be used with offlinedir")
+	}
+
+	reports, err := readReports(c.ReportFile, c.ReportKeep)
+	if err != nil {
+		return fmt.Errorf("invalid reportfile: %v", err)
+	}
+	c.reports = reports
+
+	return nil
+}
+
+// cleanAndExpandPath expands environment variables and a leading ~ in the
+// path provided, and cleans the result.
+func cleanAndExpandPath(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	// Expand initial ~ to OS specific home directory.
+	if strings.HasPrefix(path, "~") {
+		var homeDir string
+		u, err := user.Current()
+		if err == nil {
+			homeDir = u.HomeDir
+		} else {
+			homeDir = os.Getenv("HOME")
+		}
+
+		path = strings.Replace(path, "~", homeDir, 1)
+	}
+
+	return filepath.Clean(os.ExpandEnv(path))
 }
@@ -0,0 +1,357 @@
+package faraday
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestParseConfig tests reading config from a config file and the command
+// line, and validation of the resulting config.
+func TestParseConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "faraday")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, defaultConfigFilename)
+	contents := `
+testnet=true
+min_monitored=24h
+rpclisten=localhost:9000
+node=name=alice,rpcserver=localhost:10009
+`
+	err = ioutil.WriteFile(configFile, []byte(contents), 0600)
+	if err != nil {
+		t.Fatalf("could not write config file: %v", err)
+	}
+
+	reportFile := filepath.Join(dir, "reports.json")
+	reports := `[{"name": "insights", "schedule": "@weekly",
+"report": "insights"}]`
+	err = ioutil.WriteFile(reportFile, []byte(reports), 0600)
+	if err != nil {
+		t.Fatalf("could not write report file: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		expectErr bool
+		check     func(t *testing.T, cfg *config)
+	}{
+		{
+			name: "config file in faraday dir",
+			args: []string{"--faradaydir=" + dir},
+			check: func(t *testing.T, cfg *config) {
+				if cfg.network != "testnet" {
+					t.Fatalf("expected testnet, got: %v",
+						cfg.network)
+				}
+
+				if cfg.MinimumMonitored != time.Hour*24 {
+					t.Fatalf("unexpected min monitored: "+
+						"%v", cfg.MinimumMonitored)
+				}
+
+				// Our files should be placed in the testnet
+				// directory.
+				expected := filepath.Join(
+					dir, "testnet", defaultUptimeDBFile,
+				)
+				if cfg.UptimeDB != expected {
+					t.Fatalf("expected uptime db: %v, "+
+						"got: %v", expected,
+						cfg.UptimeDB)
+				}
+
+				expected = filepath.Join(
+					dir, defaultLogDirname, "testnet",
+				)
+				if cfg.LogDir != expected {
+					t.Fatalf("expected log dir: %v, "+
+						"got: %v", expected, cfg.LogDir)
+				}
+
+				if len(cfg.nodes) != 1 ||
+					cfg.nodes[0].name != "alice" {
+
+					t.Fatalf("expected node alice, got: "+
+						"%v", cfg.nodes)
+				}
+			},
+		},
+		{
+			name: "command line overrides file",
+			args: []string{
+				"--configfile=" + configFile,
+				"--rpclisten=localhost:9001",
+				"--uptimedb=" + filepath.Join(dir, "up.db"),
+			},
+			check: func(t *testing.T, cfg *config) {
+				if cfg.RPCListen != "localhost:9001" {
+					t.Fatalf("unexpected rpclisten: %v",
+						cfg.RPCListen)
+				}
+
+				if cfg.UptimeDB != filepath.Join(dir, "up.db") {
+					t.Fatalf("unexpected uptime db: %v",
+						cfg.UptimeDB)
+				}
+			},
+		},
+		{
+			name: "loop server for single node",
+			args: []string{
+				"--faradaydir=" + filepath.Join(dir, "empty"),
+				"--loopserver=localhost:11010",
+			},
+			check: func(t *testing.T, cfg *config) {
+				if len(cfg.nodes) != 1 ||
+					cfg.nodes[0].loopServer !=
+						"localhost:11010" {
+
+					t.Fatalf("expected node with loop "+
+						"server, got: %v", cfg.nodes)
+				}
+			},
+		},
+		{
+			name: "alerts",
+			args: []string{
+				"--faradaydir=" + filepath.Join(dir, "empty"),
+				"--alert=peer_offline=1h",
+				"--alert=lnd_connection",
+				"--alertwebhook=http://localhost:8000/alerts",
+			},
+			check: func(t *testing.T, cfg *config) {
+				if len(cfg.alerts) != 2 {
+					t.Fatalf("expected 2 alerts, got: %v",
+						len(cfg.alerts))
+				}
+
+				if cfg.AlertInterval != defaultAlertInterval {
+					t.Fatalf("expected default alert "+
+						"interval, got: %v",
+						cfg.AlertInterval)
+				}
+			},
+		},
+		{
+			name: "dashboard",
+			args: []string{
+				"--faradaydir=" + filepath.Join(dir, "empty"),
+				"--dashboardlisten=localhost:8466",
+			},
+			check: func(t *testing.T, cfg *config) {
+				listen := cfg.DashboardListen
+				if listen != "localhost:8466" {
+					t.Fatalf("unexpected dashboard "+
+						"address: %v", listen)
+				}
+			},
+		},
+		{
+			name: "scheduled reports",
+			args: []string{
+				"--faradaydir=" + filepath.Join(dir, "empty"),
+				"--reportfile=" + reportFile,
+			},
+			check: func(t *testing.T, cfg *config) {
+				if len(cfg.reports) != 1 ||
+					cfg.reports[0].keep != defaultReportKeep {
+
+					t.Fatalf("expected report with "+
+						"default keep, got: %v",
+						cfg.reports)
+				}
+
+				expectedDir := filepath.Join(
+					dir, "empty", defaultNetwork,
+					defaultReportDirname,
+				)
+				if cfg.ReportDir != expectedDir {
+					t.Fatalf("expected report dir: %v, "+
+						"got: %v", expectedDir,
+						cfg.ReportDir)
+				}
+			},
+		},
+		{
+			name: "no macaroons",
+			args: []string{
+				"--faradaydir=" + filepath.Join(dir, "empty"),
+				"--nomacaroons",
+				"--node=name=alice",
+				"--node=name=bob,nomacaroons=false",
+			},
+			check: func(t *testing.T, cfg *config) {
+				if len(cfg.nodes) != 2 ||
+					!cfg.nodes[0].noMacaroons ||
+					cfg.nodes[1].noMacaroons {
+
+					t.Fatalf("expected only alice "+
+						"without macaroons, got: %v",
+						cfg.nodes)
+				}
+			},
+		},
+		{
+			name: "invalid node no macaroons",
+			args: []string{
+				"--faradaydir=" + filepath.Join(dir, "empty"),
+				"--node=name=alice,nomacaroons=maybe",
+			},
+			expectErr: true,
+		},
+		{
+			name: "missing explicit config file",
+			args: []string{
+				"--configfile=" + filepath.Join(dir, "none"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative min monitored",
+			args: []string{
+				"--faradaydir=" + dir, "--min_monitored=-1h",
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative opportunity cost rate",
+			args: []string{
+				"--faradaydir=" + dir,
+				"--opportunity_cost_rate=-0.1",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid one-sided threshold",
+			args: []string{
+				"--faradaydir=" + dir,
+				"--onesided_threshold=0.5",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid rule",
+			args: []string{
+				"--faradaydir=" + dir,
+				"--rule=idle:fees_per_conf < 10d",
+			},
+			expectErr: true,
+		},
+		{
+			name: "multiple fiat price sources",
+			args: []string{
+				"--faradaydir=" + dir,
+				"--fiatpricefile=" + configFile,
+				"--fiatpriceurl=http://localhost:8000/prices",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid fiat price url",
+			args: []string{
+				"--faradaydir=" + dir,
+				"--fiatpriceurl=localhost:8000",
+			},
+			expectErr: true,
+		},
+		{
+			name: "loop server with named nodes",
+			args: []string{
+				"--configfile=" + configFile,
+				"--loopserver=localhost:11010",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid listen address",
+			args: []string{
+				"--faradaydir=" + dir, "--rpclisten=localhost",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid log file size",
+			args: []string{
+				"--faradaydir=" + dir, "--maxlogfilesize=0",
+			},
+			expectErr: true,
+		},
+		{
+			name: "multiple networks",
+			args: []string{
+				"--faradaydir=" + dir, "--simnet",
+			},
+			expectErr: true,
+		},
+		{
+			name: "offline dir is not a directory",
+			args: []string{
+				"--faradaydir=" + dir,
+				"--offlinedir=" + configFile,
+			},
+			expectErr: true,
+		},
+		{
+			name: "alert without notifier",
+			args: []string{
+				"--faradaydir=" + dir,
+				"--alert=daily_revenue=1000",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid alert",
+			args: []string{
+				"--faradaydir=" + dir,
+				"--alert=threshold=unknown:1",
+				"--alertcommand=/usr/bin/notify",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid report file",
+			args: []string{
+				"--faradaydir=" + dir,
+				"--reportfile=" + configFile,
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid dashboard address",
+			args: []string{
+				"--faradaydir=" + dir,
+				"--dashboardlisten=localhost:dashboard",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := parseConfig(test.args)
+			if test.expectErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			test.check(t, cfg)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package faraday
+
+import (
+	"time"
+
+	"github.com/lightninglabs/faraday/dashboard"
+	"github.com/lightninglabs/faraday/frdrpc"
+)
+
+// startDashboard starts serving our web dashboard, which obtains its data
+// from our rpc server's handlers. It returns a function which stops the
+// dashboard.
+func startDashboard(config *config, server *frdrpc.RPCServer) (func(),
+	error) {
+
+	d := dashboard.NewDashboard(&dashboard.Config{
+		Server:           server,
+		Listen:           config.DashboardListen,
+		MinimumMonitored: config.MinimumMonitored,
+		Now:              time.Now,
+	})
+
+	if err := d.Start(); err != nil {
+		return nil, err
+	}
+
+	return d.Stop, nil
+}
@@ -0,0 +1,320 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/lightninglabs/faraday/frdrpc"
+)
+
+const (
+	// defaultDays is the number of days that revenue and flows are shown
+	// for if the request does not set a number of days.
+	defaultDays = 30
+
+	// maxDays is the maximum number of days that revenue and flows can be
+	// requested for.
+	maxDays = 365
+
+	// day is the length of the periods that revenue is split into.
+	day = time.Hour * 24
+)
+
+// The strategies that recommendations can be requested with.
+const (
+	outlierStrategy   = "outlier"
+	thresholdStrategy = "threshold"
+)
+
+// revenuePeriod contains the fees earned and volume forwarded over a period.
+type revenuePeriod struct {
+	// Start is the unix time that the period starts at, inclusive.
+	Start int64 `json:"start"`
+
+	// End is the unix time that the period ends at, exclusive.
+	End int64 `json:"end"`
+
+	// FeesMsat is the total fees earned over the period.
+	FeesMsat int64 `json:"fees_msat"`
+
+	// VolumeMsat is the total amount forwarded over the period.
+	VolumeMsat int64 `json:"volume_msat"`
+}
+
+// flowMatrix contains the amounts forwarded between each pair of channels.
+type flowMatrix struct {
+	// Channels is the sorted set of channels that forwarded payments.
+	Channels []string `json:"channels"`
+
+	// AmountMsat contains the amount forwarded between each pair of
+	// channels, where AmountMsat[i][j] is the amount that arrived on
+	// Channels[i] and left on Channels[j].
+	AmountMsat [][]int64 `json:"amount_msat"`
+}
+
+// info serves faraday's version and the nodes that it is connected to.
+func (d *Dashboard) info(w http.ResponseWriter, r *http.Request) {
+	resp, err := d.cfg.Server.GetInfo(r.Context(), &frdrpc.GetInfoRequest{})
+	writeProto(w, resp, err)
+}
+
+// metrics serves the metrics that recommendations can be based on.
+func (d *Dashboard) metrics(w http.ResponseWriter, r *http.Request) {
+	resp, err := d.cfg.Server.ListMetrics(
+		r.Context(), &frdrpc.ListMetricsRequest{},
+	)
+	writeProto(w, resp, err)
+}
+
+// insights serves channel insights. If the request sets a number of days,
+// revenue and volume are calculated over this period, otherwise they cover
+// the lifetime of each channel.
+func (d *Dashboard) insights(w http.ResponseWriter, r *http.Request) {
+	days, err := parseDays(r, 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := d.cfg.Server.ChannelInsights(
+		r.Context(), &frdrpc.ChannelInsightsRequest{
+			LookbackSeconds: uint64(
+				(time.Duration(days) * day).Seconds(),
+			),
+			Node: r.FormValue("node"),
+		},
+	)
+	writeProto(w, resp, err)
+}
+
+// revenue serves the fees earned and volume forwarded on each of the days
+// requested, counting back from the present.
+func (d *Dashboard) revenue(w http.ResponseWriter, r *http.Request) {
+	days, err := parseDays(r, defaultDays)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	end := d.cfg.Now()
+	start := end.Add(day * time.Duration(-days))
+
+	// We fetch the full range once, and have our server split it into
+	// days, rather than requesting a report for each day.
+	resp, err := d.cfg.Server.RevenueReport(
+		r.Context(), &frdrpc.RevenueReportRequest{
+			StartTime:     uint64(start.Unix()),
+			EndTime:       uint64(end.Unix()),
+			Node:          r.FormValue("node"),
+			PeriodSeconds: uint64(day.Seconds()),
+		},
+	)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	periods := make([]*revenuePeriod, 0, len(resp.Periods))
+	for _, period := range resp.Periods {
+		periods = append(periods, &revenuePeriod{
+			Start:      int64(period.StartTime),
+			End:        int64(period.EndTime),
+			FeesMsat:   period.FeesMsat,
+			VolumeMsat: period.VolumeMsat,
+		})
+	}
+
+	writeJSON(w, periods)
+}
+
+// flows serves the amounts forwarded between each pair of channels over the
+// number of days requested.
+func (d *Dashboard) flows(w http.ResponseWriter, r *http.Request) {
+	days, err := parseDays(r, defaultDays)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	end := d.cfg.Now()
+	start := end.Add(day * time.Duration(-days))
+
+	resp, err := d.cfg.Server.RevenueReport(
+		r.Context(), &frdrpc.RevenueReportRequest{
+			StartTime: uint64(start.Unix()),
+			EndTime:   uint64(end.Unix()),
+			Node:      r.FormValue("node"),
+		},
+	)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, getFlowMatrix(resp))
+}
+
+// getFlowMatrix creates a matrix of the amounts forwarded between each pair
+// of channels in a revenue report.
+func getFlowMatrix(resp *frdrpc.RevenueReportResponse) *flowMatrix {
+	channelSet := make(map[string]bool)
+	for _, report := range resp.Reports {
+		channelSet[report.TargetChannel] = true
+
+		for pair := range report.PairReports {
+			channelSet[pair] = true
+		}
+	}
+
+	matrix := &flowMatrix{
+		Channels:   make([]string, 0, len(channelSet)),
+		AmountMsat: make([][]int64, len(channelSet)),
+	}
+
+	for channel := range channelSet {
+		matrix.Channels = append(matrix.Channels, channel)
+	}
+	sort.Strings(matrix.Channels)
+
+	index := make(map[string]int, len(matrix.Channels))
+	for i, channel := range matrix.Channels {
+		index[channel] = i
+		matrix.AmountMsat[i] = make([]int64, len(matrix.Channels))
+	}
+
+	unresolved := make(map[string]bool)
+	for _, chanID := range resp.GetUnattributed().GetShortChannelIds() {
+		unresolved[chanID] = true
+	}
+
+	// Each forward is recorded as incoming on the channel it arrived on,
+	// paired with the channel that it left on. Forwards that arrived on a
+	// channel that could not be resolved are only recorded as outgoing on
+	// the channel that they left on, so we add them from that side.
+	for _, report := range resp.Reports {
+		target := index[report.TargetChannel]
+
+		for pair, pairReport := range report.PairReports {
+			matrix.AmountMsat[target][index[pair]] +=
+				pairReport.AmountIncomingMsat
+
+			if unresolved[pair] {
+				matrix.AmountMsat[index[pair]][target] +=
+					pairReport.AmountOutgoingMsat +
+						pairReport.FeesOutgoingMsat
+			}
+		}
+	}
+
+	return matrix
+}
+
+// recommendations serves close recommendations for the strategy and metric
+// requested. Outlier recommendations use the value provided as their
+// outlier multiplier, and threshold recommendations use it as their
+// threshold.
+func (d *Dashboard) recommendations(w http.ResponseWriter, r *http.Request) {
+	var value float64
+	if valueStr := r.FormValue("value"); valueStr != "" {
+		var err error
+		value, err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest,
+				fmt.Errorf("invalid value: %v", valueStr))
+			return
+		}
+	}
+
+	recRequest := &frdrpc.CloseRecommendationRequest{
+		MinimumMonitored: int64(d.cfg.MinimumMonitored.Seconds()),
+		MetricName:       r.FormValue("metric"),
+		Node:             r.FormValue("node"),
+	}
+
+	var (
+		resp *frdrpc.CloseRecommendationsResponse
+		err  error
+	)
+
+	switch r.FormValue("strategy") {
+	case outlierStrategy, "":
+		resp, err = d.cfg.Server.OutlierRecommendations(
+			r.Context(), &frdrpc.OutlierRecommendationsRequest{
+				RecRequest:        recRequest,
+				OutlierMultiplier: float32(value),
+			},
+		)
+
+	case thresholdStrategy:
+		resp, err = d.cfg.Server.ThresholdRecommendations(
+			r.Context(), &frdrpc.ThresholdRecommendationsRequest{
+				RecRequest:     recRequest,
+				ThresholdValue: float32(value),
+			},
+		)
+
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown "+
+			"strategy: %v", r.FormValue("strategy")))
+		return
+	}
+
+	writeProto(w, resp, err)
+}
+
+// parseDays parses the number of days set in a request, returning the
+// default provided if it is not set.
+func parseDays(r *http.Request, defaultValue int) (int, error) {
+	daysStr := r.FormValue("days")
+	if daysStr == "" {
+		return defaultValue, nil
+	}
+
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days < 0 || days > maxDays {
+		return 0, fmt.Errorf("days must be a number in [0;%v]",
+			maxDays)
+	}
+
+	return days, nil
+}
+
+// writeProto writes a rpc response in the same json format as frcli's
+// output, or an error if the rpc call failed.
+func writeProto(w http.ResponseWriter, resp proto.Message, err error) {
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	marshaler := &jsonpb.Marshaler{
+		OrigName:     true,
+		EmitDefaults: true,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := marshaler.Marshal(w, resp); err != nil {
+		log.Errorf("could not write response: %v", err)
+	}
+}
+
+// writeJSON writes a value as json.
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Errorf("could not write response: %v", err)
+	}
+}
+
+// writeError writes an error with the status code provided.
+func writeError(w http.ResponseWriter, status int, err error) {
+	log.Debugf("dashboard request failed: %v", err)
+
+	http.Error(w, err.Error(), status)
+}
@@ -0,0 +1,428 @@
+package dashboard
+
+// asset is a static file that is served by the dashboard.
+type asset struct {
+	contentType string
+	content     string
+}
+
+// assets maps the paths that static assets are served on to their contents.
+// The assets are compiled into faraday's binary so that the dashboard does
+// not depend on any files being present on disk.
+var assets = map[string]*asset{
+	"/": {
+		contentType: "text/html; charset=utf-8",
+		content:     indexHTML,
+	},
+	"/app.js": {
+		contentType: "application/javascript; charset=utf-8",
+		content:     appJS,
+	},
+	"/style.css": {
+		contentType: "text/css; charset=utf-8",
+		content:     styleCSS,
+	},
+}
+
+// indexHTML is the dashboard's single page.
+const indexHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Faraday</title>
+<link rel="stylesheet" href="/style.css">
+</head>
+<body>
+<header>
+  <h1>Faraday</h1>
+  <label>Node <select id="node"></select></label>
+  <label>Days <input id="days" type="number" min="1" max="365"
+    value="30"></label>
+  <button id="refresh">Refresh</button>
+  <span id="version"></span>
+</header>
+
+<section>
+  <h2>Channel Insights</h2>
+  <table id="insights"></table>
+</section>
+
+<section>
+  <h2>Revenue</h2>
+  <div id="revenue"></div>
+</section>
+
+<section>
+  <h2>Forwarding Flows</h2>
+  <p class="note">Rows are the channel forwards arrived on, columns are
+    the channel they left on.</p>
+  <table id="flows"></table>
+</section>
+
+<section>
+  <h2>Close Recommendations</h2>
+  <form id="recommendations-form">
+    <label>Strategy <select id="strategy">
+      <option value="outlier">Outlier</option>
+      <option value="threshold">Threshold</option>
+    </select></label>
+    <label>Metric <select id="metric"></select></label>
+    <label>Value <input id="value" type="number" step="any"
+      placeholder="multiplier or threshold"></label>
+    <button type="submit">Recommend</button>
+  </form>
+  <p id="bounds" class="note"></p>
+  <table id="recommendations"></table>
+</section>
+
+<p id="error"></p>
+<script src="/app.js"></script>
+</body>
+</html>
+`
+
+// appJS fetches the dashboard's data from its api and renders it.
+const appJS = `(function() {
+  "use strict";
+
+  var insightColumns = [
+    ["chan_point", "Channel"],
+    ["capacity_sat", "Capacity (sat)"],
+    ["private", "Private"],
+    ["monitored_seconds", "Monitored (s)"],
+    ["uptime_seconds", "Uptime (s)"],
+    ["volume_incoming_msat", "Incoming (msat)"],
+    ["volume_outgoing_msat", "Outgoing (msat)"],
+    ["fees_earned_msat", "Fees (msat)"],
+    ["net_fees_msat", "Net Fees (msat)"],
+    ["balance_ratio", "Balance Ratio"],
+    ["confirmations", "Confirmations"]
+  ];
+
+  var insights = [];
+  var sortKey = "chan_point";
+  var sortAscending = true;
+
+  function el(id) {
+    return document.getElementById(id);
+  }
+
+  function query(path, params) {
+    var parts = [];
+    params.node = el("node").value || "";
+    Object.keys(params).forEach(function(key) {
+      parts.push(encodeURIComponent(key) + "=" +
+        encodeURIComponent(params[key]));
+    });
+
+    return fetch(path + "?" + parts.join("&")).then(function(resp) {
+      if (!resp.ok) {
+        return resp.text().then(function(text) {
+          throw new Error(path + ": " + text);
+        });
+      }
+      return resp.json();
+    });
+  }
+
+  function showError(err) {
+    el("error").textContent = err.message;
+  }
+
+  function cell(row, text, header) {
+    var c = document.createElement(header ? "th" : "td");
+    c.textContent = text;
+    row.appendChild(c);
+    return c;
+  }
+
+  function days() {
+    return el("days").value || "30";
+  }
+
+  function renderInsights() {
+    var table = el("insights");
+    table.innerHTML = "";
+
+    var head = table.insertRow();
+    insightColumns.forEach(function(column) {
+      var label = column[1];
+      if (column[0] === sortKey) {
+        label += sortAscending ? " ▲" : " ▼";
+      }
+
+      var c = cell(head, label, true);
+      c.className = "sortable";
+      c.onclick = function() {
+        sortAscending = column[0] === sortKey ? !sortAscending : true;
+        sortKey = column[0];
+        renderInsights();
+      };
+    });
+
+    var sorted = insights.slice().sort(function(a, b) {
+      var x = a[sortKey], y = b[sortKey];
+      if (!isNaN(Number(x)) && !isNaN(Number(y))) {
+        x = Number(x);
+        y = Number(y);
+      }
+      var result = x < y ? -1 : x > y ? 1 : 0;
+      return sortAscending ? result : -result;
+    });
+
+    sorted.forEach(function(insight) {
+      var row = table.insertRow();
+      insightColumns.forEach(function(column) {
+        cell(row, String(insight[column[0]]));
+      });
+    });
+  }
+
+  function loadInsights() {
+    return query("/api/insights", {days: days()}).then(function(resp) {
+      insights = resp.channel_insights || [];
+      renderInsights();
+    });
+  }
+
+  function loadRevenue() {
+    return query("/api/revenue", {days: days()}).then(function(periods) {
+      var width = 800, height = 200, gap = 2;
+      var slot = width / Math.max(periods.length, 1);
+      var barWidth = Math.max(slot - gap, 1);
+      var maxFees = 1, maxVolume = 1;
+      periods.forEach(function(p) {
+        maxFees = Math.max(maxFees, p.fees_msat);
+        maxVolume = Math.max(maxVolume, p.volume_msat);
+      });
+
+      var ns = "http://www.w3.org/2000/svg";
+      var svg = document.createElementNS(ns, "svg");
+      svg.setAttribute("viewBox", "0 0 " + width + " " + height);
+      svg.setAttribute("class", "chart");
+
+      periods.forEach(function(p, i) {
+        var x = i * (barWidth + gap);
+        var volume = document.createElementNS(ns, "rect");
+        var volumeHeight = height * p.volume_msat / maxVolume;
+        volume.setAttribute("x", x);
+        volume.setAttribute("y", height - volumeHeight);
+        volume.setAttribute("width", barWidth);
+        volume.setAttribute("height", volumeHeight);
+        volume.setAttribute("class", "volume");
+
+        var fees = document.createElementNS(ns, "rect");
+        var feesHeight = height * p.fees_msat / maxFees;
+        fees.setAttribute("x", x + barWidth / 4);
+        fees.setAttribute("y", height - feesHeight);
+        fees.setAttribute("width", barWidth / 2);
+        fees.setAttribute("height", feesHeight);
+        fees.setAttribute("class", "fees");
+
+        var title = document.createElementNS(ns, "title");
+        title.textContent = new Date(p.start * 1000).toDateString() +
+          ": " + p.fees_msat + " msat fees, " + p.volume_msat +
+          " msat volume";
+
+        var group = document.createElementNS(ns, "g");
+        group.appendChild(volume);
+        group.appendChild(fees);
+        group.appendChild(title);
+        svg.appendChild(group);
+      });
+
+      var container = el("revenue");
+      container.innerHTML = "";
+      container.appendChild(svg);
+
+      var legend = document.createElement("p");
+      legend.className = "note";
+      legend.textContent = "Daily fees (dark, max " + maxFees +
+        " msat) and volume (light, max " + maxVolume + " msat).";
+      container.appendChild(legend);
+    });
+  }
+
+  function loadFlows() {
+    return query("/api/flows", {days: days()}).then(function(matrix) {
+      var table = el("flows");
+      table.innerHTML = "";
+
+      var max = 1;
+      matrix.amount_msat.forEach(function(row) {
+        row.forEach(function(amount) {
+          max = Math.max(max, amount);
+        });
+      });
+
+      var head = table.insertRow();
+      cell(head, "", true);
+      matrix.channels.forEach(function(channel) {
+        cell(head, channel, true);
+      });
+
+      matrix.channels.forEach(function(channel, i) {
+        var row = table.insertRow();
+        cell(row, channel, true);
+        matrix.amount_msat[i].forEach(function(amount) {
+          var c = cell(row, amount ? String(amount) : "");
+          var alpha = amount / max;
+          c.style.backgroundColor = "rgba(46, 125, 50, " + alpha + ")";
+        });
+      });
+    });
+  }
+
+  function loadRecommendations(event) {
+    if (event) {
+      event.preventDefault();
+    }
+
+    var metric = el("metric").value;
+    if (!metric) {
+      return Promise.resolve();
+    }
+
+    var params = {
+      strategy: el("strategy").value,
+      metric: metric,
+      value: el("value").value
+    };
+
+    return query("/api/recommendations", params).then(function(resp) {
+      var bounds = resp.outlier_bounds;
+      if (bounds) {
+        el("bounds").textContent = "Quartiles: " + bounds.lower_quartile +
+          " - " + bounds.upper_quartile + ", outlier bounds: " +
+          bounds.lower_outlier + " - " + bounds.upper_outlier;
+      } else if (params.strategy === "threshold") {
+        el("bounds").textContent = "Threshold: " + params.value;
+      } else {
+        el("bounds").textContent = "";
+      }
+
+      var table = el("recommendations");
+      table.innerHTML = "";
+
+      var head = table.insertRow();
+      ["Channel", "Value", "Recommend Close"].forEach(function(label) {
+        cell(head, label, true);
+      });
+
+      var recs = resp.recommendations || [];
+      recs.forEach(function(rec) {
+        var row = table.insertRow();
+        cell(row, rec.chan_point);
+        cell(row, String(rec.value));
+        cell(row, String(rec.recommend_close));
+        if (rec.recommend_close) {
+          row.className = "close";
+        }
+      });
+    }).catch(showError);
+  }
+
+  function refresh() {
+    el("error").textContent = "";
+    Promise.all([
+      loadInsights(), loadRevenue(), loadFlows(), loadRecommendations()
+    ]).catch(showError);
+  }
+
+  function init() {
+    var info = query("/api/info", {}).then(function(resp) {
+      el("version").textContent = "v" + resp.version;
+
+      var nodes = resp.nodes || [];
+      nodes.forEach(function(node) {
+        var option = document.createElement("option");
+        option.value = node.node;
+        option.textContent = node.alias || node.node || node.pubkey;
+        el("node").appendChild(option);
+      });
+    });
+
+    var metrics = query("/api/metrics", {}).then(function(resp) {
+      var list = resp.metrics || [];
+      list.forEach(function(metric) {
+        var option = document.createElement("option");
+        option.value = metric.name;
+        option.textContent = metric.name;
+        el("metric").appendChild(option);
+      });
+    });
+
+    el("refresh").onclick = refresh;
+    el("node").onchange = refresh;
+    el("recommendations-form").onsubmit = loadRecommendations;
+
+    Promise.all([info, metrics]).then(refresh).catch(showError);
+  }
+
+  init();
+})();
+`
+
+// styleCSS is the dashboard's stylesheet.
+const styleCSS = `body {
+  font-family: sans-serif;
+  margin: 0 2em 2em 2em;
+  color: #212121;
+}
+
+header {
+  display: flex;
+  align-items: center;
+  gap: 1em;
+  border-bottom: 1px solid #e0e0e0;
+}
+
+section {
+  margin-top: 2em;
+  overflow-x: auto;
+}
+
+table {
+  border-collapse: collapse;
+  font-size: 0.9em;
+}
+
+th, td {
+  border: 1px solid #e0e0e0;
+  padding: 0.3em 0.6em;
+  text-align: right;
+}
+
+th.sortable {
+  cursor: pointer;
+  user-select: none;
+}
+
+tr.close {
+  background-color: #ffebee;
+}
+
+.chart {
+  width: 100%;
+  max-width: 800px;
+  height: 200px;
+  background-color: #fafafa;
+}
+
+.chart .volume {
+  fill: #a5d6a7;
+}
+
+.chart .fees {
+  fill: #2e7d32;
+}
+
+.note {
+  color: #757575;
+  font-size: 0.9em;
+}
+
+#error {
+  color: #c62828;
+}
+`
@@ -0,0 +1,138 @@
+// Package dashboard serves a web dashboard which displays faraday's reports
+// in a browser. The dashboard shows:
+//   - Channel insights, as a sortable table
+//   - Fees earned and volume forwarded over time
+//   - The flow of forwards between pairs of channels
+//   - Close recommendations, along with the bounds they were based on
+//
+// All of the dashboard's data is obtained from faraday's rpc handlers, so it
+// is consistent with the reports that frcli provides. Its static assets are
+// compiled into the binary, so that it does not depend on any files on disk.
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+)
+
+// shutdownTimeout is the amount of time we allow for in-flight requests to
+// complete when the dashboard is stopped.
+const shutdownTimeout = time.Second * 5
+
+// Config provides the rpc handlers and parameters used by the dashboard.
+type Config struct {
+	// Server provides the rpc handlers that the dashboard's data is
+	// obtained from.
+	Server frdrpc.FaradayServerServer
+
+	// Listen is the address that the dashboard listens on.
+	Listen string
+
+	// Listener is an optional listener that the dashboard serves on. If
+	// it is set, Listen is not used.
+	Listener net.Listener
+
+	// MinimumMonitored is the minimum amount of time that channels must
+	// be monitored for to be considered for close recommendations.
+	MinimumMonitored time.Duration
+
+	// Now returns the current time.
+	Now func() time.Time
+}
+
+// Dashboard serves faraday's web dashboard.
+type Dashboard struct {
+	cfg *Config
+
+	server *http.Server
+	wg     sync.WaitGroup
+}
+
+// NewDashboard creates a new dashboard. Note that the dashboard returned is
+// not serving requests until it is started using Start().
+func NewDashboard(cfg *Config) *Dashboard {
+	d := &Dashboard{
+		cfg: cfg,
+	}
+
+	mux := http.NewServeMux()
+	for path, asset := range assets {
+		mux.HandleFunc(path, serveAsset(path, asset))
+	}
+
+	mux.HandleFunc("/api/info", d.info)
+	mux.HandleFunc("/api/metrics", d.metrics)
+	mux.HandleFunc("/api/insights", d.insights)
+	mux.HandleFunc("/api/revenue", d.revenue)
+	mux.HandleFunc("/api/flows", d.flows)
+	mux.HandleFunc("/api/recommendations", d.recommendations)
+
+	d.server = &http.Server{
+		Handler: mux,
+	}
+
+	return d
+}
+
+// Start starts serving the dashboard.
+func (d *Dashboard) Start() error {
+	listener := d.cfg.Listener
+	if listener == nil {
+		var err error
+		listener, err = net.Listen("tcp", d.cfg.Listen)
+		if err != nil {
+			return fmt.Errorf("dashboard unable to listen on "+
+				"%v: %v", d.cfg.Listen, err)
+		}
+	}
+
+	log.Infof("Serving dashboard on: http://%v", listener.Addr())
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		err := d.server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			log.Errorf("could not serve dashboard: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the dashboard, allowing in-flight requests a short time to
+// complete.
+func (d *Dashboard) Stop() {
+	ctx, cancel := context.WithTimeout(
+		context.Background(), shutdownTimeout,
+	)
+	defer cancel()
+
+	if err := d.server.Shutdown(ctx); err != nil {
+		log.Errorf("could not shut down dashboard: %v", err)
+	}
+
+	d.wg.Wait()
+}
+
+// serveAsset returns a handler which serves a static asset at the path
+// provided. Our mux routes paths that are not otherwise handled to the root
+// path, so we check the request's path before serving the asset.
+func serveAsset(path string, asset *asset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", asset.contentType)
+		_, _ = w.Write([]byte(asset.content))
+	}
+}
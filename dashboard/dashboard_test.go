@@ -0,0 +1,286 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+)
+
+// mockServer mocks the rpc handlers used by the dashboard. Calls to any
+// handlers that are not overridden will panic.
+type mockServer struct {
+	frdrpc.FaradayServerServer
+
+	revenueRequests []*frdrpc.RevenueReportRequest
+	revenue         *frdrpc.RevenueReportResponse
+}
+
+// RevenueReport records the request provided and returns our mocked
+// response.
+func (m *mockServer) RevenueReport(_ context.Context,
+	req *frdrpc.RevenueReportRequest) (*frdrpc.RevenueReportResponse,
+	error) {
+
+	m.revenueRequests = append(m.revenueRequests, req)
+	return m.revenue, nil
+}
+
+// OutlierRecommendations returns a single recommendation for the metric
+// requested.
+func (m *mockServer) OutlierRecommendations(_ context.Context,
+	req *frdrpc.OutlierRecommendationsRequest) (
+	*frdrpc.CloseRecommendationsResponse, error) {
+
+	return &frdrpc.CloseRecommendationsResponse{
+		Recommendations: []*frdrpc.Recommendation{
+			{
+				ChanPoint: req.RecRequest.MetricName,
+			},
+		},
+	}, nil
+}
+
+// TestGetFlowMatrix tests creation of a flow matrix from a revenue report.
+func TestGetFlowMatrix(t *testing.T) {
+	resp := &frdrpc.RevenueReportResponse{
+		Reports: []*frdrpc.RevenueReport{
+			{
+				TargetChannel: "b:1",
+				PairReports: map[string]*frdrpc.PairReport{
+					"a:1": {
+						AmountIncomingMsat: 100,
+						AmountOutgoingMsat: 20,
+					},
+				},
+			},
+			{
+				TargetChannel: "a:1",
+				PairReports: map[string]*frdrpc.PairReport{
+					"b:1": {
+						AmountIncomingMsat: 20,
+						AmountOutgoingMsat: 100,
+					},
+					"c:1": {
+						AmountOutgoingMsat: 50,
+					},
+					"600:1:0": {
+						AmountOutgoingMsat: 30,
+						FeesOutgoingMsat:   3,
+					},
+				},
+			},
+		},
+		Unattributed: &frdrpc.UnattributedReport{
+			ShortChannelIds: []string{"600:1:0"},
+		},
+	}
+
+	// The forward that arrived on our unresolved channel is only
+	// recorded as outgoing on a:1, so it is added from that side.
+	expected := &flowMatrix{
+		Channels: []string{"600:1:0", "a:1", "b:1", "c:1"},
+		AmountMsat: [][]int64{
+			{0, 33, 0, 0},
+			{0, 0, 20, 0},
+			{0, 100, 0, 0},
+			{0, 0, 0, 0},
+		},
+	}
+
+	matrix := getFlowMatrix(resp)
+	if !reflect.DeepEqual(matrix, expected) {
+		t.Fatalf("expected: %+v, got: %+v", expected, matrix)
+	}
+}
+
+// TestRevenue tests serving of revenue split into daily periods with a
+// single revenue report request.
+func TestRevenue(t *testing.T) {
+	var (
+		now        = time.Unix(1000000, 0)
+		dayStart   = now.Add(-day)
+		firstStart = dayStart.Add(-day)
+	)
+
+	server := &mockServer{
+		revenue: &frdrpc.RevenueReportResponse{
+			Periods: []*frdrpc.RevenuePeriod{
+				{
+					StartTime:  uint64(firstStart.Unix()),
+					EndTime:    uint64(dayStart.Unix()),
+					Forwards:   1,
+					FeesMsat:   3,
+					VolumeMsat: 100,
+				},
+				{
+					StartTime: uint64(dayStart.Unix()),
+					EndTime:   uint64(now.Unix()),
+				},
+			},
+		},
+	}
+
+	dashboard := NewDashboard(&Config{
+		Server: server,
+		Now: func() time.Time {
+			return now
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(
+		http.MethodGet, "/api/revenue?days=2&node=alice", nil,
+	)
+	dashboard.server.Handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected ok, got: %v", rec.Code)
+	}
+
+	var periods []*revenuePeriod
+	if err := json.NewDecoder(rec.Body).Decode(&periods); err != nil {
+		t.Fatalf("could not decode response: %v", err)
+	}
+
+	expected := []*revenuePeriod{
+		{
+			Start:      firstStart.Unix(),
+			End:        dayStart.Unix(),
+			FeesMsat:   3,
+			VolumeMsat: 100,
+		},
+		{
+			Start: dayStart.Unix(),
+			End:   now.Unix(),
+		},
+	}
+	if !reflect.DeepEqual(periods, expected) {
+		t.Fatalf("expected: %+v, got: %+v", expected, periods)
+	}
+
+	expectedReq := &frdrpc.RevenueReportRequest{
+		StartTime:     uint64(firstStart.Unix()),
+		EndTime:       uint64(now.Unix()),
+		Node:          "alice",
+		PeriodSeconds: uint64(day.Seconds()),
+	}
+
+	if len(server.revenueRequests) != 1 ||
+		!reflect.DeepEqual(server.revenueRequests[0], expectedReq) {
+
+		t.Fatalf("expected single request: %v, got: %v", expectedReq,
+			server.revenueRequests)
+	}
+}
+
+// TestHandlers tests the status codes and content types returned by the
+// dashboard for various requests.
+func TestHandlers(t *testing.T) {
+	dashboard := NewDashboard(&Config{
+		Server: &mockServer{
+			revenue: &frdrpc.RevenueReportResponse{},
+		},
+		Now: time.Now,
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedType   string
+		expectedBody   string
+	}{
+		{
+			name:           "index",
+			path:           "/",
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/html; charset=utf-8",
+		},
+		{
+			name:           "script",
+			path:           "/app.js",
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/javascript; charset=utf-8",
+		},
+		{
+			name:           "unknown path",
+			path:           "/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "flows",
+			path:           "/api/flows?days=7",
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json",
+		},
+		{
+			name:           "too many days",
+			path:           "/api/flows?days=366",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid days",
+			path:           "/api/revenue?days=week",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "outlier recommendations",
+			path:           "/api/recommendations?metric=uptime",
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json",
+			expectedBody:   `"chan_point":"uptime"`,
+		},
+		{
+			name: "unknown strategy",
+			path: "/api/recommendations?metric=uptime&" +
+				"strategy=random",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid value",
+			path: "/api/recommendations?metric=uptime&" +
+				"value=high",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(
+				http.MethodGet, test.path, nil,
+			)
+			dashboard.server.Handler.ServeHTTP(rec, req)
+
+			if rec.Code != test.expectedStatus {
+				t.Fatalf("expected status: %v, got: %v",
+					test.expectedStatus, rec.Code)
+			}
+
+			if test.expectedType == "" {
+				return
+			}
+
+			contentType := rec.Header().Get("Content-Type")
+			if contentType != test.expectedType {
+				t.Fatalf("expected content type: %v, got: %v",
+					test.expectedType, contentType)
+			}
+
+			body := rec.Body.String()
+			if !strings.Contains(body, test.expectedBody) {
+				t.Fatalf("expected body to contain: %v, "+
+					"got: %v", test.expectedBody, body)
+			}
+		})
+	}
+}
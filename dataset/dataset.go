@@ -139,6 +139,45 @@ func (d Dataset) isIQROutlier(value, lowerQuartile, upperQuartile,
 	}
 }
 
+// Bounds contains the quartiles of a dataset and the bounds beyond which
+// values in the dataset are inter-quartile outliers.
+type Bounds struct {
+	// LowerQuartile is the lower quartile of the dataset.
+	LowerQuartile float64
+
+	// UpperQuartile is the upper quartile of the dataset.
+	UpperQuartile float64
+
+	// LowerOutlier is the value below which values are lower outliers.
+	LowerOutlier float64
+
+	// UpperOutlier is the value above which values are upper outliers.
+	UpperOutlier float64
+}
+
+// OutlierBounds returns the bounds beyond which values in the dataset are
+// outliers for the outlier multiplier provided, using the same calculation
+// as GetOutliers. If there are too few values to calculate quartiles, it
+// returns nil.
+func (d Dataset) OutlierBounds(outlierMultiplier float64) (*Bounds, error) {
+	lower, upper, err := d.quartiles()
+	if err == errTooFewValues {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	quartileDistance := (upper - lower) * outlierMultiplier
+
+	return &Bounds{
+		LowerQuartile: lower,
+		UpperQuartile: upper,
+		LowerOutlier:  lower - quartileDistance,
+		UpperOutlier:  upper + quartileDistance,
+	}, nil
+}
+
 // GetOutliers returns a map of the labels in the dataset to outlier results
 // which indicate whether the associated value is an upper or lower inter-
 // quartile outlier. If there are too few values to calculate inter-quartile
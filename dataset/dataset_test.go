@@ -145,6 +145,62 @@ func TestQuartiles(t *testing.T) {
 	}
 }
 
+// TestOutlierBounds tests calculation of the bounds beyond which values are
+// outliers.
+func TestOutlierBounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []float64
+		multiplier float64
+		expected   *Bounds
+	}{
+		{
+			name:       "too few values",
+			values:     []float64{1, 2},
+			multiplier: 1.5,
+			expected:   nil,
+		},
+		{
+			name: "example dataset",
+			values: []float64{
+				1, 2, 5, 5, 5, 6, 6, 6, 8, 11,
+			},
+			multiplier: 3,
+			expected: &Bounds{
+				LowerQuartile: 5,
+				UpperQuartile: 6,
+				LowerOutlier:  2,
+				UpperOutlier:  9,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			valueMap := make(map[string]float64)
+			for i, value := range test.values {
+				valueMap[fmt.Sprintf("%v", i)] = value
+			}
+
+			bounds, err := New(valueMap).OutlierBounds(
+				test.multiplier,
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(bounds, test.expected) {
+				t.Fatalf("expected: %+v, got: %+v",
+					test.expected, bounds)
+			}
+		})
+	}
+}
+
 // TestIsOutlier tests getting of upper and lower interquartile outliers.
 func TestIsOutlier(t *testing.T) {
 	// noOutlier is a outlier result for a value which is not an outlier.
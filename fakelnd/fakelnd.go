@@ -0,0 +1,606 @@
+// Package fakelnd provides an in-memory implementation of lnd's
+// LightningClient which can be used to test code that queries lnd without a
+// running node. The state that the client serves is set by the test using the
+// client, and can be changed while the client is in use.
+//
+// Only the calls that faraday makes to lnd are implemented. Calls to any other
+// method will panic, so that tests which rely on unimplemented behaviour fail
+// loudly rather than receiving empty responses.
+package fakelnd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/faraday/utils"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc"
+)
+
+// defaultMaxEvents is the number of forwarding events that lnd returns if a
+// request does not specify a maximum.
+const defaultMaxEvents = 100
+
+var (
+	// ErrChannelNotFound is returned when a call references a channel that
+	// the client does not know about.
+	ErrChannelNotFound = errors.New("channel not found")
+
+	// ErrNodeNotFound is returned when a node is not present in the
+	// client's graph.
+	ErrNodeNotFound = errors.New("node not found")
+)
+
+// Client is an in-memory implementation of lnrpc.LightningClient. It is safe
+// for concurrent use.
+type Client struct {
+	// LightningClient is embedded so that Client satisfies the full
+	// interface. It is left nil so that calls to methods that are not
+	// implemented by the fake panic.
+	lnrpc.LightningClient
+
+	// Now returns the current time. It is used to default the end time
+	// of forwarding history queries.
+	Now func() time.Time
+
+	mu sync.Mutex
+
+	info *lnrpc.GetInfoResponse
+
+	channels []*lnrpc.Channel
+
+	closedChannels []*lnrpc.ChannelCloseSummary
+
+	waitingClose []*lnrpc.PendingChannelsResponse_WaitingCloseChannel
+
+	forwards []*lnrpc.ForwardingEvent
+
+	peers []*lnrpc.Peer
+
+	nodes map[string]*lnrpc.LightningNode
+
+	edges map[uint64]*lnrpc.ChannelEdge
+
+	closeRequests []*lnrpc.CloseChannelRequest
+
+	eventSubscribers map[int]*channelEventStream
+
+	nextSubscriber int
+
+	errors map[string]error
+}
+
+// NewClient returns a client with no channels, forwards or graph data.
+func NewClient() *Client {
+	return &Client{
+		Now:              time.Now,
+		info:             &lnrpc.GetInfoResponse{},
+		nodes:            make(map[string]*lnrpc.LightningNode),
+		edges:            make(map[uint64]*lnrpc.ChannelEdge),
+		eventSubscribers: make(map[int]*channelEventStream),
+		errors:           make(map[string]error),
+	}
+}
+
+// SetInfo sets the response returned by GetInfo.
+func (c *Client) SetInfo(info *lnrpc.GetInfoResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.info = info
+}
+
+// SetChannels replaces the client's set of open channels.
+func (c *Client) SetChannels(channels ...*lnrpc.Channel) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.channels = channels
+}
+
+// SetClosedChannels replaces the client's set of closed channels.
+func (c *Client) SetClosedChannels(channels ...*lnrpc.ChannelCloseSummary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closedChannels = channels
+}
+
+// AddForwards appends forwarding events to the client's forwarding log. The
+// log is kept sorted by timestamp, as it is in lnd.
+func (c *Client) AddForwards(forwards ...*lnrpc.ForwardingEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, fwd := range forwards {
+		// Insert the forward after any events with the same or an
+		// earlier timestamp so that events at the same time retain
+		// the order they were added in.
+		i := len(c.forwards)
+		for i > 0 && c.forwards[i-1].Timestamp > fwd.Timestamp {
+			i--
+		}
+
+		c.forwards = append(c.forwards, nil)
+		copy(c.forwards[i+1:], c.forwards[i:])
+		c.forwards[i] = fwd
+	}
+}
+
+// SetPeers replaces the client's set of connected peers.
+func (c *Client) SetPeers(peers ...*lnrpc.Peer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.peers = peers
+}
+
+// AddNode adds a node to the client's graph, replacing any existing node with
+// the same public key.
+func (c *Client) AddNode(node *lnrpc.LightningNode) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nodes[node.PubKey] = node
+}
+
+// AddEdge adds a channel edge to the client's graph, replacing any existing
+// edge with the same channel id.
+func (c *Client) AddEdge(edge *lnrpc.ChannelEdge) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.edges[edge.ChannelId] = edge
+}
+
+// SetError sets an error that will be returned by all calls to the method
+// provided, for example "ListChannels". Setting a nil error clears it.
+func (c *Client) SetError(method string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		delete(c.errors, method)
+		return
+	}
+
+	c.errors[method] = err
+}
+
+// CloseRequests returns the close requests that the client has received.
+func (c *Client) CloseRequests() []*lnrpc.CloseChannelRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]*lnrpc.CloseChannelRequest(nil), c.closeRequests...)
+}
+
+// SendChannelEvent delivers a channel event to all active channel event
+// subscriptions. It blocks until each subscriber has received the event or
+// cancelled its subscription.
+func (c *Client) SendChannelEvent(event *lnrpc.ChannelEventUpdate) {
+	c.mu.Lock()
+	subscribers := make(
+		[]*channelEventStream, 0, len(c.eventSubscribers),
+	)
+	for _, sub := range c.eventSubscribers {
+		subscribers = append(subscribers, sub)
+	}
+	c.mu.Unlock()
+
+	for _, sub := range subscribers {
+		select {
+		case sub.events <- event:
+		case <-sub.ctx.Done():
+		}
+	}
+}
+
+// err returns the error set for a method, if any. The caller must hold the
+// client's mutex.
+func (c *Client) err(method string) error {
+	return c.errors[method]
+}
+
+// GetInfo returns the info set for the client.
+func (c *Client) GetInfo(_ context.Context, _ *lnrpc.GetInfoRequest,
+	_ ...grpc.CallOption) (*lnrpc.GetInfoResponse, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err("GetInfo"); err != nil {
+		return nil, err
+	}
+
+	return c.info, nil
+}
+
+// ListChannels returns the client's open channels, filtered as requested.
+func (c *Client) ListChannels(_ context.Context,
+	req *lnrpc.ListChannelsRequest, _ ...grpc.CallOption) (
+	*lnrpc.ListChannelsResponse, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err("ListChannels"); err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ListChannelsResponse{}
+	for _, channel := range c.channels {
+		switch {
+		case req.ActiveOnly && !channel.Active:
+			continue
+
+		case req.InactiveOnly && channel.Active:
+			continue
+
+		case req.PublicOnly && channel.Private:
+			continue
+
+		case req.PrivateOnly && !channel.Private:
+			continue
+		}
+
+		resp.Channels = append(resp.Channels, channel)
+	}
+
+	return resp, nil
+}
+
+// ClosedChannels returns the client's closed channels.
+func (c *Client) ClosedChannels(_ context.Context,
+	_ *lnrpc.ClosedChannelsRequest, _ ...grpc.CallOption) (
+	*lnrpc.ClosedChannelsResponse, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err("ClosedChannels"); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ClosedChannelsResponse{
+		Channels: c.closedChannels,
+	}, nil
+}
+
+// PendingChannels returns the channels that the client has been asked to
+// close, which are reported as waiting to close.
+func (c *Client) PendingChannels(_ context.Context,
+	_ *lnrpc.PendingChannelsRequest, _ ...grpc.CallOption) (
+	*lnrpc.PendingChannelsResponse, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err("PendingChannels"); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.PendingChannelsResponse{
+		WaitingCloseChannels: c.waitingClose,
+	}, nil
+}
+
+// ForwardingHistory returns a page of the client's forwarding log. Like lnd,
+// events with timestamps in the inclusive range [start time, end time] are
+// considered, the index offset is the number of events in that range to
+// skip, and the end time defaults to the present.
+func (c *Client) ForwardingHistory(_ context.Context,
+	req *lnrpc.ForwardingHistoryRequest, _ ...grpc.CallOption) (
+	*lnrpc.ForwardingHistoryResponse, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err("ForwardingHistory"); err != nil {
+		return nil, err
+	}
+
+	endTime := req.EndTime
+	if endTime == 0 {
+		endTime = uint64(c.Now().Unix())
+	}
+
+	maxEvents := req.NumMaxEvents
+	if maxEvents == 0 {
+		maxEvents = defaultMaxEvents
+	}
+
+	var (
+		events []*lnrpc.ForwardingEvent
+		skip   = req.IndexOffset
+	)
+	for _, fwd := range c.forwards {
+		if fwd.Timestamp < req.StartTime || fwd.Timestamp > endTime {
+			continue
+		}
+
+		if uint32(len(events)) >= maxEvents {
+			break
+		}
+
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		events = append(events, fwd)
+	}
+
+	return &lnrpc.ForwardingHistoryResponse{
+		ForwardingEvents: events,
+		LastOffsetIndex:  req.IndexOffset + uint32(len(events)),
+	}, nil
+}
+
+// ListPeers returns the client's connected peers.
+func (c *Client) ListPeers(_ context.Context, _ *lnrpc.ListPeersRequest,
+	_ ...grpc.CallOption) (*lnrpc.ListPeersResponse, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err("ListPeers"); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ListPeersResponse{
+		Peers: c.peers,
+	}, nil
+}
+
+// GetChanInfo returns the graph edge for the channel requested.
+func (c *Client) GetChanInfo(_ context.Context, req *lnrpc.ChanInfoRequest,
+	_ ...grpc.CallOption) (*lnrpc.ChannelEdge, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err("GetChanInfo"); err != nil {
+		return nil, err
+	}
+
+	edge, ok := c.edges[req.ChanId]
+	if !ok {
+		return nil, ErrChannelNotFound
+	}
+
+	return edge, nil
+}
+
+// GetNodeInfo returns the graph node requested, along with the channels in
+// our graph that it is party to.
+func (c *Client) GetNodeInfo(_ context.Context, req *lnrpc.NodeInfoRequest,
+	_ ...grpc.CallOption) (*lnrpc.NodeInfo, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err("GetNodeInfo"); err != nil {
+		return nil, err
+	}
+
+	node, ok := c.nodes[req.PubKey]
+	if !ok {
+		return nil, ErrNodeNotFound
+	}
+
+	info := &lnrpc.NodeInfo{
+		Node: node,
+	}
+
+	for _, edge := range c.edges {
+		if edge.Node1Pub != req.PubKey && edge.Node2Pub != req.PubKey {
+			continue
+		}
+
+		info.NumChannels++
+		info.TotalCapacity += edge.Capacity
+
+		if req.IncludeChannels {
+			info.Channels = append(info.Channels, edge)
+		}
+	}
+
+	return info, nil
+}
+
+// DescribeGraph returns all the nodes and edges in the client's graph.
+func (c *Client) DescribeGraph(_ context.Context,
+	_ *lnrpc.ChannelGraphRequest, _ ...grpc.CallOption) (
+	*lnrpc.ChannelGraph, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err("DescribeGraph"); err != nil {
+		return nil, err
+	}
+
+	graph := &lnrpc.ChannelGraph{}
+	for _, node := range c.nodes {
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	for _, edge := range c.edges {
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	return graph, nil
+}
+
+// CloseChannel records a request to close a channel. The channel is removed
+// from the client's open channels and reported as waiting to close. The
+// stream returned delivers a single pending update with the closing txid
+// set to the channel's funding txid.
+func (c *Client) CloseChannel(_ context.Context,
+	req *lnrpc.CloseChannelRequest, _ ...grpc.CallOption) (
+	lnrpc.Lightning_CloseChannelClient, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err("CloseChannel"); err != nil {
+		return nil, err
+	}
+
+	c.closeRequests = append(c.closeRequests, req)
+
+	chanPoint, err := channelPointString(req.ChannelPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	outpoint, err := utils.GetOutPointFromString(chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, channel := range c.channels {
+		if channel.ChannelPoint != chanPoint {
+			continue
+		}
+
+		pending := &lnrpc.PendingChannelsResponse_PendingChannel{
+			RemoteNodePub: channel.RemotePubkey,
+			ChannelPoint:  channel.ChannelPoint,
+			Capacity:      channel.Capacity,
+		}
+
+		// We build a new set of channels rather than removing the
+		// channel in place, so that we do not modify the slice that
+		// our caller provided.
+		channels := make([]*lnrpc.Channel, 0, len(c.channels)-1)
+		channels = append(channels, c.channels[:i]...)
+		c.channels = append(channels, c.channels[i+1:]...)
+
+		c.waitingClose = append(
+			c.waitingClose,
+			&lnrpc.PendingChannelsResponse_WaitingCloseChannel{
+				Channel: pending,
+			},
+		)
+
+		update := &lnrpc.CloseStatusUpdate_ClosePending{
+			ClosePending: &lnrpc.PendingUpdate{
+				Txid: outpoint.Hash[:],
+			},
+		}
+
+		return &closeStream{
+			updates: []*lnrpc.CloseStatusUpdate{
+				{Update: update},
+			},
+		}, nil
+	}
+
+	return nil, ErrChannelNotFound
+}
+
+// SubscribeChannelEvents returns a stream which delivers the events sent
+// with SendChannelEvent until the context provided is cancelled.
+func (c *Client) SubscribeChannelEvents(ctx context.Context,
+	_ *lnrpc.ChannelEventSubscription, _ ...grpc.CallOption) (
+	lnrpc.Lightning_SubscribeChannelEventsClient, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err("SubscribeChannelEvents"); err != nil {
+		return nil, err
+	}
+
+	id := c.nextSubscriber
+	c.nextSubscriber++
+
+	stream := &channelEventStream{
+		ctx:    ctx,
+		events: make(chan *lnrpc.ChannelEventUpdate),
+		cancel: func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			delete(c.eventSubscribers, id)
+		},
+	}
+	c.eventSubscribers[id] = stream
+
+	return stream, nil
+}
+
+// channelPointString returns the string representation of a channel point,
+// which is used to identify channels in ListChannels responses.
+func channelPointString(chanPoint *lnrpc.ChannelPoint) (string, error) {
+	if chanPoint == nil {
+		return "", ErrChannelNotFound
+	}
+
+	txid := chanPoint.GetFundingTxidStr()
+	if txid == "" {
+		var err error
+		txid, err = utils.TxidString(chanPoint.GetFundingTxidBytes())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf("%v:%v", txid, chanPoint.OutputIndex), nil
+}
+
+// closeStream is a client stream which delivers a fixed set of close
+// updates, and then io.EOF.
+type closeStream struct {
+	// ClientStream is embedded to satisfy the stream interface, calls to
+	// its methods will panic.
+	grpc.ClientStream
+
+	updates []*lnrpc.CloseStatusUpdate
+}
+
+// Recv returns the next close update.
+func (s *closeStream) Recv() (*lnrpc.CloseStatusUpdate, error) {
+	if len(s.updates) == 0 {
+		return nil, io.EOF
+	}
+
+	update := s.updates[0]
+	s.updates = s.updates[1:]
+
+	return update, nil
+}
+
+// channelEventStream is a client stream which delivers channel events until
+// its context is cancelled.
+type channelEventStream struct {
+	// ClientStream is embedded to satisfy the stream interface, calls to
+	// its methods will panic.
+	grpc.ClientStream
+
+	ctx    context.Context
+	events chan *lnrpc.ChannelEventUpdate
+	cancel func()
+	once   sync.Once
+}
+
+// Recv blocks until the next channel event is sent, or the stream's context
+// is cancelled. Once cancelled, the stream is removed from the client's set
+// of subscribers.
+func (s *channelEventStream) Recv() (*lnrpc.ChannelEventUpdate, error) {
+	select {
+	case event := <-s.events:
+		return event, nil
+
+	case <-s.ctx.Done():
+		s.once.Do(s.cancel)
+
+		return nil, s.ctx.Err()
+	}
+}
@@ -0,0 +1,169 @@
+package fakelnd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// TestForwardingHistory tests paging through the forwarding log.
+func TestForwardingHistory(t *testing.T) {
+	client := NewClient()
+	client.Now = func() time.Time {
+		return time.Unix(1000, 0)
+	}
+
+	var (
+		fwd1 = &lnrpc.ForwardingEvent{Timestamp: 100}
+		fwd2 = &lnrpc.ForwardingEvent{Timestamp: 200}
+		fwd3 = &lnrpc.ForwardingEvent{Timestamp: 300}
+		fwd4 = &lnrpc.ForwardingEvent{Timestamp: 2000}
+	)
+
+	// Add our forwards out of order to test that they are sorted.
+	client.AddForwards(fwd3, fwd1, fwd4, fwd2)
+
+	tests := []struct {
+		name           string
+		request        *lnrpc.ForwardingHistoryRequest
+		expectedEvents []*lnrpc.ForwardingEvent
+		expectedOffset uint32
+	}{
+		{
+			name:           "default end time",
+			request:        &lnrpc.ForwardingHistoryRequest{},
+			expectedEvents: []*lnrpc.ForwardingEvent{fwd1, fwd2, fwd3},
+			expectedOffset: 3,
+		},
+		{
+			name: "inclusive range",
+			request: &lnrpc.ForwardingHistoryRequest{
+				StartTime: 200,
+				EndTime:   300,
+			},
+			expectedEvents: []*lnrpc.ForwardingEvent{fwd2, fwd3},
+			expectedOffset: 2,
+		},
+		{
+			name: "first page",
+			request: &lnrpc.ForwardingHistoryRequest{
+				EndTime:      3000,
+				NumMaxEvents: 2,
+			},
+			expectedEvents: []*lnrpc.ForwardingEvent{fwd1, fwd2},
+			expectedOffset: 2,
+		},
+		{
+			name: "last page",
+			request: &lnrpc.ForwardingHistoryRequest{
+				EndTime:      3000,
+				IndexOffset:  2,
+				NumMaxEvents: 2,
+			},
+			expectedEvents: []*lnrpc.ForwardingEvent{fwd3, fwd4},
+			expectedOffset: 4,
+		},
+		{
+			name: "past end",
+			request: &lnrpc.ForwardingHistoryRequest{
+				EndTime:     3000,
+				IndexOffset: 4,
+			},
+			expectedOffset: 4,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := client.ForwardingHistory(
+				context.Background(), test.request,
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(
+				test.expectedEvents, resp.ForwardingEvents,
+			) {
+				t.Fatalf("expected: %v, got: %v",
+					test.expectedEvents,
+					resp.ForwardingEvents)
+			}
+
+			if resp.LastOffsetIndex != test.expectedOffset {
+				t.Fatalf("expected offset: %v, got: %v",
+					test.expectedOffset,
+					resp.LastOffsetIndex)
+			}
+		})
+	}
+}
+
+// TestCloseChannel tests that closing a channel moves it from our open
+// channels to our pending channels, without modifying the set of channels
+// that the client was provided with.
+func TestCloseChannel(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		txid      = "0000000000000000000000000000000000000000000000000000000000000001"
+		chanPoint = txid + ":1"
+		closing   = &lnrpc.Channel{ChannelPoint: chanPoint}
+		open      = &lnrpc.Channel{ChannelPoint: txid + ":2"}
+		provided  = []*lnrpc.Channel{closing, open}
+	)
+
+	client := NewClient()
+	client.SetChannels(provided...)
+
+	stream, err := client.CloseChannel(ctx, &lnrpc.CloseChannelRequest{
+		ChannelPoint: &lnrpc.ChannelPoint{
+			FundingTxid: &lnrpc.ChannelPoint_FundingTxidStr{
+				FundingTxidStr: txid,
+			},
+			OutputIndex: 1,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	channels, err := client.ListChannels(ctx, &lnrpc.ListChannelsRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedChannels := []*lnrpc.Channel{open}
+	if !reflect.DeepEqual(expectedChannels, channels.Channels) {
+		t.Fatalf("expected open channels: %v, got: %v",
+			expectedChannels, channels.Channels)
+	}
+
+	if provided[0] != closing || provided[1] != open {
+		t.Fatalf("provided channels modified: %v", provided)
+	}
+
+	pending, err := client.PendingChannels(
+		ctx, &lnrpc.PendingChannelsRequest{},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pending.WaitingCloseChannels) != 1 {
+		t.Fatalf("expected one waiting close channel, got: %v",
+			len(pending.WaitingCloseChannels))
+	}
+
+	if len(client.CloseRequests()) != 1 {
+		t.Fatalf("expected one close request")
+	}
+}
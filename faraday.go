@@ -2,10 +2,19 @@
 package faraday
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/lightninglabs/faraday/closer"
+	"github.com/lightninglabs/faraday/fiat"
 	"github.com/lightninglabs/faraday/frdrpc"
-	"github.com/lightninglabs/loop/lndclient"
+	"github.com/lightninglabs/faraday/liquidity"
+	"github.com/lightninglabs/faraday/offline"
+	"github.com/lightninglabs/faraday/supervisor"
+	"github.com/lightninglabs/faraday/uptime"
+	"github.com/lightningnetwork/lnd/build"
+	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/signal"
 )
 
@@ -16,39 +25,324 @@ func Main() error {
 	if err != nil {
 		return fmt.Errorf("error loading config: %v", err)
 	}
+	defer func() {
+		if err := logWriter.Close(); err != nil {
+			fmt.Printf("could not close log file: %v\n", err)
+		}
+	}()
 
-	// NewBasicClient get a lightning rpc client with
-	client, err := lndclient.NewBasicClient(
-		config.RPCServer,
-		config.TLSCertPath,
-		config.MacaroonDir,
-		config.network,
-		lndclient.MacFilename(config.MacaroonFile),
-	)
+	// Load our fiat prices, if we have a price source, so that problems
+	// with prices are reported on startup.
+	fiatPrices, err := getFiatPrices(config)
 	if err != nil {
-		return fmt.Errorf("cannot connect to lightning client: %v",
-			err)
+		return fmt.Errorf("cannot load fiat prices: %v", err)
+	}
+
+	// If we are running against a snapshot of a node, we do not connect
+	// to lnd.
+	if config.OfflineDir != "" {
+		return runOffline(config, fiatPrices)
+	}
+
+	// Open the audit log that we record channel closes in.
+	auditor, err := closer.NewFileAuditor(config.CloseAuditLog)
+	if err != nil {
+		return fmt.Errorf("cannot open close audit log: %v", err)
+	}
+	defer func() {
+		if err := auditor.Close(); err != nil {
+			log.Errorf("could not close audit log: %v", err)
+		}
+	}()
+
+	// Connect to each of our nodes, and start monitoring their peers'
+	// uptime and their channels' balances.
+	liveNodes := make([]*liveNode, 0, len(config.nodes))
+	nodes := make([]*frdrpc.Node, 0, len(config.nodes))
+	for _, nodeCfg := range config.nodes {
+		node, stop, err := startNode(config, nodeCfg)
+		if err != nil {
+			return err
+		}
+		defer stop()
+
+		liveNodes = append(liveNodes, node)
+		nodes = append(nodes, node.Node)
+	}
+
+	// If we have alerts, scheduled reports or our dashboard configured, we
+	// start them once our rpc server is running, because they use its
+	// handlers.
+	var services []serverService
+	if len(config.alerts) != 0 {
+		services = append(services, func(s *frdrpc.RPCServer) (func(),
+			error) {
+
+			return startAlerts(config, liveNodes, s), nil
+		})
+	}
+
+	if len(config.reports) != 0 {
+		services = append(services, func(s *frdrpc.RPCServer) (func(),
+			error) {
+
+			return startReports(config, s), nil
+		})
+	}
+
+	if config.DashboardListen != "" {
+		services = append(services, func(s *frdrpc.RPCServer) (func(),
+			error) {
+
+			return startDashboard(config, s)
+		})
 	}
 
 	// Instantiate the faraday gRPC server.
-	server := frdrpc.NewRPCServer(
-		&frdrpc.Config{
-			LightningClient: client,
-			RPCListen:       config.RPCListen,
+	return runServer(&frdrpc.Config{
+		LightningClient:     nodes[0].LightningClient,
+		RPCListen:           config.RPCListen,
+		CloseAudit:          auditor.Audit,
+		AllowClose:          config.AllowClose,
+		ChannelUptime:       nodes[0].ChannelUptime,
+		ChannelBalance:      nodes[0].ChannelBalance,
+		ListSwaps:           nodes[0].ListSwaps,
+		Nodes:               nodes,
+		OpportunityCostRate: config.OpportunityCostRate,
+		Rules:               config.rules,
+		FiatPrices:          fiatPrices,
+	}, services)
+}
+
+// getFiatPrices returns a source of fiat prices from the price file or price
+// endpoint in our config. If neither is set, nil is returned.
+func getFiatPrices(config *config) (fiat.PriceSource, error) {
+	switch {
+	case config.FiatPriceFile != "":
+		log.Infof("Loading fiat prices from: %v", config.FiatPriceFile)
+
+		return fiat.FileSource(config.FiatPriceFile)
+
+	case config.FiatPriceURL != "":
+		log.Infof("Using fiat price endpoint: %v", config.FiatPriceURL)
+
+		return fiat.HTTPSource(
+			config.FiatPriceURL, config.FiatPriceTimeout,
+		), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// startNode starts supervising our connection to a lnd node, opens its uptime
+// database and starts monitoring its peers. If the node has a loopd instance,
+// we also connect to it. It returns the node and a function which stops
+// monitoring, closes the database and disconnects from lnd and loopd.
+func startNode(config *config, nodeCfg *nodeConfig) (*liveNode, func(),
+	error) {
+
+	node := &liveNode{
+		Node: &frdrpc.Node{
+			Name: nodeCfg.name,
 		},
+	}
+
+	closeLoop := func() {}
+	if nodeCfg.loopServer != "" {
+		loopConn, err := dialLoop(nodeCfg, config.network)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		closeLoop = func() {
+			if err := loopConn.Close(); err != nil {
+				log.Errorf("could not close loop "+
+					"connection: %v", err)
+			}
+		}
+
+		node.ListSwaps = liquidity.ListSwaps(
+			loopConn, defaultLoopTimeout,
+		)
+	}
+
+	// Supervise our connection to lnd, so that we reconnect if lnd
+	// restarts and wait for it to be unlocked and synced.
+	lndSupervisor := supervisor.NewSupervisor(&supervisor.Config{
+		Name: nodeCfg.name,
+		Connect: func() (lnrpc.LightningClient, func(), error) {
+			return dialLnd(nodeCfg, config.network)
+		},
+		CheckInterval: defaultLndCheckInterval,
+		CheckTimeout:  defaultLndCheckTimeout,
+		MinBackoff:    defaultLndMinBackoff,
+		MaxBackoff:    defaultLndMaxBackoff,
+		Now:           time.Now,
+	})
+	lndSupervisor.Start()
+
+	client := lndSupervisor.Client()
+
+	// Open the database that we record peer uptime in, and start
+	// monitoring our peers. We allow for two missed samples before we
+	// consider a period unmonitored.
+	uptimeStore, err := uptime.NewStore(
+		nodeUptimeDB(config.UptimeDB, nodeCfg.name),
+		config.UptimePollInterval*3,
 	)
+	if err != nil {
+		lndSupervisor.Stop()
+		closeLoop()
+
+		return nil, nil, fmt.Errorf("cannot open uptime database: %v",
+			err)
+	}
+
+	uptimeMonitor := uptime.NewMonitor(&uptime.MonitorConfig{
+		OpenChannels: func() ([]*lnrpc.Channel, error) {
+			resp, err := client.ListChannels(
+				context.Background(),
+				&lnrpc.ListChannelsRequest{},
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return resp.Channels, nil
+		},
+		OnlinePeers: func() ([]string, error) {
+			resp, err := client.ListPeers(
+				context.Background(), &lnrpc.ListPeersRequest{},
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			peers := make([]string, 0, len(resp.Peers))
+			for _, peer := range resp.Peers {
+				peers = append(peers, peer.PubKey)
+			}
+
+			return peers, nil
+		},
+		Store:             uptimeStore,
+		PollInterval:      config.UptimePollInterval,
+		OneSidedThreshold: config.OneSidedThreshold,
+		Now:               time.Now,
+	})
+	uptimeMonitor.Start()
+
+	stop := func() {
+		uptimeMonitor.Stop()
+		lndSupervisor.Stop()
+
+		if err := uptimeStore.Close(); err != nil {
+			log.Errorf("could not close uptime database: %v", err)
+		}
+
+		closeLoop()
+	}
+
+	node.LightningClient = client
+	node.ChannelUptime = uptimeStore.ChannelUptime
+	node.ChannelBalance = uptimeStore.ChannelBalance
+	node.Status = lndSupervisor.Status
+	node.peerOffline = uptimeStore.PeerOffline
+
+	return node, stop, nil
+}
+
+// runOffline runs faraday's rpc server against a snapshot of a node read from
+// the offline directory.
+func runOffline(config *config, fiatPrices fiat.PriceSource) error {
+	files, err := offline.FilesFromDir(config.OfflineDir)
+	if err != nil {
+		return fmt.Errorf("cannot find offline files: %v", err)
+	}
+
+	snapshot, err := offline.Load(files)
+	if err != nil {
+		return fmt.Errorf("cannot load offline files: %v", err)
+	}
+
+	log.Infof("Running in offline mode with snapshot: %v",
+		config.OfflineDir)
+
+	// Our dashboard only uses our rpc server's handlers, so it can also
+	// serve our snapshot.
+	var services []serverService
+	if config.DashboardListen != "" {
+		services = append(services, func(s *frdrpc.RPCServer) (func(),
+			error) {
+
+			return startDashboard(config, s)
+		})
+	}
+
+	return runServer(&frdrpc.Config{
+		LightningClient:     snapshot.Client(),
+		RPCListen:           config.RPCListen,
+		Offline:             true,
+		Now:                 snapshot.Now,
+		OpportunityCostRate: config.OpportunityCostRate,
+		Rules:               config.rules,
+		FiatPrices:          fiatPrices,
+	}, services)
+}
+
+// serverService is a service which uses our rpc server's handlers. It is
+// started once the server is running, and returns a function which stops it
+// or an error if it could not be started.
+type serverService func(server *frdrpc.RPCServer) (func(), error)
+
+// runServer starts faraday's rpc server and the services provided, and runs
+// until the user terminates.
+func runServer(cfg *frdrpc.Config, services []serverService) error {
+
+	cfg.Version = SemanticVersion()
+	cfg.Commit = Commit
+	cfg.StartTime = time.Now()
+
+	// Allow our debug levels to be changed over rpc.
+	cfg.SetDebugLevel = func(levelSpec string) error {
+		return build.ParseAndSetDebugLevels(levelSpec, logWriter)
+	}
+	cfg.SubSystems = logWriter.SupportedSubsystems
+
+	server := frdrpc.NewRPCServer(cfg)
 
 	if err := server.Start(); err != nil {
 		return err
 	}
 
+	// Stop our services before our server, because they use its
+	// handlers.
+	var stops []func()
+	stop := func() error {
+		for _, stop := range stops {
+			stop()
+		}
+
+		return server.Stop()
+	}
+
+	for _, service := range services {
+		serviceStop, err := service(server)
+		if err != nil {
+			if stopErr := stop(); stopErr != nil {
+				log.Errorf("could not stop server: %v", stopErr)
+			}
+
+			return err
+		}
+
+		stops = append(stops, serviceStop)
+	}
+
 	// Run until the user terminates.
 	<-signal.ShutdownChannel()
 	log.Infof("Received shutdown signal.")
 
-	if err := server.Stop(); err != nil {
-		return err
-	}
-
-	return nil
+	return stop()
 }
@@ -0,0 +1,153 @@
+package fiat
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnknownFormat is returned when a price file does not have a .csv or
+// .json extension.
+var ErrUnknownFormat = errors.New("price file must have a .csv or .json " +
+	"extension")
+
+// jsonPrice is the json representation of a price, which is used by price
+// files and http price endpoints.
+type jsonPrice struct {
+	// Timestamp is the unix timestamp in seconds that the price was
+	// recorded at.
+	Timestamp int64 `json:"timestamp"`
+
+	// Currency is the fiat currency that the price is expressed in.
+	Currency string `json:"currency"`
+
+	// Price is the price of one bitcoin in the currency.
+	Price float64 `json:"price"`
+}
+
+// LoadFile loads a price history from a file. Files with a .csv extension
+// contain rows of timestamp,currency,price with an optional header row, and
+// timestamps expressed in unix seconds or RFC3339. Files with a .json
+// extension contain a list of objects with timestamp, currency and price
+// fields, with timestamps expressed in unix seconds.
+func LoadFile(path string) (*PriceHistory, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var prices []Price
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		prices, err = readCSV(f)
+
+	case ".json":
+		prices, err = readJSON(f)
+
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+
+	log.Debugf("Loaded %v prices from %v", len(prices), path)
+
+	return NewPriceHistory(prices)
+}
+
+// FileSource loads a price history from a file, and returns a price source
+// which serves prices from it.
+func FileSource(path string) (PriceSource, error) {
+	history, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(currency string, start, end time.Time) (*PriceHistory,
+		error) {
+
+		return history.Filter(currency, start, end), nil
+	}, nil
+}
+
+// readCSV reads prices from rows of timestamp,currency,price. If the first
+// row's timestamp cannot be parsed, it is treated as a header.
+func readCSV(r io.Reader) ([]Price, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]Price, 0, len(records))
+	for i, record := range records {
+		timestamp, err := parseTimestamp(record[0])
+		if err != nil {
+			// Skip over a header row.
+			if i == 0 {
+				continue
+			}
+
+			return nil, fmt.Errorf("row %v: %v", i+1, err)
+		}
+
+		price, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %v: invalid price: %v",
+				i+1, record[2])
+		}
+
+		prices = append(prices, Price{
+			Timestamp: timestamp,
+			Currency:  record[1],
+			Price:     price,
+		})
+	}
+
+	return prices, nil
+}
+
+// parseTimestamp parses a timestamp expressed in unix seconds or RFC3339.
+func parseTimestamp(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %v", value)
+	}
+
+	return timestamp, nil
+}
+
+// readJSON reads a list of json prices.
+func readJSON(r io.Reader) ([]Price, error) {
+	var jsonPrices []jsonPrice
+	if err := json.NewDecoder(r).Decode(&jsonPrices); err != nil {
+		return nil, err
+	}
+
+	prices := make([]Price, 0, len(jsonPrices))
+	for _, price := range jsonPrices {
+		prices = append(prices, Price{
+			Timestamp: time.Unix(price.Timestamp, 0),
+			Currency:  price.Currency,
+			Price:     price.Price,
+		})
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,99 @@
+package fiat
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestLoadFile tests loading prices from csv and json files.
+func TestLoadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fiat")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name     string
+		file     string
+		contents string
+		err      bool
+	}{
+		{
+			name: "csv with header",
+			file: "prices.csv",
+			contents: "timestamp,currency,price\n" +
+				"100,USD,8000\n" +
+				"1970-01-01T00:03:20Z,USD,9000\n",
+		},
+		{
+			name:     "csv without header",
+			file:     "prices.CSV",
+			contents: "100,USD,8000\n200,USD,9000\n",
+		},
+		{
+			name:     "csv invalid row",
+			file:     "invalid.csv",
+			contents: "100,USD,8000\nyesterday,USD,9000\n",
+			err:      true,
+		},
+		{
+			name: "json",
+			file: "prices.json",
+			contents: `[
+				{"timestamp": 100, "currency": "USD", "price": 8000},
+				{"timestamp": 200, "currency": "USD", "price": 9000}
+			]`,
+		},
+		{
+			name:     "unknown format",
+			file:     "prices.txt",
+			contents: "100,USD,8000\n",
+			err:      true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.file)
+			err := ioutil.WriteFile(
+				path, []byte(test.contents), 0600,
+			)
+			if err != nil {
+				t.Fatalf("could not write %v: %v", path, err)
+			}
+
+			source, err := FileSource(path)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			history, err := source(
+				"usd", time.Unix(0, 0), time.Unix(1000, 0),
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			price, err := history.Price("USD", time.Unix(250, 0))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if price != 9000 {
+				t.Fatalf("expected: 9000, got: %v", price)
+			}
+		})
+	}
+}
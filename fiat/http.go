@@ -0,0 +1,63 @@
+package fiat
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPSource returns a price source which queries a http endpoint for prices.
+// The endpoint is sent a GET request with currency, start and end query
+// parameters, where start and end are unix timestamps in seconds, and should
+// respond with a json list of objects with timestamp, currency and price
+// fields. The response should include the most recent price before the start
+// time, so that amounts at the start of the period can be valued.
+func HTTPSource(endpoint string, timeout time.Duration) PriceSource {
+	client := &http.Client{
+		Timeout: timeout,
+	}
+
+	return func(currency string, start, end time.Time) (*PriceHistory,
+		error) {
+
+		reqURL, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		query := reqURL.Query()
+		query.Set("currency", strings.ToUpper(currency))
+		query.Set("start", strconv.FormatInt(start.Unix(), 10))
+		query.Set("end", strconv.FormatInt(end.Unix(), 10))
+		reqURL.RawQuery = query.Encode()
+
+		log.Debugf("Querying prices: %v", reqURL)
+
+		resp, err := client.Get(reqURL.String())
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("price endpoint returned: %v",
+				resp.Status)
+		}
+
+		prices, err := readJSON(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("could not read prices: %v",
+				err)
+		}
+
+		history, err := NewPriceHistory(prices)
+		if err != nil {
+			return nil, err
+		}
+
+		return history.Filter(currency, start, end), nil
+	}
+}
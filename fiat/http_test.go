@@ -0,0 +1,57 @@
+package fiat
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestHTTPSource tests querying a http price endpoint.
+func TestHTTPSource(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+
+			if r.URL.Query().Get("currency") != "EUR" {
+				http.Error(w, "unknown currency",
+					http.StatusNotFound)
+				return
+			}
+
+			fmt.Fprint(w, `[
+				{"timestamp": 100, "currency": "EUR", "price": 7000},
+				{"timestamp": 200, "currency": "EUR", "price": 7500}
+			]`)
+		},
+	))
+	defer server.Close()
+
+	source := HTTPSource(server.URL+"/prices", time.Second)
+
+	history, err := source("eur", time.Unix(150, 0), time.Unix(300, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if query != "currency=EUR&end=300&start=150" {
+		t.Fatalf("unexpected query: %v", query)
+	}
+
+	price, err := history.Price("EUR", time.Unix(150, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if price != 7000 {
+		t.Fatalf("expected: 7000, got: %v", price)
+	}
+
+	// Errors from the endpoint should be returned.
+	_, err = source("GBP", time.Unix(0, 0), time.Unix(1, 0))
+	if err == nil {
+		t.Fatalf("expected error for unknown currency")
+	}
+}
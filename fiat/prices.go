@@ -0,0 +1,176 @@
+// Package fiat provides historical bitcoin prices which are used to value
+// amounts in fiat currencies at the time that they were earned. Prices can
+// be loaded from a local CSV or JSON file, or queried from a http price
+// endpoint.
+//
+// Prices are expressed as the price of one bitcoin in a currency, and an
+// amount is valued using the most recent price at or before the time that
+// it was earned.
+package fiat
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// msatPerBitcoin is the number of millisatoshis in one bitcoin.
+const msatPerBitcoin = btcutil.SatoshiPerBitcoin * 1000
+
+var (
+	// ErrNoPrice is returned when there is no price for a currency at or
+	// before the time requested.
+	ErrNoPrice = errors.New("no price available")
+
+	// ErrNoCurrency is returned when a price is provided without a
+	// currency.
+	ErrNoCurrency = errors.New("price currency required")
+
+	// ErrInvalidPrice is returned when a price is not positive.
+	ErrInvalidPrice = errors.New("price must be positive")
+)
+
+// Price is the price of one bitcoin in a fiat currency at a point in time.
+type Price struct {
+	// Timestamp is the time that the price was recorded at.
+	Timestamp time.Time
+
+	// Currency is the fiat currency that the price is expressed in.
+	Currency string
+
+	// Price is the price of one bitcoin in the currency.
+	Price float64
+}
+
+// PriceHistory is a set of historical prices, which can be looked up by
+// currency and time.
+type PriceHistory struct {
+	// prices maps an upper case currency code to its prices sorted by
+	// ascending timestamp.
+	prices map[string][]Price
+}
+
+// NewPriceHistory creates a price history from a set of prices, which may be
+// provided in any order. Currency codes are not case sensitive.
+func NewPriceHistory(prices []Price) (*PriceHistory, error) {
+	history := &PriceHistory{
+		prices: make(map[string][]Price),
+	}
+
+	for _, price := range prices {
+		if price.Currency == "" {
+			return nil, ErrNoCurrency
+		}
+
+		if price.Price <= 0 {
+			return nil, fmt.Errorf("%w: %v %v at %v",
+				ErrInvalidPrice, price.Price, price.Currency,
+				price.Timestamp)
+		}
+
+		currency := strings.ToUpper(price.Currency)
+		price.Currency = currency
+
+		history.prices[currency] = append(
+			history.prices[currency], price,
+		)
+	}
+
+	for _, currencyPrices := range history.prices {
+		sort.SliceStable(currencyPrices, func(i, j int) bool {
+			return currencyPrices[i].Timestamp.Before(
+				currencyPrices[j].Timestamp,
+			)
+		})
+	}
+
+	return history, nil
+}
+
+// Currencies returns the currencies that the history has prices for, sorted
+// alphabetically.
+func (p *PriceHistory) Currencies() []string {
+	currencies := make([]string, 0, len(p.prices))
+	for currency := range p.prices {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	return currencies
+}
+
+// Filter returns a price history containing the prices for a single currency
+// which are required to value amounts between the start and end time
+// provided. This includes the most recent price before the start time, so
+// that amounts at the start of the period can be valued.
+func (p *PriceHistory) Filter(currency string, start,
+	end time.Time) *PriceHistory {
+
+	currency = strings.ToUpper(currency)
+	prices := p.prices[currency]
+
+	// Find the index of the first price after our start time, and step
+	// back one price so that we include the price in effect at the start
+	// time.
+	first := sort.Search(len(prices), func(i int) bool {
+		return prices[i].Timestamp.After(start)
+	})
+	if first > 0 {
+		first--
+	}
+
+	var filtered []Price
+	for _, price := range prices[first:] {
+		if price.Timestamp.After(end) {
+			break
+		}
+
+		filtered = append(filtered, price)
+	}
+
+	history := &PriceHistory{
+		prices: make(map[string][]Price),
+	}
+	if len(filtered) != 0 {
+		history.prices[currency] = filtered
+	}
+
+	return history
+}
+
+// Price returns the price of one bitcoin in the currency provided at the
+// time provided, which is the most recent price at or before that time.
+func (p *PriceHistory) Price(currency string, ts time.Time) (float64,
+	error) {
+
+	currency = strings.ToUpper(currency)
+	prices := p.prices[currency]
+
+	// Find the first price after our timestamp, the price before it is
+	// the price that was in effect at the timestamp.
+	i := sort.Search(len(prices), func(i int) bool {
+		return prices[i].Timestamp.After(ts)
+	})
+	if i == 0 {
+		return 0, fmt.Errorf("%w: %v at %v", ErrNoPrice, currency,
+			ts.UTC())
+	}
+
+	return prices[i-1].Price, nil
+}
+
+// MsatToFiat converts an amount in millisatoshis to a fiat amount using the
+// price of one bitcoin provided.
+func MsatToFiat(amount lnwire.MilliSatoshi, price float64) float64 {
+	return float64(amount) * price / msatPerBitcoin
+}
+
+// PriceSource returns a history containing the prices required to value
+// amounts in a currency between the start and end time provided.
+type PriceSource func(currency string, start,
+	end time.Time) (*PriceHistory, error)
@@ -0,0 +1,146 @@
+package fiat
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// testPrices is a set of prices which are not sorted by timestamp.
+var testPrices = []Price{
+	{Timestamp: time.Unix(200, 0), Currency: "USD", Price: 9000},
+	{Timestamp: time.Unix(100, 0), Currency: "usd", Price: 8000},
+	{Timestamp: time.Unix(300, 0), Currency: "USD", Price: 10000},
+	{Timestamp: time.Unix(100, 0), Currency: "EUR", Price: 7000},
+}
+
+// TestPrice tests looking up the price in effect at a point in time.
+func TestPrice(t *testing.T) {
+	history, err := NewPriceHistory(testPrices)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		currency    string
+		timestamp   time.Time
+		price       float64
+		expectedErr error
+	}{
+		{
+			name:        "before first price",
+			currency:    "USD",
+			timestamp:   time.Unix(99, 0),
+			expectedErr: ErrNoPrice,
+		},
+		{
+			name:      "exact price",
+			currency:  "USD",
+			timestamp: time.Unix(200, 0),
+			price:     9000,
+		},
+		{
+			name:      "between prices",
+			currency:  "usd",
+			timestamp: time.Unix(250, 0),
+			price:     9000,
+		},
+		{
+			name:      "after last price",
+			currency:  "USD",
+			timestamp: time.Unix(1000, 0),
+			price:     10000,
+		},
+		{
+			name:      "other currency",
+			currency:  "EUR",
+			timestamp: time.Unix(1000, 0),
+			price:     7000,
+		},
+		{
+			name:        "unknown currency",
+			currency:    "GBP",
+			timestamp:   time.Unix(1000, 0),
+			expectedErr: ErrNoPrice,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			price, err := history.Price(
+				test.currency, test.timestamp,
+			)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected: %v, got: %v",
+					test.expectedErr, err)
+			}
+
+			if price != test.price {
+				t.Fatalf("expected: %v, got: %v", test.price,
+					price)
+			}
+		})
+	}
+}
+
+// TestFilter tests filtering a price history to the prices required for a
+// period, including the price in effect at its start.
+func TestFilter(t *testing.T) {
+	history, err := NewPriceHistory(testPrices)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	filtered := history.Filter("USD", time.Unix(150, 0), time.Unix(250, 0))
+
+	expected := []Price{
+		{Timestamp: time.Unix(100, 0), Currency: "USD", Price: 8000},
+		{Timestamp: time.Unix(200, 0), Currency: "USD", Price: 9000},
+	}
+
+	prices := filtered.prices["USD"]
+	if len(prices) != len(expected) {
+		t.Fatalf("expected: %v prices, got: %v", len(expected),
+			len(prices))
+	}
+
+	for i, price := range expected {
+		if prices[i] != price {
+			t.Fatalf("expected: %v, got: %v", price, prices[i])
+		}
+	}
+
+	if len(filtered.Currencies()) != 1 {
+		t.Fatalf("expected single currency, got: %v",
+			filtered.Currencies())
+	}
+}
+
+// TestNewPriceHistoryErrors tests that invalid prices are rejected.
+func TestNewPriceHistoryErrors(t *testing.T) {
+	_, err := NewPriceHistory([]Price{{Price: 1}})
+	if err != ErrNoCurrency {
+		t.Fatalf("expected: %v, got: %v", ErrNoCurrency, err)
+	}
+
+	_, err = NewPriceHistory([]Price{{Currency: "USD"}})
+	if !errors.Is(err, ErrInvalidPrice) {
+		t.Fatalf("expected: %v, got: %v", ErrInvalidPrice, err)
+	}
+}
+
+// TestMsatToFiat tests conversion of millisatoshis to fiat amounts.
+func TestMsatToFiat(t *testing.T) {
+	amount := lnwire.NewMSatFromSatoshis(50000)
+
+	if value := MsatToFiat(amount, 10000); value != 5 {
+		t.Fatalf("expected: 5, got: %v", value)
+	}
+}
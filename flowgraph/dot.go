@@ -0,0 +1,69 @@
+package flowgraph
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// minPenWidth is the width that edges with no weight are drawn with.
+	minPenWidth = 1
+
+	// maxPenWidth is the width that the edge with the largest weight in
+	// the graph is drawn with. Other edges are scaled between our minimum
+	// and maximum widths according to their weight.
+	maxPenWidth = 8
+)
+
+// dotEscaper escapes the characters that have special meaning in DOT's
+// quoted strings.
+var dotEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+)
+
+// DOT returns the graph in Graphviz's DOT language. Edges are labeled with
+// their weight in millisatoshis and drawn with widths proportional to their
+// weight.
+func (g *Graph) DOT() string {
+	var maxWeight float64
+	for _, edge := range g.Edges {
+		if weight := float64(edge.Weight); weight > maxWeight {
+			maxWeight = weight
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph flows {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box];\n")
+
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&b, "\t%v [label=%v];\n", quote(node.ID),
+			quote(node.Label))
+	}
+
+	for _, edge := range g.Edges {
+		penWidth := float64(minPenWidth)
+		if maxWeight != 0 {
+			penWidth += (maxPenWidth - minPenWidth) *
+				float64(edge.Weight) / maxWeight
+		}
+
+		label := fmt.Sprintf("%v msat", int64(edge.Weight))
+
+		fmt.Fprintf(&b, "\t%v -> %v [label=%v, penwidth=%.2f];\n",
+			quote(edge.From), quote(edge.To), quote(label),
+			penWidth)
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// quote returns a string as a DOT quoted string.
+func quote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,331 @@
+// Package flowgraph exports the flow of forwards through our node as a
+// directed, weighted graph. Each node in the graph is one of our channels,
+// or one of our peers if channels are merged by peer, and each edge points
+// from the channel that forwards arrived on to the channel that they left
+// on. This shows which of our peers feed which others, and can be written
+// in Graphviz's DOT format so that it can be rendered by standard tools.
+package flowgraph
+
+import (
+	"sort"
+
+	"github.com/lightninglabs/faraday/revenue"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// Weight is the value that the edges in our graph are weighted by.
+type Weight int
+
+const (
+	// WeightVolume weights edges by the amount forwarded.
+	WeightVolume Weight = iota
+
+	// WeightFees weights edges by the fees earned.
+	WeightFees
+)
+
+// String returns the name of a weight.
+func (w Weight) String() string {
+	switch w {
+	case WeightVolume:
+		return "volume"
+
+	case WeightFees:
+		return "fees"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Label is the value that the nodes in our graph are labeled with.
+type Label int
+
+const (
+	// LabelAlias labels nodes with the alias of the channel's peer. If
+	// the peer's alias cannot be found, its public key is used.
+	LabelAlias Label = iota
+
+	// LabelPeer labels nodes with the public key of the channel's peer.
+	LabelPeer
+)
+
+// Node is a node in our flow graph.
+type Node struct {
+	// ID uniquely identifies the node. It is the channel's outpoint, or
+	// the peer's public key if channels are merged by peer.
+	ID string
+
+	// Label is the node's display label. If the channel's peer is not
+	// known, the channel's outpoint is used.
+	Label string
+
+	// Peer is the public key of the peer that the node's channels are
+	// with. It is empty if the peer is not known.
+	Peer string
+
+	// Channels is the sorted set of channel outpoints that the node
+	// represents.
+	Channels []string
+}
+
+// Edge is a directed edge in our flow graph, which describes the forwards
+// that arrived on one node and left on another.
+type Edge struct {
+	// From is the ID of the node that forwards arrived on.
+	From string
+
+	// To is the ID of the node that forwards left on.
+	To string
+
+	// Amount is the total amount that arrived on the incoming node to be
+	// forwarded to the outgoing node.
+	Amount lnwire.MilliSatoshi
+
+	// Fees is the total fees earned by the forwards.
+	Fees lnwire.MilliSatoshi
+
+	// Weight is the edge's weight, which is either its amount or its fees.
+	Weight lnwire.MilliSatoshi
+}
+
+// Graph is a directed graph of the flow of forwards through our node.
+type Graph struct {
+	// Weight is the value that the graph's edges are weighted by.
+	Weight Weight
+
+	// Nodes is the set of nodes in the graph, sorted by ID.
+	Nodes []*Node
+
+	// Edges is the set of edges in the graph, sorted by source and then
+	// destination.
+	Edges []*Edge
+}
+
+// Config provides everything required to create a flow graph.
+type Config struct {
+	// ChannelPairs is the revenue that each of our channels produced with
+	// each of its pair channels, as produced by a revenue report.
+	ChannelPairs map[string]map[string]revenue.Revenue
+
+	// ListChannels returns all of our open channels.
+	ListChannels func() ([]*lnrpc.Channel, error)
+
+	// ClosedChannels returns all of our closed channels.
+	ClosedChannels func() ([]*lnrpc.ChannelCloseSummary, error)
+
+	// GetAlias is an optional function which returns the alias of the
+	// node with the public key provided. If it is not set, nodes that are
+	// labeled by alias are labeled with their peer's public key.
+	GetAlias func(pubkey string) (string, error)
+
+	// Weight is the value that edges are weighted by.
+	Weight Weight
+
+	// Label is the value that nodes are labeled with.
+	Label Label
+
+	// MergePeers indicates that all of our channels with a peer should be
+	// represented by a single node.
+	MergePeers bool
+}
+
+// edgeKey identifies an edge by its source and destination.
+type edgeKey struct {
+	from string
+	to   string
+}
+
+// GetGraph creates a flow graph from the channel pairs in our config.
+func GetGraph(cfg *Config) (*Graph, error) {
+	peers, err := getPeers(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		nodes   = make(map[string]*Node)
+		aliases = make(map[string]string)
+		edges   = make(map[edgeKey]*Edge)
+	)
+
+	// getNode returns the node that represents a channel, creating it if
+	// it does not exist yet.
+	getNode := func(channel string) *Node {
+		peer := peers[channel]
+
+		id := channel
+		if cfg.MergePeers && peer != "" {
+			id = peer
+		}
+
+		node, ok := nodes[id]
+		if !ok {
+			node = &Node{
+				ID:    id,
+				Label: getLabel(cfg, aliases, channel, peer),
+				Peer:  peer,
+			}
+			nodes[id] = node
+		}
+
+		// Add the channel to the node if it is not already present,
+		// which may be the case if it has already been added as part
+		// of another pair.
+		for _, c := range node.Channels {
+			if c == channel {
+				return node
+			}
+		}
+		node.Channels = append(node.Channels, channel)
+
+		return node
+	}
+
+	// addEdge adds forwards that arrived on one channel and left on
+	// another to our graph.
+	addEdge := func(incoming, outgoing string, amount,
+		fees lnwire.MilliSatoshi) {
+
+		key := edgeKey{
+			from: getNode(incoming).ID,
+			to:   getNode(outgoing).ID,
+		}
+
+		edge, ok := edges[key]
+		if !ok {
+			edge = &Edge{
+				From: key.from,
+				To:   key.to,
+			}
+			edges[key] = edge
+		}
+
+		edge.Amount += amount
+		edge.Fees += fees
+	}
+
+	// Each forward is recorded as incoming on the channel it arrived on,
+	// paired with the channel that it left on, so we only need to look at
+	// incoming revenue to add most forwards to our graph once. If the
+	// channel that a forward arrived on could not be resolved, the
+	// forward is only recorded as outgoing on the channel that it left
+	// on, paired with the unresolved channel's short channel id. The
+	// unresolved channel has no records of its own, so we add these
+	// forwards from their outgoing side instead.
+	for channel, pairs := range cfg.ChannelPairs {
+		for pair, rev := range pairs {
+			if rev.AmountIncoming != 0 {
+				addEdge(
+					channel, pair, rev.AmountIncoming,
+					rev.FeesIncoming,
+				)
+			}
+
+			_, resolved := cfg.ChannelPairs[pair]
+			if resolved || rev.AmountOutgoing == 0 {
+				continue
+			}
+
+			addEdge(
+				pair, channel,
+				rev.AmountOutgoing+rev.FeesOutgoing,
+				rev.FeesOutgoing,
+			)
+		}
+	}
+
+	graph := &Graph{
+		Weight: cfg.Weight,
+		Nodes:  make([]*Node, 0, len(nodes)),
+		Edges:  make([]*Edge, 0, len(edges)),
+	}
+
+	for _, node := range nodes {
+		sort.Strings(node.Channels)
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	for _, edge := range edges {
+		edge.Weight = edge.Amount
+		if cfg.Weight == WeightFees {
+			edge.Weight = edge.Fees
+		}
+
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].ID < graph.Nodes[j].ID
+	})
+
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From != graph.Edges[j].From {
+			return graph.Edges[i].From < graph.Edges[j].From
+		}
+
+		return graph.Edges[i].To < graph.Edges[j].To
+	})
+
+	return graph, nil
+}
+
+// getPeers returns a map of our open and closed channels' outpoints to the
+// public keys of their peers.
+func getPeers(cfg *Config) (map[string]string, error) {
+	channels, err := cfg.ListChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	closedChannels, err := cfg.ClosedChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make(map[string]string, len(channels)+len(closedChannels))
+	for _, channel := range channels {
+		peers[channel.ChannelPoint] = channel.RemotePubkey
+	}
+
+	for _, channel := range closedChannels {
+		peers[channel.ChannelPoint] = channel.RemotePubkey
+	}
+
+	return peers, nil
+}
+
+// getLabel returns the label for a channel with the peer provided. Aliases
+// are cached so that we only look up each peer once. Failure to look up an
+// alias is not treated as an error, because peers that do not have public
+// channels are not in the graph, so we fall back to the peer's public key.
+func getLabel(cfg *Config, aliases map[string]string, channel,
+	peer string) string {
+
+	if peer == "" {
+		return channel
+	}
+
+	if cfg.Label != LabelAlias || cfg.GetAlias == nil {
+		return peer
+	}
+
+	alias, ok := aliases[peer]
+	if !ok {
+		var err error
+		alias, err = cfg.GetAlias(peer)
+		if err != nil {
+			log.Debugf("Could not get alias for peer %v: %v",
+				peer, err)
+		}
+
+		aliases[peer] = alias
+	}
+
+	if alias == "" {
+		return peer
+	}
+
+	return alias
+}
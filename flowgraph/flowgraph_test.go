@@ -0,0 +1,416 @@
+package flowgraph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lightninglabs/faraday/revenue"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// TestGetGraph tests creation of flow graphs from channel pairs.
+func TestGetGraph(t *testing.T) {
+	var (
+		alice1  = "a:1"
+		alice2  = "a:2"
+		bob     = "b:1"
+		unknown = "u:1"
+
+		alicePeer = "alice"
+		bobPeer   = "bob"
+	)
+
+	// Alice's first channel forwards to bob, who forwards to alice's
+	// second channel and to a channel that we cannot find the peer for.
+	pairs := map[string]map[string]revenue.Revenue{
+		alice1: {
+			bob: {
+				AmountIncoming: 1000,
+				FeesIncoming:   10,
+			},
+		},
+		alice2: {
+			bob: {
+				AmountOutgoing: 500,
+				FeesOutgoing:   20,
+			},
+		},
+		bob: {
+			alice1: {
+				AmountOutgoing: 1000,
+				FeesOutgoing:   10,
+			},
+			alice2: {
+				AmountIncoming: 500,
+				FeesIncoming:   20,
+			},
+			unknown: {
+				AmountIncoming: 200,
+				FeesIncoming:   1,
+			},
+		},
+		unknown: {
+			bob: {
+				AmountOutgoing: 200,
+				FeesOutgoing:   1,
+			},
+		},
+	}
+
+	listChannels := func() ([]*lnrpc.Channel, error) {
+		return []*lnrpc.Channel{
+			{
+				ChannelPoint: alice1,
+				RemotePubkey: alicePeer,
+			},
+			{
+				ChannelPoint: bob,
+				RemotePubkey: bobPeer,
+			},
+		}, nil
+	}
+
+	closedChannels := func() ([]*lnrpc.ChannelCloseSummary, error) {
+		return []*lnrpc.ChannelCloseSummary{
+			{
+				ChannelPoint: alice2,
+				RemotePubkey: alicePeer,
+			},
+		}, nil
+	}
+
+	// Bob is not in the graph, so we cannot find his alias.
+	getAlias := func(pubkey string) (string, error) {
+		if pubkey == alicePeer {
+			return "Alice", nil
+		}
+
+		return "", errors.New("node not found")
+	}
+
+	tests := []struct {
+		name          string
+		weight        Weight
+		label         Label
+		mergePeers    bool
+		getAlias      func(string) (string, error)
+		expectedGraph *Graph
+	}{
+		{
+			name:     "volume by channel",
+			weight:   WeightVolume,
+			label:    LabelPeer,
+			getAlias: getAlias,
+			expectedGraph: &Graph{
+				Weight: WeightVolume,
+				Nodes: []*Node{
+					{
+						ID:       alice1,
+						Label:    alicePeer,
+						Peer:     alicePeer,
+						Channels: []string{alice1},
+					},
+					{
+						ID:       alice2,
+						Label:    alicePeer,
+						Peer:     alicePeer,
+						Channels: []string{alice2},
+					},
+					{
+						ID:       bob,
+						Label:    bobPeer,
+						Peer:     bobPeer,
+						Channels: []string{bob},
+					},
+					{
+						ID:       unknown,
+						Label:    unknown,
+						Channels: []string{unknown},
+					},
+				},
+				Edges: []*Edge{
+					{
+						From:   alice1,
+						To:     bob,
+						Amount: 1000,
+						Fees:   10,
+						Weight: 1000,
+					},
+					{
+						From:   bob,
+						To:     alice2,
+						Amount: 500,
+						Fees:   20,
+						Weight: 500,
+					},
+					{
+						From:   bob,
+						To:     unknown,
+						Amount: 200,
+						Fees:   1,
+						Weight: 200,
+					},
+				},
+			},
+		},
+		{
+			name:       "fees by peer alias",
+			weight:     WeightFees,
+			label:      LabelAlias,
+			mergePeers: true,
+			getAlias:   getAlias,
+			expectedGraph: &Graph{
+				Weight: WeightFees,
+				Nodes: []*Node{
+					{
+						ID:    alicePeer,
+						Label: "Alice",
+						Peer:  alicePeer,
+						Channels: []string{
+							alice1, alice2,
+						},
+					},
+					{
+						ID:       bobPeer,
+						Label:    bobPeer,
+						Peer:     bobPeer,
+						Channels: []string{bob},
+					},
+					{
+						ID:       unknown,
+						Label:    unknown,
+						Channels: []string{unknown},
+					},
+				},
+				Edges: []*Edge{
+					{
+						From:   alicePeer,
+						To:     bobPeer,
+						Amount: 1000,
+						Fees:   10,
+						Weight: 10,
+					},
+					{
+						From:   bobPeer,
+						To:     alicePeer,
+						Amount: 500,
+						Fees:   20,
+						Weight: 20,
+					},
+					{
+						From:   bobPeer,
+						To:     unknown,
+						Amount: 200,
+						Fees:   1,
+						Weight: 1,
+					},
+				},
+			},
+		},
+		{
+			name:       "alias unavailable",
+			weight:     WeightVolume,
+			label:      LabelAlias,
+			mergePeers: true,
+			expectedGraph: &Graph{
+				Weight: WeightVolume,
+				Nodes: []*Node{
+					{
+						ID:    alicePeer,
+						Label: alicePeer,
+						Peer:  alicePeer,
+						Channels: []string{
+							alice1, alice2,
+						},
+					},
+					{
+						ID:       bobPeer,
+						Label:    bobPeer,
+						Peer:     bobPeer,
+						Channels: []string{bob},
+					},
+					{
+						ID:       unknown,
+						Label:    unknown,
+						Channels: []string{unknown},
+					},
+				},
+				Edges: []*Edge{
+					{
+						From:   alicePeer,
+						To:     bobPeer,
+						Amount: 1000,
+						Fees:   10,
+						Weight: 1000,
+					},
+					{
+						From:   bobPeer,
+						To:     alicePeer,
+						Amount: 500,
+						Fees:   20,
+						Weight: 500,
+					},
+					{
+						From:   bobPeer,
+						To:     unknown,
+						Amount: 200,
+						Fees:   1,
+						Weight: 200,
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			graph, err := GetGraph(&Config{
+				ChannelPairs:   pairs,
+				ListChannels:   listChannels,
+				ClosedChannels: closedChannels,
+				GetAlias:       test.getAlias,
+				Weight:         test.weight,
+				Label:          test.label,
+				MergePeers:     test.mergePeers,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(graph, test.expectedGraph) {
+				for _, node := range graph.Nodes {
+					t.Logf("node: %+v", node)
+				}
+
+				for _, edge := range graph.Edges {
+					t.Logf("edge: %+v", edge)
+				}
+
+				t.Fatalf("unexpected graph")
+			}
+		})
+	}
+}
+
+// TestGetGraphUnresolved tests that forwards over channels that could not be
+// resolved are included in our graph, whichever side they are on.
+func TestGetGraphUnresolved(t *testing.T) {
+	var (
+		alice = "a:1"
+		bob   = "b:1"
+
+		// unresolvedIn is the short channel id of a channel that a
+		// forward arrived on, and unresolvedOut is the short channel
+		// id of a channel that a forward left on.
+		unresolvedIn  = "600:1:0"
+		unresolvedOut = "700:1:0"
+	)
+
+	// A forward arrived on an unresolved channel and left on alice's
+	// channel, so it is only recorded as outgoing on alice's channel.
+	// Another forward arrived on bob's channel and left on an unresolved
+	// channel, so it is only recorded as incoming on bob's channel.
+	pairs := map[string]map[string]revenue.Revenue{
+		alice: {
+			unresolvedIn: {
+				AmountOutgoing: 1000,
+				FeesOutgoing:   10,
+			},
+		},
+		bob: {
+			unresolvedOut: {
+				AmountIncoming: 520,
+				FeesIncoming:   20,
+			},
+		},
+	}
+
+	graph, err := GetGraph(&Config{
+		ChannelPairs: pairs,
+		ListChannels: func() ([]*lnrpc.Channel, error) {
+			return nil, nil
+		},
+		ClosedChannels: func() ([]*lnrpc.ChannelCloseSummary, error) {
+			return nil, nil
+		},
+		Weight: WeightVolume,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedEdges := []*Edge{
+		{
+			From:   unresolvedIn,
+			To:     alice,
+			Amount: 1010,
+			Fees:   10,
+			Weight: 1010,
+		},
+		{
+			From:   bob,
+			To:     unresolvedOut,
+			Amount: 520,
+			Fees:   20,
+			Weight: 520,
+		},
+	}
+
+	if !reflect.DeepEqual(graph.Edges, expectedEdges) {
+		for _, edge := range graph.Edges {
+			t.Logf("edge: %+v", edge)
+		}
+
+		t.Fatalf("unexpected edges")
+	}
+
+	if len(graph.Nodes) != 4 {
+		t.Fatalf("expected 4 nodes, got: %v", len(graph.Nodes))
+	}
+}
+
+// TestDOT tests writing of a graph in the DOT language.
+func TestDOT(t *testing.T) {
+	graph := &Graph{
+		Nodes: []*Node{
+			{
+				ID:    "a:1",
+				Label: `"quoted" alias`,
+			},
+			{
+				ID:    "b:1",
+				Label: "b:1",
+			},
+		},
+		Edges: []*Edge{
+			{
+				From:   "a:1",
+				To:     "b:1",
+				Weight: 1000,
+			},
+			{
+				From:   "b:1",
+				To:     "a:1",
+				Weight: 250,
+			},
+		},
+	}
+
+	expected := `digraph flows {
+	rankdir=LR;
+	node [shape=box];
+	"a:1" [label="\"quoted\" alias"];
+	"b:1" [label="b:1"];
+	"a:1" -> "b:1" [label="1000 msat", penwidth=8.00];
+	"b:1" -> "a:1" [label="250 msat", penwidth=2.75];
+}
+`
+
+	dot := graph.DOT()
+	if dot != expected {
+		t.Fatalf("expected:\n%v\ngot:\n%v", expected, dot)
+	}
+}
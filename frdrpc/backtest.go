@@ -0,0 +1,232 @@
+package frdrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/faraday/backtest"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// blockInterval is the expected time between blocks, which is used to
+// estimate block heights for past dates.
+const blockInterval = time.Minute * 10
+
+// parseBacktestRequest parses a backtest request and wraps calls to lnd to
+// produce the config and request required to run a backtest.
+func parseBacktestRequest(ctx context.Context, cfg *Config,
+	req *BacktestRequest) (*backtest.Config, *backtest.Request, error) {
+
+	chain, err := getChainTime(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := cfg.now()
+
+	endTime := now
+	if req.EndTime != 0 {
+		endTime = time.Unix(int64(req.EndTime), 0)
+	}
+
+	btReq := &backtest.Request{
+		AsOf:    time.Unix(int64(req.AsOf), 0),
+		EndTime: endTime,
+		MinimumMonitored: time.Second *
+			time.Duration(req.MinimumMonitored),
+		OpportunityCostRate: opportunityCostRate(
+			cfg, req.OpportunityCostRate,
+		),
+	}
+
+	for _, strategy := range req.Strategies {
+		metric := parseMetric(strategy.MetricName, strategy.Metric)
+
+		btStrategy := &backtest.Strategy{
+			Name:   strategy.Name,
+			Metric: metric,
+			Value:  float64(strategy.Value),
+		}
+
+		switch strategy.Type {
+		case BacktestStrategy_OUTLIER:
+			btStrategy.Type = backtest.OutlierStrategy
+
+		case BacktestStrategy_THRESHOLD:
+			btStrategy.Type = backtest.ThresholdStrategy
+		}
+
+		btReq.Strategies = append(btReq.Strategies, btStrategy)
+	}
+
+	btCfg := &backtest.Config{
+		Channels: func() ([]*backtest.Channel, error) {
+			return backtestChannels(ctx, cfg)
+		},
+		ForwardingHistory: func(start, end uint64, offset,
+			maxEvents uint32) ([]*lnrpc.ForwardingEvent, uint32,
+			error) {
+
+			return getRevenueConfig(
+				ctx, cfg, start, end,
+			).ForwardingHistory(offset, maxEvents)
+		},
+		Chain: chain,
+	}
+
+	return btCfg, btReq, nil
+}
+
+// getChainTime returns an estimate of the mapping between block heights and
+// times. We anchor our estimates at the height and timestamp of the best block
+// known to lnd, so that they remain accurate when lnd is behind the chain or
+// we are running from a snapshot. If lnd does not report the timestamp, we
+// fall back to our server's current time.
+func getChainTime(ctx context.Context,
+	cfg *Config) (*backtest.ChainTime, error) {
+
+	info, err := cfg.LightningClient.GetInfo(ctx, &lnrpc.GetInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	anchor := cfg.now()
+	if info.BestHeaderTimestamp != 0 {
+		anchor = time.Unix(info.BestHeaderTimestamp, 0)
+	}
+
+	return &backtest.ChainTime{
+		Height:        info.BlockHeight,
+		Time:          anchor,
+		BlockInterval: blockInterval,
+	}, nil
+}
+
+// backtestChannels gets a snapshot of all of our open and closed channels.
+// If faraday has recorded uptime history for a channel, it is used in place
+// of lnd's uptime, which is reset when lnd restarts. Closed channels only have
+// uptime information if faraday recorded it while they were open.
+func backtestChannels(ctx context.Context,
+	cfg *Config) ([]*backtest.Channel, error) {
+
+	openChannels, err := cfg.wrapListChannels(ctx, false)()
+	if err != nil {
+		return nil, err
+	}
+
+	closed, err := cfg.LightningClient.ClosedChannels(
+		ctx, &lnrpc.ClosedChannelsRequest{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make(
+		[]*backtest.Channel, 0, len(openChannels)+len(closed.Channels),
+	)
+
+	for _, channel := range openChannels {
+		monitored, uptime, err := recordedUptime(
+			cfg, channel.ChannelPoint,
+			time.Second*time.Duration(channel.Lifetime),
+			time.Second*time.Duration(channel.Uptime),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		channels = append(channels, &backtest.Channel{
+			ChannelPoint: channel.ChannelPoint,
+			ChannelID:    channel.ChanId,
+			Capacity:     btcutil.Amount(channel.Capacity),
+			Private:      channel.Private,
+			UptimeKnown:  monitored != 0,
+			UptimeRatio:  uptimeRatio(monitored, uptime),
+		})
+	}
+
+	for _, channel := range closed.Channels {
+		monitored, uptime, err := recordedUptime(
+			cfg, channel.ChannelPoint, 0, 0,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		channels = append(channels, &backtest.Channel{
+			ChannelPoint: channel.ChannelPoint,
+			ChannelID:    channel.ChanId,
+			Capacity:     btcutil.Amount(channel.Capacity),
+			CloseHeight:  channel.CloseHeight,
+			UptimeKnown:  monitored != 0,
+			UptimeRatio:  uptimeRatio(monitored, uptime),
+		})
+	}
+
+	return channels, nil
+}
+
+// recordedUptime returns the uptime history that faraday has recorded for a
+// channel if it covers a longer period than the monitored time and uptime
+// provided. If our server does not record uptime, the values provided are
+// returned unchanged.
+func recordedUptime(cfg *Config, chanPoint string, monitored,
+	uptime time.Duration) (time.Duration, time.Duration, error) {
+
+	if cfg.ChannelUptime == nil {
+		return monitored, uptime, nil
+	}
+
+	m, u, err := cfg.ChannelUptime(chanPoint)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if m > monitored {
+		return m, u, nil
+	}
+
+	return monitored, uptime, nil
+}
+
+// uptimeRatio returns the ratio of uptime to monitored time, or zero if the
+// channel has not been monitored.
+func uptimeRatio(monitored, uptime time.Duration) float64 {
+	if monitored == 0 {
+		return 0
+	}
+
+	return float64(uptime) / float64(monitored)
+}
+
+// rpcBacktestResponse converts a backtest report to a rpc response.
+func rpcBacktestResponse(req *BacktestRequest,
+	report *backtest.Report) *BacktestResponse {
+
+	resp := &BacktestResponse{
+		AsOf:    uint64(report.AsOf.Unix()),
+		EndTime: uint64(report.EndTime.Unix()),
+		Results: make([]*BacktestResult, 0, len(report.Results)),
+	}
+
+	// Results are returned in the same order as the strategies requested,
+	// so we can use the request's strategies in our response.
+	for i, result := range report.Results {
+		resp.Results = append(resp.Results, &BacktestResult{
+			Strategy:            req.Strategies[i],
+			TotalChannels:       int32(result.TotalChannels),
+			ConsideredChannels:  int32(result.ConsideredChannels),
+			FlaggedChannels:     int32(result.FlaggedChannels),
+			KeptChannels:        int32(result.KeptChannels),
+			FlaggedFeesMsat:     int64(result.FlaggedFees),
+			KeptFeesMsat:        int64(result.KeptFees),
+			FlaggedIdle:         int32(result.FlaggedIdle),
+			KeptIdle:            int32(result.KeptIdle),
+			MeanFlaggedFeesMsat: float32(result.MeanFlaggedFees()),
+			MeanKeptFeesMsat:    float32(result.MeanKeptFees()),
+		})
+	}
+
+	return resp
+}
@@ -5,25 +5,126 @@ import (
 	"time"
 
 	"github.com/lightninglabs/faraday/insights"
+	"github.com/lightninglabs/faraday/liquidity"
 	"github.com/lightninglabs/faraday/revenue"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
 )
 
+// insightsParams contains optional parameters which alter the revenue that
+// channel insights are calculated with.
+type insightsParams struct {
+	// lookback is the period of time, counting back from the present, that
+	// revenue is calculated over. If it is zero, revenue is calculated over
+	// the lifetime of our channels.
+	lookback time.Duration
+
+	// halfLife is the half-life used to weight forwarding events by age.
+	// If it is zero, events are not weighted.
+	halfLife time.Duration
+}
+
+// newInsightsParams creates insights params from the rpc values provided.
+func newInsightsParams(lookbackSeconds,
+	halfLifeSeconds uint64) *insightsParams {
+
+	return &insightsParams{
+		lookback: time.Second * time.Duration(lookbackSeconds),
+		halfLife: time.Second * time.Duration(halfLifeSeconds),
+	}
+}
+
+// start returns the unix time that revenue should be calculated from, given
+// the current time. If no lookback is set, zero is returned so that revenue
+// covers the lifetime of our channels.
+func (p *insightsParams) start(now time.Time) uint64 {
+	if p.lookback == 0 {
+		return 0
+	}
+
+	return uint64(now.Add(p.lookback * -1).Unix())
+}
+
 // channelInsights gets the set of channel insights we need.
-func channelInsights(ctx context.Context,
-	cfg *Config) ([]*insights.ChannelInfo, error) {
+func channelInsights(ctx context.Context, cfg *Config,
+	params *insightsParams) ([]*insights.ChannelInfo, error) {
 
 	// Get revenue from a zero start time to the present to cover
-	// revenue over the lifetime of all our channels.
-	revenueCfg := getRevenueConfig(
-		ctx, cfg, 0, uint64(time.Now().Unix()),
-	)
+	// revenue over the lifetime of all our channels, unless a lookback
+	// period is set.
+	now := cfg.now()
+	start := params.start(now)
+
+	revenueCfg := getRevenueConfig(ctx, cfg, start, uint64(now.Unix()))
+	swaps := liquidityReport(ctx, cfg, start, now)
+
+	return getChannelInsights(ctx, cfg, params, now, revenueCfg, swaps)
+}
+
+// liquidityReport gets the cost of the swaps that rebalanced our channels
+// from the start time provided until now, if we are connected to loopd.
+// Failure to reach loopd should not prevent us from producing insights, so
+// we log the error and return nil, which leaves our channels' liquidity
+// costs at zero.
+func liquidityReport(ctx context.Context, cfg *Config, start uint64,
+	now time.Time) *liquidity.Report {
+
+	if cfg.ListSwaps == nil {
+		return nil
+	}
+
+	liquidityCfg := &liquidity.Config{
+		ListSwaps:      cfg.ListSwaps,
+		ListChannels:   cfg.wrapListChannels(ctx, false),
+		ClosedChannels: cfg.wrapClosedChannels(ctx),
+		HeightAt:       cfg.wrapHeightAt(ctx),
+		EndTime:        now,
+	}
+
+	if start != 0 {
+		liquidityCfg.StartTime = time.Unix(int64(start), 0)
+	}
+
+	report, err := liquidity.GetReport(liquidityCfg)
+	if err != nil {
+		log.Warnf("Could not get liquidity costs, channel insights "+
+			"will not include them: %v", err)
+
+		return nil
+	}
+
+	return report
+}
+
+// getChannelInsights produces channel insights from the revenue config and
+// swap report provided. The cost of swaps is weighted by the same half-life
+// as our revenue. If the swap report is nil, our channels' liquidity costs
+// are left at zero.
+func getChannelInsights(ctx context.Context, cfg *Config,
+	params *insightsParams, now time.Time, revenueCfg *revenue.Config,
+	swaps *liquidity.Report) ([]*insights.ChannelInfo, error) {
+
+	revenueCfg.DecayHalfLife = params.halfLife
+	revenueCfg.Now = func() time.Time {
+		return now
+	}
 
 	report, err := revenue.GetRevenueReport(revenueCfg)
 	if err != nil {
 		return nil, err
 	}
 
+	var liquidityCosts map[string]lnwire.MilliSatoshi
+	switch {
+	case swaps == nil:
+
+	case params.halfLife != 0:
+		liquidityCosts = swaps.DecayedMsatCosts(now, params.halfLife)
+
+	default:
+		liquidityCosts = swaps.MsatCosts()
+	}
+
 	return insights.GetChannels(&insights.Config{
 		OpenChannels: cfg.wrapListChannels(ctx, false),
 		CurrentHeight: func() (u uint32, err error) {
@@ -36,7 +137,13 @@ func channelInsights(ctx context.Context,
 
 			return info.BlockHeight, nil
 		},
-		RevenueReport: report,
+		RevenueReport:  report,
+		Lookback:       params.lookback,
+		DecayHalfLife:  params.halfLife,
+		ChannelUptime:  cfg.ChannelUptime,
+		InternalPeers:  cfg.internalPeers(ctx),
+		ChannelBalance: cfg.ChannelBalance,
+		LiquidityCosts: liquidityCosts,
 	})
 }
 
@@ -55,6 +162,22 @@ func rpcChannelInsightsResponse(
 			FeesEarnedMsat:     int64(i.FeesEarned),
 			Confirmations:      i.Confirmations,
 			Private:            i.Private,
+			Internal:           i.Internal,
+			CapacitySat:        int64(i.Capacity),
+			LocalBalanceSat:    int64(i.LocalBalance),
+			RemoteBalanceSat:   int64(i.RemoteBalance),
+			PendingIncomingSat: int64(i.PendingIncoming),
+			PendingOutgoingSat: int64(i.PendingOutgoing),
+			BalanceRatio:       float32(i.BalanceRatio),
+			BalanceMonitoredSeconds: uint64(
+				i.BalanceMonitored.Seconds(),
+			),
+			OneSidedSeconds:   uint64(i.OneSided.Seconds()),
+			LiquidityCostMsat: int64(i.LiquidityCost),
+			NetFeesMsat:       i.NetFees,
+			IncomingForwards:  rpcForwardStats(i.IncomingForwards),
+			OutgoingForwards:  rpcForwardStats(i.OutgoingForwards),
+			RevenueBlocks:     i.ScalingBlocks(),
 		}
 
 		rpcInsights = append(rpcInsights, insight)
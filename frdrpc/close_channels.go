@@ -0,0 +1,145 @@
+package frdrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/faraday/closer"
+	"github.com/lightninglabs/faraday/utils"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// parseCloseChannelsRequest parses a close channels request and wraps calls to
+// lnd to produce the config and request required to close channels.
+func parseCloseChannelsRequest(ctx context.Context, cfg *Config,
+	req *CloseChannelsRequest) (*closer.Config, *closer.Request) {
+
+	pendingChannels := func() ([]string, error) {
+		resp, err := cfg.LightningClient.PendingChannels(
+			ctx, &lnrpc.PendingChannelsRequest{},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var chanPoints []string
+		for _, c := range resp.PendingOpenChannels {
+			chanPoints = append(chanPoints, c.Channel.ChannelPoint)
+		}
+
+		for _, c := range resp.PendingClosingChannels {
+			chanPoints = append(chanPoints, c.Channel.ChannelPoint)
+		}
+
+		for _, c := range resp.PendingForceClosingChannels {
+			chanPoints = append(chanPoints, c.Channel.ChannelPoint)
+		}
+
+		for _, c := range resp.WaitingCloseChannels {
+			chanPoints = append(chanPoints, c.Channel.ChannelPoint)
+		}
+
+		return chanPoints, nil
+	}
+
+	closeCfg := &closer.Config{
+		OpenChannels:    cfg.wrapListChannels(ctx, false),
+		PendingChannels: pendingChannels,
+		CloseChannel: func(chanPoint string, force bool,
+			targetConf int32, satPerByte int64) (string, error) {
+
+			return closeChannel(
+				ctx, cfg, chanPoint, force, targetConf,
+				satPerByte,
+			)
+		},
+		Audit: cfg.CloseAudit,
+		Node:  cfg.nodeName,
+	}
+
+	closeReq := &closer.Request{
+		ChannelPoints: req.ChanPoints,
+		TargetConf:    req.TargetConf,
+		SatPerByte:    req.SatPerByte,
+		AllowForce:    req.AllowForce,
+		Confirm:       req.Confirm,
+	}
+
+	return closeCfg, closeReq
+}
+
+// closeChannel initiates a channel close with lnd and waits for the closing
+// transaction to be broadcast. It returns the txid of the closing transaction.
+func closeChannel(ctx context.Context, cfg *Config, chanPoint string,
+	force bool, targetConf int32, satPerByte int64) (string, error) {
+
+	outpoint, err := utils.GetOutPointFromString(chanPoint)
+	if err != nil {
+		return "", err
+	}
+
+	stream, err := cfg.LightningClient.CloseChannel(
+		ctx, &lnrpc.CloseChannelRequest{
+			ChannelPoint: &lnrpc.ChannelPoint{
+				FundingTxid: &lnrpc.ChannelPoint_FundingTxidStr{
+					FundingTxidStr: outpoint.Hash.String(),
+				},
+				OutputIndex: outpoint.Index,
+			},
+			Force:      force,
+			TargetConf: targetConf,
+			SatPerByte: satPerByte,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	// Wait for the first update from lnd, which will indicate that our
+	// closing transaction has been broadcast.
+	update, err := stream.Recv()
+	if err != nil {
+		return "", err
+	}
+
+	switch u := update.Update.(type) {
+	case *lnrpc.CloseStatusUpdate_ClosePending:
+		return utils.TxidString(u.ClosePending.Txid)
+
+	case *lnrpc.CloseStatusUpdate_ChanClose:
+		return utils.TxidString(u.ChanClose.ClosingTxid)
+
+	default:
+		return "", fmt.Errorf("unexpected close update: %T", u)
+	}
+}
+
+// rpcCloseChannelsResponse converts a set of close results to a rpc response.
+func rpcCloseChannelsResponse(results []*closer.Result) *CloseChannelsResponse {
+	resp := &CloseChannelsResponse{
+		Results: make([]*ChannelCloseResult, 0, len(results)),
+	}
+
+	for _, result := range results {
+		rpcResult := &ChannelCloseResult{
+			ChanPoint:   result.ChannelPoint,
+			Reason:      result.Reason,
+			ClosingTxid: result.ClosingTxid,
+		}
+
+		switch result.Action {
+		case closer.ActionCooperative:
+			rpcResult.Action = ChannelCloseResult_COOPERATIVE
+
+		case closer.ActionForce:
+			rpcResult.Action = ChannelCloseResult_FORCE
+
+		default:
+			rpcResult.Action = ChannelCloseResult_SKIP
+		}
+
+		resp.Results = append(resp.Results, rpcResult)
+	}
+
+	return resp
+}
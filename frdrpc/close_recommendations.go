@@ -7,6 +7,7 @@ import (
 
 	"github.com/lightninglabs/faraday/insights"
 	"github.com/lightninglabs/faraday/recommend"
+	"github.com/lightninglabs/faraday/rules"
 )
 
 // parseRecommendationRequest parses a close recommendation request and
@@ -14,36 +15,62 @@ import (
 func parseRecommendationRequest(ctx context.Context, cfg *Config,
 	req *CloseRecommendationRequest) *recommend.CloseRecommendationConfig {
 
+	params := newInsightsParams(
+		req.LookbackSeconds, req.DecayHalfLifeSeconds,
+	)
+
 	// Create a close recommendations config with the minimum monitored
 	// value provided in the request and the default outlier multiplier.
 	recCfg := &recommend.CloseRecommendationConfig{
 		ChannelInsights: func() ([]*insights.ChannelInfo, error) {
-			return channelInsights(ctx, cfg)
+			return channelInsights(ctx, cfg, params)
 		},
 		MinimumMonitored: time.Second *
 			time.Duration(req.MinimumMonitored),
+		OpportunityCostRate: opportunityCostRate(
+			cfg, req.OpportunityCostRate,
+		),
 	}
 
 	// Get the metric that the recommendations are being calculated based
 	// on.
-	switch req.Metric {
-	case CloseRecommendationRequest_UPTIME:
-		recCfg.Metric = recommend.UptimeMetric
+	recCfg.Metric = parseMetric(req.MetricName, req.Metric)
 
-	case CloseRecommendationRequest_REVENUE:
-		recCfg.Metric = recommend.RevenueMetric
+	return recCfg
+}
 
-	case CloseRecommendationRequest_INCOMING_VOLUME:
-		recCfg.Metric = recommend.IncomingVolume
+// legacyMetrics maps the metrics that could be requested with the rpc metric
+// enum to the names of the metrics they are registered with.
+var legacyMetrics = map[CloseRecommendationRequest_Metric]string{
+	CloseRecommendationRequest_UPTIME:          recommend.UptimeMetric,
+	CloseRecommendationRequest_REVENUE:         recommend.RevenueMetric,
+	CloseRecommendationRequest_INCOMING_VOLUME: recommend.IncomingVolume,
+	CloseRecommendationRequest_OUTGOING_VOLUME: recommend.OutgoingVolume,
+	CloseRecommendationRequest_TOTAL_VOLUME:    recommend.Volume,
+	CloseRecommendationRequest_NET_VALUE:       recommend.NetValueMetric,
+	CloseRecommendationRequest_BALANCE:         recommend.BalanceMetric,
+}
 
-	case CloseRecommendationRequest_OUTGOING_VOLUME:
-		recCfg.Metric = recommend.OutgoingVolume
+// parseMetric returns the name of the metric requested. Metric names take
+// precedence over the legacy metric enum. Unknown enum values are returned
+// as an empty name, which will fail recommendation calls.
+func parseMetric(name string, metric CloseRecommendationRequest_Metric) string {
+	if name != "" {
+		return name
+	}
 
-	case CloseRecommendationRequest_TOTAL_VOLUME:
-		recCfg.Metric = recommend.Volume
+	return legacyMetrics[metric]
+}
+
+// opportunityCostRate returns the opportunity cost rate provided in a
+// request, falling back to the rate our server is configured with if it is
+// not set.
+func opportunityCostRate(cfg *Config, rate float32) float64 {
+	if rate != 0 {
+		return float64(rate)
 	}
 
-	return recCfg
+	return cfg.OpportunityCostRate
 }
 
 // parseOutlierRequest parses a rpc outlier recommendation request and returns
@@ -72,6 +99,26 @@ func parseThresholdRequest(ctx context.Context, cfg *Config,
 		float64(req.ThresholdValue)
 }
 
+// parseRuleRequest parses a rpc rule recommendation request and returns the
+// close recommendation config and rules required. If the request does not
+// contain any rules, the rules that our server is configured with are used.
+func parseRuleRequest(ctx context.Context, cfg *Config,
+	req *RuleRecommendationsRequest) (*recommend.CloseRecommendationConfig,
+	[]*rules.Rule, error) {
+
+	closeRules := cfg.Rules
+	if len(req.Rules) != 0 {
+		var err error
+		closeRules, err = recommend.ParseRules(req.Rules)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return parseRecommendationRequest(ctx, cfg, req.RecRequest),
+		closeRules, nil
+}
+
 // rpcResponse parses the response obtained getting a close recommendation
 // and converts it to a close recommendation response.
 func rpcResponse(report *recommend.Report) *CloseRecommendationsResponse {
@@ -86,15 +133,31 @@ func rpcResponse(report *recommend.Report) *CloseRecommendationsResponse {
 				ChanPoint:      chanPoint,
 				Value:          float32(rec.Value),
 				RecommendClose: rec.RecommendClose,
+				Rule:           rec.Rule,
 			},
 		)
 	}
 
-	// Sort the recommendations returned by value.
+	// Sort the recommendations returned by value, breaking ties by
+	// channel point so that our ordering is deterministic.
 	sort.SliceStable(resp.Recommendations, func(i, j int) bool {
-		return resp.Recommendations[i].Value <
-			resp.Recommendations[j].Value
+		recI, recJ := resp.Recommendations[i], resp.Recommendations[j]
+
+		if recI.Value != recJ.Value {
+			return recI.Value < recJ.Value
+		}
+
+		return recI.ChanPoint < recJ.ChanPoint
 	})
 
+	if report.OutlierBounds != nil {
+		resp.OutlierBounds = &OutlierBounds{
+			LowerQuartile: float32(report.OutlierBounds.LowerQuartile),
+			UpperQuartile: float32(report.OutlierBounds.UpperQuartile),
+			LowerOutlier:  float32(report.OutlierBounds.LowerOutlier),
+			UpperOutlier:  float32(report.OutlierBounds.UpperOutlier),
+		}
+	}
+
 	return resp
 }
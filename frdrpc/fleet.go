@@ -0,0 +1,105 @@
+package frdrpc
+
+import (
+	"context"
+
+	"github.com/lightninglabs/faraday/insights"
+)
+
+// fleetReport produces channel insights for each of the nodes in our config,
+// and totals them per node and across the fleet. If our config does not
+// have a set of named nodes, the report contains our single node. Nodes whose
+// insights cannot be obtained are reported with their error, so that one node
+// being unavailable does not prevent us from reporting on the others.
+func fleetReport(ctx context.Context, cfg *Config,
+	params *insightsParams) (*FleetReportResponse, error) {
+
+	nodes := cfg.Nodes
+	if len(nodes) == 0 {
+		nodes = []*Node{{
+			LightningClient: cfg.LightningClient,
+			ChannelUptime:   cfg.ChannelUptime,
+			ChannelBalance:  cfg.ChannelBalance,
+			ListSwaps:       cfg.ListSwaps,
+		}}
+	}
+
+	resp := &FleetReportResponse{
+		Totals: &FleetTotals{},
+	}
+
+	for _, node := range nodes {
+		nodeCfg := *cfg
+		nodeCfg.LightningClient = node.LightningClient
+		nodeCfg.ChannelUptime = node.ChannelUptime
+		nodeCfg.ChannelBalance = node.ChannelBalance
+		nodeCfg.ListSwaps = node.ListSwaps
+
+		report := &NodeReport{
+			Node: node.Name,
+		}
+		resp.Nodes = append(resp.Nodes, report)
+
+		pubkey, err := node.getPubkey(ctx)
+		if err != nil {
+			log.Warnf("Could not get info for node %v: %v",
+				node.Name, err)
+
+			report.Error = err.Error()
+			continue
+		}
+		report.Pubkey = pubkey
+
+		channels, err := channelInsights(ctx, &nodeCfg, params)
+		if err != nil {
+			log.Warnf("Could not get insights for node %v: %v",
+				node.Name, err)
+
+			report.Error = err.Error()
+			continue
+		}
+
+		report.Totals = fleetTotals(channels)
+		addFleetTotals(resp.Totals, report.Totals)
+
+		report.ChannelInsights = rpcChannelInsightsResponse(
+			channels,
+		).ChannelInsights
+	}
+
+	return resp, nil
+}
+
+// fleetTotals sums up the insights for a single node's channels.
+func fleetTotals(channels []*insights.ChannelInfo) *FleetTotals {
+	totals := &FleetTotals{
+		Channels: uint32(len(channels)),
+	}
+
+	for _, channel := range channels {
+		totals.FeesEarnedMsat += int64(channel.FeesEarned)
+		totals.VolumeIncomingMsat += int64(channel.VolumeIncoming)
+		totals.VolumeOutgoingMsat += int64(channel.VolumeOutgoing)
+
+		if !channel.Internal {
+			continue
+		}
+
+		totals.InternalChannels++
+		totals.VolumeInternalMsat += int64(
+			channel.VolumeIncoming + channel.VolumeOutgoing,
+		)
+	}
+
+	return totals
+}
+
+// addFleetTotals adds the totals for a node to the running totals provided.
+func addFleetTotals(total, node *FleetTotals) {
+	total.Channels += node.Channels
+	total.InternalChannels += node.InternalChannels
+	total.FeesEarnedMsat += node.FeesEarnedMsat
+	total.VolumeIncomingMsat += node.VolumeIncomingMsat
+	total.VolumeOutgoingMsat += node.VolumeOutgoingMsat
+	total.VolumeInternalMsat += node.VolumeInternalMsat
+}
@@ -0,0 +1,101 @@
+package frdrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/faraday/flowgraph"
+	"github.com/lightninglabs/faraday/revenue"
+)
+
+// getFlowGraph produces the flow graph requested, based on the forwards
+// that our node made over the period in the request.
+func getFlowGraph(ctx context.Context, cfg *Config,
+	req *FlowGraphRequest) (*flowgraph.Graph, error) {
+
+	graphCfg := &flowgraph.Config{
+		ListChannels:   cfg.wrapListChannels(ctx, false),
+		ClosedChannels: cfg.wrapClosedChannels(ctx),
+		MergePeers:     req.MergePeers,
+	}
+
+	switch req.Weight {
+	case FlowGraphRequest_VOLUME:
+		graphCfg.Weight = flowgraph.WeightVolume
+
+	case FlowGraphRequest_FEES:
+		graphCfg.Weight = flowgraph.WeightFees
+
+	default:
+		return nil, fmt.Errorf("unknown weight: %v", req.Weight)
+	}
+
+	switch req.Label {
+	case FlowGraphRequest_ALIAS:
+		graphCfg.Label = flowgraph.LabelAlias
+
+	case FlowGraphRequest_PEER:
+		graphCfg.Label = flowgraph.LabelPeer
+
+	default:
+		return nil, fmt.Errorf("unknown label: %v", req.Label)
+	}
+
+	// Snapshots do not include lnd's graph, so we can only look up our
+	// peers' aliases when we are online.
+	if graphCfg.Label == flowgraph.LabelAlias && !cfg.Offline {
+		graphCfg.GetAlias = cfg.wrapGetAlias(ctx)
+	}
+
+	// Progress end time to the present if it is not set.
+	endTime := req.EndTime
+	if endTime == 0 {
+		endTime = uint64(cfg.now().Unix())
+	}
+
+	report, err := revenue.GetRevenueReport(
+		getRevenueConfig(ctx, cfg, req.StartTime, endTime),
+	)
+	if err != nil {
+		return nil, err
+	}
+	graphCfg.ChannelPairs = report.ChannelPairs
+
+	return flowgraph.GetGraph(graphCfg)
+}
+
+// rpcFlowGraphResponse converts a flow graph to a rpc response, writing the
+// graph in the DOT language if it was requested.
+func rpcFlowGraphResponse(graph *flowgraph.Graph,
+	format FlowGraphRequest_Format) *FlowGraphResponse {
+
+	resp := &FlowGraphResponse{
+		Nodes: make([]*FlowGraphNode, 0, len(graph.Nodes)),
+		Edges: make([]*FlowGraphEdge, 0, len(graph.Edges)),
+	}
+
+	for _, node := range graph.Nodes {
+		resp.Nodes = append(resp.Nodes, &FlowGraphNode{
+			Id:         node.ID,
+			Label:      node.Label,
+			Peer:       node.Peer,
+			ChanPoints: node.Channels,
+		})
+	}
+
+	for _, edge := range graph.Edges {
+		resp.Edges = append(resp.Edges, &FlowGraphEdge{
+			From:       edge.From,
+			To:         edge.To,
+			AmountMsat: int64(edge.Amount),
+			FeesMsat:   int64(edge.Fees),
+			WeightMsat: int64(edge.Weight),
+		})
+	}
+
+	if format == FlowGraphRequest_DOT {
+		resp.Dot = graph.DOT()
+	}
+
+	return resp
+}
@@ -0,0 +1,125 @@
+package frdrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// The optional features that a server may have enabled.
+const (
+	featureOffline        = "offline"
+	featureMultipleNodes  = "multiple_nodes"
+	featureUptimeHistory  = "uptime_history"
+	featureCloseAudit     = "close_audit"
+	featureCloseChannels  = "close_channels"
+	featureDebugLevel     = "debug_level"
+	featureLndSupervision = "lnd_supervision"
+	featureFiatPricing    = "fiat_pricing"
+	featureLoopCosts      = "loop_costs"
+)
+
+// getInfo produces a get info response for our config. Nodes whose info
+// cannot be obtained are included with the error that lnd returned, so that
+// the daemon's info is available while lnd is down.
+func getInfo(ctx context.Context, cfg *Config, now time.Time) *GetInfoResponse {
+	resp := &GetInfoResponse{
+		Version:  cfg.Version,
+		Commit:   cfg.Commit,
+		Features: features(cfg),
+	}
+
+	if !cfg.StartTime.IsZero() {
+		resp.UptimeSeconds = uint64(now.Sub(cfg.StartTime).Seconds())
+	}
+
+	nodes := cfg.Nodes
+	if len(nodes) == 0 {
+		nodes = []*Node{{
+			LightningClient: cfg.LightningClient,
+		}}
+	}
+
+	for _, node := range nodes {
+		lndInfo := &LndInfo{
+			Node: node.Name,
+		}
+
+		info, err := node.LightningClient.GetInfo(
+			ctx, &lnrpc.GetInfoRequest{},
+		)
+		if err != nil {
+			lndInfo.Error = err.Error()
+			resp.Nodes = append(resp.Nodes, lndInfo)
+
+			continue
+		}
+
+		lndInfo.Version = info.Version
+		lndInfo.Alias = info.Alias
+		lndInfo.Pubkey = info.IdentityPubkey
+
+		if len(info.Chains) > 0 {
+			lndInfo.Network = info.Chains[0].Network
+		}
+
+		resp.Nodes = append(resp.Nodes, lndInfo)
+	}
+
+	return resp
+}
+
+// features returns the optional features that are enabled by our config.
+func features(cfg *Config) []string {
+	var (
+		features   []string
+		uptime     = cfg.ChannelUptime != nil
+		loop       = cfg.ListSwaps != nil
+		supervised bool
+	)
+
+	for _, node := range cfg.Nodes {
+		uptime = uptime || node.ChannelUptime != nil
+		supervised = supervised || node.Status != nil
+		loop = loop || node.ListSwaps != nil
+	}
+
+	if cfg.Offline {
+		features = append(features, featureOffline)
+	}
+
+	if len(cfg.Nodes) > 1 {
+		features = append(features, featureMultipleNodes)
+	}
+
+	if uptime {
+		features = append(features, featureUptimeHistory)
+	}
+
+	if cfg.CloseAudit != nil {
+		features = append(features, featureCloseAudit)
+	}
+
+	if cfg.AllowClose {
+		features = append(features, featureCloseChannels)
+	}
+
+	if cfg.SetDebugLevel != nil {
+		features = append(features, featureDebugLevel)
+	}
+
+	if supervised {
+		features = append(features, featureLndSupervision)
+	}
+
+	if cfg.FiatPrices != nil {
+		features = append(features, featureFiatPricing)
+	}
+
+	if loop {
+		features = append(features, featureLoopCosts)
+	}
+
+	return features
+}
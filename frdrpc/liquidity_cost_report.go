@@ -0,0 +1,88 @@
+package frdrpc
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/faraday/liquidity"
+)
+
+// parseLiquidityCostRequest parses a request for a liquidity cost report
+// and wraps calls to lnd and loopd to produce the config required to get a
+// liquidity cost report.
+func parseLiquidityCostRequest(ctx context.Context, cfg *Config,
+	req *LiquidityCostReportRequest) (*liquidity.Config, error) {
+
+	if cfg.ListSwaps == nil {
+		return nil, ErrLoopUnavailable
+	}
+
+	liquidityCfg := &liquidity.Config{
+		ListSwaps:      cfg.ListSwaps,
+		ListChannels:   cfg.wrapListChannels(ctx, false),
+		ClosedChannels: cfg.wrapClosedChannels(ctx),
+		HeightAt:       cfg.wrapHeightAt(ctx),
+	}
+
+	if req.StartTime != 0 {
+		liquidityCfg.StartTime = time.Unix(int64(req.StartTime), 0)
+	}
+
+	if req.EndTime != 0 {
+		liquidityCfg.EndTime = time.Unix(int64(req.EndTime), 0)
+	}
+
+	return liquidityCfg, nil
+}
+
+// rpcLiquidityCost converts a liquidity cost to its rpc equivalent.
+func rpcLiquidityCost(cost *liquidity.Cost) *LiquidityCost {
+	return &LiquidityCost{
+		Swaps:           uint32(cost.Swaps),
+		AmountSat:       int64(cost.Amount),
+		ServerCostSat:   int64(cost.Server),
+		OnchainCostSat:  int64(cost.OnChain),
+		OffchainCostSat: int64(cost.OffChain),
+		TotalCostSat:    int64(cost.Total()),
+	}
+}
+
+// rpcLiquidityCostResponse converts a liquidity cost report to a rpc
+// response. Channels are sorted by outpoint so that responses are
+// deterministic.
+func rpcLiquidityCostResponse(
+	report *liquidity.Report) *LiquidityCostReportResponse {
+
+	resp := &LiquidityCostReportResponse{
+		Total:        rpcLiquidityCost(&report.Total),
+		Unattributed: rpcLiquidityCost(&report.Unattributed),
+	}
+
+	for chanPoint, cost := range report.Channels {
+		resp.Channels = append(resp.Channels, &ChannelLiquidityCost{
+			ChanPoint: chanPoint,
+			Cost:      rpcLiquidityCost(cost),
+		})
+	}
+
+	sort.Slice(resp.Channels, func(i, j int) bool {
+		return resp.Channels[i].ChanPoint < resp.Channels[j].ChanPoint
+	})
+
+	for _, swap := range report.Swaps {
+		resp.Swaps = append(resp.Swaps, &SwapCost{
+			Id:              swap.ID,
+			Type:            swap.Type.String(),
+			State:           swap.State,
+			InitiationTime:  uint64(swap.Initiated.Unix()),
+			AmountSat:       int64(swap.Amount),
+			ServerCostSat:   int64(swap.ServerCost),
+			OnchainCostSat:  int64(swap.OnChainCost),
+			OffchainCostSat: int64(swap.OffChainCost),
+			ChanPoints:      swap.Channels,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,38 @@
+package frdrpc
+
+import "github.com/lightninglabs/faraday/recommend"
+
+// listMetrics produces a list metrics response containing all of the metrics
+// that are registered for close recommendations.
+func listMetrics() *ListMetricsResponse {
+	metrics := recommend.Metrics()
+
+	resp := &ListMetricsResponse{
+		Metrics: make([]*MetricInfo, 0, len(metrics)),
+	}
+
+	for _, metric := range metrics {
+		resp.Metrics = append(resp.Metrics, &MetricInfo{
+			Name:        metric.Name,
+			Description: metric.Description,
+			Unit:        metric.Unit,
+			Scaling:     rpcScaling(metric.Scaling),
+		})
+	}
+
+	return resp
+}
+
+// rpcScaling converts a metric's scaling to its rpc equivalent.
+func rpcScaling(scaling recommend.Scaling) MetricInfo_Scaling {
+	switch scaling {
+	case recommend.ScalePerConfirmation:
+		return MetricInfo_PER_CONFIRMATION
+
+	case recommend.ScalePerCapacity:
+		return MetricInfo_PER_CAPACITY
+
+	default:
+		return MetricInfo_RAW
+	}
+}
@@ -2,17 +2,23 @@ package frdrpc
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/lightninglabs/faraday/revenue"
 	"github.com/lightningnetwork/lnd/lnrpc"
 )
 
+// maxRevenuePeriods is the maximum number of periods that a revenue report
+// can be split into.
+const maxRevenuePeriods = 10000
+
 // parseRevenueRequest parses a request for a revenue report and wraps
 // calls to lnd client to produce the config required to get a revenue
-// report.
+// report. If the request has a fiat currency, prices for the period are
+// obtained from our price source so that fees can be valued in fiat.
 func parseRevenueRequest(ctx context.Context, cfg *Config,
-	req *RevenueReportRequest) *revenue.Config {
+	req *RevenueReportRequest) (*revenue.Config, error) {
 
 	// Progress end time to the present if it is not set.
 	// We allow start time to be zero so that revenue can
@@ -20,26 +26,55 @@ func parseRevenueRequest(ctx context.Context, cfg *Config,
 	// knowing the time it was opened.
 	endTime := req.EndTime
 	if endTime == 0 {
-		endTime = uint64(time.Now().Unix())
+		endTime = uint64(cfg.now().Unix())
 	}
 
-	return getRevenueConfig(ctx, cfg, req.StartTime, endTime)
-}
+	revenueCfg := getRevenueConfig(ctx, cfg, req.StartTime, endTime)
 
-func getRevenueConfig(ctx context.Context, cfg *Config,
-	start, end uint64) *revenue.Config {
+	if req.PeriodSeconds != 0 {
+		if req.StartTime == 0 {
+			return nil, ErrPeriodsNoStart
+		}
 
-	closedChannels := func() ([]*lnrpc.ChannelCloseSummary, error) {
-		resp, err := cfg.LightningClient.ClosedChannels(
-			ctx, &lnrpc.ClosedChannelsRequest{},
-		)
-		if err != nil {
-			return nil, err
+		if endTime > req.StartTime &&
+			(endTime-req.StartTime)/req.PeriodSeconds >=
+				maxRevenuePeriods {
+
+			return nil, ErrTooManyPeriods
 		}
 
-		return resp.Channels, nil
+		revenueCfg.PeriodLength = time.Second *
+			time.Duration(req.PeriodSeconds)
+		revenueCfg.PeriodStart = time.Unix(int64(req.StartTime), 0)
+		revenueCfg.PeriodEnd = time.Unix(int64(endTime), 0)
 	}
 
+	if req.FiatCurrency == "" {
+		return revenueCfg, nil
+	}
+
+	if cfg.FiatPrices == nil {
+		return nil, ErrFiatUnavailable
+	}
+
+	prices, err := cfg.FiatPrices(
+		req.FiatCurrency, time.Unix(int64(req.StartTime), 0),
+		time.Unix(int64(endTime), 0),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	revenueCfg.FiatPrice = func(timestamp time.Time) (float64, error) {
+		return prices.Price(req.FiatCurrency, timestamp)
+	}
+
+	return revenueCfg, nil
+}
+
+func getRevenueConfig(ctx context.Context, cfg *Config,
+	start, end uint64) *revenue.Config {
+
 	forwardingHistory := func(offset,
 		maxEvents uint32) ([]*lnrpc.ForwardingEvent, uint32, error) {
 		resp, err := cfg.LightningClient.ForwardingHistory(
@@ -57,20 +92,74 @@ func getRevenueConfig(ctx context.Context, cfg *Config,
 		return resp.ForwardingEvents, resp.LastOffsetIndex, nil
 	}
 
-	return &revenue.Config{
+	revenueCfg := &revenue.Config{
 		ListChannels:      cfg.wrapListChannels(ctx, false),
-		ClosedChannels:    closedChannels,
+		ClosedChannels:    cfg.wrapClosedChannels(ctx),
 		ForwardingHistory: forwardingHistory,
 	}
+
+	// Snapshots do not include lnd's graph, so we can only look up
+	// channels that are not in our channel lists when we are online.
+	if !cfg.Offline {
+		revenueCfg.GetChanInfo = cfg.wrapGetChanInfo(ctx)
+	}
+
+	return revenueCfg
 }
 
 // rpcRevenueResponse takes a target channel and revenue report and produces
 // a revenue report response. If the channel had no revenue, an empty report is
-// returned.
-func rpcRevenueResponse(targetChannels []string,
-	revenueReport *revenue.Report) (*RevenueReportResponse, error) {
+// returned. If fees were valued in fiat, the currency they were valued in is
+// included in the response. Forwards that could not be attributed to a channel
+// are included regardless of the target channels requested.
+func rpcRevenueResponse(targetChannels []string, revenueReport *revenue.Report,
+	fiatCurrency string) (*RevenueReportResponse, error) {
+
+	resp := &RevenueReportResponse{
+		TotalFeesMsat: int64(revenueReport.TotalFees),
+	}
+
+	for _, period := range revenueReport.Periods {
+		resp.Periods = append(resp.Periods, &RevenuePeriod{
+			StartTime:  uint64(period.Start.Unix()),
+			EndTime:    uint64(period.End.Unix()),
+			Forwards:   uint64(period.Forwards),
+			FeesMsat:   int64(period.Fees),
+			VolumeMsat: int64(period.Volume),
+		})
+	}
 
-	resp := &RevenueReportResponse{}
+	if fiatCurrency != "" {
+		resp.FiatCurrency = strings.ToUpper(fiatCurrency)
+		resp.TotalFeesFiat = revenueReport.TotalFeesFiat
+	}
+
+	// If some forwards could not be attributed to channels, we add them
+	// to our response and set a warning count so that the user knows that
+	// the per-channel reports are incomplete.
+	unattributed := revenueReport.Unattributed
+	if unattributed.Forwards != 0 {
+		log.Warnf("%v forwards could not be attributed to channels",
+			unattributed.Forwards)
+
+		resp.WarningCount = uint32(unattributed.Forwards)
+		resp.Unattributed = &UnattributedReport{
+			AmountIncomingMsat: int64(unattributed.AmountIncoming),
+			AmountOutgoingMsat: int64(unattributed.AmountOutgoing),
+			FeesMsat:           int64(unattributed.Fees),
+		}
+
+		if fiatCurrency != "" {
+			resp.Unattributed.FeesFiat = unattributed.FeesFiat
+		}
+
+		for _, chanID := range unattributed.ChannelIDs {
+			resp.Unattributed.ShortChannelIds = append(
+				resp.Unattributed.ShortChannelIds,
+				chanID.String(),
+			)
+		}
+	}
 
 	// If no channels were specifically requested, set all channels in the
 	// report as our set of target channels.
@@ -100,6 +189,18 @@ func rpcRevenueResponse(targetChannels []string,
 			continue
 		}
 
+		// Add forward stats for the channel, if it has forwarded any
+		// payments.
+		stats, ok := revenueReport.ChannelStats[targetChannel]
+		if ok {
+			rpcReport.IncomingForwards = rpcForwardStats(
+				stats.Incoming,
+			)
+			rpcReport.OutgoingForwards = rpcForwardStats(
+				stats.Outgoing,
+			)
+		}
+
 		// Add revenue reports for each of our peers to the response.
 		for peer, rp := range report {
 			rpcReport.PairReports[peer] = &PairReport{
@@ -107,6 +208,8 @@ func rpcRevenueResponse(targetChannels []string,
 				FeesOutgoingMsat:   int64(rp.FeesOutgoing),
 				AmountIncomingMsat: int64(rp.AmountIncoming),
 				FeesIncomingMsat:   int64(rp.FeesIncoming),
+				FeesOutgoingFiat:   rp.FeesOutgoingFiat,
+				FeesIncomingFiat:   rp.FeesIncomingFiat,
 			}
 		}
 
@@ -115,3 +218,20 @@ func rpcRevenueResponse(targetChannels []string,
 
 	return resp, nil
 }
+
+// rpcForwardStats converts forward stats to their rpc representation. If
+// there were no forwards, nil is returned.
+func rpcForwardStats(stats revenue.ForwardStats) *ForwardStats {
+	if stats.Count == 0 {
+		return nil
+	}
+
+	return &ForwardStats{
+		Count:          uint64(stats.Count),
+		MinSizeMsat:    int64(stats.MinSize),
+		MedianSizeMsat: int64(stats.MedianSize),
+		P90SizeMsat:    int64(stats.P90Size),
+		MaxSizeMsat:    int64(stats.MaxSize),
+		FeeRatePpm:     stats.FeeRatePPM,
+	}
+}
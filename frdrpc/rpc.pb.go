@@ -32,6 +32,8 @@ const (
 	CloseRecommendationRequest_INCOMING_VOLUME CloseRecommendationRequest_Metric = 3
 	CloseRecommendationRequest_OUTGOING_VOLUME CloseRecommendationRequest_Metric = 4
 	CloseRecommendationRequest_TOTAL_VOLUME    CloseRecommendationRequest_Metric = 5
+	CloseRecommendationRequest_NET_VALUE       CloseRecommendationRequest_Metric = 6
+	CloseRecommendationRequest_BALANCE         CloseRecommendationRequest_Metric = 7
 )
 
 var CloseRecommendationRequest_Metric_name = map[int32]string{
@@ -41,6 +43,8 @@ var CloseRecommendationRequest_Metric_name = map[int32]string{
 	3: "INCOMING_VOLUME",
 	4: "OUTGOING_VOLUME",
 	5: "TOTAL_VOLUME",
+	6: "NET_VALUE",
+	7: "BALANCE",
 }
 
 var CloseRecommendationRequest_Metric_value = map[string]int32{
@@ -50,6 +54,8 @@ var CloseRecommendationRequest_Metric_value = map[string]int32{
 	"INCOMING_VOLUME": 3,
 	"OUTGOING_VOLUME": 4,
 	"TOTAL_VOLUME":    5,
+	"NET_VALUE":       6,
+	"BALANCE":         7,
 }
 
 func (x CloseRecommendationRequest_Metric) String() string {
@@ -60,6 +66,207 @@ func (CloseRecommendationRequest_Metric) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_77a6da22d6a3feb1, []int{0, 0}
 }
 
+type ChannelCloseResult_Action int32
+
+const (
+	ChannelCloseResult_SKIP        ChannelCloseResult_Action = 0
+	ChannelCloseResult_COOPERATIVE ChannelCloseResult_Action = 1
+	ChannelCloseResult_FORCE       ChannelCloseResult_Action = 2
+)
+
+var ChannelCloseResult_Action_name = map[int32]string{
+	0: "SKIP",
+	1: "COOPERATIVE",
+	2: "FORCE",
+}
+
+var ChannelCloseResult_Action_value = map[string]int32{
+	"SKIP":        0,
+	"COOPERATIVE": 1,
+	"FORCE":       2,
+}
+
+func (x ChannelCloseResult_Action) String() string {
+	return proto.EnumName(ChannelCloseResult_Action_name, int32(x))
+}
+
+func (ChannelCloseResult_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{19, 0}
+}
+
+type BacktestStrategy_StrategyType int32
+
+const (
+	BacktestStrategy_OUTLIER   BacktestStrategy_StrategyType = 0
+	BacktestStrategy_THRESHOLD BacktestStrategy_StrategyType = 1
+)
+
+var BacktestStrategy_StrategyType_name = map[int32]string{
+	0: "OUTLIER",
+	1: "THRESHOLD",
+}
+
+var BacktestStrategy_StrategyType_value = map[string]int32{
+	"OUTLIER":   0,
+	"THRESHOLD": 1,
+}
+
+func (x BacktestStrategy_StrategyType) String() string {
+	return proto.EnumName(BacktestStrategy_StrategyType_name, int32(x))
+}
+
+func (BacktestStrategy_StrategyType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{21, 0}
+}
+
+type NodeStatus_State int32
+
+const (
+	//
+	//The state of the connection is not known, because faraday is not
+	//supervising it. This is the case in offline mode.
+	NodeStatus_UNKNOWN NodeStatus_State = 0
+	// Faraday has not yet checked lnd's state.
+	NodeStatus_CONNECTING NodeStatus_State = 1
+	// Faraday cannot reach lnd, and is reconnecting.
+	NodeStatus_DISCONNECTED NodeStatus_State = 2
+	// lnd is running, but its wallet is locked.
+	NodeStatus_WAITING_UNLOCK NodeStatus_State = 3
+	// lnd is unlocked, but is not yet synced to chain.
+	NodeStatus_WAITING_SYNC NodeStatus_State = 4
+	// lnd is unlocked and synced, and requests can be served.
+	NodeStatus_READY NodeStatus_State = 5
+)
+
+var NodeStatus_State_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "CONNECTING",
+	2: "DISCONNECTED",
+	3: "WAITING_UNLOCK",
+	4: "WAITING_SYNC",
+	5: "READY",
+}
+
+var NodeStatus_State_value = map[string]int32{
+	"UNKNOWN":        0,
+	"CONNECTING":     1,
+	"DISCONNECTED":   2,
+	"WAITING_UNLOCK": 3,
+	"WAITING_SYNC":   4,
+	"READY":          5,
+}
+
+func (x NodeStatus_State) String() string {
+	return proto.EnumName(NodeStatus_State_name, int32(x))
+}
+
+func (NodeStatus_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{34, 0}
+}
+
+type MetricInfo_Scaling int32
+
+const (
+	MetricInfo_RAW              MetricInfo_Scaling = 0
+	MetricInfo_PER_CONFIRMATION MetricInfo_Scaling = 1
+	MetricInfo_PER_CAPACITY     MetricInfo_Scaling = 2
+)
+
+var MetricInfo_Scaling_name = map[int32]string{
+	0: "RAW",
+	1: "PER_CONFIRMATION",
+	2: "PER_CAPACITY",
+}
+
+var MetricInfo_Scaling_value = map[string]int32{
+	"RAW":              0,
+	"PER_CONFIRMATION": 1,
+	"PER_CAPACITY":     2,
+}
+
+func (x MetricInfo_Scaling) String() string {
+	return proto.EnumName(MetricInfo_Scaling_name, int32(x))
+}
+
+func (MetricInfo_Scaling) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{40, 0}
+}
+
+type FlowGraphRequest_Weight int32
+
+const (
+	FlowGraphRequest_VOLUME FlowGraphRequest_Weight = 0
+	FlowGraphRequest_FEES   FlowGraphRequest_Weight = 1
+)
+
+var FlowGraphRequest_Weight_name = map[int32]string{
+	0: "VOLUME",
+	1: "FEES",
+}
+
+var FlowGraphRequest_Weight_value = map[string]int32{
+	"VOLUME": 0,
+	"FEES":   1,
+}
+
+func (x FlowGraphRequest_Weight) String() string {
+	return proto.EnumName(FlowGraphRequest_Weight_name, int32(x))
+}
+
+func (FlowGraphRequest_Weight) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{46, 0}
+}
+
+type FlowGraphRequest_Label int32
+
+const (
+	FlowGraphRequest_ALIAS FlowGraphRequest_Label = 0
+	FlowGraphRequest_PEER  FlowGraphRequest_Label = 1
+)
+
+var FlowGraphRequest_Label_name = map[int32]string{
+	0: "ALIAS",
+	1: "PEER",
+}
+
+var FlowGraphRequest_Label_value = map[string]int32{
+	"ALIAS": 0,
+	"PEER":  1,
+}
+
+func (x FlowGraphRequest_Label) String() string {
+	return proto.EnumName(FlowGraphRequest_Label_name, int32(x))
+}
+
+func (FlowGraphRequest_Label) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{46, 1}
+}
+
+type FlowGraphRequest_Format int32
+
+const (
+	FlowGraphRequest_JSON FlowGraphRequest_Format = 0
+	FlowGraphRequest_DOT  FlowGraphRequest_Format = 1
+)
+
+var FlowGraphRequest_Format_name = map[int32]string{
+	0: "JSON",
+	1: "DOT",
+}
+
+var FlowGraphRequest_Format_value = map[string]int32{
+	"JSON": 0,
+	"DOT":  1,
+}
+
+func (x FlowGraphRequest_Format) String() string {
+	return proto.EnumName(FlowGraphRequest_Format_name, int32(x))
+}
+
+func (FlowGraphRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{46, 2}
+}
+
 type CloseRecommendationRequest struct {
 	//
 	//The minimum amount of time in seconds that a channel should have been
@@ -67,15 +274,49 @@ type CloseRecommendationRequest struct {
 	//protect against closing of newer channels.
 	MinimumMonitored int64 `protobuf:"varint,1,opt,name=minimum_monitored,json=minimumMonitored,proto3" json:"minimum_monitored,omitempty"`
 	//
-	//The data point base close recommendations on. Available options are:
+	//The data point base close recommendations on. This enum is kept for
+	//backwards compatibility, and is only used if metric_name is not set.
+	//Available options are:
 	//Uptime: ratio of channel peer's uptime to the period they have been
 	//monitored to.
 	//Revenue: the revenue that the channel has produced per block that its
 	//funding transaction has been confirmed for.
-	Metric               CloseRecommendationRequest_Metric `protobuf:"varint,2,opt,name=metric,proto3,enum=frdrpc.CloseRecommendationRequest_Metric" json:"metric,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
-	XXX_unrecognized     []byte                            `json:"-"`
-	XXX_sizecache        int32                             `json:"-"`
+	//Net value: the revenue that the channel has produced less its liquidity
+	//costs and the opportunity cost of its capital, per block that its funding
+	//transaction has been confirmed for. Threshold recommendations with a threshold of zero flag
+	//channels that have a negative net value.
+	//Balance: the ratio of time that the channel's balance was not almost
+	//entirely local or remote to the period its balance has been sampled for.
+	Metric CloseRecommendationRequest_Metric `protobuf:"varint,2,opt,name=metric,proto3,enum=frdrpc.CloseRecommendationRequest_Metric" json:"metric,omitempty"`
+	//
+	//The period of time in seconds, counting back from the present, that
+	//revenue and volume should be calculated over. If this value is not set,
+	//revenue is calculated over the lifetime of each channel.
+	LookbackSeconds uint64 `protobuf:"varint,3,opt,name=lookback_seconds,json=lookbackSeconds,proto3" json:"lookback_seconds,omitempty"`
+	//
+	//An optional half-life in seconds which is used to weight forwarding
+	//events by their age, so that recent forwards contribute more to revenue
+	//and volume than older forwards. If this value is not set, all forwards
+	//are weighted equally.
+	DecayHalfLifeSeconds uint64 `protobuf:"varint,4,opt,name=decay_half_life_seconds,json=decayHalfLifeSeconds,proto3" json:"decay_half_life_seconds,omitempty"`
+	//
+	//The name of the lnd node that the request is for. If this value is not
+	//set, the request is served by the first node faraday is configured with.
+	Node string `protobuf:"bytes,5,opt,name=node,proto3" json:"node,omitempty"`
+	//
+	//The annual rate of return that could be earned on capital elsewhere,
+	//expressed as a fraction (eg, 0.05 for 5%), which is used to calculate the
+	//opportunity cost of channel capital for the net value metric. If this
+	//value is not set, the rate that faraday is configured with is used.
+	OpportunityCostRate float32 `protobuf:"fixed32,6,opt,name=opportunity_cost_rate,json=opportunityCostRate,proto3" json:"opportunity_cost_rate,omitempty"`
+	//
+	//The name of the metric to base close recommendations on, which takes
+	//precedence over the metric enum. The set of available metrics can be
+	//obtained with ListMetrics.
+	MetricName           string   `protobuf:"bytes,7,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CloseRecommendationRequest) Reset()         { *m = CloseRecommendationRequest{} }
@@ -117,6 +358,41 @@ func (m *CloseRecommendationRequest) GetMetric() CloseRecommendationRequest_Metr
 	return CloseRecommendationRequest_UNKNOWN
 }
 
+func (m *CloseRecommendationRequest) GetLookbackSeconds() uint64 {
+	if m != nil {
+		return m.LookbackSeconds
+	}
+	return 0
+}
+
+func (m *CloseRecommendationRequest) GetDecayHalfLifeSeconds() uint64 {
+	if m != nil {
+		return m.DecayHalfLifeSeconds
+	}
+	return 0
+}
+
+func (m *CloseRecommendationRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *CloseRecommendationRequest) GetOpportunityCostRate() float32 {
+	if m != nil {
+		return m.OpportunityCostRate
+	}
+	return 0
+}
+
+func (m *CloseRecommendationRequest) GetMetricName() string {
+	if m != nil {
+		return m.MetricName
+	}
+	return ""
+}
+
 type OutlierRecommendationsRequest struct {
 	//
 	//The parameters that are common to all close recommendations.
@@ -245,6 +521,63 @@ func (m *ThresholdRecommendationsRequest) GetThresholdValue() float32 {
 	return 0
 }
 
+type RuleRecommendationsRequest struct {
+	//
+	//The parameters that are common to all close recommendations. The metric
+	//is not used for rule based recommendations.
+	RecRequest *CloseRecommendationRequest `protobuf:"bytes,1,opt,name=rec_request,json=recRequest,proto3" json:"rec_request,omitempty"`
+	//
+	//The rules that channels are checked against, expressed as
+	//name:expression, for example
+	//flaky:uptime_ratio < 0.8 && fees_per_conf < 10 && monitored > 60d.
+	//Channels are recommended for close if any rule matches them, and the
+	//first rule that matches is reported. If no rules are provided, the rules
+	//that faraday is configured with are used.
+	Rules                []string `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RuleRecommendationsRequest) Reset()         { *m = RuleRecommendationsRequest{} }
+func (m *RuleRecommendationsRequest) String() string { return proto.CompactTextString(m) }
+func (*RuleRecommendationsRequest) ProtoMessage()    {}
+func (*RuleRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{3}
+}
+
+func (m *RuleRecommendationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RuleRecommendationsRequest.Unmarshal(m, b)
+}
+func (m *RuleRecommendationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RuleRecommendationsRequest.Marshal(b, m, deterministic)
+}
+func (m *RuleRecommendationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleRecommendationsRequest.Merge(m, src)
+}
+func (m *RuleRecommendationsRequest) XXX_Size() int {
+	return xxx_messageInfo_RuleRecommendationsRequest.Size(m)
+}
+func (m *RuleRecommendationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleRecommendationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleRecommendationsRequest proto.InternalMessageInfo
+
+func (m *RuleRecommendationsRequest) GetRecRequest() *CloseRecommendationRequest {
+	if m != nil {
+		return m.RecRequest
+	}
+	return nil
+}
+
+func (m *RuleRecommendationsRequest) GetRules() []string {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
 type CloseRecommendationsResponse struct {
 	//
 	//The total number of channels, before filtering out channels that are
@@ -258,17 +591,23 @@ type CloseRecommendationsResponse struct {
 	//set implies that it was not considered for close because it did not meet
 	//the criteria for close recommendations (it is private, or has not been
 	//monitored for long enough).
-	Recommendations      []*Recommendation `protobuf:"bytes,3,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Recommendations []*Recommendation `protobuf:"bytes,3,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	//
+	//The quartiles and outlier bounds that outlier recommendations were based
+	//on. This field is not set for other recommendations, or for outlier
+	//recommendations when too few channels were considered to calculate
+	//quartiles.
+	OutlierBounds        *OutlierBounds `protobuf:"bytes,4,opt,name=outlier_bounds,json=outlierBounds,proto3" json:"outlier_bounds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *CloseRecommendationsResponse) Reset()         { *m = CloseRecommendationsResponse{} }
 func (m *CloseRecommendationsResponse) String() string { return proto.CompactTextString(m) }
 func (*CloseRecommendationsResponse) ProtoMessage()    {}
 func (*CloseRecommendationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{3}
+	return fileDescriptor_77a6da22d6a3feb1, []int{4}
 }
 
 func (m *CloseRecommendationsResponse) XXX_Unmarshal(b []byte) error {
@@ -310,6 +649,82 @@ func (m *CloseRecommendationsResponse) GetRecommendations() []*Recommendation {
 	return nil
 }
 
+func (m *CloseRecommendationsResponse) GetOutlierBounds() *OutlierBounds {
+	if m != nil {
+		return m.OutlierBounds
+	}
+	return nil
+}
+
+type OutlierBounds struct {
+	// The lower quartile of the channels' values.
+	LowerQuartile float32 `protobuf:"fixed32,1,opt,name=lower_quartile,json=lowerQuartile,proto3" json:"lower_quartile,omitempty"`
+	// The upper quartile of the channels' values.
+	UpperQuartile float32 `protobuf:"fixed32,2,opt,name=upper_quartile,json=upperQuartile,proto3" json:"upper_quartile,omitempty"`
+	//
+	//The value below which channels are lower outliers, and are recommended
+	//for close.
+	LowerOutlier float32 `protobuf:"fixed32,3,opt,name=lower_outlier,json=lowerOutlier,proto3" json:"lower_outlier,omitempty"`
+	// The value above which channels are upper outliers.
+	UpperOutlier         float32  `protobuf:"fixed32,4,opt,name=upper_outlier,json=upperOutlier,proto3" json:"upper_outlier,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OutlierBounds) Reset()         { *m = OutlierBounds{} }
+func (m *OutlierBounds) String() string { return proto.CompactTextString(m) }
+func (*OutlierBounds) ProtoMessage()    {}
+func (*OutlierBounds) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{5}
+}
+
+func (m *OutlierBounds) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutlierBounds.Unmarshal(m, b)
+}
+func (m *OutlierBounds) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutlierBounds.Marshal(b, m, deterministic)
+}
+func (m *OutlierBounds) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutlierBounds.Merge(m, src)
+}
+func (m *OutlierBounds) XXX_Size() int {
+	return xxx_messageInfo_OutlierBounds.Size(m)
+}
+func (m *OutlierBounds) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutlierBounds.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutlierBounds proto.InternalMessageInfo
+
+func (m *OutlierBounds) GetLowerQuartile() float32 {
+	if m != nil {
+		return m.LowerQuartile
+	}
+	return 0
+}
+
+func (m *OutlierBounds) GetUpperQuartile() float32 {
+	if m != nil {
+		return m.UpperQuartile
+	}
+	return 0
+}
+
+func (m *OutlierBounds) GetLowerOutlier() float32 {
+	if m != nil {
+		return m.LowerOutlier
+	}
+	return 0
+}
+
+func (m *OutlierBounds) GetUpperOutlier() float32 {
+	if m != nil {
+		return m.UpperOutlier
+	}
+	return 0
+}
+
 type Recommendation struct {
 	//
 	//The channel point [funding txid: outpoint] of the channel being considered
@@ -318,7 +733,11 @@ type Recommendation struct {
 	// The value of the metric that close recommendations were based on.
 	Value float32 `protobuf:"fixed32,2,opt,name=value,proto3" json:"value,omitempty"`
 	// A boolean indicating whether we recommend closing the channel.
-	RecommendClose       bool     `protobuf:"varint,3,opt,name=recommend_close,json=recommendClose,proto3" json:"recommend_close,omitempty"`
+	RecommendClose bool `protobuf:"varint,3,opt,name=recommend_close,json=recommendClose,proto3" json:"recommend_close,omitempty"`
+	//
+	//The name of the rule that matched the channel, set for rule based
+	//recommendations.
+	Rule                 string   `protobuf:"bytes,4,opt,name=rule,proto3" json:"rule,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -328,7 +747,7 @@ func (m *Recommendation) Reset()         { *m = Recommendation{} }
 func (m *Recommendation) String() string { return proto.CompactTextString(m) }
 func (*Recommendation) ProtoMessage()    {}
 func (*Recommendation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{4}
+	return fileDescriptor_77a6da22d6a3feb1, []int{6}
 }
 
 func (m *Recommendation) XXX_Unmarshal(b []byte) error {
@@ -370,6 +789,13 @@ func (m *Recommendation) GetRecommendClose() bool {
 	return false
 }
 
+func (m *Recommendation) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
 type RevenueReportRequest struct {
 	//
 	//The funding transaction outpoints for the channels to generate a revenue
@@ -384,7 +810,23 @@ type RevenueReportRequest struct {
 	//
 	//End time is end of the range over which the report will be
 	//generated, expressed as unix epoch offset in seconds.
-	EndTime              uint64   `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	EndTime uint64 `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	//
+	//The name of the lnd node that the request is for. If this value is not
+	//set, the request is served by the first node faraday is configured with.
+	Node string `protobuf:"bytes,4,opt,name=node,proto3" json:"node,omitempty"`
+	//
+	//An optional fiat currency code (eg, USD or EUR) to value fees in. Each
+	//forward's fees are valued at the price of bitcoin when the forward
+	//happened. Faraday must be configured with a price file or price endpoint
+	//to value fees in fiat.
+	FiatCurrency string `protobuf:"bytes,5,opt,name=fiat_currency,json=fiatCurrency,proto3" json:"fiat_currency,omitempty"`
+	//
+	//An optional period length in seconds to split the report's forwards into.
+	//If set, the response includes the fees earned and volume forwarded in
+	//each consecutive period from start_time to end_time. A start time must
+	//be set to split the report into periods.
+	PeriodSeconds        uint64   `protobuf:"varint,6,opt,name=period_seconds,json=periodSeconds,proto3" json:"period_seconds,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -394,7 +836,7 @@ func (m *RevenueReportRequest) Reset()         { *m = RevenueReportRequest{} }
 func (m *RevenueReportRequest) String() string { return proto.CompactTextString(m) }
 func (*RevenueReportRequest) ProtoMessage()    {}
 func (*RevenueReportRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{5}
+	return fileDescriptor_77a6da22d6a3feb1, []int{7}
 }
 
 func (m *RevenueReportRequest) XXX_Unmarshal(b []byte) error {
@@ -436,11 +878,58 @@ func (m *RevenueReportRequest) GetEndTime() uint64 {
 	return 0
 }
 
+func (m *RevenueReportRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *RevenueReportRequest) GetFiatCurrency() string {
+	if m != nil {
+		return m.FiatCurrency
+	}
+	return ""
+}
+
+func (m *RevenueReportRequest) GetPeriodSeconds() uint64 {
+	if m != nil {
+		return m.PeriodSeconds
+	}
+	return 0
+}
+
 type RevenueReportResponse struct {
 	//
 	//Reports is a set of pairwise revenue report generated for the channel(s)
 	//over the period specified.
-	Reports              []*RevenueReport `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	Reports []*RevenueReport `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	//
+	//The total fees in millisatoshis earned by all forwards over the period
+	//specified, counting each forward once.
+	TotalFeesMsat int64 `protobuf:"varint,2,opt,name=total_fees_msat,json=totalFeesMsat,proto3" json:"total_fees_msat,omitempty"`
+	// The fiat currency that fees were valued in, if requested.
+	FiatCurrency string `protobuf:"bytes,3,opt,name=fiat_currency,json=fiatCurrency,proto3" json:"fiat_currency,omitempty"`
+	//
+	//The total fiat value of fees earned by all forwards over the period
+	//specified. This value is only set if a fiat currency was requested.
+	TotalFeesFiat float64 `protobuf:"fixed64,4,opt,name=total_fees_fiat,json=totalFeesFiat,proto3" json:"total_fees_fiat,omitempty"`
+	//
+	//The number of forwards that could not be fully attributed to channels,
+	//because the short channel id of their incoming or outgoing channel could
+	//not be found. A non-zero value indicates that the per-channel reports do
+	//not include all forwards. These forwards are included in total_fees_msat
+	//and in unattributed. If only one side of a forward could not be found, the
+	//other side is included in its channel's report, paired with the short
+	//channel id that could not be found.
+	WarningCount uint32 `protobuf:"varint,5,opt,name=warning_count,json=warningCount,proto3" json:"warning_count,omitempty"`
+	// The sides of forwards that could not be attributed to a channel.
+	Unattributed *UnattributedReport `protobuf:"bytes,6,opt,name=unattributed,proto3" json:"unattributed,omitempty"`
+	//
+	//The totals for each period that the report was split into, in
+	//chronological order. This field is only set if a period length was
+	//requested.
+	Periods              []*RevenuePeriod `protobuf:"bytes,7,rep,name=periods,proto3" json:"periods,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -450,7 +939,7 @@ func (m *RevenueReportResponse) Reset()         { *m = RevenueReportResponse{} }
 func (m *RevenueReportResponse) String() string { return proto.CompactTextString(m) }
 func (*RevenueReportResponse) ProtoMessage()    {}
 func (*RevenueReportResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{6}
+	return fileDescriptor_77a6da22d6a3feb1, []int{8}
 }
 
 func (m *RevenueReportResponse) XXX_Unmarshal(b []byte) error {
@@ -478,31 +967,241 @@ func (m *RevenueReportResponse) GetReports() []*RevenueReport {
 	return nil
 }
 
-type RevenueReport struct {
-	//
-	//Target channel is the channel that the report is generated for; incoming
-	//fields in the report mean that this channel was the incoming channel,
-	//and the pair as the outgoing, outgoing fields mean that this channel was
-	//the outgoing channel and the peer was the incoming channel.
-	TargetChannel string `protobuf:"bytes,1,opt,name=target_channel,json=targetChannel,proto3" json:"target_channel,omitempty"`
-	//
-	//Pair reports maps the channel point of a peer that we generated revenue
-	//with to a report detailing the revenue.
-	PairReports          map[string]*PairReport `protobuf:"bytes,2,rep,name=pair_reports,json=pairReports,proto3" json:"pair_reports,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+func (m *RevenueReportResponse) GetTotalFeesMsat() int64 {
+	if m != nil {
+		return m.TotalFeesMsat
+	}
+	return 0
 }
 
-func (m *RevenueReport) Reset()         { *m = RevenueReport{} }
-func (m *RevenueReport) String() string { return proto.CompactTextString(m) }
-func (*RevenueReport) ProtoMessage()    {}
-func (*RevenueReport) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{7}
+func (m *RevenueReportResponse) GetFiatCurrency() string {
+	if m != nil {
+		return m.FiatCurrency
+	}
+	return ""
 }
 
-func (m *RevenueReport) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RevenueReport.Unmarshal(m, b)
+func (m *RevenueReportResponse) GetTotalFeesFiat() float64 {
+	if m != nil {
+		return m.TotalFeesFiat
+	}
+	return 0
+}
+
+func (m *RevenueReportResponse) GetWarningCount() uint32 {
+	if m != nil {
+		return m.WarningCount
+	}
+	return 0
+}
+
+func (m *RevenueReportResponse) GetUnattributed() *UnattributedReport {
+	if m != nil {
+		return m.Unattributed
+	}
+	return nil
+}
+
+func (m *RevenueReportResponse) GetPeriods() []*RevenuePeriod {
+	if m != nil {
+		return m.Periods
+	}
+	return nil
+}
+
+type RevenuePeriod struct {
+	// The unix time in seconds that the period starts at, inclusive.
+	StartTime uint64 `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// The unix time in seconds that the period ends at, exclusive.
+	EndTime uint64 `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// The number of forwards that our node made over the period.
+	Forwards uint64 `protobuf:"varint,3,opt,name=forwards,proto3" json:"forwards,omitempty"`
+	// The total fees in millisatoshis earned over the period.
+	FeesMsat int64 `protobuf:"varint,4,opt,name=fees_msat,json=feesMsat,proto3" json:"fees_msat,omitempty"`
+	//
+	//The total amount in millisatoshis forwarded over the period, counted as
+	//the amount that left our node.
+	VolumeMsat           int64    `protobuf:"varint,5,opt,name=volume_msat,json=volumeMsat,proto3" json:"volume_msat,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevenuePeriod) Reset()         { *m = RevenuePeriod{} }
+func (m *RevenuePeriod) String() string { return proto.CompactTextString(m) }
+func (*RevenuePeriod) ProtoMessage()    {}
+func (*RevenuePeriod) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{9}
+}
+
+func (m *RevenuePeriod) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevenuePeriod.Unmarshal(m, b)
+}
+func (m *RevenuePeriod) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevenuePeriod.Marshal(b, m, deterministic)
+}
+func (m *RevenuePeriod) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevenuePeriod.Merge(m, src)
+}
+func (m *RevenuePeriod) XXX_Size() int {
+	return xxx_messageInfo_RevenuePeriod.Size(m)
+}
+func (m *RevenuePeriod) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevenuePeriod.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevenuePeriod proto.InternalMessageInfo
+
+func (m *RevenuePeriod) GetStartTime() uint64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *RevenuePeriod) GetEndTime() uint64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *RevenuePeriod) GetForwards() uint64 {
+	if m != nil {
+		return m.Forwards
+	}
+	return 0
+}
+
+func (m *RevenuePeriod) GetFeesMsat() int64 {
+	if m != nil {
+		return m.FeesMsat
+	}
+	return 0
+}
+
+func (m *RevenuePeriod) GetVolumeMsat() int64 {
+	if m != nil {
+		return m.VolumeMsat
+	}
+	return 0
+}
+
+type UnattributedReport struct {
+	//
+	//The amount in millisatoshis that arrived at our node over channels that
+	//could not be found.
+	AmountIncomingMsat int64 `protobuf:"varint,1,opt,name=amount_incoming_msat,json=amountIncomingMsat,proto3" json:"amount_incoming_msat,omitempty"`
+	//
+	//The amount in millisatoshis that left our node over channels that could
+	//not be found.
+	AmountOutgoingMsat int64 `protobuf:"varint,2,opt,name=amount_outgoing_msat,json=amountOutgoingMsat,proto3" json:"amount_outgoing_msat,omitempty"`
+	//
+	//The fees in millisatoshis earned by forwards with at least one channel
+	//that could not be found.
+	FeesMsat int64 `protobuf:"varint,3,opt,name=fees_msat,json=feesMsat,proto3" json:"fees_msat,omitempty"`
+	//
+	//The fiat value of the fees earned by forwards with at least one channel
+	//that could not be found. This value is only set if a fiat currency was
+	//requested.
+	FeesFiat float64 `protobuf:"fixed64,4,opt,name=fees_fiat,json=feesFiat,proto3" json:"fees_fiat,omitempty"`
+	//
+	//The short channel ids, in block:tx:output format, that could not be found.
+	ShortChannelIds      []string `protobuf:"bytes,5,rep,name=short_channel_ids,json=shortChannelIds,proto3" json:"short_channel_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnattributedReport) Reset()         { *m = UnattributedReport{} }
+func (m *UnattributedReport) String() string { return proto.CompactTextString(m) }
+func (*UnattributedReport) ProtoMessage()    {}
+func (*UnattributedReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{10}
+}
+
+func (m *UnattributedReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnattributedReport.Unmarshal(m, b)
+}
+func (m *UnattributedReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnattributedReport.Marshal(b, m, deterministic)
+}
+func (m *UnattributedReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnattributedReport.Merge(m, src)
+}
+func (m *UnattributedReport) XXX_Size() int {
+	return xxx_messageInfo_UnattributedReport.Size(m)
+}
+func (m *UnattributedReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnattributedReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnattributedReport proto.InternalMessageInfo
+
+func (m *UnattributedReport) GetAmountIncomingMsat() int64 {
+	if m != nil {
+		return m.AmountIncomingMsat
+	}
+	return 0
+}
+
+func (m *UnattributedReport) GetAmountOutgoingMsat() int64 {
+	if m != nil {
+		return m.AmountOutgoingMsat
+	}
+	return 0
+}
+
+func (m *UnattributedReport) GetFeesMsat() int64 {
+	if m != nil {
+		return m.FeesMsat
+	}
+	return 0
+}
+
+func (m *UnattributedReport) GetFeesFiat() float64 {
+	if m != nil {
+		return m.FeesFiat
+	}
+	return 0
+}
+
+func (m *UnattributedReport) GetShortChannelIds() []string {
+	if m != nil {
+		return m.ShortChannelIds
+	}
+	return nil
+}
+
+type RevenueReport struct {
+	//
+	//Target channel is the channel that the report is generated for; incoming
+	//fields in the report mean that this channel was the incoming channel,
+	//and the pair as the outgoing, outgoing fields mean that this channel was
+	//the outgoing channel and the peer was the incoming channel.
+	TargetChannel string `protobuf:"bytes,1,opt,name=target_channel,json=targetChannel,proto3" json:"target_channel,omitempty"`
+	//
+	//Pair reports maps the channel point of a peer that we generated revenue
+	//with to a report detailing the revenue.
+	PairReports map[string]*PairReport `protobuf:"bytes,2,rep,name=pair_reports,json=pairReports,proto3" json:"pair_reports,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The sizes and effective fee rate of forwards that arrived on the channel.
+	IncomingForwards *ForwardStats `protobuf:"bytes,3,opt,name=incoming_forwards,json=incomingForwards,proto3" json:"incoming_forwards,omitempty"`
+	// The sizes and effective fee rate of forwards that left on the channel.
+	OutgoingForwards     *ForwardStats `protobuf:"bytes,4,opt,name=outgoing_forwards,json=outgoingForwards,proto3" json:"outgoing_forwards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RevenueReport) Reset()         { *m = RevenueReport{} }
+func (m *RevenueReport) String() string { return proto.CompactTextString(m) }
+func (*RevenueReport) ProtoMessage()    {}
+func (*RevenueReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{11}
+}
+
+func (m *RevenueReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevenueReport.Unmarshal(m, b)
 }
 func (m *RevenueReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_RevenueReport.Marshal(b, m, deterministic)
@@ -533,6 +1232,107 @@ func (m *RevenueReport) GetPairReports() map[string]*PairReport {
 	return nil
 }
 
+func (m *RevenueReport) GetIncomingForwards() *ForwardStats {
+	if m != nil {
+		return m.IncomingForwards
+	}
+	return nil
+}
+
+func (m *RevenueReport) GetOutgoingForwards() *ForwardStats {
+	if m != nil {
+		return m.OutgoingForwards
+	}
+	return nil
+}
+
+type ForwardStats struct {
+	// The number of forwards.
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// The amount in millisatoshis of the smallest forward.
+	MinSizeMsat int64 `protobuf:"varint,2,opt,name=min_size_msat,json=minSizeMsat,proto3" json:"min_size_msat,omitempty"`
+	// The median forward amount in millisatoshis.
+	MedianSizeMsat int64 `protobuf:"varint,3,opt,name=median_size_msat,json=medianSizeMsat,proto3" json:"median_size_msat,omitempty"`
+	// The 90th percentile forward amount in millisatoshis.
+	P90SizeMsat int64 `protobuf:"varint,4,opt,name=p90_size_msat,json=p90SizeMsat,proto3" json:"p90_size_msat,omitempty"`
+	// The amount in millisatoshis of the largest forward.
+	MaxSizeMsat int64 `protobuf:"varint,5,opt,name=max_size_msat,json=maxSizeMsat,proto3" json:"max_size_msat,omitempty"`
+	//
+	//The effective fee rate of the forwards, expressed as the fees earned in
+	//parts per million of the amount forwarded onwards by our node.
+	FeeRatePpm           float64  `protobuf:"fixed64,6,opt,name=fee_rate_ppm,json=feeRatePpm,proto3" json:"fee_rate_ppm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ForwardStats) Reset()         { *m = ForwardStats{} }
+func (m *ForwardStats) String() string { return proto.CompactTextString(m) }
+func (*ForwardStats) ProtoMessage()    {}
+func (*ForwardStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{12}
+}
+
+func (m *ForwardStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForwardStats.Unmarshal(m, b)
+}
+func (m *ForwardStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForwardStats.Marshal(b, m, deterministic)
+}
+func (m *ForwardStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForwardStats.Merge(m, src)
+}
+func (m *ForwardStats) XXX_Size() int {
+	return xxx_messageInfo_ForwardStats.Size(m)
+}
+func (m *ForwardStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForwardStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForwardStats proto.InternalMessageInfo
+
+func (m *ForwardStats) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ForwardStats) GetMinSizeMsat() int64 {
+	if m != nil {
+		return m.MinSizeMsat
+	}
+	return 0
+}
+
+func (m *ForwardStats) GetMedianSizeMsat() int64 {
+	if m != nil {
+		return m.MedianSizeMsat
+	}
+	return 0
+}
+
+func (m *ForwardStats) GetP90SizeMsat() int64 {
+	if m != nil {
+		return m.P90SizeMsat
+	}
+	return 0
+}
+
+func (m *ForwardStats) GetMaxSizeMsat() int64 {
+	if m != nil {
+		return m.MaxSizeMsat
+	}
+	return 0
+}
+
+func (m *ForwardStats) GetFeeRatePpm() float64 {
+	if m != nil {
+		return m.FeeRatePpm
+	}
+	return 0
+}
+
 type PairReport struct {
 	//
 	//Amount outgoing msat is the amount in millisatoshis that arrived
@@ -551,7 +1351,17 @@ type PairReport struct {
 	//Fees incoming is the amount of fees in millisatoshis that we
 	//attribute to the channel for its role as the incoming channel in
 	//forwards.
-	FeesIncomingMsat     int64    `protobuf:"varint,4,opt,name=fees_incoming_msat,json=feesIncomingMsat,proto3" json:"fees_incoming_msat,omitempty"`
+	FeesIncomingMsat int64 `protobuf:"varint,4,opt,name=fees_incoming_msat,json=feesIncomingMsat,proto3" json:"fees_incoming_msat,omitempty"`
+	//
+	//The fiat value of the outgoing fees, with each forward's fees valued at
+	//the price when it happened. This value is only set if a fiat currency was
+	//requested.
+	FeesOutgoingFiat float64 `protobuf:"fixed64,5,opt,name=fees_outgoing_fiat,json=feesOutgoingFiat,proto3" json:"fees_outgoing_fiat,omitempty"`
+	//
+	//The fiat value of the incoming fees, with each forward's fees valued at
+	//the price when it happened. This value is only set if a fiat currency was
+	//requested.
+	FeesIncomingFiat     float64  `protobuf:"fixed64,6,opt,name=fees_incoming_fiat,json=feesIncomingFiat,proto3" json:"fees_incoming_fiat,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -561,7 +1371,7 @@ func (m *PairReport) Reset()         { *m = PairReport{} }
 func (m *PairReport) String() string { return proto.CompactTextString(m) }
 func (*PairReport) ProtoMessage()    {}
 func (*PairReport) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{8}
+	return fileDescriptor_77a6da22d6a3feb1, []int{13}
 }
 
 func (m *PairReport) XXX_Unmarshal(b []byte) error {
@@ -610,7 +1420,36 @@ func (m *PairReport) GetFeesIncomingMsat() int64 {
 	return 0
 }
 
+func (m *PairReport) GetFeesOutgoingFiat() float64 {
+	if m != nil {
+		return m.FeesOutgoingFiat
+	}
+	return 0
+}
+
+func (m *PairReport) GetFeesIncomingFiat() float64 {
+	if m != nil {
+		return m.FeesIncomingFiat
+	}
+	return 0
+}
+
 type ChannelInsightsRequest struct {
+	//
+	//The period of time in seconds, counting back from the present, that
+	//revenue and volume should be calculated over. If this value is not set,
+	//revenue is calculated over the lifetime of each channel.
+	LookbackSeconds uint64 `protobuf:"varint,1,opt,name=lookback_seconds,json=lookbackSeconds,proto3" json:"lookback_seconds,omitempty"`
+	//
+	//An optional half-life in seconds which is used to weight forwarding
+	//events by their age, so that recent forwards contribute more to revenue
+	//and volume than older forwards. If this value is not set, all forwards
+	//are weighted equally.
+	DecayHalfLifeSeconds uint64 `protobuf:"varint,2,opt,name=decay_half_life_seconds,json=decayHalfLifeSeconds,proto3" json:"decay_half_life_seconds,omitempty"`
+	//
+	//The name of the lnd node that the request is for. If this value is not
+	//set, the request is served by the first node faraday is configured with.
+	Node                 string   `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -620,7 +1459,7 @@ func (m *ChannelInsightsRequest) Reset()         { *m = ChannelInsightsRequest{}
 func (m *ChannelInsightsRequest) String() string { return proto.CompactTextString(m) }
 func (*ChannelInsightsRequest) ProtoMessage()    {}
 func (*ChannelInsightsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{9}
+	return fileDescriptor_77a6da22d6a3feb1, []int{14}
 }
 
 func (m *ChannelInsightsRequest) XXX_Unmarshal(b []byte) error {
@@ -641,6 +1480,27 @@ func (m *ChannelInsightsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ChannelInsightsRequest proto.InternalMessageInfo
 
+func (m *ChannelInsightsRequest) GetLookbackSeconds() uint64 {
+	if m != nil {
+		return m.LookbackSeconds
+	}
+	return 0
+}
+
+func (m *ChannelInsightsRequest) GetDecayHalfLifeSeconds() uint64 {
+	if m != nil {
+		return m.DecayHalfLifeSeconds
+	}
+	return 0
+}
+
+func (m *ChannelInsightsRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
 type ChannelInsightsResponse struct {
 	// Insights for the set of currently open channels.
 	ChannelInsights      []*ChannelInsight `protobuf:"bytes,1,rep,name=channel_insights,json=channelInsights,proto3" json:"channel_insights,omitempty"`
@@ -653,7 +1513,7 @@ func (m *ChannelInsightsResponse) Reset()         { *m = ChannelInsightsResponse
 func (m *ChannelInsightsResponse) String() string { return proto.CompactTextString(m) }
 func (*ChannelInsightsResponse) ProtoMessage()    {}
 func (*ChannelInsightsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{10}
+	return fileDescriptor_77a6da22d6a3feb1, []int{15}
 }
 
 func (m *ChannelInsightsResponse) XXX_Unmarshal(b []byte) error {
@@ -708,7 +1568,61 @@ type ChannelInsight struct {
 	// The number of confirmations the funding transaction has.
 	Confirmations uint32 `protobuf:"varint,7,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
 	// True if the channel is private.
-	Private              bool     `protobuf:"varint,8,opt,name=private,proto3" json:"private,omitempty"`
+	Private bool `protobuf:"varint,8,opt,name=private,proto3" json:"private,omitempty"`
+	//
+	//True if the channel's peer is another node that faraday is configured
+	//with, meaning that the channel is internal to our fleet of nodes.
+	Internal bool `protobuf:"varint,9,opt,name=internal,proto3" json:"internal,omitempty"`
+	// The total capacity of the channel in satoshis.
+	CapacitySat int64 `protobuf:"varint,10,opt,name=capacity_sat,json=capacitySat,proto3" json:"capacity_sat,omitempty"`
+	// Our current balance in the channel in satoshis.
+	LocalBalanceSat int64 `protobuf:"varint,11,opt,name=local_balance_sat,json=localBalanceSat,proto3" json:"local_balance_sat,omitempty"`
+	// The remote peer's current balance in the channel in satoshis.
+	RemoteBalanceSat int64 `protobuf:"varint,12,opt,name=remote_balance_sat,json=remoteBalanceSat,proto3" json:"remote_balance_sat,omitempty"`
+	//
+	//The total amount, in satoshis, of htlcs that are pending in the incoming
+	//direction.
+	PendingIncomingSat int64 `protobuf:"varint,13,opt,name=pending_incoming_sat,json=pendingIncomingSat,proto3" json:"pending_incoming_sat,omitempty"`
+	//
+	//The total amount, in satoshis, of htlcs that are pending in the outgoing
+	//direction.
+	PendingOutgoingSat int64 `protobuf:"varint,14,opt,name=pending_outgoing_sat,json=pendingOutgoingSat,proto3" json:"pending_outgoing_sat,omitempty"`
+	//
+	//The ratio of our local balance to the total of our local and remote
+	//balances, expressed in [0;1].
+	BalanceRatio float32 `protobuf:"fixed32,15,opt,name=balance_ratio,json=balanceRatio,proto3" json:"balance_ratio,omitempty"`
+	// The amount of time in seconds that faraday has sampled the balance for.
+	BalanceMonitoredSeconds uint64 `protobuf:"varint,16,opt,name=balance_monitored_seconds,json=balanceMonitoredSeconds,proto3" json:"balance_monitored_seconds,omitempty"`
+	//
+	//The amount of time in seconds that the channel's balance has been almost
+	//entirely local or remote over the period it has been sampled for.
+	OneSidedSeconds uint64 `protobuf:"varint,17,opt,name=one_sided_seconds,json=oneSidedSeconds,proto3" json:"one_sided_seconds,omitempty"`
+	//
+	//The total cost, in millisatoshis, of the loop swaps that rebalanced this
+	//channel over the period that its fees were calculated over. This value is
+	//only set if faraday is connected to loopd.
+	LiquidityCostMsat int64 `protobuf:"varint,18,opt,name=liquidity_cost_msat,json=liquidityCostMsat,proto3" json:"liquidity_cost_msat,omitempty"`
+	//
+	//The fees earned by this channel less its liquidity cost, expressed in
+	//millisatoshis. This value may be negative if rebalancing the channel
+	//cost more than it earned.
+	NetFeesMsat int64 `protobuf:"varint,19,opt,name=net_fees_msat,json=netFeesMsat,proto3" json:"net_fees_msat,omitempty"`
+	//
+	//The sizes and effective fee rate of forwards that arrived on the channel
+	//over the period that its fees were calculated over.
+	IncomingForwards *ForwardStats `protobuf:"bytes,20,opt,name=incoming_forwards,json=incomingForwards,proto3" json:"incoming_forwards,omitempty"`
+	//
+	//The sizes and effective fee rate of forwards that left on the channel over
+	//the period that its fees were calculated over.
+	OutgoingForwards *ForwardStats `protobuf:"bytes,21,opt,name=outgoing_forwards,json=outgoingForwards,proto3" json:"outgoing_forwards,omitempty"`
+	//
+	//The effective number of blocks that the channel's fees and volume were
+	//calculated over. This is the channel's confirmations, limited to the
+	//lookback period requested, with each block weighted by the decay
+	//half-life requested in the same way as the forwards in it. Values that
+	//are expressed per confirmation, and the opportunity cost of the channel's
+	//capital, are scaled by this number of blocks.
+	RevenueBlocks        float64  `protobuf:"fixed64,22,opt,name=revenue_blocks,json=revenueBlocks,proto3" json:"revenue_blocks,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -718,7 +1632,7 @@ func (m *ChannelInsight) Reset()         { *m = ChannelInsight{} }
 func (m *ChannelInsight) String() string { return proto.CompactTextString(m) }
 func (*ChannelInsight) ProtoMessage()    {}
 func (*ChannelInsight) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{11}
+	return fileDescriptor_77a6da22d6a3feb1, []int{16}
 }
 
 func (m *ChannelInsight) XXX_Unmarshal(b []byte) error {
@@ -795,145 +1709,2942 @@ func (m *ChannelInsight) GetPrivate() bool {
 	return false
 }
 
-func init() {
-	proto.RegisterEnum("frdrpc.CloseRecommendationRequest_Metric", CloseRecommendationRequest_Metric_name, CloseRecommendationRequest_Metric_value)
-	proto.RegisterType((*CloseRecommendationRequest)(nil), "frdrpc.CloseRecommendationRequest")
-	proto.RegisterType((*OutlierRecommendationsRequest)(nil), "frdrpc.OutlierRecommendationsRequest")
-	proto.RegisterType((*ThresholdRecommendationsRequest)(nil), "frdrpc.ThresholdRecommendationsRequest")
-	proto.RegisterType((*CloseRecommendationsResponse)(nil), "frdrpc.CloseRecommendationsResponse")
-	proto.RegisterType((*Recommendation)(nil), "frdrpc.Recommendation")
-	proto.RegisterType((*RevenueReportRequest)(nil), "frdrpc.RevenueReportRequest")
-	proto.RegisterType((*RevenueReportResponse)(nil), "frdrpc.RevenueReportResponse")
-	proto.RegisterType((*RevenueReport)(nil), "frdrpc.RevenueReport")
-	proto.RegisterMapType((map[string]*PairReport)(nil), "frdrpc.RevenueReport.PairReportsEntry")
-	proto.RegisterType((*PairReport)(nil), "frdrpc.PairReport")
-	proto.RegisterType((*ChannelInsightsRequest)(nil), "frdrpc.ChannelInsightsRequest")
-	proto.RegisterType((*ChannelInsightsResponse)(nil), "frdrpc.ChannelInsightsResponse")
-	proto.RegisterType((*ChannelInsight)(nil), "frdrpc.ChannelInsight")
+func (m *ChannelInsight) GetInternal() bool {
+	if m != nil {
+		return m.Internal
+	}
+	return false
 }
 
-func init() { proto.RegisterFile("rpc.proto", fileDescriptor_77a6da22d6a3feb1) }
+func (m *ChannelInsight) GetCapacitySat() int64 {
+	if m != nil {
+		return m.CapacitySat
+	}
+	return 0
+}
 
-var fileDescriptor_77a6da22d6a3feb1 = []byte{
-	// 945 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xc1, 0x6e, 0xe3, 0x36,
-	0x13, 0xfe, 0x65, 0x25, 0x4e, 0x32, 0x5e, 0xdb, 0x5a, 0x6e, 0x36, 0xbf, 0x1b, 0x24, 0x8d, 0x21,
-	0xec, 0x76, 0x5d, 0xb4, 0x75, 0x16, 0xee, 0xa5, 0xe8, 0xa9, 0x41, 0xe0, 0x6e, 0x8d, 0xc6, 0x76,
-	0xc0, 0xb5, 0xd3, 0x4b, 0x01, 0x41, 0x95, 0x18, 0x87, 0xad, 0x45, 0x6a, 0x29, 0xca, 0x40, 0xce,
-	0x7d, 0x80, 0x1e, 0xfa, 0x32, 0x7d, 0x88, 0x5e, 0x7a, 0xe8, 0xbb, 0xf4, 0x58, 0x88, 0xa4, 0x24,
-	0xcb, 0x75, 0x36, 0xbd, 0xf4, 0x26, 0x7e, 0xdf, 0xc7, 0xf9, 0xc8, 0x99, 0x31, 0xc7, 0x70, 0x20,
-	0xe2, 0xa0, 0x1f, 0x0b, 0x2e, 0x39, 0xaa, 0xdf, 0x8a, 0x50, 0xc4, 0xc1, 0xf1, 0xc9, 0x82, 0xf3,
-	0xc5, 0x92, 0x9c, 0xfb, 0x31, 0x3d, 0xf7, 0x19, 0xe3, 0xd2, 0x97, 0x94, 0xb3, 0x44, 0xab, 0xdc,
-	0xbf, 0x2c, 0x38, 0xbe, 0x5c, 0xf2, 0x84, 0x60, 0x12, 0xf0, 0x28, 0x22, 0x2c, 0x54, 0x34, 0x26,
-	0xef, 0x52, 0x92, 0x48, 0xf4, 0x09, 0x3c, 0x8d, 0x28, 0xa3, 0x51, 0x1a, 0x79, 0x11, 0x67, 0x54,
-	0x72, 0x41, 0xc2, 0x8e, 0xd5, 0xb5, 0x7a, 0x36, 0x76, 0x0c, 0x31, 0xce, 0x71, 0x74, 0x01, 0xf5,
-	0x88, 0x48, 0x41, 0x83, 0x4e, 0xad, 0x6b, 0xf5, 0x5a, 0x83, 0x8f, 0xfb, 0xfa, 0x08, 0xfd, 0x87,
-	0x0d, 0xfa, 0x63, 0xb5, 0x01, 0x9b, 0x8d, 0xee, 0x8f, 0x50, 0xd7, 0x08, 0x6a, 0xc0, 0xde, 0x7c,
-	0xf2, 0xed, 0x64, 0xfa, 0xdd, 0xc4, 0xf9, 0x1f, 0x02, 0xa8, 0xcf, 0xaf, 0x67, 0xa3, 0xf1, 0xd0,
-	0xb1, 0x32, 0x02, 0x0f, 0x6f, 0x86, 0x93, 0xf9, 0xd0, 0xa9, 0xa1, 0x67, 0xd0, 0x1e, 0x4d, 0x2e,
-	0xa7, 0xe3, 0xd1, 0xe4, 0x8d, 0x77, 0x33, 0xbd, 0x9a, 0x8f, 0x87, 0x8e, 0x9d, 0x81, 0xd3, 0xf9,
-	0xec, 0xcd, 0x74, 0x0d, 0xdc, 0x41, 0x0e, 0x3c, 0x99, 0x4d, 0x67, 0x17, 0x57, 0x39, 0xb2, 0xeb,
-	0xfe, 0x6a, 0xc1, 0xe9, 0x34, 0x95, 0x4b, 0x4a, 0x44, 0xf5, 0x6c, 0x49, 0x7e, 0xfb, 0x4b, 0x68,
-	0x08, 0x12, 0x78, 0x42, 0x2f, 0xd5, 0xbd, 0x1b, 0x03, 0xf7, 0xf1, 0x5b, 0x61, 0x10, 0x24, 0xc8,
-	0x83, 0x7c, 0x06, 0x88, 0x6b, 0x17, 0x2f, 0x4a, 0x97, 0x92, 0xc6, 0xd9, 0xa7, 0xca, 0x50, 0x0d,
-	0x3f, 0x35, 0xcc, 0xb8, 0x20, 0xdc, 0x5f, 0x2c, 0x38, 0x9b, 0xdd, 0x09, 0x92, 0xdc, 0xf1, 0x65,
-	0xf8, 0x5f, 0x9e, 0xeb, 0x15, 0xb4, 0x65, 0xee, 0xe3, 0xad, 0xfc, 0x65, 0x4a, 0xcc, 0xa1, 0x5a,
-	0x05, 0x7c, 0x93, 0xa1, 0xee, 0x6f, 0x16, 0x9c, 0x6c, 0x89, 0x99, 0x60, 0x92, 0xc4, 0x9c, 0x25,
-	0x04, 0xbd, 0x84, 0x96, 0xe4, 0xd2, 0x5f, 0x7a, 0xc1, 0x9d, 0xcf, 0x18, 0x59, 0x26, 0xea, 0x44,
-	0xbb, 0xb8, 0xa9, 0xd0, 0x4b, 0x03, 0xa2, 0x73, 0x78, 0x16, 0x70, 0x96, 0xd0, 0x90, 0x08, 0x12,
-	0x96, 0xda, 0x9a, 0xd2, 0xa2, 0x92, 0x2a, 0x36, 0x7c, 0x05, 0x6d, 0x51, 0xb5, 0xec, 0xd8, 0x5d,
-	0xbb, 0xd7, 0x18, 0x1c, 0xe5, 0x57, 0xdd, 0xb8, 0xe5, 0xa6, 0xdc, 0x65, 0xd0, 0xaa, 0x4a, 0xd0,
-	0x29, 0x40, 0xe6, 0xec, 0xc5, 0x9c, 0x32, 0x9d, 0xb9, 0x03, 0x7c, 0x90, 0x21, 0xd7, 0x19, 0x80,
-	0x0e, 0x61, 0x77, 0x3d, 0x15, 0x7a, 0x91, 0xa5, 0xaa, 0x88, 0xec, 0x05, 0x59, 0x2a, 0x3a, 0x76,
-	0xd7, 0xea, 0xed, 0xe3, 0x56, 0x01, 0xab, 0x04, 0xb9, 0xef, 0xe0, 0x10, 0x93, 0x15, 0x61, 0x29,
-	0xc1, 0x24, 0xe6, 0x42, 0xe6, 0xb9, 0x3e, 0x83, 0x46, 0xe9, 0x9a, 0xa5, 0xc7, 0xee, 0x1d, 0x60,
-	0x28, 0x6c, 0x93, 0xec, 0x58, 0x89, 0xf4, 0x85, 0xf4, 0x24, 0x8d, 0xb4, 0xf9, 0x0e, 0x3e, 0x50,
-	0xc8, 0x8c, 0x46, 0x04, 0x7d, 0x00, 0xfb, 0x99, 0xb5, 0x22, 0x6d, 0x45, 0xee, 0x11, 0x16, 0x66,
-	0x94, 0xfb, 0x0d, 0x3c, 0xdf, 0xb0, 0x34, 0x55, 0x39, 0x87, 0x3d, 0xa1, 0x10, 0xed, 0xd7, 0x18,
-	0x3c, 0x2f, 0xb3, 0xb6, 0xae, 0xcf, 0x55, 0xee, 0x9f, 0x16, 0x34, 0x2b, 0x94, 0x2a, 0xac, 0x2f,
-	0x16, 0x44, 0xe6, 0xd5, 0x32, 0x09, 0x6b, 0x6a, 0xd4, 0x14, 0x0a, 0x8d, 0xe0, 0x49, 0xec, 0x53,
-	0xe1, 0xe5, 0x76, 0x35, 0x65, 0xf7, 0xd1, 0x56, 0xbb, 0xfe, 0xb5, 0x4f, 0x85, 0xfe, 0x4c, 0x86,
-	0x4c, 0x8a, 0x7b, 0xdc, 0x88, 0x4b, 0xe4, 0x18, 0x83, 0xb3, 0x29, 0x40, 0x0e, 0xd8, 0x3f, 0x91,
-	0x7b, 0x63, 0x9d, 0x7d, 0xa2, 0xde, 0x7a, 0x95, 0x1a, 0x03, 0x94, 0x3b, 0x95, 0x5b, 0x4d, 0xe5,
-	0xbe, 0xac, 0x7d, 0x61, 0xb9, 0xbf, 0x5b, 0x00, 0x25, 0x83, 0x5e, 0xc3, 0xa1, 0x1f, 0xf1, 0x94,
-	0x49, 0x8f, 0xa7, 0x72, 0xc1, 0x29, 0x5b, 0x78, 0x51, 0xe2, 0x4b, 0xf3, 0xaa, 0x21, 0xcd, 0x4d,
-	0x0d, 0x35, 0x4e, 0x7c, 0x89, 0x3e, 0x05, 0x74, 0x4b, 0x48, 0xb2, 0xa1, 0xaf, 0xe9, 0x57, 0x30,
-	0x63, 0x2a, 0xea, 0x32, 0x3e, 0x65, 0x01, 0x8f, 0x0a, 0xbd, 0xbd, 0x1e, 0x7f, 0x64, 0xa8, 0x4a,
-	0xfc, 0xaa, 0x7e, 0xa7, 0x8c, 0xbf, 0xae, 0x76, 0x3b, 0x70, 0x64, 0x12, 0x3f, 0x62, 0x09, 0x5d,
-	0xdc, 0xc9, 0xfc, 0x59, 0x70, 0xbf, 0x87, 0xff, 0xff, 0x83, 0x31, 0xcd, 0x70, 0x01, 0x8e, 0x29,
-	0xa1, 0x47, 0x0d, 0x67, 0xba, 0xa2, 0xf8, 0x2d, 0x55, 0xb7, 0xe2, 0x76, 0x50, 0x0d, 0xe5, 0xfe,
-	0x51, 0x83, 0x56, 0x55, 0xf3, 0xd8, 0x8f, 0x29, 0x1b, 0x1e, 0xf9, 0x70, 0xf0, 0x12, 0x12, 0x70,
-	0x16, 0x26, 0xa6, 0xb7, 0x9d, 0x82, 0x78, 0xab, 0xf1, 0xac, 0xd7, 0xd2, 0x38, 0x6b, 0xf0, 0x42,
-	0xa9, 0x1b, 0xbd, 0xa9, 0xd1, 0x5c, 0xf6, 0x1a, 0x0e, 0x57, 0x7c, 0x99, 0x46, 0x64, 0x6b, 0xb6,
-	0x90, 0xe6, 0x2a, 0xd9, 0x2d, 0x77, 0x54, 0xeb, 0xb7, 0xbb, 0xbe, 0xa3, 0x52, 0xc1, 0x1e, 0xa8,
-	0xac, 0x7b, 0xc4, 0x17, 0x8c, 0x84, 0x5a, 0x5d, 0x57, 0xea, 0x56, 0x86, 0x0f, 0x15, 0xac, 0x94,
-	0x2f, 0xa0, 0x19, 0x70, 0x76, 0x4b, 0x45, 0x64, 0xde, 0xa7, 0xbd, 0xae, 0xd5, 0x6b, 0xe2, 0x2a,
-	0x88, 0x3a, 0xb0, 0x17, 0x0b, 0xba, 0xf2, 0x25, 0xe9, 0xec, 0xab, 0x67, 0x23, 0x5f, 0x0e, 0x7e,
-	0xb6, 0xa1, 0xf9, 0xb5, 0x2f, 0xfc, 0xd0, 0xbf, 0x7f, 0x4b, 0xc4, 0x8a, 0x08, 0x44, 0xe0, 0x68,
-	0xfb, 0x4c, 0x42, 0x2f, 0xf3, 0x42, 0xbd, 0x77, 0x66, 0x1d, 0xbf, 0x78, 0xcf, 0x18, 0x28, 0xfb,
-	0x81, 0x42, 0xe7, 0xa1, 0x21, 0x83, 0x5e, 0xe5, 0x11, 0x1e, 0x19, 0x43, 0xff, 0xd2, 0xea, 0x6a,
-	0xf3, 0x55, 0x39, 0xd9, 0xfe, 0x0e, 0x99, 0xa0, 0xa7, 0x0f, 0xb0, 0x26, 0x1a, 0x86, 0xf6, 0x46,
-	0x8f, 0xa3, 0x0f, 0xb7, 0x77, 0x70, 0x71, 0xcc, 0xb3, 0x07, 0x79, 0x1d, 0xf3, 0x87, 0xba, 0xfa,
-	0x2b, 0xf4, 0xf9, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x58, 0xfa, 0x74, 0x8e, 0x3d, 0x09, 0x00,
-	0x00,
+func (m *ChannelInsight) GetLocalBalanceSat() int64 {
+	if m != nil {
+		return m.LocalBalanceSat
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
+func (m *ChannelInsight) GetRemoteBalanceSat() int64 {
+	if m != nil {
+		return m.RemoteBalanceSat
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetPendingIncomingSat() int64 {
+	if m != nil {
+		return m.PendingIncomingSat
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetPendingOutgoingSat() int64 {
+	if m != nil {
+		return m.PendingOutgoingSat
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetBalanceRatio() float32 {
+	if m != nil {
+		return m.BalanceRatio
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetBalanceMonitoredSeconds() uint64 {
+	if m != nil {
+		return m.BalanceMonitoredSeconds
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetOneSidedSeconds() uint64 {
+	if m != nil {
+		return m.OneSidedSeconds
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetLiquidityCostMsat() int64 {
+	if m != nil {
+		return m.LiquidityCostMsat
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetNetFeesMsat() int64 {
+	if m != nil {
+		return m.NetFeesMsat
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetIncomingForwards() *ForwardStats {
+	if m != nil {
+		return m.IncomingForwards
+	}
+	return nil
+}
+
+func (m *ChannelInsight) GetOutgoingForwards() *ForwardStats {
+	if m != nil {
+		return m.OutgoingForwards
+	}
+	return nil
+}
+
+func (m *ChannelInsight) GetRevenueBlocks() float64 {
+	if m != nil {
+		return m.RevenueBlocks
+	}
+	return 0
+}
+
+type CloseChannelsRequest struct {
+	//
+	//The funding transaction outpoints for the channels to close, expressed
+	//with the format fundingTxID:outpoint. Channels are checked against the
+	//current set of open and pending channels before they are closed.
+	ChanPoints []string `protobuf:"bytes,1,rep,name=chan_points,json=chanPoints,proto3" json:"chan_points,omitempty"`
+	//
+	//The number of blocks that the closing transactions should confirm within.
+	//This value may not be set if sat_per_byte is set.
+	TargetConf int32 `protobuf:"varint,2,opt,name=target_conf,json=targetConf,proto3" json:"target_conf,omitempty"`
+	//
+	//The fee rate, in satoshis per byte, to use for the closing transactions.
+	//This value may not be set if target_conf is set.
+	SatPerByte int64 `protobuf:"varint,3,opt,name=sat_per_byte,json=satPerByte,proto3" json:"sat_per_byte,omitempty"`
+	//
+	//Set to allow force closes of channels whose peer is offline. If this
+	//value is not set, channels with offline peers will be skipped.
+	AllowForce bool `protobuf:"varint,4,opt,name=allow_force,json=allowForce,proto3" json:"allow_force,omitempty"`
+	//
+	//Confirm must be set for channels to be closed. If it is not set, the
+	//response will contain the actions that would be taken for each channel,
+	//but no channels will be closed.
+	Confirm bool `protobuf:"varint,5,opt,name=confirm,proto3" json:"confirm,omitempty"`
+	//
+	//The name of the lnd node that the request is for. If this value is not
+	//set, the request is served by the first node faraday is configured with.
+	Node                 string   `protobuf:"bytes,6,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseChannelsRequest) Reset()         { *m = CloseChannelsRequest{} }
+func (m *CloseChannelsRequest) String() string { return proto.CompactTextString(m) }
+func (*CloseChannelsRequest) ProtoMessage()    {}
+func (*CloseChannelsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{17}
+}
+
+func (m *CloseChannelsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseChannelsRequest.Unmarshal(m, b)
+}
+func (m *CloseChannelsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseChannelsRequest.Marshal(b, m, deterministic)
+}
+func (m *CloseChannelsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseChannelsRequest.Merge(m, src)
+}
+func (m *CloseChannelsRequest) XXX_Size() int {
+	return xxx_messageInfo_CloseChannelsRequest.Size(m)
+}
+func (m *CloseChannelsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseChannelsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloseChannelsRequest proto.InternalMessageInfo
+
+func (m *CloseChannelsRequest) GetChanPoints() []string {
+	if m != nil {
+		return m.ChanPoints
+	}
+	return nil
+}
+
+func (m *CloseChannelsRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *CloseChannelsRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+func (m *CloseChannelsRequest) GetAllowForce() bool {
+	if m != nil {
+		return m.AllowForce
+	}
+	return false
+}
+
+func (m *CloseChannelsRequest) GetConfirm() bool {
+	if m != nil {
+		return m.Confirm
+	}
+	return false
+}
+
+func (m *CloseChannelsRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+type CloseChannelsResponse struct {
+	// The result of the close attempt for each channel requested.
+	Results              []*ChannelCloseResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *CloseChannelsResponse) Reset()         { *m = CloseChannelsResponse{} }
+func (m *CloseChannelsResponse) String() string { return proto.CompactTextString(m) }
+func (*CloseChannelsResponse) ProtoMessage()    {}
+func (*CloseChannelsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{18}
+}
+
+func (m *CloseChannelsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseChannelsResponse.Unmarshal(m, b)
+}
+func (m *CloseChannelsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseChannelsResponse.Marshal(b, m, deterministic)
+}
+func (m *CloseChannelsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseChannelsResponse.Merge(m, src)
+}
+func (m *CloseChannelsResponse) XXX_Size() int {
+	return xxx_messageInfo_CloseChannelsResponse.Size(m)
+}
+func (m *CloseChannelsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseChannelsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloseChannelsResponse proto.InternalMessageInfo
+
+func (m *CloseChannelsResponse) GetResults() []*ChannelCloseResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type ChannelCloseResult struct {
+	// The outpoint of the channel's funding transaction.
+	ChanPoint string `protobuf:"bytes,1,opt,name=chan_point,json=chanPoint,proto3" json:"chan_point,omitempty"`
+	// The action that was taken, or would be taken, for the channel.
+	Action ChannelCloseResult_Action `protobuf:"varint,2,opt,name=action,proto3,enum=frdrpc.ChannelCloseResult_Action" json:"action,omitempty"`
+	// The reason that the channel was skipped, if applicable.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The txid of the closing transaction, set if the channel was closed.
+	ClosingTxid          string   `protobuf:"bytes,4,opt,name=closing_txid,json=closingTxid,proto3" json:"closing_txid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelCloseResult) Reset()         { *m = ChannelCloseResult{} }
+func (m *ChannelCloseResult) String() string { return proto.CompactTextString(m) }
+func (*ChannelCloseResult) ProtoMessage()    {}
+func (*ChannelCloseResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{19}
+}
+
+func (m *ChannelCloseResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelCloseResult.Unmarshal(m, b)
+}
+func (m *ChannelCloseResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelCloseResult.Marshal(b, m, deterministic)
+}
+func (m *ChannelCloseResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelCloseResult.Merge(m, src)
+}
+func (m *ChannelCloseResult) XXX_Size() int {
+	return xxx_messageInfo_ChannelCloseResult.Size(m)
+}
+func (m *ChannelCloseResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelCloseResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelCloseResult proto.InternalMessageInfo
+
+func (m *ChannelCloseResult) GetChanPoint() string {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return ""
+}
+
+func (m *ChannelCloseResult) GetAction() ChannelCloseResult_Action {
+	if m != nil {
+		return m.Action
+	}
+	return ChannelCloseResult_SKIP
+}
+
+func (m *ChannelCloseResult) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ChannelCloseResult) GetClosingTxid() string {
+	if m != nil {
+		return m.ClosingTxid
+	}
+	return ""
+}
+
+type BacktestRequest struct {
+	// The set of close recommendation strategies to backtest.
+	Strategies []*BacktestStrategy `protobuf:"bytes,1,rep,name=strategies,proto3" json:"strategies,omitempty"`
+	//
+	//The date, expressed as unix epoch offset in seconds, that strategies
+	//should be run at. Only forwards that occurred before this date will be
+	//used to produce recommendations.
+	AsOf uint64 `protobuf:"varint,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	//
+	//The end of the period over which recommendations will be assessed,
+	//expressed as unix epoch offset in seconds. If this value is not set, the
+	//present is used.
+	EndTime uint64 `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	//
+	//The minimum amount of time in seconds that a channel should have been
+	//open for at the backtest date to be eligible for close.
+	MinimumMonitored int64 `protobuf:"varint,4,opt,name=minimum_monitored,json=minimumMonitored,proto3" json:"minimum_monitored,omitempty"`
+	//
+	//The name of the lnd node that the request is for. If this value is not
+	//set, the request is served by the first node faraday is configured with.
+	Node string `protobuf:"bytes,5,opt,name=node,proto3" json:"node,omitempty"`
+	//
+	//The annual rate of return used to calculate the opportunity cost of
+	//channel capital for strategies that use the net value metric. If this
+	//value is not set, the rate that faraday is configured with is used.
+	OpportunityCostRate  float32  `protobuf:"fixed32,6,opt,name=opportunity_cost_rate,json=opportunityCostRate,proto3" json:"opportunity_cost_rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BacktestRequest) Reset()         { *m = BacktestRequest{} }
+func (m *BacktestRequest) String() string { return proto.CompactTextString(m) }
+func (*BacktestRequest) ProtoMessage()    {}
+func (*BacktestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{20}
+}
+
+func (m *BacktestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BacktestRequest.Unmarshal(m, b)
+}
+func (m *BacktestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BacktestRequest.Marshal(b, m, deterministic)
+}
+func (m *BacktestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BacktestRequest.Merge(m, src)
+}
+func (m *BacktestRequest) XXX_Size() int {
+	return xxx_messageInfo_BacktestRequest.Size(m)
+}
+func (m *BacktestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BacktestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BacktestRequest proto.InternalMessageInfo
+
+func (m *BacktestRequest) GetStrategies() []*BacktestStrategy {
+	if m != nil {
+		return m.Strategies
+	}
+	return nil
+}
+
+func (m *BacktestRequest) GetAsOf() uint64 {
+	if m != nil {
+		return m.AsOf
+	}
+	return 0
+}
+
+func (m *BacktestRequest) GetEndTime() uint64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *BacktestRequest) GetMinimumMonitored() int64 {
+	if m != nil {
+		return m.MinimumMonitored
+	}
+	return 0
+}
+
+func (m *BacktestRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *BacktestRequest) GetOpportunityCostRate() float32 {
+	if m != nil {
+		return m.OpportunityCostRate
+	}
+	return 0
+}
+
+type BacktestStrategy struct {
+	// An optional label for the strategy.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The type of close recommendations that the strategy uses.
+	Type BacktestStrategy_StrategyType `protobuf:"varint,2,opt,name=type,proto3,enum=frdrpc.BacktestStrategy_StrategyType" json:"type,omitempty"`
+	//
+	//The metric that close recommendations are based on. This enum is kept for
+	//backwards compatibility, and is only used if metric_name is not set.
+	Metric CloseRecommendationRequest_Metric `protobuf:"varint,3,opt,name=metric,proto3,enum=frdrpc.CloseRecommendationRequest_Metric" json:"metric,omitempty"`
+	//
+	//The outlier multiplier for outlier strategies, or the threshold value
+	//for threshold strategies.
+	Value float32 `protobuf:"fixed32,4,opt,name=value,proto3" json:"value,omitempty"`
+	//
+	//The name of the metric that close recommendations are based on, which
+	//takes precedence over the metric enum.
+	MetricName           string   `protobuf:"bytes,5,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BacktestStrategy) Reset()         { *m = BacktestStrategy{} }
+func (m *BacktestStrategy) String() string { return proto.CompactTextString(m) }
+func (*BacktestStrategy) ProtoMessage()    {}
+func (*BacktestStrategy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{21}
+}
+
+func (m *BacktestStrategy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BacktestStrategy.Unmarshal(m, b)
+}
+func (m *BacktestStrategy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BacktestStrategy.Marshal(b, m, deterministic)
+}
+func (m *BacktestStrategy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BacktestStrategy.Merge(m, src)
+}
+func (m *BacktestStrategy) XXX_Size() int {
+	return xxx_messageInfo_BacktestStrategy.Size(m)
+}
+func (m *BacktestStrategy) XXX_DiscardUnknown() {
+	xxx_messageInfo_BacktestStrategy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BacktestStrategy proto.InternalMessageInfo
+
+func (m *BacktestStrategy) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *BacktestStrategy) GetType() BacktestStrategy_StrategyType {
+	if m != nil {
+		return m.Type
+	}
+	return BacktestStrategy_OUTLIER
+}
+
+func (m *BacktestStrategy) GetMetric() CloseRecommendationRequest_Metric {
+	if m != nil {
+		return m.Metric
+	}
+	return CloseRecommendationRequest_UNKNOWN
+}
+
+func (m *BacktestStrategy) GetValue() float32 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *BacktestStrategy) GetMetricName() string {
+	if m != nil {
+		return m.MetricName
+	}
+	return ""
+}
+
+type BacktestResponse struct {
+	// The date that strategies were run at.
+	AsOf uint64 `protobuf:"varint,1,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// The end of the period that recommendations were assessed over.
+	EndTime uint64 `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// The results for each strategy, in the order they were requested.
+	Results              []*BacktestResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BacktestResponse) Reset()         { *m = BacktestResponse{} }
+func (m *BacktestResponse) String() string { return proto.CompactTextString(m) }
+func (*BacktestResponse) ProtoMessage()    {}
+func (*BacktestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{22}
+}
+
+func (m *BacktestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BacktestResponse.Unmarshal(m, b)
+}
+func (m *BacktestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BacktestResponse.Marshal(b, m, deterministic)
+}
+func (m *BacktestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BacktestResponse.Merge(m, src)
+}
+func (m *BacktestResponse) XXX_Size() int {
+	return xxx_messageInfo_BacktestResponse.Size(m)
+}
+func (m *BacktestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BacktestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BacktestResponse proto.InternalMessageInfo
+
+func (m *BacktestResponse) GetAsOf() uint64 {
+	if m != nil {
+		return m.AsOf
+	}
+	return 0
+}
+
+func (m *BacktestResponse) GetEndTime() uint64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *BacktestResponse) GetResults() []*BacktestResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BacktestResult struct {
+	// The strategy that was backtested.
+	Strategy *BacktestStrategy `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	// The number of channels that were open at the backtest date.
+	TotalChannels int32 `protobuf:"varint,2,opt,name=total_channels,json=totalChannels,proto3" json:"total_channels,omitempty"`
+	// The number of channels that were eligible for close.
+	ConsideredChannels int32 `protobuf:"varint,3,opt,name=considered_channels,json=consideredChannels,proto3" json:"considered_channels,omitempty"`
+	// The number of channels that the strategy recommended closing.
+	FlaggedChannels int32 `protobuf:"varint,4,opt,name=flagged_channels,json=flaggedChannels,proto3" json:"flagged_channels,omitempty"`
+	// The number of channels the strategy did not recommend closing.
+	KeptChannels int32 `protobuf:"varint,5,opt,name=kept_channels,json=keptChannels,proto3" json:"kept_channels,omitempty"`
+	//
+	//The total fees, in millisatoshis, that flagged channels earned after the
+	//backtest date.
+	FlaggedFeesMsat int64 `protobuf:"varint,6,opt,name=flagged_fees_msat,json=flaggedFeesMsat,proto3" json:"flagged_fees_msat,omitempty"`
+	//
+	//The total fees, in millisatoshis, that kept channels earned after the
+	//backtest date.
+	KeptFeesMsat int64 `protobuf:"varint,7,opt,name=kept_fees_msat,json=keptFeesMsat,proto3" json:"kept_fees_msat,omitempty"`
+	// The number of flagged channels that earned no fees afterwards.
+	FlaggedIdle int32 `protobuf:"varint,8,opt,name=flagged_idle,json=flaggedIdle,proto3" json:"flagged_idle,omitempty"`
+	// The number of kept channels that earned no fees afterwards.
+	KeptIdle int32 `protobuf:"varint,9,opt,name=kept_idle,json=keptIdle,proto3" json:"kept_idle,omitempty"`
+	// The average fees earned by a flagged channel, in millisatoshis.
+	MeanFlaggedFeesMsat float32 `protobuf:"fixed32,10,opt,name=mean_flagged_fees_msat,json=meanFlaggedFeesMsat,proto3" json:"mean_flagged_fees_msat,omitempty"`
+	// The average fees earned by a kept channel, in millisatoshis.
+	MeanKeptFeesMsat     float32  `protobuf:"fixed32,11,opt,name=mean_kept_fees_msat,json=meanKeptFeesMsat,proto3" json:"mean_kept_fees_msat,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BacktestResult) Reset()         { *m = BacktestResult{} }
+func (m *BacktestResult) String() string { return proto.CompactTextString(m) }
+func (*BacktestResult) ProtoMessage()    {}
+func (*BacktestResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{23}
+}
+
+func (m *BacktestResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BacktestResult.Unmarshal(m, b)
+}
+func (m *BacktestResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BacktestResult.Marshal(b, m, deterministic)
+}
+func (m *BacktestResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BacktestResult.Merge(m, src)
+}
+func (m *BacktestResult) XXX_Size() int {
+	return xxx_messageInfo_BacktestResult.Size(m)
+}
+func (m *BacktestResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BacktestResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BacktestResult proto.InternalMessageInfo
+
+func (m *BacktestResult) GetStrategy() *BacktestStrategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return nil
+}
+
+func (m *BacktestResult) GetTotalChannels() int32 {
+	if m != nil {
+		return m.TotalChannels
+	}
+	return 0
+}
+
+func (m *BacktestResult) GetConsideredChannels() int32 {
+	if m != nil {
+		return m.ConsideredChannels
+	}
+	return 0
+}
+
+func (m *BacktestResult) GetFlaggedChannels() int32 {
+	if m != nil {
+		return m.FlaggedChannels
+	}
+	return 0
+}
+
+func (m *BacktestResult) GetKeptChannels() int32 {
+	if m != nil {
+		return m.KeptChannels
+	}
+	return 0
+}
+
+func (m *BacktestResult) GetFlaggedFeesMsat() int64 {
+	if m != nil {
+		return m.FlaggedFeesMsat
+	}
+	return 0
+}
+
+func (m *BacktestResult) GetKeptFeesMsat() int64 {
+	if m != nil {
+		return m.KeptFeesMsat
+	}
+	return 0
+}
+
+func (m *BacktestResult) GetFlaggedIdle() int32 {
+	if m != nil {
+		return m.FlaggedIdle
+	}
+	return 0
+}
+
+func (m *BacktestResult) GetKeptIdle() int32 {
+	if m != nil {
+		return m.KeptIdle
+	}
+	return 0
+}
+
+func (m *BacktestResult) GetMeanFlaggedFeesMsat() float32 {
+	if m != nil {
+		return m.MeanFlaggedFeesMsat
+	}
+	return 0
+}
+
+func (m *BacktestResult) GetMeanKeptFeesMsat() float32 {
+	if m != nil {
+		return m.MeanKeptFeesMsat
+	}
+	return 0
+}
+
+type SubscribeChannelInsightsRequest struct {
+	// The parameters used to produce channel insights.
+	Request *ChannelInsightsRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	//
+	//An optional interval, in seconds, at which channel insights should be
+	//pushed regardless of whether any events have occurred. If it is set,
+	//the interval must be at least 60 seconds. Insights are always pushed
+	//when a forward, channel open or close or peer online or offline event
+	//occurs.
+	IntervalSeconds      uint64   `protobuf:"varint,2,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeChannelInsightsRequest) Reset()         { *m = SubscribeChannelInsightsRequest{} }
+func (m *SubscribeChannelInsightsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeChannelInsightsRequest) ProtoMessage()    {}
+func (*SubscribeChannelInsightsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{24}
+}
+
+func (m *SubscribeChannelInsightsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeChannelInsightsRequest.Unmarshal(m, b)
+}
+func (m *SubscribeChannelInsightsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeChannelInsightsRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeChannelInsightsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeChannelInsightsRequest.Merge(m, src)
+}
+func (m *SubscribeChannelInsightsRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeChannelInsightsRequest.Size(m)
+}
+func (m *SubscribeChannelInsightsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeChannelInsightsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeChannelInsightsRequest proto.InternalMessageInfo
+
+func (m *SubscribeChannelInsightsRequest) GetRequest() *ChannelInsightsRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SubscribeChannelInsightsRequest) GetIntervalSeconds() uint64 {
+	if m != nil {
+		return m.IntervalSeconds
+	}
+	return 0
+}
+
+type SubscribeRecommendationsRequest struct {
+	// The parameters used to produce close recommendations.
+	//
+	// Types that are valid to be assigned to Request:
+	//	*SubscribeRecommendationsRequest_OutlierRequest
+	//	*SubscribeRecommendationsRequest_ThresholdRequest
+	//	*SubscribeRecommendationsRequest_RuleRequest
+	Request isSubscribeRecommendationsRequest_Request `protobuf_oneof:"request"`
+	//
+	//An optional interval, in seconds, at which recommendations should be
+	//pushed regardless of whether any events have occurred. If it is set,
+	//the interval must be at least 60 seconds. Recommendations are always
+	//pushed when a forward, channel open or close or peer online or offline
+	//event occurs.
+	IntervalSeconds      uint64   `protobuf:"varint,3,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeRecommendationsRequest) Reset()         { *m = SubscribeRecommendationsRequest{} }
+func (m *SubscribeRecommendationsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRecommendationsRequest) ProtoMessage()    {}
+func (*SubscribeRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{25}
+}
+
+func (m *SubscribeRecommendationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeRecommendationsRequest.Unmarshal(m, b)
+}
+func (m *SubscribeRecommendationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeRecommendationsRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeRecommendationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRecommendationsRequest.Merge(m, src)
+}
+func (m *SubscribeRecommendationsRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeRecommendationsRequest.Size(m)
+}
+func (m *SubscribeRecommendationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRecommendationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRecommendationsRequest proto.InternalMessageInfo
+
+type isSubscribeRecommendationsRequest_Request interface {
+	isSubscribeRecommendationsRequest_Request()
+}
+
+type SubscribeRecommendationsRequest_OutlierRequest struct {
+	OutlierRequest *OutlierRecommendationsRequest `protobuf:"bytes,1,opt,name=outlier_request,json=outlierRequest,proto3,oneof"`
+}
+
+type SubscribeRecommendationsRequest_ThresholdRequest struct {
+	ThresholdRequest *ThresholdRecommendationsRequest `protobuf:"bytes,2,opt,name=threshold_request,json=thresholdRequest,proto3,oneof"`
+}
+
+type SubscribeRecommendationsRequest_RuleRequest struct {
+	RuleRequest *RuleRecommendationsRequest `protobuf:"bytes,4,opt,name=rule_request,json=ruleRequest,proto3,oneof"`
+}
+
+func (*SubscribeRecommendationsRequest_OutlierRequest) isSubscribeRecommendationsRequest_Request() {}
+
+func (*SubscribeRecommendationsRequest_ThresholdRequest) isSubscribeRecommendationsRequest_Request() {
+}
+
+func (*SubscribeRecommendationsRequest_RuleRequest) isSubscribeRecommendationsRequest_Request() {}
+
+func (m *SubscribeRecommendationsRequest) GetRequest() isSubscribeRecommendationsRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SubscribeRecommendationsRequest) GetOutlierRequest() *OutlierRecommendationsRequest {
+	if x, ok := m.GetRequest().(*SubscribeRecommendationsRequest_OutlierRequest); ok {
+		return x.OutlierRequest
+	}
+	return nil
+}
+
+func (m *SubscribeRecommendationsRequest) GetThresholdRequest() *ThresholdRecommendationsRequest {
+	if x, ok := m.GetRequest().(*SubscribeRecommendationsRequest_ThresholdRequest); ok {
+		return x.ThresholdRequest
+	}
+	return nil
+}
+
+func (m *SubscribeRecommendationsRequest) GetRuleRequest() *RuleRecommendationsRequest {
+	if x, ok := m.GetRequest().(*SubscribeRecommendationsRequest_RuleRequest); ok {
+		return x.RuleRequest
+	}
+	return nil
+}
+
+func (m *SubscribeRecommendationsRequest) GetIntervalSeconds() uint64 {
+	if m != nil {
+		return m.IntervalSeconds
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SubscribeRecommendationsRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SubscribeRecommendationsRequest_OutlierRequest)(nil),
+		(*SubscribeRecommendationsRequest_ThresholdRequest)(nil),
+		(*SubscribeRecommendationsRequest_RuleRequest)(nil),
+	}
+}
+
+type FleetReportRequest struct {
+	//
+	//The period of time in seconds, counting back from the present, that
+	//revenue and volume should be calculated over. If this value is not set,
+	//revenue is calculated over the lifetime of each channel.
+	LookbackSeconds uint64 `protobuf:"varint,1,opt,name=lookback_seconds,json=lookbackSeconds,proto3" json:"lookback_seconds,omitempty"`
+	//
+	//An optional half-life in seconds which is used to weight forwarding
+	//events by their age. If this value is not set, all forwards are weighted
+	//equally.
+	DecayHalfLifeSeconds uint64   `protobuf:"varint,2,opt,name=decay_half_life_seconds,json=decayHalfLifeSeconds,proto3" json:"decay_half_life_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FleetReportRequest) Reset()         { *m = FleetReportRequest{} }
+func (m *FleetReportRequest) String() string { return proto.CompactTextString(m) }
+func (*FleetReportRequest) ProtoMessage()    {}
+func (*FleetReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{26}
+}
+
+func (m *FleetReportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FleetReportRequest.Unmarshal(m, b)
+}
+func (m *FleetReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FleetReportRequest.Marshal(b, m, deterministic)
+}
+func (m *FleetReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FleetReportRequest.Merge(m, src)
+}
+func (m *FleetReportRequest) XXX_Size() int {
+	return xxx_messageInfo_FleetReportRequest.Size(m)
+}
+func (m *FleetReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FleetReportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FleetReportRequest proto.InternalMessageInfo
+
+func (m *FleetReportRequest) GetLookbackSeconds() uint64 {
+	if m != nil {
+		return m.LookbackSeconds
+	}
+	return 0
+}
+
+func (m *FleetReportRequest) GetDecayHalfLifeSeconds() uint64 {
+	if m != nil {
+		return m.DecayHalfLifeSeconds
+	}
+	return 0
+}
+
+type FleetReportResponse struct {
+	// A report for each of the nodes faraday is configured with.
+	Nodes []*NodeReport `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// Totals across all of our nodes.
+	Totals               *FleetTotals `protobuf:"bytes,2,opt,name=totals,proto3" json:"totals,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *FleetReportResponse) Reset()         { *m = FleetReportResponse{} }
+func (m *FleetReportResponse) String() string { return proto.CompactTextString(m) }
+func (*FleetReportResponse) ProtoMessage()    {}
+func (*FleetReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{27}
+}
+
+func (m *FleetReportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FleetReportResponse.Unmarshal(m, b)
+}
+func (m *FleetReportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FleetReportResponse.Marshal(b, m, deterministic)
+}
+func (m *FleetReportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FleetReportResponse.Merge(m, src)
+}
+func (m *FleetReportResponse) XXX_Size() int {
+	return xxx_messageInfo_FleetReportResponse.Size(m)
+}
+func (m *FleetReportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FleetReportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FleetReportResponse proto.InternalMessageInfo
+
+func (m *FleetReportResponse) GetNodes() []*NodeReport {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *FleetReportResponse) GetTotals() *FleetTotals {
+	if m != nil {
+		return m.Totals
+	}
+	return nil
+}
+
+type NodeReport struct {
+	// The name of the node.
+	Node string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// The node's identity public key.
+	Pubkey string `protobuf:"bytes,2,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	// Insights for the node's currently open channels.
+	ChannelInsights []*ChannelInsight `protobuf:"bytes,3,rep,name=channel_insights,json=channelInsights,proto3" json:"channel_insights,omitempty"`
+	// Totals for the node.
+	Totals *FleetTotals `protobuf:"bytes,4,opt,name=totals,proto3" json:"totals,omitempty"`
+	//
+	//The error returned when faraday requested the node's insights, if it could
+	//not obtain them. Nodes with errors are not included in the fleet's totals.
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeReport) Reset()         { *m = NodeReport{} }
+func (m *NodeReport) String() string { return proto.CompactTextString(m) }
+func (*NodeReport) ProtoMessage()    {}
+func (*NodeReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{28}
+}
+
+func (m *NodeReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeReport.Unmarshal(m, b)
+}
+func (m *NodeReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeReport.Marshal(b, m, deterministic)
+}
+func (m *NodeReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeReport.Merge(m, src)
+}
+func (m *NodeReport) XXX_Size() int {
+	return xxx_messageInfo_NodeReport.Size(m)
+}
+func (m *NodeReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeReport proto.InternalMessageInfo
+
+func (m *NodeReport) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *NodeReport) GetPubkey() string {
+	if m != nil {
+		return m.Pubkey
+	}
+	return ""
+}
+
+func (m *NodeReport) GetChannelInsights() []*ChannelInsight {
+	if m != nil {
+		return m.ChannelInsights
+	}
+	return nil
+}
+
+func (m *NodeReport) GetTotals() *FleetTotals {
+	if m != nil {
+		return m.Totals
+	}
+	return nil
+}
+
+func (m *NodeReport) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type FleetTotals struct {
+	// The number of open channels.
+	Channels uint32 `protobuf:"varint,1,opt,name=channels,proto3" json:"channels,omitempty"`
+	//
+	//The number of open channels which are with another one of our nodes.
+	//Internal channels are counted once for each of our nodes that are party
+	//to them.
+	InternalChannels uint32 `protobuf:"varint,2,opt,name=internal_channels,json=internalChannels,proto3" json:"internal_channels,omitempty"`
+	// The total fees earned by open channels in millisatoshis.
+	FeesEarnedMsat int64 `protobuf:"varint,3,opt,name=fees_earned_msat,json=feesEarnedMsat,proto3" json:"fees_earned_msat,omitempty"`
+	//
+	//The volume, in millisatoshis, that has been forwarded with open channels
+	//as the incoming channel.
+	VolumeIncomingMsat int64 `protobuf:"varint,4,opt,name=volume_incoming_msat,json=volumeIncomingMsat,proto3" json:"volume_incoming_msat,omitempty"`
+	//
+	//The volume, in millisatoshis, that has been forwarded with open channels
+	//as the outgoing channel.
+	VolumeOutgoingMsat int64 `protobuf:"varint,5,opt,name=volume_outgoing_msat,json=volumeOutgoingMsat,proto3" json:"volume_outgoing_msat,omitempty"`
+	//
+	//The volume, in millisatoshis, that was forwarded to or from one of our
+	//other nodes over internal channels. Forwards between our own nodes are
+	//counted by both nodes, so this volume may be subtracted from the
+	//incoming and outgoing volume to get volume with external peers.
+	VolumeInternalMsat   int64    `protobuf:"varint,6,opt,name=volume_internal_msat,json=volumeInternalMsat,proto3" json:"volume_internal_msat,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FleetTotals) Reset()         { *m = FleetTotals{} }
+func (m *FleetTotals) String() string { return proto.CompactTextString(m) }
+func (*FleetTotals) ProtoMessage()    {}
+func (*FleetTotals) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{29}
+}
+
+func (m *FleetTotals) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FleetTotals.Unmarshal(m, b)
+}
+func (m *FleetTotals) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FleetTotals.Marshal(b, m, deterministic)
+}
+func (m *FleetTotals) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FleetTotals.Merge(m, src)
+}
+func (m *FleetTotals) XXX_Size() int {
+	return xxx_messageInfo_FleetTotals.Size(m)
+}
+func (m *FleetTotals) XXX_DiscardUnknown() {
+	xxx_messageInfo_FleetTotals.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FleetTotals proto.InternalMessageInfo
+
+func (m *FleetTotals) GetChannels() uint32 {
+	if m != nil {
+		return m.Channels
+	}
+	return 0
+}
+
+func (m *FleetTotals) GetInternalChannels() uint32 {
+	if m != nil {
+		return m.InternalChannels
+	}
+	return 0
+}
+
+func (m *FleetTotals) GetFeesEarnedMsat() int64 {
+	if m != nil {
+		return m.FeesEarnedMsat
+	}
+	return 0
+}
+
+func (m *FleetTotals) GetVolumeIncomingMsat() int64 {
+	if m != nil {
+		return m.VolumeIncomingMsat
+	}
+	return 0
+}
+
+func (m *FleetTotals) GetVolumeOutgoingMsat() int64 {
+	if m != nil {
+		return m.VolumeOutgoingMsat
+	}
+	return 0
+}
+
+func (m *FleetTotals) GetVolumeInternalMsat() int64 {
+	if m != nil {
+		return m.VolumeInternalMsat
+	}
+	return 0
+}
+
+type DebugLevelRequest struct {
+	//
+	//If set, the debug level is not changed and the list of available
+	//subsystems is returned.
+	Show bool `protobuf:"varint,1,opt,name=show,proto3" json:"show,omitempty"`
+	//
+	//The debug level to set, either a single level for all subsystems, or a
+	//comma separated list of <subsystem>=<level> pairs.
+	LevelSpec            string   `protobuf:"bytes,2,opt,name=level_spec,json=levelSpec,proto3" json:"level_spec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DebugLevelRequest) Reset()         { *m = DebugLevelRequest{} }
+func (m *DebugLevelRequest) String() string { return proto.CompactTextString(m) }
+func (*DebugLevelRequest) ProtoMessage()    {}
+func (*DebugLevelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{30}
+}
+
+func (m *DebugLevelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DebugLevelRequest.Unmarshal(m, b)
+}
+func (m *DebugLevelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DebugLevelRequest.Marshal(b, m, deterministic)
+}
+func (m *DebugLevelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DebugLevelRequest.Merge(m, src)
+}
+func (m *DebugLevelRequest) XXX_Size() int {
+	return xxx_messageInfo_DebugLevelRequest.Size(m)
+}
+func (m *DebugLevelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DebugLevelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DebugLevelRequest proto.InternalMessageInfo
+
+func (m *DebugLevelRequest) GetShow() bool {
+	if m != nil {
+		return m.Show
+	}
+	return false
+}
+
+func (m *DebugLevelRequest) GetLevelSpec() string {
+	if m != nil {
+		return m.LevelSpec
+	}
+	return ""
+}
+
+type DebugLevelResponse struct {
+	// A space separated list of the subsystems that faraday logs for.
+	SubSystems           string   `protobuf:"bytes,1,opt,name=sub_systems,json=subSystems,proto3" json:"sub_systems,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DebugLevelResponse) Reset()         { *m = DebugLevelResponse{} }
+func (m *DebugLevelResponse) String() string { return proto.CompactTextString(m) }
+func (*DebugLevelResponse) ProtoMessage()    {}
+func (*DebugLevelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{31}
+}
+
+func (m *DebugLevelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DebugLevelResponse.Unmarshal(m, b)
+}
+func (m *DebugLevelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DebugLevelResponse.Marshal(b, m, deterministic)
+}
+func (m *DebugLevelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DebugLevelResponse.Merge(m, src)
+}
+func (m *DebugLevelResponse) XXX_Size() int {
+	return xxx_messageInfo_DebugLevelResponse.Size(m)
+}
+func (m *DebugLevelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DebugLevelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DebugLevelResponse proto.InternalMessageInfo
+
+func (m *DebugLevelResponse) GetSubSystems() string {
+	if m != nil {
+		return m.SubSystems
+	}
+	return ""
+}
+
+type StatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
+func (m *StatusRequest) String() string { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()    {}
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{32}
+}
+
+func (m *StatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusRequest.Unmarshal(m, b)
+}
+func (m *StatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusRequest.Marshal(b, m, deterministic)
+}
+func (m *StatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusRequest.Merge(m, src)
+}
+func (m *StatusRequest) XXX_Size() int {
+	return xxx_messageInfo_StatusRequest.Size(m)
+}
+func (m *StatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusRequest proto.InternalMessageInfo
+
+type StatusResponse struct {
+	// The status of faraday's connection to each of its lnd nodes.
+	Nodes                []*NodeStatus `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
+func (m *StatusResponse) String() string { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()    {}
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{33}
+}
+
+func (m *StatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusResponse.Unmarshal(m, b)
+}
+func (m *StatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusResponse.Marshal(b, m, deterministic)
+}
+func (m *StatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusResponse.Merge(m, src)
+}
+func (m *StatusResponse) XXX_Size() int {
+	return xxx_messageInfo_StatusResponse.Size(m)
+}
+func (m *StatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusResponse proto.InternalMessageInfo
+
+func (m *StatusResponse) GetNodes() []*NodeStatus {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type NodeStatus struct {
+	// The name of the node.
+	Node string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// The state of faraday's connection to the node.
+	State NodeStatus_State `protobuf:"varint,2,opt,name=state,proto3,enum=frdrpc.NodeStatus_State" json:"state,omitempty"`
+	// The unix timestamp in seconds that the connection entered its
+	// current state.
+	StateSince int64 `protobuf:"varint,3,opt,name=state_since,json=stateSince,proto3" json:"state_since,omitempty"`
+	// The unix timestamp in seconds that faraday last checked lnd's state.
+	LastCheck int64 `protobuf:"varint,4,opt,name=last_check,json=lastCheck,proto3" json:"last_check,omitempty"`
+	// The error that caused the connection to enter its current state, if
+	// any.
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// The number of times faraday has connected to lnd.
+	ConnectAttempts      uint32   `protobuf:"varint,6,opt,name=connect_attempts,json=connectAttempts,proto3" json:"connect_attempts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeStatus) Reset()         { *m = NodeStatus{} }
+func (m *NodeStatus) String() string { return proto.CompactTextString(m) }
+func (*NodeStatus) ProtoMessage()    {}
+func (*NodeStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{34}
+}
+
+func (m *NodeStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeStatus.Unmarshal(m, b)
+}
+func (m *NodeStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeStatus.Marshal(b, m, deterministic)
+}
+func (m *NodeStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeStatus.Merge(m, src)
+}
+func (m *NodeStatus) XXX_Size() int {
+	return xxx_messageInfo_NodeStatus.Size(m)
+}
+func (m *NodeStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeStatus proto.InternalMessageInfo
+
+func (m *NodeStatus) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *NodeStatus) GetState() NodeStatus_State {
+	if m != nil {
+		return m.State
+	}
+	return NodeStatus_UNKNOWN
+}
+
+func (m *NodeStatus) GetStateSince() int64 {
+	if m != nil {
+		return m.StateSince
+	}
+	return 0
+}
+
+func (m *NodeStatus) GetLastCheck() int64 {
+	if m != nil {
+		return m.LastCheck
+	}
+	return 0
+}
+
+func (m *NodeStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *NodeStatus) GetConnectAttempts() uint32 {
+	if m != nil {
+		return m.ConnectAttempts
+	}
+	return 0
+}
+
+type GetInfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetInfoRequest) Reset()         { *m = GetInfoRequest{} }
+func (m *GetInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetInfoRequest) ProtoMessage()    {}
+func (*GetInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{35}
+}
+
+func (m *GetInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetInfoRequest.Unmarshal(m, b)
+}
+func (m *GetInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetInfoRequest.Merge(m, src)
+}
+func (m *GetInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetInfoRequest.Size(m)
+}
+func (m *GetInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetInfoRequest proto.InternalMessageInfo
+
+type GetInfoResponse struct {
+	// The semantic version of the faraday daemon.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// The commit that the faraday daemon was built from.
+	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	// The number of seconds that the faraday daemon has been running for.
+	UptimeSeconds uint64 `protobuf:"varint,3,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	// Information about each of the lnd nodes that faraday is connected to.
+	Nodes []*LndInfo `protobuf:"bytes,4,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	//
+	//The optional features that are enabled on the daemon, for example
+	//uptime_history or close_audit.
+	Features             []string `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetInfoResponse) Reset()         { *m = GetInfoResponse{} }
+func (m *GetInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*GetInfoResponse) ProtoMessage()    {}
+func (*GetInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{36}
+}
+
+func (m *GetInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetInfoResponse.Unmarshal(m, b)
+}
+func (m *GetInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *GetInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetInfoResponse.Merge(m, src)
+}
+func (m *GetInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_GetInfoResponse.Size(m)
+}
+func (m *GetInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetInfoResponse proto.InternalMessageInfo
+
+func (m *GetInfoResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetUptimeSeconds() uint64 {
+	if m != nil {
+		return m.UptimeSeconds
+	}
+	return 0
+}
+
+func (m *GetInfoResponse) GetNodes() []*LndInfo {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *GetInfoResponse) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+type LndInfo struct {
+	// The name of the node.
+	Node string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// The version of lnd that the node is running.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// The node's alias.
+	Alias string `protobuf:"bytes,3,opt,name=alias,proto3" json:"alias,omitempty"`
+	// The node's identity public key.
+	Pubkey string `protobuf:"bytes,4,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	// The bitcoin network that the node is running on.
+	Network string `protobuf:"bytes,5,opt,name=network,proto3" json:"network,omitempty"`
+	//
+	//The error returned when faraday requested the node's info, if it could
+	//not be obtained.
+	Error                string   `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LndInfo) Reset()         { *m = LndInfo{} }
+func (m *LndInfo) String() string { return proto.CompactTextString(m) }
+func (*LndInfo) ProtoMessage()    {}
+func (*LndInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{37}
+}
+
+func (m *LndInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LndInfo.Unmarshal(m, b)
+}
+func (m *LndInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LndInfo.Marshal(b, m, deterministic)
+}
+func (m *LndInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LndInfo.Merge(m, src)
+}
+func (m *LndInfo) XXX_Size() int {
+	return xxx_messageInfo_LndInfo.Size(m)
+}
+func (m *LndInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_LndInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LndInfo proto.InternalMessageInfo
+
+func (m *LndInfo) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *LndInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *LndInfo) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+func (m *LndInfo) GetPubkey() string {
+	if m != nil {
+		return m.Pubkey
+	}
+	return ""
+}
+
+func (m *LndInfo) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *LndInfo) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ListMetricsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListMetricsRequest) Reset()         { *m = ListMetricsRequest{} }
+func (m *ListMetricsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListMetricsRequest) ProtoMessage()    {}
+func (*ListMetricsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{38}
+}
+
+func (m *ListMetricsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMetricsRequest.Unmarshal(m, b)
+}
+func (m *ListMetricsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMetricsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListMetricsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMetricsRequest.Merge(m, src)
+}
+func (m *ListMetricsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListMetricsRequest.Size(m)
+}
+func (m *ListMetricsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMetricsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMetricsRequest proto.InternalMessageInfo
+
+type ListMetricsResponse struct {
+	// The metrics that close recommendations can be based on, sorted by name.
+	Metrics              []*MetricInfo `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListMetricsResponse) Reset()         { *m = ListMetricsResponse{} }
+func (m *ListMetricsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListMetricsResponse) ProtoMessage()    {}
+func (*ListMetricsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{39}
+}
+
+func (m *ListMetricsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMetricsResponse.Unmarshal(m, b)
+}
+func (m *ListMetricsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMetricsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListMetricsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMetricsResponse.Merge(m, src)
+}
+func (m *ListMetricsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListMetricsResponse.Size(m)
+}
+func (m *ListMetricsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMetricsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMetricsResponse proto.InternalMessageInfo
+
+func (m *ListMetricsResponse) GetMetrics() []*MetricInfo {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+type MetricInfo struct {
+	//
+	//The name of the metric, which is used to request recommendations based on
+	//it.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// A description of the metric.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// The unit of the metric's value once it has been scaled.
+	Unit string `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	//
+	//The way that the metric's value is scaled so that channels of different
+	//ages and sizes can be compared. Values can be used as is, divided by the
+	//number of confirmations the channel's funding transaction has, or divided
+	//by the channel's capacity in satoshis.
+	Scaling              MetricInfo_Scaling `protobuf:"varint,4,opt,name=scaling,proto3,enum=frdrpc.MetricInfo_Scaling" json:"scaling,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *MetricInfo) Reset()         { *m = MetricInfo{} }
+func (m *MetricInfo) String() string { return proto.CompactTextString(m) }
+func (*MetricInfo) ProtoMessage()    {}
+func (*MetricInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{40}
+}
+
+func (m *MetricInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetricInfo.Unmarshal(m, b)
+}
+func (m *MetricInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetricInfo.Marshal(b, m, deterministic)
+}
+func (m *MetricInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricInfo.Merge(m, src)
+}
+func (m *MetricInfo) XXX_Size() int {
+	return xxx_messageInfo_MetricInfo.Size(m)
+}
+func (m *MetricInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricInfo proto.InternalMessageInfo
+
+func (m *MetricInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MetricInfo) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *MetricInfo) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+func (m *MetricInfo) GetScaling() MetricInfo_Scaling {
+	if m != nil {
+		return m.Scaling
+	}
+	return MetricInfo_RAW
+}
+
+type LiquidityCostReportRequest struct {
+	//
+	//The unix time from which to produce the report, inclusive. If this value
+	//is not set, the report covers all swaps up to the end time.
+	StartTime uint64 `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	//
+	//The unix time until which to produce the report, exclusive. If this value
+	//is not set, the report covers all swaps from the start time.
+	EndTime uint64 `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	//
+	//The name of the lnd node that the request is for. If this value is not
+	//set, the request is served by the first node faraday is configured with.
+	Node                 string   `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LiquidityCostReportRequest) Reset()         { *m = LiquidityCostReportRequest{} }
+func (m *LiquidityCostReportRequest) String() string { return proto.CompactTextString(m) }
+func (*LiquidityCostReportRequest) ProtoMessage()    {}
+func (*LiquidityCostReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{41}
+}
+
+func (m *LiquidityCostReportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LiquidityCostReportRequest.Unmarshal(m, b)
+}
+func (m *LiquidityCostReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LiquidityCostReportRequest.Marshal(b, m, deterministic)
+}
+func (m *LiquidityCostReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LiquidityCostReportRequest.Merge(m, src)
+}
+func (m *LiquidityCostReportRequest) XXX_Size() int {
+	return xxx_messageInfo_LiquidityCostReportRequest.Size(m)
+}
+func (m *LiquidityCostReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LiquidityCostReportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LiquidityCostReportRequest proto.InternalMessageInfo
+
+func (m *LiquidityCostReportRequest) GetStartTime() uint64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *LiquidityCostReportRequest) GetEndTime() uint64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *LiquidityCostReportRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+type LiquidityCostReportResponse struct {
+	// The total cost of all swaps in the period.
+	Total *LiquidityCost `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
+	//
+	//The cost of swaps that could not be attributed to any of our channels,
+	//because they did not restrict the channels that they used.
+	Unattributed *LiquidityCost `protobuf:"bytes,2,opt,name=unattributed,proto3" json:"unattributed,omitempty"`
+	// The cost of swaps attributed to each channel.
+	Channels []*ChannelLiquidityCost `protobuf:"bytes,3,rep,name=channels,proto3" json:"channels,omitempty"`
+	// The swaps that were included in the report.
+	Swaps                []*SwapCost `protobuf:"bytes,4,rep,name=swaps,proto3" json:"swaps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *LiquidityCostReportResponse) Reset()         { *m = LiquidityCostReportResponse{} }
+func (m *LiquidityCostReportResponse) String() string { return proto.CompactTextString(m) }
+func (*LiquidityCostReportResponse) ProtoMessage()    {}
+func (*LiquidityCostReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{42}
+}
+
+func (m *LiquidityCostReportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LiquidityCostReportResponse.Unmarshal(m, b)
+}
+func (m *LiquidityCostReportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LiquidityCostReportResponse.Marshal(b, m, deterministic)
+}
+func (m *LiquidityCostReportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LiquidityCostReportResponse.Merge(m, src)
+}
+func (m *LiquidityCostReportResponse) XXX_Size() int {
+	return xxx_messageInfo_LiquidityCostReportResponse.Size(m)
+}
+func (m *LiquidityCostReportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LiquidityCostReportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LiquidityCostReportResponse proto.InternalMessageInfo
+
+func (m *LiquidityCostReportResponse) GetTotal() *LiquidityCost {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+func (m *LiquidityCostReportResponse) GetUnattributed() *LiquidityCost {
+	if m != nil {
+		return m.Unattributed
+	}
+	return nil
+}
+
+func (m *LiquidityCostReportResponse) GetChannels() []*ChannelLiquidityCost {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *LiquidityCostReportResponse) GetSwaps() []*SwapCost {
+	if m != nil {
+		return m.Swaps
+	}
+	return nil
+}
+
+type LiquidityCost struct {
+	// The number of swaps that contributed to this cost.
+	Swaps uint32 `protobuf:"varint,1,opt,name=swaps,proto3" json:"swaps,omitempty"`
+	// The total amount swapped, in satoshis.
+	AmountSat int64 `protobuf:"varint,2,opt,name=amount_sat,json=amountSat,proto3" json:"amount_sat,omitempty"`
+	// The fees paid to the swap server, in satoshis.
+	ServerCostSat int64 `protobuf:"varint,3,opt,name=server_cost_sat,json=serverCostSat,proto3" json:"server_cost_sat,omitempty"`
+	// The on chain fees paid for the swaps, in satoshis.
+	OnchainCostSat int64 `protobuf:"varint,4,opt,name=onchain_cost_sat,json=onchainCostSat,proto3" json:"onchain_cost_sat,omitempty"`
+	//
+	//The off chain routing fees paid for the swaps, including any prepay that
+	//was not refunded, in satoshis.
+	OffchainCostSat int64 `protobuf:"varint,5,opt,name=offchain_cost_sat,json=offchainCostSat,proto3" json:"offchain_cost_sat,omitempty"`
+	// The total cost of the swaps, in satoshis.
+	TotalCostSat         int64    `protobuf:"varint,6,opt,name=total_cost_sat,json=totalCostSat,proto3" json:"total_cost_sat,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LiquidityCost) Reset()         { *m = LiquidityCost{} }
+func (m *LiquidityCost) String() string { return proto.CompactTextString(m) }
+func (*LiquidityCost) ProtoMessage()    {}
+func (*LiquidityCost) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{43}
+}
+
+func (m *LiquidityCost) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LiquidityCost.Unmarshal(m, b)
+}
+func (m *LiquidityCost) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LiquidityCost.Marshal(b, m, deterministic)
+}
+func (m *LiquidityCost) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LiquidityCost.Merge(m, src)
+}
+func (m *LiquidityCost) XXX_Size() int {
+	return xxx_messageInfo_LiquidityCost.Size(m)
+}
+func (m *LiquidityCost) XXX_DiscardUnknown() {
+	xxx_messageInfo_LiquidityCost.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LiquidityCost proto.InternalMessageInfo
+
+func (m *LiquidityCost) GetSwaps() uint32 {
+	if m != nil {
+		return m.Swaps
+	}
+	return 0
+}
+
+func (m *LiquidityCost) GetAmountSat() int64 {
+	if m != nil {
+		return m.AmountSat
+	}
+	return 0
+}
+
+func (m *LiquidityCost) GetServerCostSat() int64 {
+	if m != nil {
+		return m.ServerCostSat
+	}
+	return 0
+}
+
+func (m *LiquidityCost) GetOnchainCostSat() int64 {
+	if m != nil {
+		return m.OnchainCostSat
+	}
+	return 0
+}
+
+func (m *LiquidityCost) GetOffchainCostSat() int64 {
+	if m != nil {
+		return m.OffchainCostSat
+	}
+	return 0
+}
+
+func (m *LiquidityCost) GetTotalCostSat() int64 {
+	if m != nil {
+		return m.TotalCostSat
+	}
+	return 0
+}
+
+type ChannelLiquidityCost struct {
+	// The outpoint of the channel's funding transaction.
+	ChanPoint string `protobuf:"bytes,1,opt,name=chan_point,json=chanPoint,proto3" json:"chan_point,omitempty"`
+	//
+	//The channel's share of the cost of the swaps that rebalanced it. Swaps
+	//that rebalanced several channels are split evenly between them.
+	Cost                 *LiquidityCost `protobuf:"bytes,2,opt,name=cost,proto3" json:"cost,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ChannelLiquidityCost) Reset()         { *m = ChannelLiquidityCost{} }
+func (m *ChannelLiquidityCost) String() string { return proto.CompactTextString(m) }
+func (*ChannelLiquidityCost) ProtoMessage()    {}
+func (*ChannelLiquidityCost) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{44}
+}
+
+func (m *ChannelLiquidityCost) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelLiquidityCost.Unmarshal(m, b)
+}
+func (m *ChannelLiquidityCost) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelLiquidityCost.Marshal(b, m, deterministic)
+}
+func (m *ChannelLiquidityCost) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelLiquidityCost.Merge(m, src)
+}
+func (m *ChannelLiquidityCost) XXX_Size() int {
+	return xxx_messageInfo_ChannelLiquidityCost.Size(m)
+}
+func (m *ChannelLiquidityCost) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelLiquidityCost.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelLiquidityCost proto.InternalMessageInfo
+
+func (m *ChannelLiquidityCost) GetChanPoint() string {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return ""
+}
+
+func (m *ChannelLiquidityCost) GetCost() *LiquidityCost {
+	if m != nil {
+		return m.Cost
+	}
+	return nil
+}
+
+type SwapCost struct {
+	// The swap's identifier.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The type of swap, either loop_out or loop_in.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// The final state of the swap.
+	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// The unix time that the swap was initiated at.
+	InitiationTime uint64 `protobuf:"varint,4,opt,name=initiation_time,json=initiationTime,proto3" json:"initiation_time,omitempty"`
+	// The amount swapped, in satoshis.
+	AmountSat int64 `protobuf:"varint,5,opt,name=amount_sat,json=amountSat,proto3" json:"amount_sat,omitempty"`
+	// The fees paid to the swap server, in satoshis.
+	ServerCostSat int64 `protobuf:"varint,6,opt,name=server_cost_sat,json=serverCostSat,proto3" json:"server_cost_sat,omitempty"`
+	// The on chain fees paid for the swap, in satoshis.
+	OnchainCostSat int64 `protobuf:"varint,7,opt,name=onchain_cost_sat,json=onchainCostSat,proto3" json:"onchain_cost_sat,omitempty"`
+	// The off chain routing fees paid for the swap, in satoshis.
+	OffchainCostSat int64 `protobuf:"varint,8,opt,name=offchain_cost_sat,json=offchainCostSat,proto3" json:"offchain_cost_sat,omitempty"`
+	// The channels that the cost of the swap was attributed to.
+	ChanPoints           []string `protobuf:"bytes,9,rep,name=chan_points,json=chanPoints,proto3" json:"chan_points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SwapCost) Reset()         { *m = SwapCost{} }
+func (m *SwapCost) String() string { return proto.CompactTextString(m) }
+func (*SwapCost) ProtoMessage()    {}
+func (*SwapCost) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{45}
+}
+
+func (m *SwapCost) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SwapCost.Unmarshal(m, b)
+}
+func (m *SwapCost) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SwapCost.Marshal(b, m, deterministic)
+}
+func (m *SwapCost) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SwapCost.Merge(m, src)
+}
+func (m *SwapCost) XXX_Size() int {
+	return xxx_messageInfo_SwapCost.Size(m)
+}
+func (m *SwapCost) XXX_DiscardUnknown() {
+	xxx_messageInfo_SwapCost.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SwapCost proto.InternalMessageInfo
+
+func (m *SwapCost) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SwapCost) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *SwapCost) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *SwapCost) GetInitiationTime() uint64 {
+	if m != nil {
+		return m.InitiationTime
+	}
+	return 0
+}
+
+func (m *SwapCost) GetAmountSat() int64 {
+	if m != nil {
+		return m.AmountSat
+	}
+	return 0
+}
+
+func (m *SwapCost) GetServerCostSat() int64 {
+	if m != nil {
+		return m.ServerCostSat
+	}
+	return 0
+}
+
+func (m *SwapCost) GetOnchainCostSat() int64 {
+	if m != nil {
+		return m.OnchainCostSat
+	}
+	return 0
+}
+
+func (m *SwapCost) GetOffchainCostSat() int64 {
+	if m != nil {
+		return m.OffchainCostSat
+	}
+	return 0
+}
+
+func (m *SwapCost) GetChanPoints() []string {
+	if m != nil {
+		return m.ChanPoints
+	}
+	return nil
+}
+
+type FlowGraphRequest struct {
+	//
+	//The unix time from which to produce the graph, inclusive. If this value
+	//is not set, the graph covers all forwards up to the end time.
+	StartTime uint64 `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	//
+	//The unix time until which to produce the graph, exclusive. If this value
+	//is not set, the graph covers forwards until the present.
+	EndTime uint64 `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	//
+	//The name of the lnd node that the request is for. If this value is not
+	//set, the request is served by the first node faraday is configured with.
+	Node string `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	// The value that edges are weighted by.
+	Weight FlowGraphRequest_Weight `protobuf:"varint,4,opt,name=weight,proto3,enum=frdrpc.FlowGraphRequest_Weight" json:"weight,omitempty"`
+	//
+	//The value that nodes are labeled with. Nodes whose peer's alias cannot be
+	//found are labeled with the peer's public key, and nodes whose peer is not
+	//known are labeled with their channel outpoint.
+	Label FlowGraphRequest_Label `protobuf:"varint,5,opt,name=label,proto3,enum=frdrpc.FlowGraphRequest_Label" json:"label,omitempty"`
+	//
+	//Whether all of our channels with a peer should be represented by a single
+	//node, so that the graph shows the flow of forwards between peers.
+	MergePeers bool `protobuf:"varint,6,opt,name=merge_peers,json=mergePeers,proto3" json:"merge_peers,omitempty"`
+	//
+	//The format of the graph. Nodes and edges are always included in the
+	//response, and the graph is additionally written in Graphviz's DOT
+	//language if DOT is requested.
+	Format               FlowGraphRequest_Format `protobuf:"varint,7,opt,name=format,proto3,enum=frdrpc.FlowGraphRequest_Format" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *FlowGraphRequest) Reset()         { *m = FlowGraphRequest{} }
+func (m *FlowGraphRequest) String() string { return proto.CompactTextString(m) }
+func (*FlowGraphRequest) ProtoMessage()    {}
+func (*FlowGraphRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{46}
+}
+
+func (m *FlowGraphRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlowGraphRequest.Unmarshal(m, b)
+}
+func (m *FlowGraphRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlowGraphRequest.Marshal(b, m, deterministic)
+}
+func (m *FlowGraphRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlowGraphRequest.Merge(m, src)
+}
+func (m *FlowGraphRequest) XXX_Size() int {
+	return xxx_messageInfo_FlowGraphRequest.Size(m)
+}
+func (m *FlowGraphRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlowGraphRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlowGraphRequest proto.InternalMessageInfo
+
+func (m *FlowGraphRequest) GetStartTime() uint64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *FlowGraphRequest) GetEndTime() uint64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *FlowGraphRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *FlowGraphRequest) GetWeight() FlowGraphRequest_Weight {
+	if m != nil {
+		return m.Weight
+	}
+	return FlowGraphRequest_VOLUME
+}
+
+func (m *FlowGraphRequest) GetLabel() FlowGraphRequest_Label {
+	if m != nil {
+		return m.Label
+	}
+	return FlowGraphRequest_ALIAS
+}
+
+func (m *FlowGraphRequest) GetMergePeers() bool {
+	if m != nil {
+		return m.MergePeers
+	}
+	return false
+}
+
+func (m *FlowGraphRequest) GetFormat() FlowGraphRequest_Format {
+	if m != nil {
+		return m.Format
+	}
+	return FlowGraphRequest_JSON
+}
+
+type FlowGraphResponse struct {
+	// The nodes in the graph, sorted by id.
+	Nodes []*FlowGraphNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// The edges in the graph, sorted by source and then destination.
+	Edges []*FlowGraphEdge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	// The graph in Graphviz's DOT language, set if it was requested.
+	Dot                  string   `protobuf:"bytes,3,opt,name=dot,proto3" json:"dot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlowGraphResponse) Reset()         { *m = FlowGraphResponse{} }
+func (m *FlowGraphResponse) String() string { return proto.CompactTextString(m) }
+func (*FlowGraphResponse) ProtoMessage()    {}
+func (*FlowGraphResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{47}
+}
+
+func (m *FlowGraphResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlowGraphResponse.Unmarshal(m, b)
+}
+func (m *FlowGraphResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlowGraphResponse.Marshal(b, m, deterministic)
+}
+func (m *FlowGraphResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlowGraphResponse.Merge(m, src)
+}
+func (m *FlowGraphResponse) XXX_Size() int {
+	return xxx_messageInfo_FlowGraphResponse.Size(m)
+}
+func (m *FlowGraphResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlowGraphResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlowGraphResponse proto.InternalMessageInfo
+
+func (m *FlowGraphResponse) GetNodes() []*FlowGraphNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *FlowGraphResponse) GetEdges() []*FlowGraphEdge {
+	if m != nil {
+		return m.Edges
+	}
+	return nil
+}
+
+func (m *FlowGraphResponse) GetDot() string {
+	if m != nil {
+		return m.Dot
+	}
+	return ""
+}
+
+type FlowGraphNode struct {
+	//
+	//The node's identifier, which is its channel outpoint, or its peer's
+	//public key if channels were merged by peer.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The node's display label.
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// The public key of the node's peer, if it is known.
+	Peer string `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+	// The outpoints of the channels that the node represents.
+	ChanPoints           []string `protobuf:"bytes,4,rep,name=chan_points,json=chanPoints,proto3" json:"chan_points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlowGraphNode) Reset()         { *m = FlowGraphNode{} }
+func (m *FlowGraphNode) String() string { return proto.CompactTextString(m) }
+func (*FlowGraphNode) ProtoMessage()    {}
+func (*FlowGraphNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{48}
+}
+
+func (m *FlowGraphNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlowGraphNode.Unmarshal(m, b)
+}
+func (m *FlowGraphNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlowGraphNode.Marshal(b, m, deterministic)
+}
+func (m *FlowGraphNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlowGraphNode.Merge(m, src)
+}
+func (m *FlowGraphNode) XXX_Size() int {
+	return xxx_messageInfo_FlowGraphNode.Size(m)
+}
+func (m *FlowGraphNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlowGraphNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlowGraphNode proto.InternalMessageInfo
+
+func (m *FlowGraphNode) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *FlowGraphNode) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *FlowGraphNode) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *FlowGraphNode) GetChanPoints() []string {
+	if m != nil {
+		return m.ChanPoints
+	}
+	return nil
+}
+
+type FlowGraphEdge struct {
+	// The id of the node that forwards arrived on.
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// The id of the node that forwards left on.
+	To string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	//
+	//The total amount that arrived on the incoming node to be forwarded to the
+	//outgoing node, in millisatoshis.
+	AmountMsat int64 `protobuf:"varint,3,opt,name=amount_msat,json=amountMsat,proto3" json:"amount_msat,omitempty"`
+	// The total fees earned by the forwards, in millisatoshis.
+	FeesMsat int64 `protobuf:"varint,4,opt,name=fees_msat,json=feesMsat,proto3" json:"fees_msat,omitempty"`
+	// The edge's weight, which is either its amount or its fees.
+	WeightMsat           int64    `protobuf:"varint,5,opt,name=weight_msat,json=weightMsat,proto3" json:"weight_msat,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlowGraphEdge) Reset()         { *m = FlowGraphEdge{} }
+func (m *FlowGraphEdge) String() string { return proto.CompactTextString(m) }
+func (*FlowGraphEdge) ProtoMessage()    {}
+func (*FlowGraphEdge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{49}
+}
+
+func (m *FlowGraphEdge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlowGraphEdge.Unmarshal(m, b)
+}
+func (m *FlowGraphEdge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlowGraphEdge.Marshal(b, m, deterministic)
+}
+func (m *FlowGraphEdge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlowGraphEdge.Merge(m, src)
+}
+func (m *FlowGraphEdge) XXX_Size() int {
+	return xxx_messageInfo_FlowGraphEdge.Size(m)
+}
+func (m *FlowGraphEdge) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlowGraphEdge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlowGraphEdge proto.InternalMessageInfo
+
+func (m *FlowGraphEdge) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *FlowGraphEdge) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *FlowGraphEdge) GetAmountMsat() int64 {
+	if m != nil {
+		return m.AmountMsat
+	}
+	return 0
+}
+
+func (m *FlowGraphEdge) GetFeesMsat() int64 {
+	if m != nil {
+		return m.FeesMsat
+	}
+	return 0
+}
+
+func (m *FlowGraphEdge) GetWeightMsat() int64 {
+	if m != nil {
+		return m.WeightMsat
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("frdrpc.CloseRecommendationRequest_Metric", CloseRecommendationRequest_Metric_name, CloseRecommendationRequest_Metric_value)
+	proto.RegisterEnum("frdrpc.ChannelCloseResult_Action", ChannelCloseResult_Action_name, ChannelCloseResult_Action_value)
+	proto.RegisterEnum("frdrpc.BacktestStrategy_StrategyType", BacktestStrategy_StrategyType_name, BacktestStrategy_StrategyType_value)
+	proto.RegisterEnum("frdrpc.NodeStatus_State", NodeStatus_State_name, NodeStatus_State_value)
+	proto.RegisterEnum("frdrpc.MetricInfo_Scaling", MetricInfo_Scaling_name, MetricInfo_Scaling_value)
+	proto.RegisterEnum("frdrpc.FlowGraphRequest_Weight", FlowGraphRequest_Weight_name, FlowGraphRequest_Weight_value)
+	proto.RegisterEnum("frdrpc.FlowGraphRequest_Label", FlowGraphRequest_Label_name, FlowGraphRequest_Label_value)
+	proto.RegisterEnum("frdrpc.FlowGraphRequest_Format", FlowGraphRequest_Format_name, FlowGraphRequest_Format_value)
+	proto.RegisterType((*CloseRecommendationRequest)(nil), "frdrpc.CloseRecommendationRequest")
+	proto.RegisterType((*OutlierRecommendationsRequest)(nil), "frdrpc.OutlierRecommendationsRequest")
+	proto.RegisterType((*ThresholdRecommendationsRequest)(nil), "frdrpc.ThresholdRecommendationsRequest")
+	proto.RegisterType((*RuleRecommendationsRequest)(nil), "frdrpc.RuleRecommendationsRequest")
+	proto.RegisterType((*CloseRecommendationsResponse)(nil), "frdrpc.CloseRecommendationsResponse")
+	proto.RegisterType((*OutlierBounds)(nil), "frdrpc.OutlierBounds")
+	proto.RegisterType((*Recommendation)(nil), "frdrpc.Recommendation")
+	proto.RegisterType((*RevenueReportRequest)(nil), "frdrpc.RevenueReportRequest")
+	proto.RegisterType((*RevenueReportResponse)(nil), "frdrpc.RevenueReportResponse")
+	proto.RegisterType((*RevenuePeriod)(nil), "frdrpc.RevenuePeriod")
+	proto.RegisterType((*UnattributedReport)(nil), "frdrpc.UnattributedReport")
+	proto.RegisterType((*RevenueReport)(nil), "frdrpc.RevenueReport")
+	proto.RegisterMapType((map[string]*PairReport)(nil), "frdrpc.RevenueReport.PairReportsEntry")
+	proto.RegisterType((*ForwardStats)(nil), "frdrpc.ForwardStats")
+	proto.RegisterType((*PairReport)(nil), "frdrpc.PairReport")
+	proto.RegisterType((*ChannelInsightsRequest)(nil), "frdrpc.ChannelInsightsRequest")
+	proto.RegisterType((*ChannelInsightsResponse)(nil), "frdrpc.ChannelInsightsResponse")
+	proto.RegisterType((*ChannelInsight)(nil), "frdrpc.ChannelInsight")
+	proto.RegisterType((*CloseChannelsRequest)(nil), "frdrpc.CloseChannelsRequest")
+	proto.RegisterType((*CloseChannelsResponse)(nil), "frdrpc.CloseChannelsResponse")
+	proto.RegisterType((*ChannelCloseResult)(nil), "frdrpc.ChannelCloseResult")
+	proto.RegisterType((*BacktestRequest)(nil), "frdrpc.BacktestRequest")
+	proto.RegisterType((*BacktestStrategy)(nil), "frdrpc.BacktestStrategy")
+	proto.RegisterType((*BacktestResponse)(nil), "frdrpc.BacktestResponse")
+	proto.RegisterType((*BacktestResult)(nil), "frdrpc.BacktestResult")
+	proto.RegisterType((*SubscribeChannelInsightsRequest)(nil), "frdrpc.SubscribeChannelInsightsRequest")
+	proto.RegisterType((*SubscribeRecommendationsRequest)(nil), "frdrpc.SubscribeRecommendationsRequest")
+	proto.RegisterType((*FleetReportRequest)(nil), "frdrpc.FleetReportRequest")
+	proto.RegisterType((*FleetReportResponse)(nil), "frdrpc.FleetReportResponse")
+	proto.RegisterType((*NodeReport)(nil), "frdrpc.NodeReport")
+	proto.RegisterType((*FleetTotals)(nil), "frdrpc.FleetTotals")
+	proto.RegisterType((*DebugLevelRequest)(nil), "frdrpc.DebugLevelRequest")
+	proto.RegisterType((*DebugLevelResponse)(nil), "frdrpc.DebugLevelResponse")
+	proto.RegisterType((*StatusRequest)(nil), "frdrpc.StatusRequest")
+	proto.RegisterType((*StatusResponse)(nil), "frdrpc.StatusResponse")
+	proto.RegisterType((*NodeStatus)(nil), "frdrpc.NodeStatus")
+	proto.RegisterType((*GetInfoRequest)(nil), "frdrpc.GetInfoRequest")
+	proto.RegisterType((*GetInfoResponse)(nil), "frdrpc.GetInfoResponse")
+	proto.RegisterType((*LndInfo)(nil), "frdrpc.LndInfo")
+	proto.RegisterType((*ListMetricsRequest)(nil), "frdrpc.ListMetricsRequest")
+	proto.RegisterType((*ListMetricsResponse)(nil), "frdrpc.ListMetricsResponse")
+	proto.RegisterType((*MetricInfo)(nil), "frdrpc.MetricInfo")
+	proto.RegisterType((*LiquidityCostReportRequest)(nil), "frdrpc.LiquidityCostReportRequest")
+	proto.RegisterType((*LiquidityCostReportResponse)(nil), "frdrpc.LiquidityCostReportResponse")
+	proto.RegisterType((*LiquidityCost)(nil), "frdrpc.LiquidityCost")
+	proto.RegisterType((*ChannelLiquidityCost)(nil), "frdrpc.ChannelLiquidityCost")
+	proto.RegisterType((*SwapCost)(nil), "frdrpc.SwapCost")
+	proto.RegisterType((*FlowGraphRequest)(nil), "frdrpc.FlowGraphRequest")
+	proto.RegisterType((*FlowGraphResponse)(nil), "frdrpc.FlowGraphResponse")
+	proto.RegisterType((*FlowGraphNode)(nil), "frdrpc.FlowGraphNode")
+	proto.RegisterType((*FlowGraphEdge)(nil), "frdrpc.FlowGraphEdge")
+}
+
+func init() { proto.RegisterFile("rpc.proto", fileDescriptor_77a6da22d6a3feb1) }
+
+var fileDescriptor_77a6da22d6a3feb1 = []byte{
+	// 3813 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0xcd, 0x6f, 0x24, 0x59,
+	0x52, 0xef, 0xac, 0x4f, 0x57, 0x94, 0xab, 0x2a, 0xfd, 0xec, 0xee, 0xae, 0xa9, 0xee, 0x19, 0x7b,
+	0x72, 0x3e, 0xd6, 0x33, 0xb3, 0xeb, 0x69, 0x79, 0x67, 0xb5, 0xd3, 0x2d, 0x40, 0x5b, 0x5d, 0x5d,
+	0xee, 0x2e, 0xc6, 0x76, 0x99, 0xac, 0x72, 0x8f, 0x46, 0x42, 0x24, 0xe9, 0xac, 0x57, 0x76, 0xae,
+	0xb3, 0x32, 0x73, 0x32, 0xb3, 0xec, 0xf1, 0x5e, 0x90, 0x10, 0x82, 0x1b, 0x48, 0x20, 0xf8, 0x07,
+	0x38, 0x80, 0x84, 0xb8, 0x70, 0xe4, 0xc0, 0x19, 0xb8, 0x21, 0x4e, 0x88, 0x15, 0xe2, 0x80, 0x04,
+	0x47, 0x4e, 0x9c, 0x51, 0xbc, 0x8f, 0xfc, 0xa8, 0xca, 0x6a, 0x7b, 0x16, 0x66, 0x4f, 0xae, 0x8c,
+	0xf8, 0xbd, 0xc8, 0x78, 0x11, 0xf1, 0xe2, 0x45, 0x44, 0x1a, 0x6a, 0x81, 0x6f, 0xed, 0xf9, 0x81,
+	0x17, 0x79, 0xa4, 0x32, 0x0d, 0x26, 0x81, 0x6f, 0x75, 0x1e, 0x9f, 0x7b, 0xde, 0xb9, 0x43, 0x3f,
+	0x35, 0x7d, 0xfb, 0x53, 0xd3, 0x75, 0xbd, 0xc8, 0x8c, 0x6c, 0xcf, 0x0d, 0x39, 0x4a, 0xfb, 0xf7,
+	0x22, 0x74, 0x7a, 0x8e, 0x17, 0x52, 0x9d, 0x5a, 0xde, 0x6c, 0x46, 0xdd, 0x09, 0x63, 0xeb, 0xf4,
+	0xeb, 0x39, 0x0d, 0x23, 0xf2, 0x09, 0x6c, 0xcc, 0x6c, 0xd7, 0x9e, 0xcd, 0x67, 0xc6, 0xcc, 0x73,
+	0xed, 0xc8, 0x0b, 0xe8, 0xa4, 0xad, 0xec, 0x28, 0xbb, 0x45, 0x5d, 0x15, 0x8c, 0x23, 0x49, 0x27,
+	0x5d, 0xa8, 0xcc, 0x68, 0x14, 0xd8, 0x56, 0xbb, 0xb0, 0xa3, 0xec, 0x36, 0xf7, 0x3f, 0xda, 0xe3,
+	0x2a, 0xec, 0xad, 0x7e, 0xc1, 0xde, 0x11, 0x5b, 0xa0, 0x8b, 0x85, 0xe4, 0x23, 0x50, 0x1d, 0xcf,
+	0xbb, 0x3c, 0x33, 0xad, 0x4b, 0x23, 0xa4, 0x96, 0xe7, 0x4e, 0xc2, 0x76, 0x71, 0x47, 0xd9, 0x2d,
+	0xe9, 0x2d, 0x49, 0x1f, 0x71, 0x32, 0xf9, 0x11, 0x3c, 0x9c, 0x50, 0xcb, 0xbc, 0x31, 0x2e, 0x4c,
+	0x67, 0x6a, 0x38, 0xf6, 0x94, 0xc6, 0x2b, 0x4a, 0x6c, 0xc5, 0x16, 0x63, 0xbf, 0x32, 0x9d, 0xe9,
+	0xa1, 0x3d, 0xa5, 0x72, 0x19, 0x81, 0x92, 0xeb, 0x4d, 0x68, 0xbb, 0xbc, 0xa3, 0xec, 0xd6, 0x74,
+	0xf6, 0x9b, 0xec, 0xc3, 0x7d, 0xcf, 0xf7, 0xbd, 0x20, 0x9a, 0xbb, 0x76, 0x74, 0x63, 0x58, 0x5e,
+	0x18, 0x19, 0x81, 0x19, 0xd1, 0x76, 0x65, 0x47, 0xd9, 0x2d, 0xe8, 0x9b, 0x29, 0x66, 0xcf, 0x0b,
+	0x23, 0xdd, 0x8c, 0x28, 0xd9, 0x86, 0x3a, 0xd7, 0xd9, 0x70, 0xcd, 0x19, 0x6d, 0x57, 0x99, 0x38,
+	0xe0, 0xa4, 0x63, 0x73, 0x46, 0xb5, 0xdf, 0x57, 0xa0, 0xc2, 0x77, 0x47, 0xea, 0x50, 0x3d, 0x3d,
+	0xfe, 0xe2, 0x78, 0xf8, 0xe5, 0xb1, 0x7a, 0x8f, 0x00, 0x54, 0x4e, 0x4f, 0xc6, 0x83, 0xa3, 0xbe,
+	0xaa, 0x20, 0x43, 0xef, 0xbf, 0xee, 0x1f, 0x9f, 0xf6, 0xd5, 0x02, 0xd9, 0x84, 0xd6, 0xe0, 0xb8,
+	0x37, 0x3c, 0x1a, 0x1c, 0xbf, 0x34, 0x5e, 0x0f, 0x0f, 0x4f, 0x8f, 0xfa, 0x6a, 0x11, 0x89, 0xc3,
+	0xd3, 0xf1, 0xcb, 0x61, 0x8a, 0x58, 0x22, 0x2a, 0xac, 0x8f, 0x87, 0xe3, 0xee, 0xa1, 0xa4, 0x94,
+	0x49, 0x03, 0x6a, 0xc7, 0xfd, 0xb1, 0xf1, 0xba, 0x7b, 0x78, 0xda, 0x57, 0x2b, 0x28, 0xf7, 0x79,
+	0xf7, 0xb0, 0x7b, 0xdc, 0xeb, 0xab, 0x55, 0xed, 0x4f, 0x14, 0x78, 0x7b, 0x38, 0x8f, 0x1c, 0x9b,
+	0x06, 0x59, 0x1f, 0x84, 0xd2, 0xcb, 0x3d, 0xa8, 0x07, 0xd4, 0x32, 0x02, 0xfe, 0xc8, 0xfc, 0x5b,
+	0xdf, 0xd7, 0x6e, 0xf7, 0x9e, 0x0e, 0x01, 0xb5, 0xa4, 0x90, 0x1f, 0x00, 0xf1, 0xf8, 0x5b, 0x8c,
+	0xd9, 0xdc, 0x89, 0x6c, 0x1f, 0x7f, 0xb2, 0x48, 0x28, 0xe8, 0x1b, 0x82, 0x73, 0x14, 0x33, 0xb4,
+	0x3f, 0x52, 0x60, 0x7b, 0x7c, 0x11, 0xd0, 0xf0, 0xc2, 0x73, 0x26, 0xdf, 0xa5, 0x5e, 0xdf, 0x83,
+	0x56, 0x24, 0xdf, 0x63, 0x5c, 0x99, 0xce, 0x9c, 0x0a, 0xa5, 0x9a, 0x31, 0xf9, 0x35, 0x52, 0xb5,
+	0x6b, 0xe8, 0xe8, 0x73, 0x87, 0x7e, 0x97, 0xba, 0x6c, 0x41, 0x39, 0x98, 0x3b, 0x34, 0x6c, 0x17,
+	0x76, 0x8a, 0xbb, 0x35, 0x9d, 0x3f, 0x68, 0xff, 0xa3, 0xc0, 0xe3, 0x1c, 0x01, 0xa1, 0x4e, 0x43,
+	0xdf, 0x73, 0x43, 0x4a, 0x3e, 0x80, 0x66, 0xe4, 0x45, 0xa6, 0x63, 0x58, 0x17, 0xa6, 0xeb, 0x52,
+	0x27, 0x64, 0xaf, 0x2f, 0xeb, 0x0d, 0x46, 0xed, 0x09, 0x22, 0xf9, 0x14, 0x36, 0x2d, 0xcf, 0x0d,
+	0xed, 0x09, 0x0d, 0xe8, 0x24, 0xc1, 0x16, 0x18, 0x96, 0x24, 0xac, 0x78, 0xc1, 0x4f, 0xa0, 0x15,
+	0x64, 0x5f, 0xd9, 0x2e, 0xee, 0x14, 0x77, 0xeb, 0xfb, 0x0f, 0xe4, 0xbe, 0x16, 0xb6, 0xb4, 0x08,
+	0x27, 0xbf, 0x02, 0x4d, 0xe9, 0xf4, 0x33, 0x6f, 0x2e, 0xcf, 0x5e, 0x7d, 0xff, 0xbe, 0x14, 0x20,
+	0x02, 0xef, 0x39, 0x63, 0xea, 0x0d, 0x2f, 0xfd, 0xa8, 0xfd, 0x85, 0x02, 0x8d, 0x0c, 0x00, 0x77,
+	0xea, 0x78, 0xd7, 0x34, 0x30, 0xbe, 0x9e, 0x9b, 0x41, 0x64, 0x3b, 0x94, 0xed, 0xb4, 0xa0, 0x37,
+	0x18, 0xf5, 0x37, 0x04, 0x11, 0x61, 0x73, 0xdf, 0x4f, 0xc3, 0xb8, 0x4b, 0x1b, 0x8c, 0x1a, 0xc3,
+	0xde, 0x03, 0xbe, 0xce, 0x10, 0xaf, 0x65, 0xa9, 0xa4, 0xa0, 0xaf, 0x33, 0xa2, 0x78, 0x31, 0x82,
+	0xb8, 0x2c, 0x09, 0x2a, 0x71, 0x10, 0x23, 0x0a, 0x90, 0xf6, 0xbb, 0x0a, 0x34, 0xb3, 0xb6, 0x20,
+	0x6f, 0x03, 0xa0, 0x89, 0x0d, 0xdf, 0xb3, 0x5d, 0x1e, 0x0f, 0x35, 0xbd, 0x86, 0x94, 0x13, 0x24,
+	0xa0, 0xab, 0xd3, 0xc1, 0xc6, 0x1f, 0x30, 0x18, 0x63, 0x13, 0x1a, 0x16, 0xfa, 0x9c, 0xe9, 0xb4,
+	0xa6, 0x37, 0x63, 0x32, 0x8b, 0x04, 0x4c, 0x53, 0x18, 0x1c, 0x4c, 0x99, 0x9a, 0xce, 0x7e, 0x6b,
+	0xff, 0xa4, 0xc0, 0x96, 0x4e, 0xaf, 0xa8, 0x3b, 0xa7, 0x3a, 0xc5, 0x8c, 0x24, 0xc3, 0x6a, 0x1b,
+	0xea, 0x89, 0x2a, 0x18, 0x1c, 0x18, 0x5c, 0x10, 0xeb, 0x12, 0xa2, 0xae, 0x61, 0x64, 0x06, 0x91,
+	0x11, 0xd9, 0x33, 0xae, 0x51, 0x49, 0xaf, 0x31, 0xca, 0xd8, 0x9e, 0x51, 0xf2, 0x16, 0xac, 0xa1,
+	0x3e, 0x8c, 0xc9, 0xb3, 0x6d, 0x95, 0xba, 0x13, 0xc6, 0x92, 0xe9, 0xb2, 0x94, 0x4a, 0x97, 0xef,
+	0x41, 0x63, 0x6a, 0x9b, 0x91, 0x61, 0xcd, 0x83, 0x80, 0xba, 0xd6, 0x8d, 0xc8, 0xa5, 0xeb, 0x48,
+	0xec, 0x09, 0x1a, 0xba, 0xc8, 0xa7, 0x81, 0xed, 0x4d, 0xe2, 0xac, 0x5c, 0x61, 0x92, 0x1b, 0x9c,
+	0x2a, 0xd2, 0xb1, 0xf6, 0xcf, 0x05, 0xb8, 0xbf, 0xb0, 0x27, 0x11, 0xf4, 0x9f, 0x42, 0x35, 0x60,
+	0x14, 0xbe, 0xa1, 0x54, 0x4c, 0x65, 0xf1, 0x12, 0x45, 0x3e, 0x84, 0x16, 0x3f, 0x25, 0x53, 0x4a,
+	0x43, 0x63, 0x16, 0x9a, 0x11, 0xdb, 0x69, 0x51, 0x1c, 0x93, 0x03, 0x4a, 0xc3, 0xa3, 0xd0, 0x8c,
+	0x96, 0xd5, 0x2f, 0xe6, 0xa8, 0x9f, 0x15, 0x86, 0x2c, 0x66, 0x02, 0x25, 0x25, 0xec, 0xc0, 0xe6,
+	0xc2, 0xae, 0xcd, 0xc0, 0xb5, 0xdd, 0x73, 0xc3, 0xf2, 0xe6, 0x6e, 0xc4, 0x6c, 0xd1, 0xd0, 0xd7,
+	0x05, 0xb1, 0x87, 0x34, 0xf2, 0x6b, 0xb0, 0x3e, 0x77, 0xcd, 0x28, 0x0a, 0xec, 0xb3, 0x79, 0x44,
+	0x27, 0xcc, 0x12, 0xf5, 0xfd, 0x8e, 0xdc, 0xcf, 0x69, 0x8a, 0x27, 0x36, 0x95, 0xc1, 0xa3, 0x29,
+	0xb8, 0xd5, 0xc2, 0x76, 0x35, 0xd7, 0x14, 0x27, 0x8c, 0xab, 0x4b, 0x94, 0xf6, 0xe7, 0x0a, 0x34,
+	0x32, 0xac, 0x85, 0x08, 0x50, 0xde, 0x14, 0x01, 0x85, 0x6c, 0x04, 0x74, 0x60, 0x6d, 0xea, 0x05,
+	0xd7, 0x66, 0x10, 0x5f, 0xc5, 0xf1, 0x33, 0x79, 0x04, 0xb5, 0xc4, 0xd8, 0x25, 0x66, 0xec, 0xb5,
+	0xa9, 0xb4, 0xf3, 0x36, 0xd4, 0xaf, 0x3c, 0x67, 0x3e, 0xa3, 0x9c, 0x5d, 0x66, 0x6c, 0xe0, 0x24,
+	0x04, 0x68, 0xff, 0xa6, 0x00, 0x59, 0xde, 0x3b, 0x79, 0x02, 0x5b, 0xe6, 0x0c, 0xed, 0x66, 0xd8,
+	0xae, 0xe5, 0xcd, 0xd0, 0xb4, 0x4c, 0x00, 0x2f, 0x3b, 0x08, 0xe7, 0x0d, 0x04, 0x8b, 0xbd, 0x29,
+	0x59, 0xe1, 0xcd, 0xa3, 0x73, 0x2f, 0x5e, 0x51, 0x48, 0xaf, 0x18, 0x0a, 0x16, 0x5b, 0x91, 0x51,
+	0xbc, 0xb8, 0xa0, 0xb8, 0x64, 0xa6, 0xbc, 0xbe, 0x36, 0x95, 0x0e, 0xff, 0x18, 0x36, 0xc2, 0x0b,
+	0x2f, 0x88, 0x64, 0x7e, 0x35, 0xec, 0x49, 0xd8, 0x2e, 0xb3, 0x13, 0xd7, 0x62, 0x0c, 0x91, 0x5d,
+	0x07, 0x93, 0x50, 0xfb, 0xd7, 0x42, 0xec, 0x06, 0xb1, 0x37, 0xcc, 0xe4, 0x66, 0x70, 0x4e, 0xe3,
+	0xe5, 0x22, 0x71, 0x34, 0x38, 0x55, 0xac, 0x25, 0x03, 0x58, 0xf7, 0x4d, 0x3b, 0x30, 0xe4, 0x01,
+	0x28, 0x30, 0xaf, 0x7f, 0x98, 0x7b, 0x00, 0xf6, 0x4e, 0x4c, 0x3b, 0xe0, 0x3f, 0xc3, 0xbe, 0x1b,
+	0x05, 0x37, 0x7a, 0xdd, 0x4f, 0x28, 0xa4, 0x0b, 0x1b, 0xb1, 0x19, 0x33, 0x7e, 0xac, 0xef, 0x6f,
+	0x49, 0x79, 0x07, 0x9c, 0x3e, 0x8a, 0xcc, 0x28, 0xd4, 0x55, 0x09, 0x3f, 0x90, 0x5e, 0xee, 0xc2,
+	0x46, 0x6c, 0xd7, 0x58, 0x44, 0xe9, 0x4d, 0x22, 0x24, 0x5c, 0x8a, 0xe8, 0xe8, 0xa0, 0x2e, 0xaa,
+	0x49, 0x54, 0x28, 0x5e, 0xd2, 0x1b, 0x61, 0x00, 0xfc, 0x49, 0x76, 0xd3, 0x39, 0xb3, 0xbe, 0x4f,
+	0xa4, 0xf0, 0x64, 0xa9, 0xc8, 0xa3, 0xcf, 0x0a, 0x9f, 0x2b, 0xda, 0xcf, 0x15, 0x58, 0x4f, 0xbf,
+	0x16, 0x53, 0x2e, 0x3f, 0x83, 0x3c, 0xbc, 0xf9, 0x03, 0xd1, 0xa0, 0x31, 0xb3, 0x5d, 0x23, 0xb4,
+	0x7f, 0x46, 0xd3, 0x51, 0x51, 0x9f, 0xd9, 0xee, 0xc8, 0xfe, 0x19, 0x8b, 0x44, 0xb2, 0x0b, 0xea,
+	0x8c, 0x4e, 0x6c, 0x33, 0x0d, 0xe3, 0x51, 0xd1, 0xe4, 0xf4, 0x18, 0xa9, 0x41, 0xc3, 0x7f, 0xfa,
+	0x24, 0x05, 0xe3, 0x51, 0x5f, 0xf7, 0x9f, 0x3e, 0x49, 0x63, 0x66, 0xe6, 0x37, 0x29, 0x4c, 0x59,
+	0xbc, 0xd1, 0xfc, 0x26, 0xc6, 0xec, 0xc0, 0xfa, 0x94, 0x52, 0x56, 0x65, 0x1a, 0xbe, 0x3f, 0x63,
+	0x29, 0x41, 0xd1, 0x61, 0x4a, 0x29, 0x56, 0x97, 0x27, 0xfe, 0x4c, 0xfb, 0xcb, 0x02, 0x40, 0xb2,
+	0xf1, 0x95, 0x31, 0xae, 0xac, 0x8c, 0xf1, 0xef, 0x03, 0x61, 0x61, 0x9c, 0x77, 0x26, 0x54, 0xe4,
+	0x64, 0xd0, 0xab, 0x4e, 0x5d, 0x71, 0xe5, 0xa9, 0x93, 0xf2, 0xb3, 0xf8, 0x52, 0x22, 0x3f, 0x17,
+	0x9d, 0x44, 0x92, 0x2d, 0x2c, 0xa3, 0x64, 0xb5, 0x39, 0xb0, 0x53, 0xe8, 0x24, 0x74, 0x11, 0x5d,
+	0x49, 0xd0, 0x52, 0x36, 0xa2, 0xb5, 0x3f, 0x54, 0xe0, 0x81, 0x3c, 0x76, 0x6e, 0x68, 0x9f, 0x5f,
+	0x44, 0x71, 0xd9, 0x96, 0xd7, 0x50, 0x28, 0xdf, 0xba, 0xa1, 0x28, 0xdc, 0xa1, 0xa1, 0x28, 0x26,
+	0x37, 0xa4, 0xf6, 0x9b, 0xf0, 0x70, 0x49, 0x1f, 0x71, 0xad, 0x75, 0x41, 0x8d, 0x33, 0x87, 0xe0,
+	0xb5, 0x95, 0x6c, 0xd1, 0x95, 0x5d, 0xaa, 0xb7, 0xac, 0xac, 0x28, 0xed, 0xe7, 0x55, 0x68, 0x66,
+	0x31, 0xb7, 0x15, 0x23, 0xd8, 0xc6, 0xc9, 0x36, 0x6d, 0x61, 0x53, 0x6a, 0xcc, 0x90, 0x1b, 0x62,
+	0xc5, 0x15, 0xde, 0x04, 0x0b, 0x1d, 0x58, 0x83, 0x53, 0x25, 0xec, 0x09, 0x6c, 0x89, 0xf4, 0x9e,
+	0x17, 0x00, 0x84, 0xf3, 0x16, 0xd3, 0xb4, 0x58, 0x91, 0x0d, 0xc9, 0x72, 0x7a, 0x45, 0x26, 0x28,
+	0x77, 0x81, 0x39, 0xdb, 0xa0, 0x66, 0xe0, 0xd2, 0x09, 0x47, 0x57, 0xf8, 0xb9, 0x44, 0x7a, 0x9f,
+	0x91, 0x19, 0xf2, 0x7d, 0x68, 0x58, 0x9e, 0x3b, 0xb5, 0x83, 0x99, 0x28, 0x64, 0xab, 0xec, 0x1e,
+	0xce, 0x12, 0x49, 0x1b, 0xaa, 0x7e, 0x60, 0x5f, 0x61, 0x6b, 0xb7, 0xc6, 0xca, 0x2e, 0xf9, 0x88,
+	0xb7, 0x9c, 0xed, 0x46, 0x34, 0x70, 0x4d, 0xa7, 0x5d, 0x63, 0xac, 0xf8, 0x99, 0xbc, 0x0b, 0xeb,
+	0x96, 0xe9, 0x9b, 0x16, 0xf6, 0x86, 0xa8, 0x01, 0xf0, 0xe3, 0x2c, 0x69, 0x23, 0x7e, 0x2b, 0x38,
+	0x9e, 0x65, 0x3a, 0xc6, 0x99, 0xe9, 0x98, 0xae, 0x45, 0x19, 0xae, 0xce, 0x70, 0x2d, 0xc6, 0x78,
+	0xce, 0xe9, 0x23, 0x1e, 0xdb, 0x01, 0x9d, 0x79, 0x11, 0xcd, 0x80, 0xd7, 0xf9, 0xb9, 0xe1, 0x9c,
+	0x14, 0xfa, 0x09, 0x6c, 0xf9, 0xd4, 0x9d, 0xa0, 0xb1, 0x62, 0x3b, 0x23, 0xbe, 0xc1, 0x8d, 0x26,
+	0x78, 0xd2, 0xce, 0x0b, 0x2b, 0x62, 0x3b, 0xe3, 0x8a, 0x66, 0x66, 0x85, 0xb4, 0xf3, 0x88, 0x17,
+	0x31, 0x52, 0x95, 0x00, 0x2d, 0xd5, 0x6e, 0xf1, 0x12, 0x58, 0x10, 0x75, 0xa4, 0x91, 0x67, 0xf0,
+	0x96, 0x04, 0x2d, 0xc7, 0x92, 0xca, 0x22, 0xe4, 0xa1, 0x00, 0x1c, 0x2d, 0x86, 0xd4, 0xc7, 0xb0,
+	0xe1, 0xb9, 0xd4, 0xc0, 0xfe, 0x23, 0x59, 0xb3, 0xc1, 0x8f, 0xa1, 0xe7, 0xd2, 0x91, 0x3d, 0x49,
+	0xb0, 0x7b, 0xb0, 0xe9, 0xd8, 0x5f, 0xcf, 0xed, 0x49, 0xdc, 0x8a, 0x33, 0xb7, 0x13, 0xa6, 0xfd,
+	0x46, 0xcc, 0xc2, 0x46, 0x5c, 0x66, 0x5b, 0x97, 0x46, 0xa9, 0xa2, 0x6f, 0x93, 0xbb, 0xc7, 0xa5,
+	0x51, 0x5c, 0xf2, 0xe5, 0x5e, 0x82, 0x5b, 0xff, 0xf7, 0x4b, 0xf0, 0xfe, 0xb7, 0xb9, 0x04, 0xf1,
+	0x60, 0x05, 0xfc, 0xe6, 0x36, 0xce, 0x1c, 0xcf, 0xba, 0x0c, 0xdb, 0x0f, 0x78, 0x49, 0x29, 0xa8,
+	0xcf, 0x19, 0x51, 0xfb, 0x07, 0x05, 0xb6, 0x58, 0x13, 0x20, 0xfb, 0xb4, 0x3b, 0x97, 0xf9, 0xdb,
+	0x50, 0x97, 0xd5, 0x85, 0xe7, 0x4e, 0x45, 0xe3, 0x07, 0x9c, 0xd4, 0xf3, 0xdc, 0x29, 0xde, 0x3a,
+	0xa1, 0x19, 0x19, 0xd8, 0xed, 0x9c, 0xdd, 0x44, 0x54, 0x24, 0x77, 0x08, 0xcd, 0xe8, 0x84, 0x06,
+	0xcf, 0x6f, 0xf8, 0x58, 0xc3, 0x74, 0x1c, 0xef, 0x1a, 0xf7, 0x68, 0xf1, 0xb2, 0x7f, 0x4d, 0x07,
+	0x46, 0x3a, 0x40, 0x0a, 0x1e, 0x21, 0x71, 0xa6, 0xd8, 0xb9, 0x5d, 0xd3, 0xe5, 0x63, 0x9c, 0x08,
+	0x2b, 0xa9, 0x44, 0x78, 0x04, 0xf7, 0x17, 0xb6, 0x22, 0xd2, 0xe0, 0x67, 0x58, 0xdd, 0x87, 0x73,
+	0x27, 0xce, 0x7e, 0x9d, 0x85, 0xec, 0x27, 0x1a, 0x62, 0x84, 0xe8, 0x12, 0xaa, 0xfd, 0x8b, 0x02,
+	0x64, 0x99, 0x7f, 0x5b, 0xf6, 0x7b, 0x0a, 0x15, 0xd3, 0xc2, 0x04, 0x20, 0xe6, 0x52, 0xef, 0xae,
+	0x7e, 0xd5, 0x5e, 0x97, 0x01, 0x75, 0xb1, 0x80, 0x3c, 0x80, 0x4a, 0x40, 0xcd, 0xd0, 0x73, 0x45,
+	0x7a, 0x17, 0x4f, 0x2c, 0x25, 0x38, 0x5e, 0x88, 0xc1, 0x10, 0x7d, 0x63, 0x4f, 0x44, 0x7b, 0x54,
+	0x17, 0xb4, 0xf1, 0x37, 0xf6, 0x44, 0xdb, 0x83, 0x0a, 0x17, 0x46, 0xd6, 0xa0, 0x34, 0xfa, 0x62,
+	0x70, 0xa2, 0xde, 0x23, 0x2d, 0xa8, 0xf7, 0x86, 0xc3, 0x93, 0xbe, 0xde, 0x1d, 0x0f, 0x5e, 0xe3,
+	0x00, 0xa8, 0x06, 0xe5, 0x83, 0xa1, 0xde, 0xeb, 0xab, 0x05, 0xed, 0xbf, 0x15, 0x68, 0x3d, 0x37,
+	0xad, 0xcb, 0x88, 0x86, 0x71, 0x63, 0xf7, 0x39, 0x56, 0xed, 0x58, 0x23, 0x9c, 0xdb, 0x54, 0x1a,
+	0xaa, 0x2d, 0xb5, 0x97, 0xe0, 0x11, 0x47, 0xdc, 0xe8, 0x29, 0x2c, 0xd9, 0x84, 0xb2, 0x19, 0x1a,
+	0xde, 0x54, 0x64, 0xf9, 0x92, 0x19, 0x0e, 0xa7, 0x6f, 0xea, 0xf3, 0x72, 0x07, 0x7d, 0xa5, 0x15,
+	0x83, 0xbe, 0xff, 0xa7, 0x19, 0x9a, 0xf6, 0x07, 0x05, 0x50, 0x17, 0x77, 0xc1, 0x84, 0x9b, 0xa2,
+	0x47, 0x41, 0xe1, 0xe6, 0x8c, 0x92, 0xa7, 0x50, 0x8a, 0x6e, 0x7c, 0x2a, 0xfc, 0xf7, 0xc1, 0x2a,
+	0x0b, 0xec, 0xc9, 0x1f, 0xe3, 0x1b, 0x9f, 0xea, 0x6c, 0x49, 0x6a, 0x28, 0x59, 0xfc, 0x45, 0x87,
+	0x92, 0x71, 0x2b, 0x5f, 0x4a, 0xb7, 0xf2, 0x0b, 0x03, 0xc0, 0xf2, 0xd2, 0x00, 0xf0, 0x63, 0x58,
+	0x4f, 0xeb, 0x83, 0x43, 0xb9, 0xe1, 0xe9, 0xf8, 0x70, 0xd0, 0xd7, 0xd5, 0x7b, 0x38, 0xb0, 0x1b,
+	0xbf, 0xd2, 0xfb, 0xa3, 0x57, 0xc3, 0xc3, 0x17, 0xaa, 0xa2, 0x45, 0x89, 0x21, 0xe2, 0x23, 0x12,
+	0xbb, 0x50, 0x59, 0xe1, 0xc2, 0x85, 0x46, 0xed, 0x49, 0x72, 0xa4, 0x16, 0xa6, 0x38, 0x29, 0xd1,
+	0x99, 0xe3, 0xf4, 0x1f, 0x45, 0x68, 0x66, 0x79, 0xe4, 0x33, 0x58, 0x13, 0x51, 0x74, 0x23, 0x66,
+	0x5c, 0xab, 0xe3, 0x2d, 0x46, 0xe6, 0x0c, 0xa8, 0x0a, 0xdf, 0x62, 0x40, 0x55, 0x5c, 0x39, 0xa0,
+	0xfa, 0x08, 0xd4, 0xa9, 0x63, 0x9e, 0x9f, 0xa7, 0xd1, 0x25, 0x86, 0x6e, 0x09, 0x7a, 0x0c, 0x7d,
+	0x0f, 0x1a, 0x97, 0xd4, 0x8f, 0x12, 0x5c, 0x99, 0xe1, 0xd6, 0x91, 0x18, 0x83, 0x3e, 0x86, 0x0d,
+	0x29, 0x2f, 0xb9, 0x2f, 0x78, 0x41, 0x21, 0x05, 0xc6, 0x77, 0xc6, 0xfb, 0xd0, 0x64, 0x02, 0x13,
+	0x60, 0x95, 0x01, 0x99, 0xc4, 0x18, 0xf5, 0x2e, 0xac, 0x4b, 0x89, 0xf6, 0xc4, 0xe1, 0x65, 0x45,
+	0x59, 0xaf, 0x0b, 0xda, 0x60, 0xe2, 0x50, 0x6c, 0x27, 0x99, 0x20, 0xc6, 0xaf, 0x31, 0xfe, 0x1a,
+	0x12, 0x18, 0xf3, 0x87, 0xf0, 0x60, 0x46, 0x4d, 0xd7, 0x58, 0x56, 0x0b, 0xf8, 0xb9, 0x41, 0xee,
+	0xc1, 0x82, 0x6a, 0x3f, 0x00, 0x46, 0x36, 0x16, 0xf4, 0xab, 0xb3, 0x15, 0x2a, 0xb2, 0xbe, 0x48,
+	0xe9, 0x88, 0x93, 0xe8, 0xed, 0xd1, 0xfc, 0x2c, 0xb4, 0x02, 0xfb, 0x8c, 0xae, 0xa8, 0x93, 0x3f,
+	0xc7, 0xe0, 0x49, 0x8f, 0x36, 0xdf, 0xc9, 0xaf, 0x46, 0xe5, 0x02, 0x5d, 0xc2, 0xd1, 0x47, 0xac,
+	0x52, 0xba, 0x32, 0x9d, 0x85, 0xd2, 0xb2, 0x25, 0xe9, 0x72, 0xd8, 0xf3, 0x8f, 0x85, 0x94, 0x22,
+	0x2b, 0xe6, 0xac, 0x27, 0xd0, 0x92, 0x13, 0xc5, 0xac, 0x42, 0x1f, 0x2c, 0x8c, 0x14, 0xf3, 0xd7,
+	0xbf, 0xba, 0xa7, 0xcb, 0x89, 0xa4, 0x94, 0xf8, 0x1a, 0x36, 0x92, 0x01, 0xb0, 0x94, 0xc9, 0x3b,
+	0xcc, 0xef, 0x49, 0x99, 0xb7, 0x4c, 0xa2, 0x5f, 0xdd, 0xd3, 0xd5, 0x28, 0x81, 0x70, 0xb9, 0x2f,
+	0x61, 0x1d, 0xc7, 0x72, 0xb1, 0xc8, 0x52, 0x76, 0x24, 0xbc, 0x7a, 0x96, 0xfc, 0xea, 0x9e, 0x5e,
+	0x0f, 0x18, 0x77, 0xb5, 0x05, 0x8b, 0xb9, 0x16, 0x7c, 0x5e, 0x8b, 0xdd, 0xa4, 0x5d, 0x01, 0x39,
+	0x70, 0x28, 0x8d, 0xb2, 0xa3, 0xc0, 0xef, 0xbc, 0xdf, 0xd1, 0x1c, 0xd8, 0xcc, 0xbc, 0x57, 0x64,
+	0xab, 0x5d, 0x28, 0xe3, 0x3d, 0x20, 0x6f, 0xa9, 0xb8, 0x77, 0x3f, 0xf6, 0x26, 0x72, 0x52, 0xc7,
+	0x01, 0xe4, 0x13, 0xa8, 0xb0, 0xb4, 0x10, 0x0a, 0x27, 0x6c, 0xc6, 0xe5, 0x13, 0x8a, 0x1d, 0x33,
+	0x96, 0x2e, 0x20, 0xda, 0xdf, 0x29, 0x00, 0x89, 0x88, 0xf8, 0xe6, 0x51, 0x52, 0x37, 0xcf, 0x03,
+	0xa8, 0xf8, 0xf3, 0x33, 0x1c, 0x25, 0x14, 0xf8, 0x1d, 0xcd, 0x9f, 0x72, 0x3b, 0xad, 0xe2, 0xb7,
+	0xea, 0xb4, 0x52, 0xaa, 0x96, 0x6e, 0x55, 0x15, 0xaf, 0x09, 0x1a, 0x04, 0x5e, 0x20, 0xae, 0x02,
+	0xfe, 0xa0, 0xfd, 0x59, 0x01, 0xea, 0x29, 0x34, 0x36, 0x1a, 0x99, 0x29, 0x7e, 0x43, 0x8f, 0x9f,
+	0xf1, 0x12, 0x96, 0x4d, 0x47, 0x36, 0x93, 0x36, 0x74, 0x55, 0x32, 0xe2, 0x5c, 0x96, 0xd7, 0x1b,
+	0x15, 0x73, 0x7b, 0xa3, 0x5f, 0x46, 0xa7, 0x96, 0x7e, 0x87, 0xd8, 0x41, 0x2a, 0xb9, 0xc6, 0xef,
+	0xe0, 0x2c, 0x96, 0x95, 0x0e, 0x60, 0xe3, 0x05, 0x3d, 0x9b, 0x9f, 0x1f, 0xd2, 0x2b, 0xea, 0xc8,
+	0xf0, 0x25, 0x50, 0x0a, 0x2f, 0xbc, 0x6b, 0x66, 0x99, 0x35, 0x9d, 0xfd, 0xc6, 0xea, 0xce, 0x41,
+	0x8c, 0x11, 0xfa, 0xd4, 0x12, 0x3e, 0xae, 0x31, 0xca, 0xc8, 0xa7, 0x96, 0xf6, 0x23, 0x20, 0x69,
+	0x39, 0x22, 0x1c, 0xb7, 0xa1, 0x1e, 0xce, 0xcf, 0x8c, 0xf0, 0x26, 0x8c, 0xe8, 0x2c, 0x14, 0xf1,
+	0x02, 0xe1, 0xfc, 0x6c, 0xc4, 0x29, 0x5a, 0x0b, 0x1a, 0x58, 0xa7, 0xcf, 0xe5, 0xa1, 0xd4, 0x9e,
+	0x41, 0x53, 0x12, 0xee, 0x10, 0xd2, 0x02, 0xca, 0x01, 0xda, 0xdf, 0x16, 0x00, 0x12, 0x6a, 0x6e,
+	0x94, 0xee, 0x41, 0x39, 0x8c, 0xb0, 0x1e, 0xe2, 0x35, 0x4c, 0x7b, 0x59, 0xd8, 0x1e, 0xfe, 0xa1,
+	0x3a, 0x87, 0xb1, 0x0d, 0xe0, 0x0f, 0x23, 0xb4, 0x5d, 0x2b, 0xa9, 0xd4, 0x91, 0x34, 0x42, 0x0a,
+	0x33, 0x8b, 0x19, 0xe2, 0x85, 0x47, 0xad, 0x4b, 0xe1, 0xcb, 0x1a, 0x52, 0x7a, 0x48, 0xc8, 0x8f,
+	0x46, 0x4c, 0x0f, 0x96, 0xe7, 0xba, 0xd4, 0x8a, 0x0c, 0x33, 0x8a, 0xe8, 0xcc, 0x8f, 0xf8, 0x5c,
+	0xbe, 0xa1, 0xb7, 0x04, 0xbd, 0x2b, 0xc8, 0xda, 0x39, 0x94, 0x99, 0x42, 0xd9, 0xaf, 0x97, 0x4d,
+	0x80, 0xde, 0xf0, 0xf8, 0xb8, 0xdf, 0x1b, 0x0f, 0x8e, 0x5f, 0xaa, 0x0a, 0x7e, 0x8a, 0x7c, 0x31,
+	0x18, 0x09, 0x52, 0xff, 0x85, 0x5a, 0x20, 0x04, 0x9a, 0x5f, 0x76, 0x07, 0xc8, 0x36, 0x4e, 0x8f,
+	0x0f, 0x87, 0xbd, 0x2f, 0xd4, 0x22, 0xa2, 0x24, 0x6d, 0xf4, 0xd5, 0x71, 0x4f, 0x2d, 0x61, 0xe1,
+	0xab, 0xf7, 0xbb, 0x2f, 0xbe, 0x52, 0xcb, 0x9a, 0x0a, 0xcd, 0x97, 0x34, 0x1a, 0xb8, 0x53, 0x4f,
+	0xba, 0xe2, 0xaf, 0x15, 0x68, 0xc5, 0x24, 0xe1, 0x8c, 0x36, 0x54, 0xaf, 0x68, 0x10, 0x62, 0x15,
+	0xcf, 0xcd, 0x2a, 0x1f, 0xf1, 0xfc, 0x63, 0x96, 0xb5, 0x23, 0x79, 0xfe, 0xf9, 0xd3, 0x5d, 0xe7,
+	0x18, 0x1f, 0x48, 0x2f, 0x97, 0x98, 0x97, 0x5b, 0xd2, 0x31, 0x87, 0xee, 0x84, 0x29, 0xc0, 0xb9,
+	0x6c, 0x0c, 0x4e, 0xcd, 0x68, 0x1e, 0x50, 0x39, 0xee, 0x8d, 0x9f, 0xb5, 0x3f, 0x55, 0xa0, 0x2a,
+	0xe0, 0xb9, 0xbe, 0x4f, 0xe9, 0x5e, 0xc8, 0xea, 0xbe, 0x05, 0x65, 0xd3, 0xb1, 0xcd, 0x50, 0xb4,
+	0x17, 0xfc, 0x21, 0x95, 0xd1, 0x4a, 0x99, 0x8c, 0xd6, 0x86, 0xaa, 0x4b, 0xa3, 0x6b, 0x2f, 0xb8,
+	0x14, 0x5e, 0x95, 0x8f, 0x89, 0xb7, 0x2b, 0xe9, 0xdc, 0xb3, 0x05, 0xe4, 0xd0, 0x0e, 0x23, 0x5e,
+	0xce, 0xc6, 0x81, 0xde, 0x83, 0xcd, 0x0c, 0x55, 0x18, 0xf8, 0xfb, 0x50, 0xe5, 0xc5, 0xeb, 0x52,
+	0xbc, 0x73, 0x24, 0x33, 0x86, 0x84, 0x68, 0x7f, 0xaf, 0x00, 0x24, 0xf4, 0xdc, 0xa2, 0x7d, 0x07,
+	0xea, 0x13, 0x8a, 0x77, 0xbd, 0x1f, 0x25, 0x3b, 0x4f, 0x93, 0x70, 0x15, 0x36, 0x04, 0x72, 0x74,
+	0x86, 0xbf, 0xb1, 0x31, 0x0c, 0x2d, 0xd3, 0xb1, 0xdd, 0x73, 0xb6, 0xf9, 0x66, 0xd2, 0x18, 0x26,
+	0xaf, 0xdb, 0x1b, 0x71, 0x84, 0x2e, 0xa1, 0xda, 0x33, 0xa8, 0x0a, 0x1a, 0xa9, 0x42, 0x51, 0xef,
+	0x7e, 0xa9, 0xde, 0x23, 0x5b, 0xa0, 0x9e, 0xf4, 0x75, 0xa3, 0x37, 0x3c, 0x3e, 0x18, 0xe8, 0x47,
+	0xdd, 0xf1, 0x60, 0x78, 0xcc, 0x03, 0x96, 0x51, 0xbb, 0x27, 0xdd, 0xde, 0x60, 0xfc, 0x95, 0x5a,
+	0xd0, 0x7e, 0x0a, 0x9d, 0xc3, 0xf4, 0x54, 0x21, 0x7b, 0xa1, 0xfe, 0xe2, 0x1f, 0x4e, 0xf2, 0x06,
+	0x83, 0xff, 0xa9, 0xc0, 0xa3, 0xdc, 0x97, 0x09, 0x27, 0x7c, 0x02, 0x65, 0x76, 0x9b, 0x88, 0x9a,
+	0x27, 0xfe, 0xce, 0x93, 0x5d, 0xc3, 0x31, 0xe4, 0xe9, 0xc2, 0x67, 0xa5, 0xc2, 0x9b, 0xd6, 0x64,
+	0xa0, 0xe4, 0xf3, 0xd4, 0x2d, 0xc4, 0xef, 0xc4, 0xc7, 0x0b, 0x77, 0x62, 0x76, 0x75, 0x8c, 0x26,
+	0x1f, 0x42, 0x39, 0xbc, 0x36, 0x7d, 0x79, 0x5c, 0x54, 0xb9, 0x6c, 0x74, 0x6d, 0xfa, 0x5c, 0x39,
+	0xc6, 0xd6, 0xfe, 0x4b, 0x81, 0x46, 0x46, 0x06, 0xc6, 0x28, 0x5f, 0xc9, 0xaf, 0x3d, 0xfe, 0x80,
+	0xf6, 0x15, 0x73, 0xe7, 0x64, 0x3a, 0x5d, 0xe3, 0x14, 0x1c, 0x4d, 0x7d, 0x08, 0xad, 0x90, 0x06,
+	0x57, 0x34, 0xe0, 0x1d, 0x65, 0x72, 0xc9, 0x35, 0x38, 0x19, 0x25, 0x8f, 0xf8, 0xa4, 0xd0, 0x73,
+	0xad, 0x0b, 0xd3, 0x76, 0x13, 0x20, 0xcf, 0x89, 0x4d, 0x41, 0x97, 0x48, 0x9c, 0x45, 0x4d, 0xa7,
+	0x0b, 0x50, 0x7e, 0xb1, 0xb5, 0x24, 0x43, 0x62, 0xdf, 0x8f, 0xfb, 0x1a, 0x09, 0xe4, 0xf7, 0xd9,
+	0x3a, 0xa3, 0x0a, 0x94, 0xf6, 0xdb, 0xb0, 0x95, 0x67, 0xb4, 0xdb, 0xc6, 0x12, 0x1f, 0x41, 0x09,
+	0xc5, 0xbe, 0xd9, 0x6d, 0x0c, 0xa2, 0xfd, 0x55, 0x01, 0xd6, 0xa4, 0x81, 0x49, 0x13, 0x0a, 0xf6,
+	0x44, 0x88, 0x2b, 0xd8, 0xac, 0x1b, 0x8f, 0x9b, 0xe3, 0x9a, 0xe8, 0x7a, 0xb7, 0xe4, 0x6d, 0x23,
+	0xf2, 0x0a, 0x7b, 0xc0, 0xaf, 0xcf, 0xb6, 0x6b, 0x47, 0x36, 0xab, 0x46, 0x79, 0xcc, 0xf2, 0x7f,
+	0x95, 0x69, 0x26, 0x64, 0x16, 0xba, 0x59, 0xa7, 0x94, 0xef, 0xe0, 0x94, 0xca, 0x5d, 0x9d, 0x52,
+	0xbd, 0xbb, 0x53, 0xd6, 0xf2, 0x9d, 0xb2, 0x30, 0x06, 0xab, 0x2d, 0x8e, 0xc1, 0xb4, 0xdf, 0x2b,
+	0x82, 0x7a, 0xe0, 0x78, 0xd7, 0x2f, 0x03, 0xd3, 0xbf, 0xf8, 0x4e, 0xce, 0x31, 0xf9, 0x31, 0x54,
+	0xae, 0x29, 0xd6, 0x88, 0x22, 0x49, 0x6d, 0x27, 0x85, 0x61, 0xf6, 0xbd, 0x7b, 0x5f, 0x32, 0x98,
+	0x2e, 0xe0, 0xe4, 0x33, 0x28, 0x3b, 0xe6, 0x19, 0x75, 0x98, 0x51, 0x9b, 0xfb, 0xef, 0xac, 0x5c,
+	0x77, 0x88, 0x28, 0x9d, 0x83, 0xf9, 0xac, 0x21, 0x38, 0xa7, 0x86, 0x4f, 0x69, 0xc0, 0x6f, 0xec,
+	0x35, 0x9c, 0x35, 0x04, 0xe7, 0xf4, 0x04, 0x29, 0xa8, 0xcf, 0xd4, 0x0b, 0x66, 0xc2, 0xbe, 0x6f,
+	0xd2, 0xe7, 0x80, 0xc1, 0x74, 0x01, 0xd7, 0xde, 0x81, 0x0a, 0xd7, 0x10, 0xff, 0x2f, 0x49, 0xfc,
+	0x3b, 0xd1, 0x3d, 0x9c, 0x58, 0x1d, 0xf4, 0xfb, 0x23, 0x55, 0xd1, 0x1e, 0x43, 0x99, 0x69, 0x82,
+	0x17, 0x76, 0xf7, 0x70, 0xd0, 0x1d, 0x71, 0xee, 0x49, 0xbf, 0xaf, 0xab, 0x8a, 0xf6, 0x08, 0x2a,
+	0x5c, 0x1e, 0xd2, 0x7e, 0x7d, 0x34, 0xc4, 0x0a, 0xa1, 0x0a, 0xc5, 0x17, 0xc3, 0xb1, 0xaa, 0x68,
+	0xbf, 0x03, 0x1b, 0xa9, 0xb7, 0x27, 0x09, 0x2e, 0x5d, 0x53, 0xdd, 0x5f, 0xd2, 0x93, 0x15, 0xfb,
+	0x71, 0xa7, 0x50, 0xa6, 0x93, 0x73, 0x2a, 0xbf, 0x7f, 0x2e, 0x83, 0xfb, 0x93, 0x73, 0xaa, 0x73,
+	0x0c, 0x7e, 0x4e, 0x9c, 0x78, 0xf2, 0x2e, 0xc1, 0x9f, 0xda, 0x4f, 0xa1, 0x91, 0x11, 0xbb, 0x74,
+	0x72, 0xb6, 0xa4, 0x33, 0xf8, 0xd1, 0xe1, 0x0f, 0xe8, 0x6f, 0x34, 0xb3, 0xf4, 0x37, 0xfe, 0x5e,
+	0x8c, 0xb9, 0xd2, 0x52, 0xcc, 0xfd, 0xb1, 0x02, 0x8d, 0x8c, 0x5a, 0x28, 0x66, 0x1a, 0x78, 0x33,
+	0x79, 0x25, 0xe2, 0x6f, 0x54, 0x20, 0xf2, 0xc4, 0xdb, 0x0a, 0x91, 0x87, 0x62, 0xc5, 0x39, 0x4b,
+	0x95, 0xef, 0xe2, 0xe8, 0x2d, 0x7f, 0xa7, 0xce, 0xf9, 0xc0, 0xce, 0xa3, 0x2a, 0xf3, 0x81, 0x9d,
+	0x93, 0x10, 0xb0, 0xff, 0x37, 0x00, 0x8d, 0x03, 0x33, 0x30, 0x27, 0xe6, 0xcd, 0x88, 0x1d, 0x4c,
+	0x42, 0xe1, 0x41, 0x7e, 0xfb, 0x4c, 0xee, 0xd6, 0x5e, 0x77, 0xde, 0x7f, 0xc3, 0xf8, 0x2c, 0xa9,
+	0x25, 0x6c, 0x68, 0xaf, 0xea, 0xa8, 0xc9, 0x5d, 0x7b, 0xee, 0x3b, 0xbe, 0xca, 0x80, 0xcd, 0x9c,
+	0x4e, 0x9b, 0xdc, 0xa1, 0x0d, 0xbf, 0xe3, 0x0b, 0x0e, 0x17, 0xbf, 0xe1, 0x3f, 0xce, 0xff, 0x3f,
+	0x14, 0x21, 0xf4, 0xed, 0x15, 0x5c, 0x21, 0x4d, 0x87, 0xd6, 0xc2, 0x40, 0x85, 0xdc, 0x32, 0x69,
+	0xe9, 0x6c, 0xaf, 0xe4, 0x27, 0x1a, 0x66, 0x86, 0xec, 0x89, 0x86, 0x79, 0x9f, 0x11, 0x3a, 0x6f,
+	0xaf, 0xe0, 0x0a, 0x69, 0xbf, 0x0a, 0x6b, 0x72, 0xd2, 0x47, 0x1e, 0x2e, 0x4f, 0x10, 0xb9, 0x8c,
+	0xf6, 0x32, 0x43, 0x2c, 0x9f, 0x42, 0x7b, 0xd5, 0xac, 0x29, 0x71, 0xfd, 0x2d, 0xd3, 0xa8, 0x5b,
+	0xb7, 0xfc, 0x44, 0x21, 0x97, 0xa9, 0xf7, 0xac, 0x0c, 0xb1, 0x5b, 0x86, 0x4d, 0x77, 0x8b, 0x80,
+	0x27, 0x0a, 0x39, 0x10, 0x3d, 0xbc, 0x88, 0x80, 0x4e, 0x66, 0x0c, 0x90, 0xf5, 0xff, 0xa3, 0x5c,
+	0x9e, 0x30, 0x4e, 0x0f, 0x20, 0xe9, 0x55, 0xc9, 0x5b, 0x12, 0xba, 0xd4, 0x07, 0x77, 0x3a, 0x79,
+	0x2c, 0x21, 0xe4, 0xc7, 0x50, 0x11, 0x7d, 0x66, 0x9c, 0x10, 0x33, 0x9d, 0x6c, 0xe7, 0xc1, 0x22,
+	0x59, 0x2c, 0x7c, 0x06, 0x55, 0xd1, 0x55, 0x91, 0x18, 0x92, 0xed, 0xbc, 0x3a, 0x0f, 0x97, 0xe8,
+	0x62, 0xed, 0x01, 0xd4, 0x53, 0x4d, 0x43, 0x62, 0x81, 0xe5, 0xfe, 0xa2, 0xf3, 0x28, 0x97, 0x27,
+	0xe4, 0xfc, 0x16, 0x6c, 0x66, 0x0b, 0x1c, 0x6e, 0x51, 0x2d, 0xbf, 0xfa, 0xc9, 0x58, 0xf6, 0xbd,
+	0x37, 0x62, 0x84, 0xfc, 0x9f, 0x40, 0x2d, 0x4e, 0xc3, 0xa4, 0xbd, 0xea, 0x16, 0xec, 0xbc, 0x95,
+	0xc3, 0xe1, 0x12, 0xce, 0x2a, 0xec, 0x1f, 0xa3, 0x7f, 0xf8, 0xbf, 0x03, 0x00, 0x24, 0x47, 0x97,
+	0xa9, 0x4b, 0x2d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
 var _ grpc.ClientConn
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// FaradayServerClient is the client API for FaradayServer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FaradayServerClient interface {
+	OutlierRecommendations(ctx context.Context, in *OutlierRecommendationsRequest, opts ...grpc.CallOption) (*CloseRecommendationsResponse, error)
+	ThresholdRecommendations(ctx context.Context, in *ThresholdRecommendationsRequest, opts ...grpc.CallOption) (*CloseRecommendationsResponse, error)
+	RuleRecommendations(ctx context.Context, in *RuleRecommendationsRequest, opts ...grpc.CallOption) (*CloseRecommendationsResponse, error)
+	RevenueReport(ctx context.Context, in *RevenueReportRequest, opts ...grpc.CallOption) (*RevenueReportResponse, error)
+	ChannelInsights(ctx context.Context, in *ChannelInsightsRequest, opts ...grpc.CallOption) (*ChannelInsightsResponse, error)
+	CloseChannels(ctx context.Context, in *CloseChannelsRequest, opts ...grpc.CallOption) (*CloseChannelsResponse, error)
+	Backtest(ctx context.Context, in *BacktestRequest, opts ...grpc.CallOption) (*BacktestResponse, error)
+	SubscribeChannelInsights(ctx context.Context, in *SubscribeChannelInsightsRequest, opts ...grpc.CallOption) (FaradayServer_SubscribeChannelInsightsClient, error)
+	SubscribeRecommendations(ctx context.Context, in *SubscribeRecommendationsRequest, opts ...grpc.CallOption) (FaradayServer_SubscribeRecommendationsClient, error)
+	FleetReport(ctx context.Context, in *FleetReportRequest, opts ...grpc.CallOption) (*FleetReportResponse, error)
+	DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error)
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	ListMetrics(ctx context.Context, in *ListMetricsRequest, opts ...grpc.CallOption) (*ListMetricsResponse, error)
+	LiquidityCostReport(ctx context.Context, in *LiquidityCostReportRequest, opts ...grpc.CallOption) (*LiquidityCostReportResponse, error)
+	FlowGraph(ctx context.Context, in *FlowGraphRequest, opts ...grpc.CallOption) (*FlowGraphResponse, error)
+}
+
+type faradayServerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFaradayServerClient(cc *grpc.ClientConn) FaradayServerClient {
+	return &faradayServerClient{cc}
+}
+
+func (c *faradayServerClient) OutlierRecommendations(ctx context.Context, in *OutlierRecommendationsRequest, opts ...grpc.CallOption) (*CloseRecommendationsResponse, error) {
+	out := new(CloseRecommendationsResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/OutlierRecommendations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faradayServerClient) ThresholdRecommendations(ctx context.Context, in *ThresholdRecommendationsRequest, opts ...grpc.CallOption) (*CloseRecommendationsResponse, error) {
+	out := new(CloseRecommendationsResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/ThresholdRecommendations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faradayServerClient) RuleRecommendations(ctx context.Context, in *RuleRecommendationsRequest, opts ...grpc.CallOption) (*CloseRecommendationsResponse, error) {
+	out := new(CloseRecommendationsResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/RuleRecommendations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faradayServerClient) RevenueReport(ctx context.Context, in *RevenueReportRequest, opts ...grpc.CallOption) (*RevenueReportResponse, error) {
+	out := new(RevenueReportResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/RevenueReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faradayServerClient) ChannelInsights(ctx context.Context, in *ChannelInsightsRequest, opts ...grpc.CallOption) (*ChannelInsightsResponse, error) {
+	out := new(ChannelInsightsResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/ChannelInsights", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faradayServerClient) CloseChannels(ctx context.Context, in *CloseChannelsRequest, opts ...grpc.CallOption) (*CloseChannelsResponse, error) {
+	out := new(CloseChannelsResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/CloseChannels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faradayServerClient) Backtest(ctx context.Context, in *BacktestRequest, opts ...grpc.CallOption) (*BacktestResponse, error) {
+	out := new(BacktestResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/Backtest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// FaradayServerClient is the client API for FaradayServer service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type FaradayServerClient interface {
-	OutlierRecommendations(ctx context.Context, in *OutlierRecommendationsRequest, opts ...grpc.CallOption) (*CloseRecommendationsResponse, error)
-	ThresholdRecommendations(ctx context.Context, in *ThresholdRecommendationsRequest, opts ...grpc.CallOption) (*CloseRecommendationsResponse, error)
-	RevenueReport(ctx context.Context, in *RevenueReportRequest, opts ...grpc.CallOption) (*RevenueReportResponse, error)
-	ChannelInsights(ctx context.Context, in *ChannelInsightsRequest, opts ...grpc.CallOption) (*ChannelInsightsResponse, error)
+func (c *faradayServerClient) SubscribeChannelInsights(ctx context.Context, in *SubscribeChannelInsightsRequest, opts ...grpc.CallOption) (FaradayServer_SubscribeChannelInsightsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FaradayServer_serviceDesc.Streams[0], "/frdrpc.FaradayServer/SubscribeChannelInsights", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &faradayServerSubscribeChannelInsightsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-type faradayServerClient struct {
-	cc *grpc.ClientConn
+type FaradayServer_SubscribeChannelInsightsClient interface {
+	Recv() (*ChannelInsightsResponse, error)
+	grpc.ClientStream
 }
 
-func NewFaradayServerClient(cc *grpc.ClientConn) FaradayServerClient {
-	return &faradayServerClient{cc}
+type faradayServerSubscribeChannelInsightsClient struct {
+	grpc.ClientStream
 }
 
-func (c *faradayServerClient) OutlierRecommendations(ctx context.Context, in *OutlierRecommendationsRequest, opts ...grpc.CallOption) (*CloseRecommendationsResponse, error) {
-	out := new(CloseRecommendationsResponse)
-	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/OutlierRecommendations", in, out, opts...)
+func (x *faradayServerSubscribeChannelInsightsClient) Recv() (*ChannelInsightsResponse, error) {
+	m := new(ChannelInsightsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *faradayServerClient) SubscribeRecommendations(ctx context.Context, in *SubscribeRecommendationsRequest, opts ...grpc.CallOption) (FaradayServer_SubscribeRecommendationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FaradayServer_serviceDesc.Streams[1], "/frdrpc.FaradayServer/SubscribeRecommendations", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &faradayServerSubscribeRecommendationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FaradayServer_SubscribeRecommendationsClient interface {
+	Recv() (*CloseRecommendationsResponse, error)
+	grpc.ClientStream
+}
+
+type faradayServerSubscribeRecommendationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *faradayServerSubscribeRecommendationsClient) Recv() (*CloseRecommendationsResponse, error) {
+	m := new(CloseRecommendationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *faradayServerClient) FleetReport(ctx context.Context, in *FleetReportRequest, opts ...grpc.CallOption) (*FleetReportResponse, error) {
+	out := new(FleetReportResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/FleetReport", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *faradayServerClient) ThresholdRecommendations(ctx context.Context, in *ThresholdRecommendationsRequest, opts ...grpc.CallOption) (*CloseRecommendationsResponse, error) {
-	out := new(CloseRecommendationsResponse)
-	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/ThresholdRecommendations", in, out, opts...)
+func (c *faradayServerClient) DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error) {
+	out := new(DebugLevelResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/DebugLevel", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *faradayServerClient) RevenueReport(ctx context.Context, in *RevenueReportRequest, opts ...grpc.CallOption) (*RevenueReportResponse, error) {
-	out := new(RevenueReportResponse)
-	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/RevenueReport", in, out, opts...)
+func (c *faradayServerClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/Status", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *faradayServerClient) ChannelInsights(ctx context.Context, in *ChannelInsightsRequest, opts ...grpc.CallOption) (*ChannelInsightsResponse, error) {
-	out := new(ChannelInsightsResponse)
-	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/ChannelInsights", in, out, opts...)
+func (c *faradayServerClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
+	out := new(GetInfoResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/GetInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faradayServerClient) ListMetrics(ctx context.Context, in *ListMetricsRequest, opts ...grpc.CallOption) (*ListMetricsResponse, error) {
+	out := new(ListMetricsResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/ListMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faradayServerClient) LiquidityCostReport(ctx context.Context, in *LiquidityCostReportRequest, opts ...grpc.CallOption) (*LiquidityCostReportResponse, error) {
+	out := new(LiquidityCostReportResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/LiquidityCostReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faradayServerClient) FlowGraph(ctx context.Context, in *FlowGraphRequest, opts ...grpc.CallOption) (*FlowGraphResponse, error) {
+	out := new(FlowGraphResponse)
+	err := c.cc.Invoke(ctx, "/frdrpc.FaradayServer/FlowGraph", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -944,8 +4655,20 @@ func (c *faradayServerClient) ChannelInsights(ctx context.Context, in *ChannelIn
 type FaradayServerServer interface {
 	OutlierRecommendations(context.Context, *OutlierRecommendationsRequest) (*CloseRecommendationsResponse, error)
 	ThresholdRecommendations(context.Context, *ThresholdRecommendationsRequest) (*CloseRecommendationsResponse, error)
+	RuleRecommendations(context.Context, *RuleRecommendationsRequest) (*CloseRecommendationsResponse, error)
 	RevenueReport(context.Context, *RevenueReportRequest) (*RevenueReportResponse, error)
 	ChannelInsights(context.Context, *ChannelInsightsRequest) (*ChannelInsightsResponse, error)
+	CloseChannels(context.Context, *CloseChannelsRequest) (*CloseChannelsResponse, error)
+	Backtest(context.Context, *BacktestRequest) (*BacktestResponse, error)
+	SubscribeChannelInsights(*SubscribeChannelInsightsRequest, FaradayServer_SubscribeChannelInsightsServer) error
+	SubscribeRecommendations(*SubscribeRecommendationsRequest, FaradayServer_SubscribeRecommendationsServer) error
+	FleetReport(context.Context, *FleetReportRequest) (*FleetReportResponse, error)
+	DebugLevel(context.Context, *DebugLevelRequest) (*DebugLevelResponse, error)
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	ListMetrics(context.Context, *ListMetricsRequest) (*ListMetricsResponse, error)
+	LiquidityCostReport(context.Context, *LiquidityCostReportRequest) (*LiquidityCostReportResponse, error)
+	FlowGraph(context.Context, *FlowGraphRequest) (*FlowGraphResponse, error)
 }
 
 func RegisterFaradayServerServer(s *grpc.Server, srv FaradayServerServer) {
@@ -988,6 +4711,24 @@ func _FaradayServer_ThresholdRecommendations_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FaradayServer_RuleRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleRecommendationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaradayServerServer).RuleRecommendations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/frdrpc.FaradayServer/RuleRecommendations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaradayServerServer).RuleRecommendations(ctx, req.(*RuleRecommendationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FaradayServer_RevenueReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RevenueReportRequest)
 	if err := dec(in); err != nil {
@@ -1024,6 +4765,210 @@ func _FaradayServer_ChannelInsights_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FaradayServer_CloseChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaradayServerServer).CloseChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/frdrpc.FaradayServer/CloseChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaradayServerServer).CloseChannels(ctx, req.(*CloseChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaradayServer_Backtest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BacktestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaradayServerServer).Backtest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/frdrpc.FaradayServer/Backtest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaradayServerServer).Backtest(ctx, req.(*BacktestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaradayServer_SubscribeChannelInsights_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeChannelInsightsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FaradayServerServer).SubscribeChannelInsights(m, &faradayServerSubscribeChannelInsightsServer{stream})
+}
+
+type FaradayServer_SubscribeChannelInsightsServer interface {
+	Send(*ChannelInsightsResponse) error
+	grpc.ServerStream
+}
+
+type faradayServerSubscribeChannelInsightsServer struct {
+	grpc.ServerStream
+}
+
+func (x *faradayServerSubscribeChannelInsightsServer) Send(m *ChannelInsightsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FaradayServer_SubscribeRecommendations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRecommendationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FaradayServerServer).SubscribeRecommendations(m, &faradayServerSubscribeRecommendationsServer{stream})
+}
+
+type FaradayServer_SubscribeRecommendationsServer interface {
+	Send(*CloseRecommendationsResponse) error
+	grpc.ServerStream
+}
+
+type faradayServerSubscribeRecommendationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *faradayServerSubscribeRecommendationsServer) Send(m *CloseRecommendationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FaradayServer_FleetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FleetReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaradayServerServer).FleetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/frdrpc.FaradayServer/FleetReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaradayServerServer).FleetReport(ctx, req.(*FleetReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaradayServer_DebugLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaradayServerServer).DebugLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/frdrpc.FaradayServer/DebugLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaradayServerServer).DebugLevel(ctx, req.(*DebugLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaradayServer_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaradayServerServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/frdrpc.FaradayServer/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaradayServerServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaradayServer_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaradayServerServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/frdrpc.FaradayServer/GetInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaradayServerServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaradayServer_ListMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaradayServerServer).ListMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/frdrpc.FaradayServer/ListMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaradayServerServer).ListMetrics(ctx, req.(*ListMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaradayServer_LiquidityCostReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LiquidityCostReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaradayServerServer).LiquidityCostReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/frdrpc.FaradayServer/LiquidityCostReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaradayServerServer).LiquidityCostReport(ctx, req.(*LiquidityCostReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaradayServer_FlowGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlowGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaradayServerServer).FlowGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/frdrpc.FaradayServer/FlowGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaradayServerServer).FlowGraph(ctx, req.(*FlowGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _FaradayServer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "frdrpc.FaradayServer",
 	HandlerType: (*FaradayServerServer)(nil),
@@ -1036,6 +4981,10 @@ var _FaradayServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ThresholdRecommendations",
 			Handler:    _FaradayServer_ThresholdRecommendations_Handler,
 		},
+		{
+			MethodName: "RuleRecommendations",
+			Handler:    _FaradayServer_RuleRecommendations_Handler,
+		},
 		{
 			MethodName: "RevenueReport",
 			Handler:    _FaradayServer_RevenueReport_Handler,
@@ -1044,7 +4993,54 @@ var _FaradayServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ChannelInsights",
 			Handler:    _FaradayServer_ChannelInsights_Handler,
 		},
+		{
+			MethodName: "CloseChannels",
+			Handler:    _FaradayServer_CloseChannels_Handler,
+		},
+		{
+			MethodName: "Backtest",
+			Handler:    _FaradayServer_Backtest_Handler,
+		},
+		{
+			MethodName: "FleetReport",
+			Handler:    _FaradayServer_FleetReport_Handler,
+		},
+		{
+			MethodName: "DebugLevel",
+			Handler:    _FaradayServer_DebugLevel_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _FaradayServer_Status_Handler,
+		},
+		{
+			MethodName: "GetInfo",
+			Handler:    _FaradayServer_GetInfo_Handler,
+		},
+		{
+			MethodName: "ListMetrics",
+			Handler:    _FaradayServer_ListMetrics_Handler,
+		},
+		{
+			MethodName: "LiquidityCostReport",
+			Handler:    _FaradayServer_LiquidityCostReport_Handler,
+		},
+		{
+			MethodName: "FlowGraph",
+			Handler:    _FaradayServer_FlowGraph_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeChannelInsights",
+			Handler:       _FaradayServer_SubscribeChannelInsights_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeRecommendations",
+			Handler:       _FaradayServer_SubscribeRecommendations_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
 }
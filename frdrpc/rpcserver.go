@@ -11,17 +11,63 @@ package frdrpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/lightninglabs/faraday/backtest"
+	"github.com/lightninglabs/faraday/closer"
+	"github.com/lightninglabs/faraday/fiat"
+	"github.com/lightninglabs/faraday/liquidity"
 	"github.com/lightninglabs/faraday/recommend"
 	"github.com/lightninglabs/faraday/revenue"
+	"github.com/lightninglabs/faraday/rules"
+	"github.com/lightninglabs/faraday/supervisor"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"google.golang.org/grpc"
 )
 
+var (
+	// ErrUnknownNode is returned when a request names a node that faraday
+	// is not configured with.
+	ErrUnknownNode = errors.New("unknown node")
+
+	// ErrDebugLevelUnsupported is returned when debug levels are changed
+	// on a server which was not configured to allow it.
+	ErrDebugLevelUnsupported = errors.New("debug levels cannot be " +
+		"changed on this server")
+
+	// ErrFiatUnavailable is returned when fees are requested in fiat from
+	// a server which was not configured with a price source.
+	ErrFiatUnavailable = errors.New("faraday is not configured with a " +
+		"fiat price source")
+
+	// ErrLoopUnavailable is returned when liquidity costs are requested
+	// for a node that faraday does not have a loopd connection for.
+	ErrLoopUnavailable = errors.New("faraday is not connected to loopd")
+
+	// ErrCloseDisabled is returned when channel closes are requested from
+	// a server which was not configured to allow them.
+	ErrCloseDisabled = errors.New("channel closes are disabled, start " +
+		"faraday with --allowclose to enable them")
+
+	// ErrPeriodsNoStart is returned when a revenue report is split into
+	// periods without a start time.
+	ErrPeriodsNoStart = errors.New("a start time is required to split " +
+		"a revenue report into periods")
+
+	// ErrTooManyPeriods is returned when a revenue report is split into
+	// more periods than we allow.
+	ErrTooManyPeriods = fmt.Errorf("revenue reports can be split into "+
+		"at most %v periods", maxRevenuePeriods)
+)
+
 // RPCServer implements the faraday service, serving requests over grpc.
 type RPCServer struct {
 	// To be used atomically.
@@ -45,12 +91,221 @@ type RPCServer struct {
 
 // Config provides closures and settings required to run the rpc server.
 type Config struct {
+	// Version is the semantic version of the faraday daemon.
+	Version string
+
+	// Commit is the commit that the faraday daemon was built from.
+	Commit string
+
+	// StartTime is the time that the faraday daemon was started.
+	StartTime time.Time
+
+	// Offline indicates that the server is running against a snapshot of
+	// a node rather than a live lnd node.
+	Offline bool
+
+	// Now is an optional function which returns the time that reports and
+	// recommendations are produced as of. Offline servers set it to the
+	// time of their snapshot. If it is not set, time.Now is used.
+	Now func() time.Time
+
 	// LightningClient is a client which can be used to query lnd.
 	LightningClient lnrpc.LightningClient
 
 	// RPCListen is the address:port that the rpc server should listen
 	// on.
 	RPCListen string
+
+	// RPCListener is an optional listener that the rpc server should
+	// serve on. If it is set, RPCListen is ignored. This allows the
+	// server to be run on in-memory listeners in tests.
+	RPCListener net.Listener
+
+	// CloseAudit records the outcome of each channel close that faraday
+	// attempts.
+	CloseAudit func(*closer.AuditEntry) error
+
+	// AllowClose indicates that channels may be closed over rpc. Our rpc
+	// server is not authenticated, so closes must be explicitly enabled.
+	AllowClose bool
+
+	// ChannelUptime is an optional function which returns the uptime
+	// history that faraday has recorded for a channel's peer.
+	ChannelUptime func(chanPoint string) (time.Duration, time.Duration,
+		error)
+
+	// ChannelBalance is an optional function which returns the balance
+	// history that faraday has recorded for a channel.
+	ChannelBalance func(chanPoint string) (time.Duration, time.Duration,
+		error)
+
+	// SetDebugLevel is an optional function which sets faraday's debug
+	// levels from a level spec. If it is not set, debug levels cannot be
+	// changed at runtime.
+	SetDebugLevel func(levelSpec string) error
+
+	// SubSystems is an optional function which returns the subsystems that
+	// faraday logs for.
+	SubSystems func() []string
+
+	// OpportunityCostRate is the default annual rate of return used to
+	// calculate the opportunity cost of channel capital when requests do
+	// not provide one.
+	OpportunityCostRate float64
+
+	// Rules is an optional set of close recommendation rules which are
+	// used for rule based recommendations when requests do not provide
+	// their own.
+	Rules []*rules.Rule
+
+	// FiatPrices is an optional source of bitcoin prices which is used to
+	// value fees in fiat currencies. If it is not set, fiat values cannot
+	// be requested.
+	FiatPrices fiat.PriceSource
+
+	// ListSwaps is an optional function which lists the swaps that loopd
+	// has made for our node. If it is set, the cost of the swaps is
+	// included in channel insights and liquidity cost reports can be
+	// requested.
+	ListSwaps func() ([]*liquidity.Swap, error)
+
+	// nodeName is the name of the node that a config serves requests
+	// for. It is set when a config is obtained for a node with
+	// nodeConfig.
+	nodeName string
+
+	// Nodes is an optional set of named lnd nodes that faraday serves
+	// requests for. If it is set, requests are served by the node named
+	// in the request, or by the first node if no name is provided, and
+	// LightningClient, ChannelUptime, ChannelBalance and ListSwaps are
+	// ignored.
+	Nodes []*Node
+}
+
+// Node is a named lnd node that faraday serves requests for.
+type Node struct {
+	// Name is the name that requests use to select the node.
+	Name string
+
+	// LightningClient is a client which can be used to query the node.
+	LightningClient lnrpc.LightningClient
+
+	// ChannelUptime is an optional function which returns the uptime
+	// history that faraday has recorded for a channel's peer on this
+	// node.
+	ChannelUptime func(chanPoint string) (time.Duration, time.Duration,
+		error)
+
+	// ChannelBalance is an optional function which returns the balance
+	// history that faraday has recorded for a channel on this node.
+	ChannelBalance func(chanPoint string) (time.Duration, time.Duration,
+		error)
+
+	// ListSwaps is an optional function which lists the swaps that loopd
+	// has made for this node.
+	ListSwaps func() ([]*liquidity.Swap, error)
+
+	// Status is an optional function which returns the status of our
+	// connection to the node. If it is not set, the connection is not
+	// supervised and its status is unknown.
+	Status func() supervisor.Status
+
+	// pubkey is the node's identity public key, which is cached once it
+	// has been obtained from the node because it does not change.
+	pubkey    string
+	pubkeyMtx sync.Mutex
+}
+
+// getPubkey returns the node's identity public key, querying the node if we
+// have not yet obtained it.
+func (n *Node) getPubkey(ctx context.Context) (string, error) {
+	n.pubkeyMtx.Lock()
+	defer n.pubkeyMtx.Unlock()
+
+	if n.pubkey != "" {
+		return n.pubkey, nil
+	}
+
+	info, err := n.LightningClient.GetInfo(ctx, &lnrpc.GetInfoRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	n.pubkey = info.IdentityPubkey
+
+	return n.pubkey, nil
+}
+
+// nodeConfig returns a copy of our config which serves requests for the node
+// with the name provided. If no name is provided, the first node is used. If
+// no nodes are configured, our config is returned as is.
+func (c *Config) nodeConfig(name string) (*Config, error) {
+	if len(c.Nodes) == 0 {
+		if name != "" {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownNode, name)
+		}
+
+		return c, nil
+	}
+
+	node := c.Nodes[0]
+	if name != "" {
+		node = nil
+		for _, n := range c.Nodes {
+			if n.Name == name {
+				node = n
+				break
+			}
+		}
+
+		if node == nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownNode, name)
+		}
+	}
+
+	nodeCfg := *c
+	nodeCfg.nodeName = node.Name
+	nodeCfg.LightningClient = node.LightningClient
+	nodeCfg.ChannelUptime = node.ChannelUptime
+	nodeCfg.ChannelBalance = node.ChannelBalance
+	nodeCfg.ListSwaps = node.ListSwaps
+
+	return &nodeCfg, nil
+}
+
+// now returns the time that reports and recommendations are produced as of.
+func (c *Config) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+
+	return time.Now()
+}
+
+// internalPeers returns the set of public keys of the nodes that we are
+// configured with, so that channels between them can be identified. Nodes
+// whose public key cannot be obtained are skipped, so that one node being
+// unavailable does not prevent us from serving requests for the others.
+func (c *Config) internalPeers(ctx context.Context) map[string]bool {
+	if len(c.Nodes) < 2 {
+		return nil
+	}
+
+	peers := make(map[string]bool, len(c.Nodes))
+	for _, node := range c.Nodes {
+		pubkey, err := node.getPubkey(ctx)
+		if err != nil {
+			log.Warnf("Could not get public key for node %v, "+
+				"its channels will not be marked as internal: "+
+				"%v", node.Name, err)
+
+			continue
+		}
+
+		peers[pubkey] = true
+	}
+
+	return peers
 }
 
 // wrapListChannels wraps the listchannels call to lnd, with a publicOnly bool
@@ -73,6 +328,80 @@ func (c *Config) wrapListChannels(ctx context.Context,
 	}
 }
 
+// wrapClosedChannels wraps the closedchannels call to lnd.
+func (c *Config) wrapClosedChannels(
+	ctx context.Context) func() ([]*lnrpc.ChannelCloseSummary, error) {
+
+	return func() ([]*lnrpc.ChannelCloseSummary, error) {
+		resp, err := c.LightningClient.ClosedChannels(
+			ctx, &lnrpc.ClosedChannelsRequest{},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Channels, nil
+	}
+}
+
+// wrapHeightAt returns a function which estimates the block height at a
+// time. The chain's current height is only queried from lnd the first time
+// that the function is called.
+func (c *Config) wrapHeightAt(
+	ctx context.Context) func(time.Time) (uint32, error) {
+
+	var chain *backtest.ChainTime
+	return func(t time.Time) (uint32, error) {
+		if chain == nil {
+			var err error
+			chain, err = getChainTime(ctx, c)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		return chain.HeightAt(t), nil
+	}
+}
+
+// wrapGetChanInfo wraps the getchaninfo call to lnd, returning the outpoint
+// of the channel requested.
+func (c *Config) wrapGetChanInfo(
+	ctx context.Context) func(lnwire.ShortChannelID) (string, error) {
+
+	return func(chanID lnwire.ShortChannelID) (string, error) {
+		edge, err := c.LightningClient.GetChanInfo(
+			ctx, &lnrpc.ChanInfoRequest{
+				ChanId: chanID.ToUint64(),
+			},
+		)
+		if err != nil {
+			return "", err
+		}
+
+		return edge.ChanPoint, nil
+	}
+}
+
+// wrapGetAlias wraps the getnodeinfo call to lnd, returning the alias of the
+// node requested.
+func (c *Config) wrapGetAlias(
+	ctx context.Context) func(pubkey string) (string, error) {
+
+	return func(pubkey string) (string, error) {
+		info, err := c.LightningClient.GetNodeInfo(
+			ctx, &lnrpc.NodeInfoRequest{
+				PubKey: pubkey,
+			},
+		)
+		if err != nil {
+			return "", err
+		}
+
+		return info.GetNode().GetAlias(), nil
+	}
+}
+
 // NewRPCServer returns a server which will listen for rpc requests on the
 // rpc listen address provided. Note that the server returned is not running,
 // and should be started using Start().
@@ -94,11 +423,14 @@ func (s *RPCServer) Start() error {
 
 	// Start the gRPC RPCServer listening for HTTP/2 connections.
 	log.Info("Starting gRPC listener")
-	grpcListener, err := net.Listen("tcp", s.cfg.RPCListen)
-	if err != nil {
-		return fmt.Errorf("RPC RPCServer unable to listen on %v",
-			s.cfg.RPCListen)
-
+	grpcListener := s.cfg.RPCListener
+	if grpcListener == nil {
+		var err error
+		grpcListener, err = net.Listen("tcp", s.cfg.RPCListen)
+		if err != nil {
+			return fmt.Errorf("RPC RPCServer unable to listen on "+
+				"%v", s.cfg.RPCListen)
+		}
 	}
 	s.rpcListener = grpcListener
 
@@ -134,7 +466,12 @@ func (s *RPCServer) OutlierRecommendations(ctx context.Context,
 	req *OutlierRecommendationsRequest) (*CloseRecommendationsResponse,
 	error) {
 
-	cfg, multiplier := parseOutlierRequest(ctx, s.cfg, req)
+	nodeCfg, err := s.cfg.nodeConfig(req.GetRecRequest().GetNode())
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, multiplier := parseOutlierRequest(ctx, nodeCfg, req)
 
 	report, err := recommend.OutlierRecommendations(cfg, multiplier)
 	if err != nil {
@@ -151,7 +488,12 @@ func (s *RPCServer) ThresholdRecommendations(ctx context.Context,
 	req *ThresholdRecommendationsRequest) (*CloseRecommendationsResponse,
 	error) {
 
-	cfg, threshold := parseThresholdRequest(ctx, s.cfg, req)
+	nodeCfg, err := s.cfg.nodeConfig(req.GetRecRequest().GetNode())
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, threshold := parseThresholdRequest(ctx, nodeCfg, req)
 
 	report, err := recommend.ThresholdRecommendations(cfg, threshold)
 	if err != nil {
@@ -161,19 +503,53 @@ func (s *RPCServer) ThresholdRecommendations(ctx context.Context,
 	return rpcResponse(report), nil
 }
 
+// RuleRecommendations returns a set of close recommendations for the current
+// set of open channels based on a set of rules.
+func (s *RPCServer) RuleRecommendations(ctx context.Context,
+	req *RuleRecommendationsRequest) (*CloseRecommendationsResponse,
+	error) {
+
+	nodeCfg, err := s.cfg.nodeConfig(req.GetRecRequest().GetNode())
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, closeRules, err := parseRuleRequest(ctx, nodeCfg, req)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := recommend.RuleRecommendations(cfg, closeRules)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpcResponse(report), nil
+}
+
 // RevenueReport returns a pairwise revenue report for a channel
 // over the period requested.
 func (s *RPCServer) RevenueReport(ctx context.Context,
 	req *RevenueReportRequest) (*RevenueReportResponse, error) {
 
-	revenueConfig := parseRevenueRequest(ctx, s.cfg, req)
+	nodeCfg, err := s.cfg.nodeConfig(req.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	revenueConfig, err := parseRevenueRequest(ctx, nodeCfg, req)
+	if err != nil {
+		return nil, err
+	}
 
 	report, err := revenue.GetRevenueReport(revenueConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	return rpcRevenueResponse(req.GetChanPoints(), report)
+	return rpcRevenueResponse(
+		req.GetChanPoints(), report, req.FiatCurrency,
+	)
 }
 
 // ChannelInsights returns the channel insights for our currently open set
@@ -181,10 +557,251 @@ func (s *RPCServer) RevenueReport(ctx context.Context,
 func (s *RPCServer) ChannelInsights(ctx context.Context,
 	req *ChannelInsightsRequest) (*ChannelInsightsResponse, error) {
 
-	insights, err := channelInsights(ctx, s.cfg)
+	nodeCfg, err := s.cfg.nodeConfig(req.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	params := newInsightsParams(
+		req.LookbackSeconds, req.DecayHalfLifeSeconds,
+	)
+
+	insights, err := channelInsights(ctx, nodeCfg, params)
 	if err != nil {
 		return nil, err
 	}
 
 	return rpcChannelInsightsResponse(insights), nil
 }
+
+// CloseChannels closes the set of channels requested, checking that each
+// channel is still open and not pending before closing it. Channels are only
+// closed if the request is confirmed, and the server is configured to allow
+// closes.
+func (s *RPCServer) CloseChannels(ctx context.Context,
+	req *CloseChannelsRequest) (*CloseChannelsResponse, error) {
+
+	if !s.cfg.AllowClose {
+		return nil, ErrCloseDisabled
+	}
+
+	nodeCfg, err := s.cfg.nodeConfig(req.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, closeReq := parseCloseChannelsRequest(ctx, nodeCfg, req)
+
+	results, err := closer.CloseChannels(cfg, closeReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpcCloseChannelsResponse(results), nil
+}
+
+// Backtest runs a set of close recommendation strategies as of a date in the
+// past and reports on the revenue that flagged and kept channels earned
+// afterwards.
+func (s *RPCServer) Backtest(ctx context.Context,
+	req *BacktestRequest) (*BacktestResponse, error) {
+
+	nodeCfg, err := s.cfg.nodeConfig(req.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, btReq, err := parseBacktestRequest(ctx, nodeCfg, req)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := backtest.Run(cfg, btReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpcBacktestResponse(req, report), nil
+}
+
+// SubscribeChannelInsights streams channel insights for our currently open
+// set of channels. Insights are sent when the subscription starts, and then
+// each time a channel event, new forward or the optional update interval
+// changes them.
+func (s *RPCServer) SubscribeChannelInsights(
+	req *SubscribeChannelInsightsRequest,
+	stream FaradayServer_SubscribeChannelInsightsServer) error {
+
+	ctx := stream.Context()
+
+	insightsReq := req.GetRequest()
+	nodeCfg, err := s.cfg.nodeConfig(insightsReq.GetNode())
+	if err != nil {
+		return err
+	}
+
+	cache := newInsightsCache(nodeCfg, newInsightsParams(
+		insightsReq.GetLookbackSeconds(),
+		insightsReq.GetDecayHalfLifeSeconds(),
+	))
+
+	return runSubscription(
+		ctx, nodeCfg, req.IntervalSeconds,
+		func() (proto.Message, error) {
+			insights, err := cache.channelInsights(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return rpcChannelInsightsResponse(insights), nil
+		},
+		stableInsights,
+		func(resp proto.Message) error {
+			return stream.Send(resp.(*ChannelInsightsResponse))
+		},
+	)
+}
+
+// SubscribeRecommendations streams close recommendations for our currently
+// open set of channels. Recommendations are sent when the subscription
+// starts, and then each time a channel event, new forward or the optional
+// update interval changes them.
+func (s *RPCServer) SubscribeRecommendations(
+	req *SubscribeRecommendationsRequest,
+	stream FaradayServer_SubscribeRecommendationsServer) error {
+
+	ctx := stream.Context()
+
+	nodeCfg, getReport, err := getRecommendationFunc(ctx, s.cfg, req)
+	if err != nil {
+		return err
+	}
+
+	return runSubscription(
+		ctx, nodeCfg, req.IntervalSeconds,
+		func() (proto.Message, error) {
+			report, err := getReport()
+			if err != nil {
+				return nil, err
+			}
+
+			return rpcResponse(report), nil
+		},
+		stableRecommendations,
+		func(resp proto.Message) error {
+			return stream.Send(resp.(*CloseRecommendationsResponse))
+		},
+	)
+}
+
+// FleetReport returns channel insights for each of the nodes that faraday is
+// configured with, along with totals per node and across the fleet. Channels
+// between our own nodes are marked as internal.
+func (s *RPCServer) FleetReport(ctx context.Context,
+	req *FleetReportRequest) (*FleetReportResponse, error) {
+
+	params := newInsightsParams(
+		req.LookbackSeconds, req.DecayHalfLifeSeconds,
+	)
+
+	return fleetReport(ctx, s.cfg, params)
+}
+
+// DebugLevel changes faraday's debug levels at runtime, or lists the
+// subsystems that are available if show is set.
+func (s *RPCServer) DebugLevel(ctx context.Context,
+	req *DebugLevelRequest) (*DebugLevelResponse, error) {
+
+	if s.cfg.SetDebugLevel == nil || s.cfg.SubSystems == nil {
+		return nil, ErrDebugLevelUnsupported
+	}
+
+	if req.Show {
+		return &DebugLevelResponse{
+			SubSystems: strings.Join(s.cfg.SubSystems(), " "),
+		}, nil
+	}
+
+	log.Infof("Changing debug level to: %v", req.LevelSpec)
+
+	if err := s.cfg.SetDebugLevel(req.LevelSpec); err != nil {
+		return nil, err
+	}
+
+	return &DebugLevelResponse{}, nil
+}
+
+// Status returns the status of faraday's connection to each of its lnd
+// nodes.
+func (s *RPCServer) Status(ctx context.Context,
+	req *StatusRequest) (*StatusResponse, error) {
+
+	return rpcStatusResponse(s.cfg), nil
+}
+
+// GetInfo returns the version of the faraday daemon, information about each
+// of the lnd nodes it is connected to and the optional features that are
+// enabled.
+func (s *RPCServer) GetInfo(ctx context.Context,
+	req *GetInfoRequest) (*GetInfoResponse, error) {
+
+	return getInfo(ctx, s.cfg, time.Now()), nil
+}
+
+// ListMetrics returns the metrics that close recommendations can be based
+// on.
+func (s *RPCServer) ListMetrics(ctx context.Context,
+	req *ListMetricsRequest) (*ListMetricsResponse, error) {
+
+	return listMetrics(), nil
+}
+
+// LiquidityCostReport returns the cost of the loop swaps that our node made
+// over the period requested, attributed to the channels they rebalanced.
+func (s *RPCServer) LiquidityCostReport(ctx context.Context,
+	req *LiquidityCostReportRequest) (*LiquidityCostReportResponse,
+	error) {
+
+	nodeCfg, err := s.cfg.nodeConfig(req.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := parseLiquidityCostRequest(ctx, nodeCfg, req)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := liquidity.GetReport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpcLiquidityCostResponse(report), nil
+}
+
+// FlowGraph returns a directed graph of the flow of forwards between our
+// channels, or between our peers if channels are merged by peer, over the
+// period requested.
+func (s *RPCServer) FlowGraph(ctx context.Context,
+	req *FlowGraphRequest) (*FlowGraphResponse, error) {
+
+	switch req.Format {
+	case FlowGraphRequest_JSON, FlowGraphRequest_DOT:
+
+	default:
+		return nil, fmt.Errorf("unknown format: %v", req.Format)
+	}
+
+	nodeCfg, err := s.cfg.nodeConfig(req.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	graph, err := getFlowGraph(ctx, nodeCfg, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpcFlowGraphResponse(graph, req.Format), nil
+}
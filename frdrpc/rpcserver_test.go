@@ -0,0 +1,1497 @@
+package frdrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lightninglabs/faraday/fakelnd"
+	"github.com/lightninglabs/faraday/fiat"
+	"github.com/lightninglabs/faraday/liquidity"
+	"github.com/lightninglabs/faraday/recommend"
+	"github.com/lightninglabs/faraday/supervisor"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// bufSize is the size of the in-memory buffer used by our test listener.
+const bufSize = 1024 * 1024
+
+// testHeight is the block height that our fake lnd node reports.
+const testHeight = 1000
+
+// testChannel describes a channel that is served by our fake lnd node.
+type testChannel struct {
+	chanPoint string
+	height    uint32
+	lifetime  int64
+	uptime    int64
+}
+
+// chanID returns the short channel id for a test channel.
+func (c testChannel) chanID() uint64 {
+	return lnwire.ShortChannelID{
+		BlockHeight: c.height,
+	}.ToUint64()
+}
+
+var (
+	// testChannels is the set of channels that our fake node has open.
+	// The peer of the channel at index 4 has been offline for most of the
+	// time it has been monitored.
+	testChannels = []testChannel{
+		{chanPoint: "a:0", height: 901, lifetime: 1000, uptime: 1000},
+		{chanPoint: "a:1", height: 902, lifetime: 1000, uptime: 1000},
+		{chanPoint: "a:2", height: 903, lifetime: 1000, uptime: 990},
+		{chanPoint: "a:3", height: 904, lifetime: 1000, uptime: 1000},
+		{chanPoint: "a:4", height: 905, lifetime: 1000, uptime: 100},
+		{chanPoint: "a:5", height: 906, lifetime: 1000, uptime: 1000},
+	}
+
+	// testClosedChannel is a channel that our node has closed.
+	testClosedChannel = testChannel{chanPoint: "b:0", height: 800}
+)
+
+// newTestClient returns a fake lnd client which serves our test channels
+// and a forwarding log with a forward from our first channel to our second,
+// and another from our closed channel to our first.
+func newTestClient() *fakelnd.Client {
+	client := fakelnd.NewClient()
+	client.SetInfo(&lnrpc.GetInfoResponse{
+		BlockHeight: testHeight,
+	})
+
+	var channels []*lnrpc.Channel
+	for _, c := range testChannels {
+		channels = append(channels, &lnrpc.Channel{
+			ChannelPoint: c.chanPoint,
+			ChanId:       c.chanID(),
+			Lifetime:     c.lifetime,
+			Uptime:       c.uptime,
+		})
+	}
+	client.SetChannels(channels...)
+
+	client.SetClosedChannels(&lnrpc.ChannelCloseSummary{
+		ChannelPoint: testClosedChannel.chanPoint,
+		ChanId:       testClosedChannel.chanID(),
+	})
+
+	client.AddForwards(
+		&lnrpc.ForwardingEvent{
+			Timestamp:  100,
+			ChanIdIn:   testChannels[0].chanID(),
+			ChanIdOut:  testChannels[1].chanID(),
+			AmtInMsat:  2000,
+			AmtOutMsat: 1000,
+		},
+		&lnrpc.ForwardingEvent{
+			Timestamp:  200,
+			ChanIdIn:   testClosedChannel.chanID(),
+			ChanIdOut:  testChannels[0].chanID(),
+			AmtInMsat:  6000,
+			AmtOutMsat: 4000,
+		},
+	)
+
+	return client
+}
+
+// startTestServer starts a rpc server which queries the client provided on
+// an in-memory listener, and returns a client connected to it and a cleanup
+// function.
+func startTestServer(t *testing.T,
+	lnd lnrpc.LightningClient) (FaradayServerClient, func()) {
+
+	return startConfigServer(t, &Config{
+		LightningClient: lnd,
+	})
+}
+
+// startConfigServer starts a rpc server with the config provided on an
+// in-memory listener, and returns a client connected to it and a cleanup
+// function.
+func startConfigServer(t *testing.T, cfg *Config) (FaradayServerClient,
+	func()) {
+
+	listener := bufconn.Listen(bufSize)
+	cfg.RPCListener = listener
+
+	server := NewRPCServer(cfg)
+	if err := server.Start(); err != nil {
+		t.Fatalf("could not start server: %v", err)
+	}
+
+	dialer := func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}
+
+	conn, err := grpc.Dial(
+		"bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatalf("could not dial server: %v", err)
+	}
+
+	cleanup := func() {
+		if err := conn.Close(); err != nil {
+			t.Errorf("could not close connection: %v", err)
+		}
+
+		if err := server.Stop(); err != nil {
+			t.Errorf("could not stop server: %v", err)
+		}
+	}
+
+	return NewFaradayServerClient(conn), cleanup
+}
+
+// singleForward returns the forward stats for a single forward of the size
+// and effective fee rate provided.
+func singleForward(size int64, feeRate float64) *ForwardStats {
+	return &ForwardStats{
+		Count:          1,
+		MinSizeMsat:    size,
+		MedianSizeMsat: size,
+		P90SizeMsat:    size,
+		MaxSizeMsat:    size,
+		FeeRatePpm:     feeRate,
+	}
+}
+
+// assertResponse fails the test if the response received does not equal the
+// response expected.
+func assertResponse(t *testing.T, expected, actual proto.Message) {
+	t.Helper()
+
+	if !proto.Equal(expected, actual) {
+		t.Fatalf("expected: %v\ngot: %v", expected, actual)
+	}
+}
+
+// TestChannelInsights tests getting channel insights over rpc.
+func TestChannelInsights(t *testing.T) {
+	client, cleanup := startTestServer(t, newTestClient())
+	defer cleanup()
+
+	resp, err := client.ChannelInsights(
+		context.Background(), &ChannelInsightsRequest{},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &ChannelInsightsResponse{}
+	for _, c := range testChannels {
+		expected.ChannelInsights = append(
+			expected.ChannelInsights, &ChannelInsight{
+				ChanPoint:        c.chanPoint,
+				MonitoredSeconds: uint64(c.lifetime),
+				UptimeSeconds:    uint64(c.uptime),
+				Confirmations:    testHeight + 1 - c.height,
+				RevenueBlocks: float64(
+					testHeight + 1 - c.height,
+				),
+			},
+		)
+	}
+
+	// Our first channel received 2000 msat to forward to our second
+	// channel, and sent 4000 msat that arrived on our closed channel. It
+	// is attributed half of the fees for both forwards.
+	expected.ChannelInsights[0].VolumeIncomingMsat = 2000
+	expected.ChannelInsights[0].VolumeOutgoingMsat = 4000
+	expected.ChannelInsights[0].FeesEarnedMsat = 1500
+	expected.ChannelInsights[0].NetFeesMsat = 1500
+	expected.ChannelInsights[0].IncomingForwards = singleForward(2000, 1e6)
+	expected.ChannelInsights[0].OutgoingForwards = singleForward(
+		4000, 5e5,
+	)
+
+	expected.ChannelInsights[1].VolumeOutgoingMsat = 1000
+	expected.ChannelInsights[1].FeesEarnedMsat = 500
+	expected.ChannelInsights[1].NetFeesMsat = 500
+	expected.ChannelInsights[1].OutgoingForwards = singleForward(1000, 1e6)
+
+	assertResponse(t, expected, resp)
+}
+
+// TestChannelInsightsNow tests that lookback periods are applied relative to
+// the time that our server is configured with, as they are when we are
+// running against a snapshot.
+func TestChannelInsightsNow(t *testing.T) {
+	client, cleanup := startConfigServer(t, &Config{
+		LightningClient: newTestClient(),
+		Now: func() time.Time {
+			return time.Unix(250, 0)
+		},
+	})
+	defer cleanup()
+
+	resp, err := client.ChannelInsights(
+		context.Background(), &ChannelInsightsRequest{
+			LookbackSeconds: 100,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only our second forward is within our lookback period, so our
+	// first channel should be attributed half of its fees, and our second
+	// channel should have no revenue.
+	fees := []int64{
+		resp.ChannelInsights[0].FeesEarnedMsat,
+		resp.ChannelInsights[1].FeesEarnedMsat,
+	}
+	if fees[0] != 1000 || fees[1] != 0 {
+		t.Fatalf("expected fees [1000 0], got: %v", fees)
+	}
+}
+
+// forwardRecorder wraps a fake lnd client and records the forwarding history
+// requests that it receives.
+type forwardRecorder struct {
+	*fakelnd.Client
+
+	requests []*lnrpc.ForwardingHistoryRequest
+}
+
+// ForwardingHistory records the request provided and passes it on to our
+// fake client.
+func (f *forwardRecorder) ForwardingHistory(ctx context.Context,
+	req *lnrpc.ForwardingHistoryRequest, opts ...grpc.CallOption) (
+	*lnrpc.ForwardingHistoryResponse, error) {
+
+	f.requests = append(f.requests, req)
+	return f.Client.ForwardingHistory(ctx, req, opts...)
+}
+
+// TestInsightsCache tests that subscriptions only read forwards since their
+// last update from lnd, and include cached forwards in their insights.
+func TestInsightsCache(t *testing.T) {
+	ctx := context.Background()
+
+	lnd := &forwardRecorder{Client: newTestClient()}
+	cache := newInsightsCache(
+		&Config{LightningClient: lnd}, newInsightsParams(0, 0),
+	)
+
+	// fees returns the fees earned by our second channel.
+	fees := func() lnwire.MilliSatoshi {
+		insights, err := cache.channelInsights(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return insights[1].FeesEarned
+	}
+
+	if fee := fees(); fee != 500 {
+		t.Fatalf("expected 500 msat fees, got: %v", fee)
+	}
+
+	// Add a forward from our second channel that happened after our
+	// first read.
+	lnd.AddForwards(&lnrpc.ForwardingEvent{
+		Timestamp:  lnd.requests[0].EndTime + 1,
+		ChanIdIn:   testChannels[1].chanID(),
+		ChanIdOut:  testChannels[0].chanID(),
+		AmtInMsat:  3000,
+		AmtOutMsat: 2000,
+	})
+
+	// Wait until the second that our forward happened in has passed, so
+	// that our next read includes it.
+	time.Sleep(time.Until(
+		time.Unix(int64(lnd.requests[0].EndTime)+2, 0),
+	))
+
+	if fee := fees(); fee != 1000 {
+		t.Fatalf("expected 1000 msat fees, got: %v", fee)
+	}
+
+	if len(lnd.requests) != 2 {
+		t.Fatalf("expected 2 forwarding history requests, got: %v",
+			len(lnd.requests))
+	}
+
+	if lnd.requests[0].StartTime != 0 {
+		t.Fatalf("expected first read from zero, got: %v",
+			lnd.requests[0].StartTime)
+	}
+
+	if lnd.requests[1].StartTime != lnd.requests[0].EndTime+1 {
+		t.Fatalf("expected second read from: %v, got: %v",
+			lnd.requests[0].EndTime+1, lnd.requests[1].StartTime)
+	}
+}
+
+// TestSubscribeIntervalTooShort tests that subscriptions with an update
+// interval shorter than our minimum are rejected.
+func TestSubscribeIntervalTooShort(t *testing.T) {
+	client, cleanup := startTestServer(t, newTestClient())
+	defer cleanup()
+
+	stream, err := client.SubscribeChannelInsights(
+		context.Background(), &SubscribeChannelInsightsRequest{
+			Request:         &ChannelInsightsRequest{},
+			IntervalSeconds: 1,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := stream.Recv(); err == nil {
+		t.Fatalf("expected error for short interval")
+	}
+}
+
+// TestRevenueReport tests getting a revenue report over rpc.
+func TestRevenueReport(t *testing.T) {
+	client, cleanup := startTestServer(t, newTestClient())
+	defer cleanup()
+
+	// Request a report for our first channel that only covers our first
+	// forward.
+	resp, err := client.RevenueReport(
+		context.Background(), &RevenueReportRequest{
+			ChanPoints: []string{testChannels[0].chanPoint},
+			StartTime:  50,
+			EndTime:    150,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &RevenueReportResponse{
+		Reports: []*RevenueReport{
+			{
+				TargetChannel: testChannels[0].chanPoint,
+				PairReports: map[string]*PairReport{
+					testChannels[1].chanPoint: {
+						AmountIncomingMsat: 2000,
+						FeesIncomingMsat:   1000,
+					},
+				},
+				IncomingForwards: singleForward(2000, 1e6),
+			},
+		},
+		TotalFeesMsat: 1000,
+	}
+
+	assertResponse(t, expected, resp)
+
+	// Split a report over both of our forwards into periods. Our second
+	// forward is at our end time, so it is included in our last period.
+	resp, err = client.RevenueReport(
+		context.Background(), &RevenueReportRequest{
+			StartTime:     50,
+			EndTime:       200,
+			PeriodSeconds: 100,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedPeriods := []*RevenuePeriod{
+		{
+			StartTime:  50,
+			EndTime:    150,
+			Forwards:   1,
+			FeesMsat:   1000,
+			VolumeMsat: 1000,
+		},
+		{
+			StartTime:  150,
+			EndTime:    200,
+			Forwards:   1,
+			FeesMsat:   2000,
+			VolumeMsat: 4000,
+		},
+	}
+
+	if len(resp.Periods) != len(expectedPeriods) {
+		t.Fatalf("expected: %v periods, got: %v",
+			len(expectedPeriods), len(resp.Periods))
+	}
+
+	for i, period := range expectedPeriods {
+		assertResponse(t, period, resp.Periods[i])
+	}
+
+	// Periods cannot be requested without a start time.
+	_, err = client.RevenueReport(
+		context.Background(), &RevenueReportRequest{
+			PeriodSeconds: 100,
+		},
+	)
+	if err == nil {
+		t.Fatalf("expected error without start time")
+	}
+}
+
+// TestRevenueReportUnattributed tests getting a revenue report with forwards
+// over channels that are not in our channel lists. Channels that are found in
+// the graph are attributed, and the remainder are reported as unattributed.
+// If only one side of a forward cannot be found, the other side is still
+// attributed to its channel.
+func TestRevenueReportUnattributed(t *testing.T) {
+	// forgotten is a channel that lnd no longer lists, but which is still
+	// in the graph, and unknown is a channel which cannot be found.
+	forgotten := testChannel{chanPoint: "c:0", height: 700}
+	unknown := testChannel{height: 600}
+
+	lnd := newTestClient()
+	lnd.AddEdge(&lnrpc.ChannelEdge{
+		ChannelId: forgotten.chanID(),
+		ChanPoint: forgotten.chanPoint,
+	})
+	lnd.AddForwards(
+		&lnrpc.ForwardingEvent{
+			Timestamp:  300,
+			ChanIdIn:   forgotten.chanID(),
+			ChanIdOut:  testChannels[0].chanID(),
+			AmtInMsat:  300,
+			AmtOutMsat: 200,
+		},
+		&lnrpc.ForwardingEvent{
+			Timestamp:  400,
+			ChanIdIn:   unknown.chanID(),
+			ChanIdOut:  testChannels[1].chanID(),
+			AmtInMsat:  50,
+			AmtOutMsat: 40,
+		},
+	)
+
+	client, cleanup := startTestServer(t, lnd)
+	defer cleanup()
+
+	resp, err := client.RevenueReport(
+		context.Background(), &RevenueReportRequest{
+			ChanPoints: []string{
+				forgotten.chanPoint, testChannels[1].chanPoint,
+			},
+			StartTime: 250,
+			EndTime:   450,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &RevenueReportResponse{
+		Reports: []*RevenueReport{
+			{
+				TargetChannel: forgotten.chanPoint,
+				PairReports: map[string]*PairReport{
+					testChannels[0].chanPoint: {
+						AmountIncomingMsat: 300,
+						FeesIncomingMsat:   100,
+					},
+				},
+				IncomingForwards: singleForward(300, 5e5),
+			},
+			{
+				TargetChannel: testChannels[1].chanPoint,
+				PairReports: map[string]*PairReport{
+					"600:0:0": {
+						AmountOutgoingMsat: 40,
+						FeesOutgoingMsat:   10,
+					},
+				},
+				OutgoingForwards: singleForward(40, 2.5e5),
+			},
+		},
+		TotalFeesMsat: 110,
+		WarningCount:  1,
+		Unattributed: &UnattributedReport{
+			AmountIncomingMsat: 50,
+			FeesMsat:           10,
+			ShortChannelIds:    []string{"600:0:0"},
+		},
+	}
+
+	assertResponse(t, expected, resp)
+}
+
+// TestRevenueReportFiat tests getting a revenue report with fees valued in
+// fiat at the price when each forward happened.
+func TestRevenueReportFiat(t *testing.T) {
+	prices, err := fiat.NewPriceHistory([]fiat.Price{
+		{Timestamp: time.Unix(0, 0), Currency: "USD", Price: 1e8},
+		{Timestamp: time.Unix(150, 0), Currency: "USD", Price: 2e8},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client, cleanup := startConfigServer(t, &Config{
+		LightningClient: newTestClient(),
+		FiatPrices: func(currency string, start,
+			end time.Time) (*fiat.PriceHistory, error) {
+
+			return prices.Filter(currency, start, end), nil
+		},
+	})
+	defer cleanup()
+
+	resp, err := client.RevenueReport(
+		context.Background(), &RevenueReportRequest{
+			ChanPoints:   []string{testChannels[0].chanPoint},
+			StartTime:    50,
+			EndTime:      250,
+			FiatCurrency: "usd",
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Our first forward is valued at our first price, and our second
+	// forward at our second price.
+	expected := &RevenueReportResponse{
+		Reports: []*RevenueReport{
+			{
+				TargetChannel: testChannels[0].chanPoint,
+				PairReports: map[string]*PairReport{
+					testChannels[1].chanPoint: {
+						AmountIncomingMsat: 2000,
+						FeesIncomingMsat:   1000,
+						FeesIncomingFiat:   1,
+					},
+					testClosedChannel.chanPoint: {
+						AmountOutgoingMsat: 4000,
+						FeesOutgoingMsat:   2000,
+						FeesOutgoingFiat:   4,
+					},
+				},
+				IncomingForwards: singleForward(2000, 1e6),
+				OutgoingForwards: singleForward(4000, 5e5),
+			},
+		},
+		TotalFeesMsat: 3000,
+		FiatCurrency:  "USD",
+		TotalFeesFiat: 5,
+	}
+
+	assertResponse(t, expected, resp)
+
+	// Fiat values cannot be requested from a server without a price
+	// source.
+	noPrices, cleanupNoPrices := startTestServer(t, newTestClient())
+	defer cleanupNoPrices()
+
+	_, err = noPrices.RevenueReport(
+		context.Background(), &RevenueReportRequest{
+			FiatCurrency: "USD",
+		},
+	)
+	if err == nil {
+		t.Fatalf("expected error without price source")
+	}
+}
+
+// TestCloseChannels tests that channels can only be closed over rpc when the
+// server is configured to allow closes.
+func TestCloseChannels(t *testing.T) {
+	req := &CloseChannelsRequest{
+		ChanPoints: []string{testChannels[0].chanPoint},
+	}
+
+	client, cleanup := startTestServer(t, newTestClient())
+	defer cleanup()
+
+	_, err := client.CloseChannels(context.Background(), req)
+	if err == nil {
+		t.Fatalf("expected error when closes are disabled")
+	}
+
+	allowed, cleanupAllowed := startConfigServer(t, &Config{
+		LightningClient: newTestClient(),
+		AllowClose:      true,
+	})
+	defer cleanupAllowed()
+
+	// Our test channels are not active, so they are skipped unless force
+	// closes are allowed.
+	resp, err := allowed.CloseChannels(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &CloseChannelsResponse{
+		Results: []*ChannelCloseResult{
+			{
+				ChanPoint: testChannels[0].chanPoint,
+				Action:    ChannelCloseResult_SKIP,
+				Reason: "peer is offline and force close not " +
+					"allowed",
+			},
+		},
+	}
+
+	assertResponse(t, expected, resp)
+}
+
+// TestLiquidityCostReport tests getting a liquidity cost report over rpc, and
+// the inclusion of liquidity costs in channel insights.
+func TestLiquidityCostReport(t *testing.T) {
+	// Create a swap which rebalanced our first channel, and a later swap
+	// which rebalanced our second channel and our closed channel.
+	first := &liquidity.Swap{
+		ID:               "first",
+		Type:             liquidity.LoopOut,
+		State:            "SUCCESS",
+		Initiated:        time.Unix(100, 0),
+		Amount:           10000,
+		ServerCost:       2,
+		OnChainCost:      1,
+		OutgoingChannels: []uint64{testChannels[0].chanID()},
+	}
+
+	second := &liquidity.Swap{
+		ID:         "second",
+		Type:       liquidity.LoopOut,
+		State:      "SUCCESS",
+		Initiated:  time.Unix(300, 0),
+		Amount:     20000,
+		ServerCost: 4,
+		OutgoingChannels: []uint64{
+			testClosedChannel.chanID(), testChannels[1].chanID(),
+		},
+	}
+
+	client, cleanup := startConfigServer(t, &Config{
+		LightningClient: newTestClient(),
+		ListSwaps: func() ([]*liquidity.Swap, error) {
+			return []*liquidity.Swap{second, first}, nil
+		},
+	})
+	defer cleanup()
+
+	// Request a report that only covers our first swap.
+	resp, err := client.LiquidityCostReport(
+		context.Background(), &LiquidityCostReportRequest{
+			StartTime: 50,
+			EndTime:   250,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	firstCost := &LiquidityCost{
+		Swaps:          1,
+		AmountSat:      10000,
+		ServerCostSat:  2,
+		OnchainCostSat: 1,
+		TotalCostSat:   3,
+	}
+
+	expected := &LiquidityCostReportResponse{
+		Total:        firstCost,
+		Unattributed: &LiquidityCost{},
+		Channels: []*ChannelLiquidityCost{
+			{
+				ChanPoint: testChannels[0].chanPoint,
+				Cost:      firstCost,
+			},
+		},
+		Swaps: []*SwapCost{
+			{
+				Id:             "first",
+				Type:           "loop_out",
+				State:          "SUCCESS",
+				InitiationTime: 100,
+				AmountSat:      10000,
+				ServerCostSat:  2,
+				OnchainCostSat: 1,
+				ChanPoints: []string{
+					testChannels[0].chanPoint,
+				},
+			},
+		},
+	}
+
+	assertResponse(t, expected, resp)
+
+	// Channel insights cover the lifetime of our channels, so include
+	// both swaps. Our second channel is attributed half of the second
+	// swap's cost.
+	insights, err := client.ChannelInsights(
+		context.Background(), &ChannelInsightsRequest{},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedCosts := map[string]*ChannelInsight{
+		testChannels[0].chanPoint: {
+			LiquidityCostMsat: 3000,
+			NetFeesMsat:       -1500,
+		},
+		testChannels[1].chanPoint: {
+			LiquidityCostMsat: 2000,
+			NetFeesMsat:       -1500,
+		},
+	}
+
+	for _, insight := range insights.ChannelInsights {
+		expected, ok := expectedCosts[insight.ChanPoint]
+		if !ok {
+			continue
+		}
+
+		if insight.LiquidityCostMsat != expected.LiquidityCostMsat ||
+			insight.NetFeesMsat != expected.NetFeesMsat {
+
+			t.Fatalf("expected: %v cost and %v net fees for %v, "+
+				"got: %v and %v", expected.LiquidityCostMsat,
+				expected.NetFeesMsat, insight.ChanPoint,
+				insight.LiquidityCostMsat,
+				insight.NetFeesMsat)
+		}
+	}
+
+	// If loopd is unavailable, channel insights are still produced, but
+	// do not include liquidity costs.
+	loopDown, cleanupLoopDown := startConfigServer(t, &Config{
+		LightningClient: newTestClient(),
+		ListSwaps: func() ([]*liquidity.Swap, error) {
+			return nil, errors.New("loopd unavailable")
+		},
+	})
+	defer cleanupLoopDown()
+
+	insights, err = loopDown.ChannelInsights(
+		context.Background(), &ChannelInsightsRequest{},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, insight := range insights.ChannelInsights {
+		if insight.LiquidityCostMsat != 0 {
+			t.Fatalf("expected no liquidity cost for %v, got: %v",
+				insight.ChanPoint, insight.LiquidityCostMsat)
+		}
+	}
+
+	// Liquidity costs cannot be requested from a server which is not
+	// connected to loopd.
+	noLoop, cleanupNoLoop := startTestServer(t, newTestClient())
+	defer cleanupNoLoop()
+
+	_, err = noLoop.LiquidityCostReport(
+		context.Background(), &LiquidityCostReportRequest{},
+	)
+	if err == nil {
+		t.Fatalf("expected error without loopd connection")
+	}
+}
+
+// TestFlowGraph tests getting a graph of the flow of forwards between our
+// peers over rpc.
+func TestFlowGraph(t *testing.T) {
+	// Our first two channels are with alice, who is in the graph, and our
+	// closed channel is with bob, who is not.
+	lnd := newTestClient()
+	lnd.SetChannels(
+		&lnrpc.Channel{
+			ChannelPoint: testChannels[0].chanPoint,
+			ChanId:       testChannels[0].chanID(),
+			RemotePubkey: "alice",
+		},
+		&lnrpc.Channel{
+			ChannelPoint: testChannels[1].chanPoint,
+			ChanId:       testChannels[1].chanID(),
+			RemotePubkey: "alice",
+		},
+	)
+	lnd.SetClosedChannels(&lnrpc.ChannelCloseSummary{
+		ChannelPoint: testClosedChannel.chanPoint,
+		ChanId:       testClosedChannel.chanID(),
+		RemotePubkey: "bob",
+	})
+	lnd.AddNode(&lnrpc.LightningNode{
+		PubKey: "alice",
+		Alias:  "Alice",
+	})
+
+	client, cleanup := startTestServer(t, lnd)
+	defer cleanup()
+
+	resp, err := client.FlowGraph(
+		context.Background(), &FlowGraphRequest{
+			Weight:     FlowGraphRequest_FEES,
+			Label:      FlowGraphRequest_ALIAS,
+			MergePeers: true,
+			Format:     FlowGraphRequest_DOT,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Our first forward was between two of alice's channels, and our
+	// second was from bob to alice.
+	expected := &FlowGraphResponse{
+		Nodes: []*FlowGraphNode{
+			{
+				Id:    "alice",
+				Label: "Alice",
+				Peer:  "alice",
+				ChanPoints: []string{
+					testChannels[0].chanPoint,
+					testChannels[1].chanPoint,
+				},
+			},
+			{
+				Id:    "bob",
+				Label: "bob",
+				Peer:  "bob",
+				ChanPoints: []string{
+					testClosedChannel.chanPoint,
+				},
+			},
+		},
+		Edges: []*FlowGraphEdge{
+			{
+				From:       "alice",
+				To:         "alice",
+				AmountMsat: 2000,
+				FeesMsat:   1000,
+				WeightMsat: 1000,
+			},
+			{
+				From:       "bob",
+				To:         "alice",
+				AmountMsat: 6000,
+				FeesMsat:   2000,
+				WeightMsat: 2000,
+			},
+		},
+		Dot: `digraph flows {
+	rankdir=LR;
+	node [shape=box];
+	"alice" [label="Alice"];
+	"bob" [label="bob"];
+	"alice" -> "alice" [label="1000 msat", penwidth=4.50];
+	"bob" -> "alice" [label="2000 msat", penwidth=8.00];
+}
+`,
+	}
+
+	assertResponse(t, expected, resp)
+
+	// Requests with an unknown weight should fail.
+	_, err = client.FlowGraph(
+		context.Background(), &FlowGraphRequest{
+			Weight: 5,
+		},
+	)
+	if err == nil {
+		t.Fatalf("expected error for unknown weight")
+	}
+}
+
+// TestOutlierRecommendations tests getting outlier recommendations over rpc.
+func TestOutlierRecommendations(t *testing.T) {
+	client, cleanup := startTestServer(t, newTestClient())
+	defer cleanup()
+
+	resp, err := client.OutlierRecommendations(
+		context.Background(), &OutlierRecommendationsRequest{
+			RecRequest: &CloseRecommendationRequest{
+				MinimumMonitored: 100,
+				Metric:           CloseRecommendationRequest_UPTIME,
+			},
+			OutlierMultiplier: 1.5,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only the channel with a much lower uptime than the others should be
+	// recommended for close. Our uptime values are [0.1, 0.99, 1, 1, 1, 1],
+	// so our quartiles are 0.99 and 1.
+	expected := &CloseRecommendationsResponse{
+		TotalChannels:      int32(len(testChannels)),
+		ConsideredChannels: int32(len(testChannels)),
+		Recommendations:    uptimeRecommendations(0.9),
+		OutlierBounds: &OutlierBounds{
+			LowerQuartile: 0.99,
+			UpperQuartile: 1,
+			LowerOutlier:  0.975,
+			UpperOutlier:  1.015,
+		},
+	}
+
+	assertResponse(t, expected, resp)
+}
+
+// TestThresholdRecommendations tests getting threshold recommendations over
+// rpc.
+func TestThresholdRecommendations(t *testing.T) {
+	client, cleanup := startTestServer(t, newTestClient())
+	defer cleanup()
+
+	resp, err := client.ThresholdRecommendations(
+		context.Background(), &ThresholdRecommendationsRequest{
+			RecRequest: &CloseRecommendationRequest{
+				MinimumMonitored: 100,
+				Metric:           CloseRecommendationRequest_UPTIME,
+			},
+			ThresholdValue: 0.995,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Our channels with uptime below the threshold should be recommended
+	// for close.
+	expected := &CloseRecommendationsResponse{
+		TotalChannels:      int32(len(testChannels)),
+		ConsideredChannels: int32(len(testChannels)),
+		Recommendations:    uptimeRecommendations(0.995),
+	}
+
+	assertResponse(t, expected, resp)
+}
+
+// TestRuleRecommendations tests getting rule based recommendations over rpc,
+// using rules from the request and from our server's config.
+func TestRuleRecommendations(t *testing.T) {
+	configRules, err := recommend.ParseRules([]string{
+		"offline:uptime_ratio < 0.995",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client, cleanup := startConfigServer(t, &Config{
+		LightningClient: newTestClient(),
+		Rules:           configRules,
+	})
+	defer cleanup()
+
+	tests := []struct {
+		name     string
+		rules    []string
+		expected map[string]string
+		err      bool
+	}{
+		{
+			name:  "request rules",
+			rules: []string{"flaky:uptime_ratio < 0.5"},
+			expected: map[string]string{
+				testChannels[4].chanPoint: "flaky",
+			},
+		},
+		{
+			name: "config rules",
+			expected: map[string]string{
+				testChannels[2].chanPoint: "offline",
+				testChannels[4].chanPoint: "offline",
+			},
+		},
+		{
+			name:  "invalid rule",
+			rules: []string{"uptime_ratio < 1h"},
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := client.RuleRecommendations(
+				context.Background(),
+				&RuleRecommendationsRequest{
+					RecRequest: &CloseRecommendationRequest{
+						MinimumMonitored: 100,
+					},
+					Rules: test.rules,
+				},
+			)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(resp.Recommendations) != len(testChannels) {
+				t.Fatalf("expected: %v recommendations, "+
+					"got: %v", len(testChannels),
+					len(resp.Recommendations))
+			}
+
+			for _, rec := range resp.Recommendations {
+				rule := test.expected[rec.ChanPoint]
+				if rec.Rule != rule ||
+					rec.RecommendClose != (rule != "") {
+
+					t.Fatalf("unexpected recommendation: "+
+						"%v", rec)
+				}
+			}
+		})
+	}
+}
+
+// uptimeRecommendations returns the recommendations we expect for our test
+// channels' uptime, where channels with an uptime ratio below the value
+// provided are recommended for close. Recommendations are sorted by value,
+// then channel point.
+func uptimeRecommendations(below float32) []*Recommendation {
+	recs := []*Recommendation{
+		rec(testChannels[4], below),
+		rec(testChannels[2], below),
+		rec(testChannels[0], below),
+		rec(testChannels[1], below),
+		rec(testChannels[3], below),
+		rec(testChannels[5], below),
+	}
+
+	return recs
+}
+
+// rec returns the uptime recommendation for a test channel.
+func rec(c testChannel, below float32) *Recommendation {
+	value := float32(c.uptime) / float32(c.lifetime)
+
+	return &Recommendation{
+		ChanPoint:      c.chanPoint,
+		Value:          value,
+		RecommendClose: value < below,
+	}
+}
+
+// TestLndError tests that errors from lnd are returned to the client.
+func TestLndError(t *testing.T) {
+	lnd := newTestClient()
+	lnd.SetError("ListChannels", fmt.Errorf("lnd unavailable"))
+
+	client, cleanup := startTestServer(t, lnd)
+	defer cleanup()
+
+	_, err := client.ChannelInsights(
+		context.Background(), &ChannelInsightsRequest{},
+	)
+	if err == nil {
+		t.Fatalf("expected error from lnd")
+	}
+}
+
+// newFleetNode returns a named node with our test channels, where each
+// channel's peer is the node's peer at the same index.
+func newFleetNode(name, pubkey string, peers ...string) *Node {
+	lnd := newTestClient()
+	lnd.SetInfo(&lnrpc.GetInfoResponse{
+		IdentityPubkey: pubkey,
+		BlockHeight:    testHeight,
+	})
+
+	var channels []*lnrpc.Channel
+	for i, peer := range peers {
+		c := testChannels[i]
+		channels = append(channels, &lnrpc.Channel{
+			ChannelPoint: c.chanPoint,
+			ChanId:       c.chanID(),
+			RemotePubkey: peer,
+			Lifetime:     c.lifetime,
+			Uptime:       c.uptime,
+		})
+	}
+	lnd.SetChannels(channels...)
+
+	return &Node{
+		Name:            name,
+		LightningClient: lnd,
+	}
+}
+
+// TestFleet tests selecting nodes by name and getting a fleet report for a
+// set of nodes, where alice has a channel with bob and an external peer.
+func TestFleet(t *testing.T) {
+	client, cleanup := startConfigServer(t, &Config{
+		Nodes: []*Node{
+			newFleetNode("alice", "02aa", "02bb", "02cc"),
+			newFleetNode("bob", "02bb", "02aa"),
+		},
+	})
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Requests that do not name a node are served by alice, who has two
+	// channels.
+	resp, err := client.ChannelInsights(ctx, &ChannelInsightsRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.ChannelInsights) != 2 {
+		t.Fatalf("expected 2 channels, got: %v",
+			len(resp.ChannelInsights))
+	}
+
+	if !resp.ChannelInsights[0].Internal ||
+		resp.ChannelInsights[1].Internal {
+
+		t.Fatalf("expected only first channel to be internal")
+	}
+
+	// Requests for bob are served by bob.
+	resp, err = client.ChannelInsights(ctx, &ChannelInsightsRequest{
+		Node: "bob",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.ChannelInsights) != 1 {
+		t.Fatalf("expected 1 channel, got: %v",
+			len(resp.ChannelInsights))
+	}
+
+	// Requests for unknown nodes fail.
+	_, err = client.RevenueReport(ctx, &RevenueReportRequest{
+		Node: "carol",
+	})
+	if err == nil {
+		t.Fatalf("expected unknown node to fail")
+	}
+
+	fleet, err := client.FleetReport(ctx, &FleetReportRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(fleet.Nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got: %v", len(fleet.Nodes))
+	}
+
+	if fleet.Nodes[0].Node != "alice" || fleet.Nodes[0].Pubkey != "02aa" {
+		t.Fatalf("unexpected first node: %v", fleet.Nodes[0])
+	}
+
+	// Both nodes share the same forwarding log, but bob does not know
+	// alice's second channel, so only the incoming side of the forward to
+	// that channel is attributed to bob. Our first channel is internal for
+	// both nodes.
+	expected := &FleetTotals{
+		Channels:           3,
+		InternalChannels:   2,
+		FeesEarnedMsat:     1500 + 500 + 1000 + 500,
+		VolumeIncomingMsat: 2000 + 2000,
+		VolumeOutgoingMsat: 4000 + 1000 + 4000,
+		VolumeInternalMsat: 6000 + 4000 + 2000,
+	}
+
+	assertResponse(t, expected, fleet.Totals)
+}
+
+// TestFleetNodeDown tests that a node which cannot be reached does not
+// prevent us from serving requests for the other nodes in our fleet, and that
+// the public keys of nodes that later go down are remembered.
+func TestFleetNodeDown(t *testing.T) {
+	alice := newFleetNode("alice", "02aa", "02bb", "02cc")
+	bob := newFleetNode("bob", "02bb", "02aa")
+	carol := newFleetNode("carol", "02cc")
+
+	carolLnd := carol.LightningClient.(*fakelnd.Client)
+	carolLnd.SetError("GetInfo", fmt.Errorf("lnd unavailable"))
+
+	client, cleanup := startConfigServer(t, &Config{
+		Nodes: []*Node{alice, bob, carol},
+	})
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Alice's channel with bob is internal. Carol's public key is not
+	// known, so alice's channel with her is not.
+	resp, err := client.ChannelInsights(ctx, &ChannelInsightsRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.ChannelInsights[0].Internal ||
+		resp.ChannelInsights[1].Internal {
+
+		t.Fatalf("expected only first channel to be internal")
+	}
+
+	// Once bob's public key is known, his channel remains internal when
+	// he cannot be reached.
+	bobLnd := bob.LightningClient.(*fakelnd.Client)
+	bobLnd.SetError("GetInfo", fmt.Errorf("lnd unavailable"))
+
+	resp, err = client.ChannelInsights(ctx, &ChannelInsightsRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.ChannelInsights[0].Internal {
+		t.Fatalf("expected first channel to be internal")
+	}
+
+	// Our fleet report includes insights for alice and bob, and the error
+	// for carol.
+	fleet, err := client.FleetReport(ctx, &FleetReportRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(fleet.Nodes) != 3 {
+		t.Fatalf("expected 3 nodes, got: %v", len(fleet.Nodes))
+	}
+
+	if fleet.Nodes[0].Totals.GetChannels() != 2 {
+		t.Fatalf("expected alice to have 2 channels, got: %v",
+			fleet.Nodes[0].Totals)
+	}
+
+	if fleet.Nodes[2].Error == "" || fleet.Nodes[2].Totals != nil {
+		t.Fatalf("expected error for carol, got: %v", fleet.Nodes[2])
+	}
+}
+
+// TestDebugLevel tests changing debug levels over rpc.
+func TestDebugLevel(t *testing.T) {
+	// A server which is not configured to change debug levels should
+	// fail.
+	client, cleanup := startTestServer(t, newTestClient())
+	_, err := client.DebugLevel(context.Background(), &DebugLevelRequest{
+		LevelSpec: "debug",
+	})
+	cleanup()
+	if err == nil {
+		t.Fatalf("expected debug level change to fail")
+	}
+
+	var levelSpec string
+	client, cleanup = startConfigServer(t, &Config{
+		LightningClient: newTestClient(),
+		SetDebugLevel: func(spec string) error {
+			levelSpec = spec
+			return nil
+		},
+		SubSystems: func() []string {
+			return []string{"FRDY", "FRPC"}
+		},
+	})
+	defer cleanup()
+
+	resp, err := client.DebugLevel(context.Background(), &DebugLevelRequest{
+		Show: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertResponse(t, &DebugLevelResponse{SubSystems: "FRDY FRPC"}, resp)
+
+	_, err = client.DebugLevel(context.Background(), &DebugLevelRequest{
+		LevelSpec: "FRPC=debug",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if levelSpec != "FRPC=debug" {
+		t.Fatalf("expected level spec to be set, got: %v", levelSpec)
+	}
+}
+
+// TestStatus tests getting the status of our lnd connections over rpc.
+func TestStatus(t *testing.T) {
+	since := time.Unix(1000, 0)
+
+	client, cleanup := startConfigServer(t, &Config{
+		Nodes: []*Node{
+			{
+				Name:            "alice",
+				LightningClient: newTestClient(),
+				Status: func() supervisor.Status {
+					return supervisor.Status{
+						State:           supervisor.StateDisconnected,
+						Since:           since,
+						Err:             fmt.Errorf("refused"),
+						ConnectAttempts: 2,
+					}
+				},
+			},
+			{
+				Name:            "bob",
+				LightningClient: newTestClient(),
+			},
+		},
+	})
+	defer cleanup()
+
+	resp, err := client.Status(context.Background(), &StatusRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Bob's connection is not supervised, so its state is unknown.
+	expected := &StatusResponse{
+		Nodes: []*NodeStatus{
+			{
+				Node:            "alice",
+				State:           NodeStatus_DISCONNECTED,
+				StateSince:      since.Unix(),
+				Error:           "refused",
+				ConnectAttempts: 2,
+			},
+			{
+				Node: "bob",
+			},
+		},
+	}
+
+	assertResponse(t, expected, resp)
+}
+
+// TestGetInfo tests getting the daemon's info over rpc, including nodes whose
+// info cannot be obtained.
+func TestGetInfo(t *testing.T) {
+	alice := newTestClient()
+	alice.SetInfo(&lnrpc.GetInfoResponse{
+		Version:        "0.8.0-beta",
+		Alias:          "alice",
+		IdentityPubkey: "02aa",
+		Chains: []*lnrpc.Chain{
+			{Chain: "bitcoin", Network: "testnet"},
+		},
+	})
+
+	bob := newTestClient()
+	bob.SetError("GetInfo", fmt.Errorf("lnd unavailable"))
+
+	client, cleanup := startConfigServer(t, &Config{
+		Version: "0.1.0-alpha",
+		Commit:  "abc",
+		Nodes: []*Node{
+			{
+				Name:            "alice",
+				LightningClient: alice,
+			},
+			{
+				Name:            "bob",
+				LightningClient: bob,
+			},
+		},
+	})
+	defer cleanup()
+
+	resp, err := client.GetInfo(context.Background(), &GetInfoRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &GetInfoResponse{
+		Version: "0.1.0-alpha",
+		Commit:  "abc",
+		Nodes: []*LndInfo{
+			{
+				Node:    "alice",
+				Version: "0.8.0-beta",
+				Alias:   "alice",
+				Pubkey:  "02aa",
+				Network: "testnet",
+			},
+			{
+				Node:  "bob",
+				Error: "lnd unavailable",
+			},
+		},
+		Features: []string{featureMultipleNodes},
+	}
+
+	assertResponse(t, expected, resp)
+}
+
+// TestListMetrics tests listing the metrics that recommendations can be based
+// on over rpc, and getting recommendations for a metric by name.
+func TestListMetrics(t *testing.T) {
+	client, cleanup := startTestServer(t, newTestClient())
+	defer cleanup()
+
+	resp, err := client.ListMetrics(
+		context.Background(), &ListMetricsRequest{},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Metrics) != len(recommend.Metrics()) {
+		t.Fatalf("expected: %v metrics, got: %v",
+			len(recommend.Metrics()), len(resp.Metrics))
+	}
+
+	var uptime *MetricInfo
+	for _, metric := range resp.Metrics {
+		if metric.Name == recommend.UptimeMetric {
+			uptime = metric
+		}
+	}
+
+	if uptime == nil || uptime.Scaling != MetricInfo_RAW {
+		t.Fatalf("expected raw uptime metric, got: %v", uptime)
+	}
+
+	recs, err := client.ThresholdRecommendations(
+		context.Background(), &ThresholdRecommendationsRequest{
+			RecRequest: &CloseRecommendationRequest{
+				MinimumMonitored: 100,
+				MetricName:       uptime.Name,
+			},
+			ThresholdValue: 0.995,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &CloseRecommendationsResponse{
+		TotalChannels:      int32(len(testChannels)),
+		ConsideredChannels: int32(len(testChannels)),
+		Recommendations:    uptimeRecommendations(0.995),
+	}
+
+	assertResponse(t, expected, recs)
+
+	// Requests for metrics that are not registered should fail.
+	_, err = client.ThresholdRecommendations(
+		context.Background(), &ThresholdRecommendationsRequest{
+			RecRequest: &CloseRecommendationRequest{
+				MinimumMonitored: 100,
+				MetricName:       "unknown",
+			},
+		},
+	)
+	if err == nil {
+		t.Fatalf("expected error for unknown metric")
+	}
+}
@@ -0,0 +1,70 @@
+package frdrpc
+
+import (
+	"github.com/lightninglabs/faraday/supervisor"
+)
+
+// rpcStatusResponse returns the status of each of the nodes in our config.
+// If our config does not have a set of named nodes, our single node's
+// connection is not supervised, so its status is unknown.
+func rpcStatusResponse(cfg *Config) *StatusResponse {
+	resp := &StatusResponse{}
+
+	if len(cfg.Nodes) == 0 {
+		resp.Nodes = append(resp.Nodes, &NodeStatus{})
+		return resp
+	}
+
+	for _, node := range cfg.Nodes {
+		nodeStatus := &NodeStatus{
+			Node: node.Name,
+		}
+
+		if node.Status != nil {
+			setNodeStatus(nodeStatus, node.Status())
+		}
+
+		resp.Nodes = append(resp.Nodes, nodeStatus)
+	}
+
+	return resp
+}
+
+// setNodeStatus sets the fields of a rpc node status from the status of a
+// supervised connection.
+func setNodeStatus(nodeStatus *NodeStatus, status supervisor.Status) {
+	nodeStatus.State = rpcState(status.State)
+	nodeStatus.StateSince = status.Since.Unix()
+	nodeStatus.ConnectAttempts = uint32(status.ConnectAttempts)
+
+	if !status.LastCheck.IsZero() {
+		nodeStatus.LastCheck = status.LastCheck.Unix()
+	}
+
+	if status.Err != nil {
+		nodeStatus.Error = status.Err.Error()
+	}
+}
+
+// rpcState converts a supervisor state to a rpc state.
+func rpcState(state supervisor.State) NodeStatus_State {
+	switch state {
+	case supervisor.StateConnecting:
+		return NodeStatus_CONNECTING
+
+	case supervisor.StateDisconnected:
+		return NodeStatus_DISCONNECTED
+
+	case supervisor.StateWaitingUnlock:
+		return NodeStatus_WAITING_UNLOCK
+
+	case supervisor.StateWaitingSync:
+		return NodeStatus_WAITING_SYNC
+
+	case supervisor.StateReady:
+		return NodeStatus_READY
+
+	default:
+		return NodeStatus_UNKNOWN
+	}
+}
@@ -0,0 +1,413 @@
+package frdrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lightninglabs/faraday/insights"
+	"github.com/lightninglabs/faraday/liquidity"
+	"github.com/lightninglabs/faraday/recommend"
+	"github.com/lightninglabs/faraday/subscribe"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+const (
+	// forwardPollInterval is the interval at which subscriptions poll
+	// lnd's forwarding log for new forwards.
+	forwardPollInterval = time.Second * 10
+
+	// minUpdateInterval is the minimum amount of time between
+	// subscription updates. Each update recalculates insights for all of
+	// our channels, so we coalesce busy periods into a single update per
+	// interval, and do not allow clients to request updates more often.
+	minUpdateInterval = time.Minute
+
+	// swapRefreshInterval is the minimum amount of time between queries
+	// to loopd for the swaps that a subscription's insights include.
+	swapRefreshInterval = time.Minute * 10
+
+	// maxForwardQuery is the number of forwards that a subscription
+	// reads from lnd's forwarding log at a time.
+	maxForwardQuery uint32 = 500
+)
+
+var (
+	// errNoRecommendationRequest is returned when a subscription to
+	// recommendations does not specify the type of recommendations
+	// required.
+	errNoRecommendationRequest = errors.New("outlier, threshold or rule " +
+		"recommendation request required")
+
+	// errIntervalTooShort is returned when a subscription requests an
+	// update interval that is shorter than our minimum update interval.
+	errIntervalTooShort = fmt.Errorf("update interval must be zero or "+
+		"at least %v", minUpdateInterval)
+)
+
+// getSubscribeConfig wraps calls to lnd to produce the config required to
+// trigger subscription updates.
+func getSubscribeConfig(ctx context.Context, cfg *Config,
+	intervalSeconds uint64) *subscribe.Config {
+
+	channelEvents := func(ctx context.Context) (
+		func() (*lnrpc.ChannelEventUpdate, error), error) {
+
+		stream, err := cfg.LightningClient.SubscribeChannelEvents(
+			ctx, &lnrpc.ChannelEventSubscription{},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return stream.Recv, nil
+	}
+
+	// hasForwards queries for a single forward since the time provided,
+	// so that we do not need to read the full forwarding log to check
+	// for new forwards.
+	hasForwards := func(since time.Time) (bool, error) {
+		resp, err := cfg.LightningClient.ForwardingHistory(
+			ctx, &lnrpc.ForwardingHistoryRequest{
+				StartTime:    uint64(since.Unix()),
+				EndTime:      uint64(time.Now().Unix()),
+				NumMaxEvents: 1,
+			},
+		)
+		if err != nil {
+			return false, err
+		}
+
+		return len(resp.ForwardingEvents) > 0, nil
+	}
+
+	interval := time.Second * time.Duration(intervalSeconds)
+
+	return &subscribe.Config{
+		ChannelEvents:       channelEvents,
+		HasForwards:         hasForwards,
+		ForwardPollInterval: forwardPollInterval,
+		Interval:            interval,
+		MinUpdateInterval:   minUpdateInterval,
+		Now:                 time.Now,
+	}
+}
+
+// runSubscription runs a subscription which sends the response produced by
+// the function provided each time an update is triggered. Updates triggered
+// by events are only sent if the stable part of the response, as returned by
+// the stable function provided, differs from the last response sent. Interval
+// updates are always sent, so that clients receive fields which change with
+// every sample (such as uptime) at the interval they requested. It returns nil
+// if the subscription ends because the client cancelled it.
+func runSubscription(ctx context.Context, cfg *Config,
+	intervalSeconds uint64, getResponse func() (proto.Message, error),
+	stable func(proto.Message) proto.Message,
+	send func(proto.Message) error) error {
+
+	interval := time.Second * time.Duration(intervalSeconds)
+	if interval != 0 && interval < minUpdateInterval {
+		return errIntervalTooShort
+	}
+
+	var lastStable proto.Message
+
+	err := subscribe.Run(
+		ctx, getSubscribeConfig(ctx, cfg, intervalSeconds),
+		func(reason subscribe.Reason) error {
+			resp, err := getResponse()
+			if err != nil {
+				return err
+			}
+
+			current := stable(resp)
+			if reason != subscribe.ReasonInterval &&
+				lastStable != nil &&
+				proto.Equal(current, lastStable) {
+
+				log.Tracef("subscription update for: %v "+
+					"unchanged", reason)
+
+				return nil
+			}
+			lastStable = current
+
+			return send(resp)
+		},
+	)
+	if err == context.Canceled {
+		return nil
+	}
+
+	return err
+}
+
+// insightsCache produces channel insights for a subscription. lnd's
+// forwarding log only grows, so rather than reading the full log for every
+// update, the cache keeps the forwards that it has already read and only
+// queries lnd for forwards since its last update. We keep the forwards
+// themselves rather than per-channel totals, because a lookback window drops
+// old forwards, decay weights change with every update, and forward stats
+// need the size of each forward. Swaps rarely change, so loopd is only
+// queried once per swapRefreshInterval.
+type insightsCache struct {
+	cfg    *Config
+	params *insightsParams
+
+	// forwards contains the forwards that we have read from lnd within
+	// our lookback window, in the order that lnd returned them.
+	forwards []*lnrpc.ForwardingEvent
+
+	// readUntil is the unix time up to which we have read forwards,
+	// inclusive. It is zero if we have not read any forwards yet.
+	readUntil uint64
+
+	// swaps is our last swap report, which is nil if we could not get
+	// one.
+	swaps *liquidity.Report
+
+	// swapsUpdated is the time that we last queried for swaps.
+	swapsUpdated time.Time
+}
+
+// newInsightsCache creates an insights cache for the node config and params
+// provided.
+func newInsightsCache(cfg *Config, params *insightsParams) *insightsCache {
+	return &insightsCache{
+		cfg:    cfg,
+		params: params,
+	}
+}
+
+// channelInsights reads any new forwards from lnd, and produces channel
+// insights from all of the forwards that we have cached.
+func (c *insightsCache) channelInsights(
+	ctx context.Context) ([]*insights.ChannelInfo, error) {
+
+	now := c.cfg.now()
+	start := c.params.start(now)
+
+	if err := c.readForwards(ctx, start, now); err != nil {
+		return nil, err
+	}
+
+	if c.swapsUpdated.IsZero() ||
+		now.Sub(c.swapsUpdated) >= swapRefreshInterval {
+
+		c.swaps = liquidityReport(ctx, c.cfg, start, now)
+		c.swapsUpdated = now
+	}
+
+	revenueCfg := getRevenueConfig(ctx, c.cfg, start, uint64(now.Unix()))
+	revenueCfg.ForwardingHistory = c.forwardingHistory
+
+	return getChannelInsights(
+		ctx, c.cfg, c.params, now, revenueCfg, c.swaps,
+	)
+}
+
+// readForwards reads the forwards that have occurred since our last read
+// from lnd, and drops any forwards that are now before the start of our
+// lookback window. Forwards may still be added to lnd's log in the current
+// second after we query it, so we only read until the end of the previous
+// second and leave the current second for our next read.
+func (c *insightsCache) readForwards(ctx context.Context, start uint64,
+	now time.Time) error {
+
+	from := start
+	if c.readUntil != 0 && c.readUntil+1 > from {
+		from = c.readUntil + 1
+	}
+
+	// We only add the forwards that we read to our cache once we have
+	// read all of them, so that a failed read is retried in full.
+	var (
+		end  = uint64(now.Unix()) - 1
+		read []*lnrpc.ForwardingEvent
+	)
+	for offset := uint32(0); from <= end; {
+		resp, err := c.cfg.LightningClient.ForwardingHistory(
+			ctx, &lnrpc.ForwardingHistoryRequest{
+				StartTime:    from,
+				EndTime:      end,
+				IndexOffset:  offset,
+				NumMaxEvents: maxForwardQuery,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		events := resp.ForwardingEvents
+		read = append(read, events...)
+
+		if uint32(len(events)) < maxForwardQuery {
+			c.forwards = append(c.forwards, read...)
+			c.readUntil = end
+			break
+		}
+
+		offset = resp.LastOffsetIndex
+	}
+
+	// Our forwards are in chronological order, so we drop forwards from
+	// the front of our cache until we reach our lookback window. We copy
+	// the forwards that we keep so that dropped forwards can be freed.
+	var expired int
+	for expired < len(c.forwards) &&
+		c.forwards[expired].Timestamp < start {
+
+		expired++
+	}
+
+	if expired != 0 {
+		c.forwards = append(
+			[]*lnrpc.ForwardingEvent(nil), c.forwards[expired:]...,
+		)
+	}
+
+	return nil
+}
+
+// forwardingHistory serves paginated queries for our cached forwards.
+func (c *insightsCache) forwardingHistory(offset, maxEvents uint32) (
+	[]*lnrpc.ForwardingEvent, uint32, error) {
+
+	total := uint32(len(c.forwards))
+	if offset >= total {
+		return nil, offset, nil
+	}
+
+	end := offset + maxEvents
+	if end > total {
+		end = total
+	}
+
+	return c.forwards[offset:end], end, nil
+}
+
+// stableInsights returns a copy of a channel insights response with the
+// fields that change every time our channels are sampled cleared, so that
+// event triggered updates are only sent when something else changed.
+func stableInsights(msg proto.Message) proto.Message {
+	resp := proto.Clone(msg).(*ChannelInsightsResponse)
+
+	for _, insight := range resp.ChannelInsights {
+		insight.MonitoredSeconds = 0
+		insight.UptimeSeconds = 0
+		insight.BalanceMonitoredSeconds = 0
+		insight.OneSidedSeconds = 0
+	}
+
+	return resp
+}
+
+// stableRecommendations returns a copy of a close recommendations response
+// with the values that recommendations are based on cleared. These values
+// include uptime ratios which change every time our channels are sampled, so
+// event triggered updates are only sent when the set of channels considered
+// or recommended for close changes.
+func stableRecommendations(msg proto.Message) proto.Message {
+	resp := proto.Clone(msg).(*CloseRecommendationsResponse)
+	resp.OutlierBounds = nil
+
+	for _, rec := range resp.Recommendations {
+		rec.Value = 0
+	}
+
+	return resp
+}
+
+// getRecommendationFunc returns a function which produces close
+// recommendations for the request provided, along with the config for the
+// node that the request is for.
+func getRecommendationFunc(ctx context.Context, cfg *Config,
+	req *SubscribeRecommendationsRequest) (*Config,
+	func() (*recommend.Report, error), error) {
+
+	switch r := req.Request.(type) {
+	case *SubscribeRecommendationsRequest_OutlierRequest:
+		nodeCfg, err := cfg.nodeConfig(
+			r.OutlierRequest.GetRecRequest().GetNode(),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		recCfg, multiplier := parseOutlierRequest(
+			ctx, nodeCfg, r.OutlierRequest,
+		)
+		cacheInsights(ctx, nodeCfg, r.OutlierRequest.GetRecRequest(),
+			recCfg)
+
+		return nodeCfg, func() (*recommend.Report, error) {
+			return recommend.OutlierRecommendations(
+				recCfg, multiplier,
+			)
+		}, nil
+
+	case *SubscribeRecommendationsRequest_ThresholdRequest:
+		nodeCfg, err := cfg.nodeConfig(
+			r.ThresholdRequest.GetRecRequest().GetNode(),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		recCfg, threshold := parseThresholdRequest(
+			ctx, nodeCfg, r.ThresholdRequest,
+		)
+		cacheInsights(ctx, nodeCfg, r.ThresholdRequest.GetRecRequest(),
+			recCfg)
+
+		return nodeCfg, func() (*recommend.Report, error) {
+			return recommend.ThresholdRecommendations(
+				recCfg, threshold,
+			)
+		}, nil
+
+	case *SubscribeRecommendationsRequest_RuleRequest:
+		nodeCfg, err := cfg.nodeConfig(
+			r.RuleRequest.GetRecRequest().GetNode(),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		recCfg, closeRules, err := parseRuleRequest(
+			ctx, nodeCfg, r.RuleRequest,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		cacheInsights(ctx, nodeCfg, r.RuleRequest.GetRecRequest(),
+			recCfg)
+
+		return nodeCfg, func() (*recommend.Report, error) {
+			return recommend.RuleRecommendations(
+				recCfg, closeRules,
+			)
+		}, nil
+
+	default:
+		return nil, nil, errNoRecommendationRequest
+	}
+}
+
+// cacheInsights sets the recommendation config provided to produce its
+// channel insights from an insights cache, so that each update of a
+// recommendations subscription only reads new forwards from lnd.
+func cacheInsights(ctx context.Context, cfg *Config,
+	req *CloseRecommendationRequest,
+	recCfg *recommend.CloseRecommendationConfig) {
+
+	cache := newInsightsCache(cfg, newInsightsParams(
+		req.GetLookbackSeconds(), req.GetDecayHalfLifeSeconds(),
+	))
+
+	recCfg.ChannelInsights = func() ([]*insights.ChannelInfo, error) {
+		return cache.channelInsights(ctx)
+	}
+}
@@ -3,14 +3,22 @@ module github.com/lightninglabs/faraday
 require (
 	github.com/btcsuite/btcd v0.20.1-beta
 	github.com/btcsuite/btclog v0.0.0-20170628155309-84c8d2346e9f
+	github.com/btcsuite/btcutil v0.0.0-20190425235716-9e5f4b9a998d
+	github.com/coreos/bbolt v1.3.3
 	github.com/golang/protobuf v1.3.3
+	github.com/google/go-cmp v0.3.1 // indirect
+	github.com/grpc-ecosystem/grpc-gateway v1.10.0 // indirect
 	github.com/jessevdk/go-flags v1.4.0
 	github.com/lightninglabs/loop v0.2.4-alpha
 	github.com/lightninglabs/protobuf-hex-display v1.3.3-0.20191212020323-b444784ce75d
 	github.com/lightningnetwork/lnd v0.8.0-beta-rc3.0.20191025122959-1a0ab538d53c
 	github.com/urfave/cli v1.20.0
+	golang.org/x/crypto v0.0.0-20190829043050-9756ffdc2472 // indirect
+	golang.org/x/net v0.0.0-20190827160401-ba9fcec4b297 // indirect
+	golang.org/x/text v0.3.2 // indirect
 	google.golang.org/genproto v0.0.0-20190819201941-24fa4b261c55
 	google.golang.org/grpc v1.27.0
+	gopkg.in/macaroon.v2 v2.1.0
 )
 
 go 1.13
@@ -1,13 +1,19 @@
 package insights
 
 import (
+	"math"
 	"time"
 
+	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/faraday/revenue"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
+// blockInterval is the expected time between blocks, which is used to
+// convert periods of time to a number of blocks.
+const blockInterval = time.Minute * 10
+
 // ChannelInfo provides a set of performance metrics for a lightning channel.
 type ChannelInfo struct {
 	// ChannelPoint is the outpoint of the channel's funding transaction.
@@ -34,12 +40,83 @@ type ChannelInfo struct {
 	// channels.
 	FeesEarned lnwire.MilliSatoshi
 
+	// IncomingForwards describes the sizes and effective fee rate of the
+	// forwards that arrived on the channel.
+	IncomingForwards revenue.ForwardStats
+
+	// OutgoingForwards describes the sizes and effective fee rate of the
+	// forwards that left on the channel.
+	OutgoingForwards revenue.ForwardStats
+
+	// LiquidityCost is the total cost of the swaps that rebalanced the
+	// channel over the period that its fees were earned over.
+	LiquidityCost lnwire.MilliSatoshi
+
+	// NetFees is the fees earned by the channel less its liquidity cost,
+	// which may be negative if rebalancing cost more than the channel
+	// earned.
+	NetFees int64
+
+	// Capacity is the total capacity of the channel.
+	Capacity btcutil.Amount
+
+	// LocalBalance is our current balance in the channel.
+	LocalBalance btcutil.Amount
+
+	// RemoteBalance is our peer's current balance in the channel.
+	RemoteBalance btcutil.Amount
+
+	// PendingIncoming is the total amount of the htlcs that are currently
+	// pending in the channel in the incoming direction.
+	PendingIncoming btcutil.Amount
+
+	// PendingOutgoing is the total amount of the htlcs that are currently
+	// pending in the channel in the outgoing direction.
+	PendingOutgoing btcutil.Amount
+
+	// BalanceRatio is the ratio of our local balance to the total of our
+	// local and remote balances, expressed in [0;1].
+	BalanceRatio float64
+
+	// BalanceMonitored is the amount of time that the channel's balance
+	// has been sampled for.
+	BalanceMonitored time.Duration
+
+	// OneSided is the amount of time that the channel's balance has been
+	// almost entirely local or almost entirely remote over the period it
+	// has been sampled for.
+	OneSided time.Duration
+
 	// Confirmations is the number of confirmations the funding transction
 	// has.
 	Confirmations uint32
 
+	// RevenueBlocks is the effective number of blocks that the channel's
+	// fees and volume were earned over, if revenue was only calculated
+	// over a lookback period or weighted by a decay half-life. Each block
+	// is weighted in the same way as the forwards in it, so that values
+	// scaled by this number are comparable between channels of different
+	// ages. If it is zero, revenue covers all of the channel's
+	// confirmations with equal weight.
+	RevenueBlocks float64
+
 	// Private indicates whether the channel is private.
 	Private bool
+
+	// Internal indicates whether the channel's peer is another node that
+	// we operate.
+	Internal bool
+}
+
+// ScalingBlocks returns the number of blocks that the channel's fees and
+// volume were earned over, which values that are expressed per confirmation
+// should be scaled by.
+func (c *ChannelInfo) ScalingBlocks() float64 {
+	if c.RevenueBlocks != 0 {
+		return c.RevenueBlocks
+	}
+
+	return float64(c.Confirmations)
 }
 
 // Config provides insights with everything it needs to obtain channel
@@ -55,6 +132,56 @@ type Config struct {
 
 	// RevenueReport is a report our channels revenue.
 	RevenueReport *revenue.Report
+
+	// Lookback is the period, counting back from the present, that our
+	// revenue report covers. If it is zero, our revenue report covers the
+	// lifetime of our channels.
+	Lookback time.Duration
+
+	// DecayHalfLife is the half-life that the forwards in our revenue
+	// report were weighted by. If it is zero, forwards were not weighted.
+	DecayHalfLife time.Duration
+
+	// ChannelUptime is an optional function which returns the amount of
+	// time that faraday has monitored a channel's peer for, and the amount
+	// of time the peer was online over that period. If this history covers
+	// a longer period than lnd's in-memory values, which are reset when lnd
+	// restarts, it is used in place of them.
+	ChannelUptime func(chanPoint string) (time.Duration, time.Duration,
+		error)
+
+	// InternalPeers is an optional set of the public keys of other nodes
+	// that we operate. Channels with these peers are marked as internal.
+	InternalPeers map[string]bool
+
+	// ChannelBalance is an optional function which returns the amount of
+	// time that faraday has sampled a channel's balance for, and the
+	// amount of time the balance was one-sided over that period.
+	ChannelBalance func(chanPoint string) (time.Duration, time.Duration,
+		error)
+
+	// LiquidityCosts is an optional map of channel outpoints to the cost
+	// of the swaps that rebalanced them over the period covered by our
+	// revenue report.
+	LiquidityCosts map[string]lnwire.MilliSatoshi
+}
+
+// BalanceRatio returns the ratio of our local balance to the total of our
+// local and remote balances. If the channel has no balance, zero is
+// returned.
+func BalanceRatio(local, remote btcutil.Amount) float64 {
+	total := local + remote
+	if total == 0 {
+		return 0
+	}
+
+	return float64(local) / float64(total)
+}
+
+// IsOneSided returns a boolean indicating whether a balance ratio is within
+// the threshold provided of being entirely local or entirely remote.
+func IsOneSided(ratio, threshold float64) bool {
+	return ratio <= threshold || ratio >= 1-threshold
 }
 
 // GetChannels returns an array of channel insights.
@@ -87,25 +214,60 @@ func GetChannels(cfg *Config) ([]*ChannelInfo, error) {
 		monitored := time.Second * time.Duration(channel.Lifetime)
 		uptime := time.Second * time.Duration(channel.Uptime)
 
+		// If we have our own uptime history for the channel, and it
+		// covers a longer period than lnd's, we use it instead.
+		if cfg.ChannelUptime != nil {
+			m, u, err := cfg.ChannelUptime(channel.ChannelPoint)
+			if err != nil {
+				return nil, err
+			}
+
+			if m > monitored {
+				monitored, uptime = m, u
+			}
+		}
+
+		local := btcutil.Amount(channel.LocalBalance)
+		remote := btcutil.Amount(channel.RemoteBalance)
+
 		// Create a channel insight for the channel.
 		channelInsight := &ChannelInfo{
 			ChannelPoint:  channel.ChannelPoint,
 			MonitoredFor:  monitored,
 			Uptime:        uptime,
+			Capacity:      btcutil.Amount(channel.Capacity),
+			LocalBalance:  local,
+			RemoteBalance: remote,
+			BalanceRatio:  BalanceRatio(local, remote),
 			Confirmations: confirmations,
 			Private:       channel.Private,
+			Internal:      cfg.InternalPeers[channel.RemotePubkey],
+		}
+
+		for _, htlc := range channel.PendingHtlcs {
+			if htlc.Incoming {
+				channelInsight.PendingIncoming +=
+					btcutil.Amount(htlc.Amount)
+			} else {
+				channelInsight.PendingOutgoing +=
+					btcutil.Amount(htlc.Amount)
+			}
 		}
 
-		// If the channel is not present in the revenue report, it has
-		// not generated any revenue over the period so we can add it
-		// to our set of insights and proceed to the next channel.
-		reports, ok := cfg.RevenueReport.ChannelPairs[channel.ChannelPoint]
-		if !ok {
-			insights = append(insights, channelInsight)
-			continue
+		if cfg.ChannelBalance != nil {
+			m, o, err := cfg.ChannelBalance(channel.ChannelPoint)
+			if err != nil {
+				return nil, err
+			}
+
+			channelInsight.BalanceMonitored = m
+			channelInsight.OneSided = o
 		}
 
-		// Accumulate revenue totals for the channel.
+		// Accumulate revenue totals for the channel. If the channel is
+		// not present in the revenue report, it has not generated any
+		// revenue over the period.
+		reports := cfg.RevenueReport.ChannelPairs[channel.ChannelPoint]
 		for _, rev := range reports {
 			channelInsight.VolumeIncoming += rev.AmountIncoming
 			channelInsight.VolumeOutgoing += rev.AmountOutgoing
@@ -116,8 +278,55 @@ func GetChannels(cfg *Config) ([]*ChannelInfo, error) {
 				(rev.FeesOutgoing + rev.FeesIncoming) / 2
 		}
 
+		stats := cfg.RevenueReport.ChannelStats[channel.ChannelPoint]
+		if stats != nil {
+			channelInsight.IncomingForwards = stats.Incoming
+			channelInsight.OutgoingForwards = stats.Outgoing
+		}
+
+		channelInsight.RevenueBlocks = revenueBlocks(
+			confirmations, cfg.Lookback, cfg.DecayHalfLife,
+		)
+
+		channelInsight.LiquidityCost =
+			cfg.LiquidityCosts[channel.ChannelPoint]
+
+		channelInsight.NetFees = int64(channelInsight.FeesEarned) -
+			int64(channelInsight.LiquidityCost)
+
 		insights = append(insights, channelInsight)
 	}
 
 	return insights, nil
 }
+
+// revenueBlocks returns the effective number of blocks that a channel's
+// revenue was calculated over, or zero if revenue covers all of the channel's
+// confirmations with equal weight. If revenue only covers a lookback period
+// that is shorter than the time that the channel has been open for, only the
+// blocks in the period are counted, so that per confirmation values are not
+// diluted by blocks that are not covered. If forwards were weighted by a
+// decay half-life, each block is weighted by the same 0.5^(age/half-life)
+// as the forwards in it, which integrates to:
+// half-life * (1 - 0.5^(blocks/half-life)) / ln(2).
+func revenueBlocks(confirmations uint32, lookback,
+	halfLife time.Duration) float64 {
+
+	if lookback == 0 && halfLife == 0 {
+		return 0
+	}
+
+	blocks := float64(confirmations)
+	if lookback != 0 {
+		blocks = math.Min(blocks, float64(lookback)/float64(blockInterval))
+	}
+
+	if halfLife == 0 {
+		return blocks
+	}
+
+	halfLifeBlocks := float64(halfLife) / float64(blockInterval)
+
+	return halfLifeBlocks * (1 - math.Pow(0.5, blocks/halfLifeBlocks)) /
+		math.Ln2
+}
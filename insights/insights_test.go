@@ -1,10 +1,12 @@
 package insights
 
 import (
+	"math"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/faraday/revenue"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -25,6 +27,17 @@ func TestGetChannels(t *testing.T) {
 		ChannelPairs: map[string]map[string]revenue.Revenue{},
 	}
 
+	// incomingStats are the stats for forwards that arrived on our channel
+	// in our revenue report.
+	incomingStats := revenue.ForwardStats{
+		Count:      2,
+		MinSize:    10,
+		MedianSize: 10,
+		P90Size:    10,
+		MaxSize:    10,
+		FeeRatePPM: 1000,
+	}
+
 	// report is a revenue report with the channel opened in block 1000 in
 	// it.
 	report := &revenue.Report{
@@ -44,15 +57,38 @@ func TestGetChannels(t *testing.T) {
 				},
 			},
 		},
+		ChannelStats: map[string]*revenue.ChannelStats{
+			"a:1": {
+				Incoming: incomingStats,
+			},
+		},
 	}
 
 	hourInSeconds := int64(time.Hour.Seconds())
 
+	// uptimeHistory returns uptime history for a channel that covers a
+	// longer period than lnd's values.
+	uptimeHistory := func(_ string) (time.Duration, time.Duration, error) {
+		return time.Hour * 2, time.Hour * 2, nil
+	}
+
+	// shortHistory returns uptime history for a channel that covers a
+	// shorter period than lnd's values.
+	shortHistory := func(_ string) (time.Duration, time.Duration, error) {
+		return time.Minute, time.Minute, nil
+	}
+
 	tests := []struct {
-		name             string
-		channels         []*lnrpc.Channel
-		currentHeight    uint32
-		revenue          *revenue.Report
+		name          string
+		channels      []*lnrpc.Channel
+		currentHeight uint32
+		revenue       *revenue.Report
+		channelUptime func(string) (time.Duration, time.Duration,
+			error)
+		internalPeers  map[string]bool
+		channelBalance func(string) (time.Duration, time.Duration,
+			error)
+		liquidityCosts   map[string]lnwire.MilliSatoshi
 		expectedInsights []*ChannelInfo
 	}{
 		{
@@ -91,6 +127,7 @@ func TestGetChannels(t *testing.T) {
 					Lifetime:     hourInSeconds,
 					Uptime:       hourInSeconds / 2,
 					ChanId:       channelHeight1000.ToUint64(),
+					Capacity:     100000,
 				},
 			},
 			currentHeight: 1001,
@@ -100,11 +137,184 @@ func TestGetChannels(t *testing.T) {
 					ChannelPoint:   "a:1",
 					MonitoredFor:   time.Hour,
 					Uptime:         time.Minute * 30,
+					Capacity:       100000,
 					Confirmations:  2,
 					VolumeIncoming: 20,
 					VolumeOutgoing: 25,
 					FeesEarned:     20,
+					NetFees:        20,
 					Private:        false,
+
+					IncomingForwards: incomingStats,
+				},
+			},
+		},
+		{
+			name: "longer uptime history used",
+			channels: []*lnrpc.Channel{
+				{
+					ChannelPoint: "a:1",
+					Lifetime:     hourInSeconds,
+					Uptime:       hourInSeconds / 2,
+					ChanId:       channelHeight1000.ToUint64(),
+				},
+			},
+			currentHeight: 1000,
+			revenue:       noRevenue,
+			channelUptime: uptimeHistory,
+			expectedInsights: []*ChannelInfo{
+				{
+					ChannelPoint:  "a:1",
+					MonitoredFor:  time.Hour * 2,
+					Uptime:        time.Hour * 2,
+					Confirmations: 1,
+				},
+			},
+		},
+		{
+			name: "shorter uptime history not used",
+			channels: []*lnrpc.Channel{
+				{
+					ChannelPoint: "a:1",
+					Lifetime:     hourInSeconds,
+					Uptime:       hourInSeconds / 2,
+					ChanId:       channelHeight1000.ToUint64(),
+				},
+			},
+			currentHeight: 1000,
+			revenue:       noRevenue,
+			channelUptime: shortHistory,
+			expectedInsights: []*ChannelInfo{
+				{
+					ChannelPoint:  "a:1",
+					MonitoredFor:  time.Hour,
+					Uptime:        time.Minute * 30,
+					Confirmations: 1,
+				},
+			},
+		},
+		{
+			name: "internal channel",
+			channels: []*lnrpc.Channel{
+				{
+					ChannelPoint: "a:1",
+					RemotePubkey: "02aa",
+					Lifetime:     hourInSeconds,
+					Uptime:       hourInSeconds,
+					ChanId:       channelHeight1000.ToUint64(),
+				},
+				{
+					ChannelPoint: "a:2",
+					RemotePubkey: "02bb",
+					Lifetime:     hourInSeconds,
+					Uptime:       hourInSeconds,
+					ChanId:       channelHeight1000.ToUint64(),
+				},
+			},
+			currentHeight: 1000,
+			revenue:       noRevenue,
+			internalPeers: map[string]bool{"02bb": true},
+			expectedInsights: []*ChannelInfo{
+				{
+					ChannelPoint:  "a:1",
+					MonitoredFor:  time.Hour,
+					Uptime:        time.Hour,
+					Confirmations: 1,
+				},
+				{
+					ChannelPoint:  "a:2",
+					MonitoredFor:  time.Hour,
+					Uptime:        time.Hour,
+					Confirmations: 1,
+					Internal:      true,
+				},
+			},
+		},
+		{
+			name: "liquidity",
+			channels: []*lnrpc.Channel{
+				{
+					ChannelPoint:  "a:1",
+					Lifetime:      hourInSeconds,
+					Uptime:        hourInSeconds,
+					ChanId:        channelHeight1000.ToUint64(),
+					Capacity:      1000,
+					LocalBalance:  750,
+					RemoteBalance: 150,
+					PendingHtlcs: []*lnrpc.HTLC{
+						{Incoming: true, Amount: 50},
+						{Incoming: false, Amount: 20},
+						{Incoming: false, Amount: 30},
+					},
+				},
+			},
+			currentHeight: 1000,
+			revenue:       noRevenue,
+			channelBalance: func(_ string) (time.Duration,
+				time.Duration, error) {
+
+				return time.Hour, time.Minute, nil
+			},
+			expectedInsights: []*ChannelInfo{
+				{
+					ChannelPoint:     "a:1",
+					MonitoredFor:     time.Hour,
+					Uptime:           time.Hour,
+					Capacity:         1000,
+					LocalBalance:     750,
+					RemoteBalance:    150,
+					PendingIncoming:  50,
+					PendingOutgoing:  50,
+					BalanceRatio:     750.0 / 900.0,
+					BalanceMonitored: time.Hour,
+					OneSided:         time.Minute,
+					Confirmations:    1,
+				},
+			},
+		},
+		{
+			name: "liquidity costs",
+			channels: []*lnrpc.Channel{
+				{
+					ChannelPoint: "a:1",
+					Lifetime:     hourInSeconds,
+					Uptime:       hourInSeconds,
+					ChanId:       channelHeight1000.ToUint64(),
+				},
+				{
+					ChannelPoint: "a:2",
+					Lifetime:     hourInSeconds,
+					Uptime:       hourInSeconds,
+					ChanId:       channelHeight1000.ToUint64(),
+				},
+			},
+			currentHeight: 1000,
+			revenue:       report,
+			liquidityCosts: map[string]lnwire.MilliSatoshi{
+				"a:1": 30,
+				"a:2": 5,
+			},
+			expectedInsights: []*ChannelInfo{
+				{
+					ChannelPoint:   "a:1",
+					MonitoredFor:   time.Hour,
+					Uptime:         time.Hour,
+					Confirmations:  1,
+					VolumeIncoming: 20,
+					VolumeOutgoing: 25,
+					FeesEarned:     20,
+					LiquidityCost:  30,
+					NetFees:        -10,
+
+					IncomingForwards: incomingStats,
+				},
+				{
+					ChannelPoint:  "a:2",
+					MonitoredFor:  time.Hour,
+					Uptime:        time.Hour,
+					Confirmations: 1,
+					LiquidityCost: 5,
+					NetFees:       -5,
 				},
 			},
 		},
@@ -125,7 +335,11 @@ func TestGetChannels(t *testing.T) {
 				CurrentHeight: func() (u uint32, e error) {
 					return test.currentHeight, nil
 				},
-				RevenueReport: test.revenue,
+				RevenueReport:  test.revenue,
+				ChannelUptime:  test.channelUptime,
+				InternalPeers:  test.internalPeers,
+				ChannelBalance: test.channelBalance,
+				LiquidityCosts: test.liquidityCosts,
 			})
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
@@ -147,3 +361,121 @@ func TestGetChannels(t *testing.T) {
 		})
 	}
 }
+
+// TestIsOneSided tests identification of one-sided balance ratios.
+func TestIsOneSided(t *testing.T) {
+	tests := []struct {
+		name     string
+		local    btcutil.Amount
+		remote   btcutil.Amount
+		oneSided bool
+	}{
+		{
+			name:     "balanced",
+			local:    500,
+			remote:   500,
+			oneSided: false,
+		},
+		{
+			name:     "all local",
+			local:    1000,
+			remote:   0,
+			oneSided: true,
+		},
+		{
+			name:     "mostly remote",
+			local:    10,
+			remote:   990,
+			oneSided: true,
+		},
+		{
+			name:     "no balance",
+			local:    0,
+			remote:   0,
+			oneSided: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ratio := BalanceRatio(test.local, test.remote)
+			oneSided := IsOneSided(ratio, 0.05)
+			if oneSided != test.oneSided {
+				t.Fatalf("expected: %v, got: %v",
+					test.oneSided, oneSided)
+			}
+		})
+	}
+}
+
+// TestRevenueBlocks tests calculation of the effective number of blocks that
+// a channel's revenue covers.
+func TestRevenueBlocks(t *testing.T) {
+	day := time.Hour * 24
+
+	tests := []struct {
+		name          string
+		confirmations uint32
+		lookback      time.Duration
+		halfLife      time.Duration
+		expected      float64
+	}{
+		{
+			name:          "lifetime",
+			confirmations: 1000,
+			expected:      0,
+		},
+		{
+			name:          "lookback longer than channel",
+			confirmations: 100,
+			lookback:      day,
+			expected:      100,
+		},
+		{
+			name:          "lookback shorter than channel",
+			confirmations: 1000,
+			lookback:      day,
+			expected:      144,
+		},
+		{
+			name:          "one half-life",
+			confirmations: 144,
+			halfLife:      day,
+			expected:      72 / math.Ln2,
+		},
+		{
+			name:          "half-life and lookback",
+			confirmations: 1000,
+			lookback:      day,
+			halfLife:      day,
+			expected:      72 / math.Ln2,
+		},
+		{
+			name:          "many half-lives",
+			confirmations: 144 * 100,
+			halfLife:      day,
+			expected:      144 / math.Ln2,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			blocks := revenueBlocks(
+				test.confirmations, test.lookback,
+				test.halfLife,
+			)
+			if math.Abs(blocks-test.expected) > 1e-6 {
+				t.Fatalf("expected: %v, got: %v",
+					test.expected, blocks)
+			}
+		})
+	}
+}
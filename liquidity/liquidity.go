@@ -0,0 +1,430 @@
+// Package liquidity accounts for the cost of the swaps that are used to
+// manage the liquidity of our channels. Loop Out swaps move funds out of a
+// channel to refill its inbound liquidity, and Loop In swaps move on-chain
+// funds into a channel to refill its outbound liquidity. Each swap pays:
+//   - A server fee, which includes the prepayment made for Loop Out swaps
+//   - On-chain fees for the swap's htlc transactions
+//   - Off-chain routing fees for the swap and prepay payments
+//
+// Where possible, these costs are attributed to the channels that a swap
+// rebalanced. Loop Out swaps are attributed to the channels in their outgoing
+// channel set, and Loop In swaps are attributed to the channels that we had
+// open with the peer that was used as their last hop when the swap was
+// initiated. If a swap rebalanced more than
+// one channel, its costs are split evenly between them. Swaps that cannot be
+// tied to a channel are reported as unattributed.
+package liquidity
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// SwapType is the type of a swap.
+type SwapType int
+
+const (
+	// LoopOut is a swap which moves funds off-chain to on-chain.
+	LoopOut SwapType = iota
+
+	// LoopIn is a swap which moves funds on-chain to off-chain.
+	LoopIn
+)
+
+// String returns the name of a swap type.
+func (s SwapType) String() string {
+	switch s {
+	case LoopOut:
+		return "loop_out"
+
+	case LoopIn:
+		return "loop_in"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Swap describes a swap and the costs that we paid for it.
+type Swap struct {
+	// ID is the swap's identifier.
+	ID string
+
+	// Type is the type of swap.
+	Type SwapType
+
+	// State is the swap's current state.
+	State string
+
+	// Initiated is the time that the swap was initiated.
+	Initiated time.Time
+
+	// Amount is the amount that was swapped, excluding fees.
+	Amount btcutil.Amount
+
+	// ServerCost is the fee paid to the swap server.
+	ServerCost btcutil.Amount
+
+	// OnChainCost is the on-chain fees paid for the swap.
+	OnChainCost btcutil.Amount
+
+	// OffChainCost is the routing fees paid for the swap.
+	OffChainCost btcutil.Amount
+
+	// OutgoingChannels is the set of short channel ids that a Loop Out
+	// swap was restricted to.
+	OutgoingChannels []uint64
+
+	// LastHop is the hex encoded public key of the peer that a Loop In
+	// swap was restricted to use as its last hop.
+	LastHop string
+}
+
+// Cost is the cost of a set of swaps.
+type Cost struct {
+	// Swaps is the number of swaps that contributed to the cost.
+	Swaps int
+
+	// Amount is the total amount that was swapped.
+	Amount btcutil.Amount
+
+	// Server is the total fees paid to the swap server.
+	Server btcutil.Amount
+
+	// OnChain is the total on-chain fees paid.
+	OnChain btcutil.Amount
+
+	// OffChain is the total routing fees paid.
+	OffChain btcutil.Amount
+}
+
+// Total returns the total cost of the swaps.
+func (c *Cost) Total() btcutil.Amount {
+	return c.Server + c.OnChain + c.OffChain
+}
+
+// add adds a share of a swap to the cost.
+func (c *Cost) add(share *Cost) {
+	c.Swaps += share.Swaps
+	c.Amount += share.Amount
+	c.Server += share.Server
+	c.OnChain += share.OnChain
+	c.OffChain += share.OffChain
+}
+
+// SwapCost is a swap and the channels that its cost was attributed to.
+type SwapCost struct {
+	*Swap
+
+	// Channels is the set of channel outpoints that the swap's cost was
+	// attributed to. It is empty if the swap could not be tied to a
+	// channel.
+	Channels []string
+}
+
+// Report is a report of the cost of the swaps that we made over a period.
+type Report struct {
+	// Total is the total cost of all swaps.
+	Total Cost
+
+	// Unattributed is the cost of the swaps that could not be tied to a
+	// channel.
+	Unattributed Cost
+
+	// Channels maps channel outpoints to the cost of the swaps that were
+	// attributed to them.
+	Channels map[string]*Cost
+
+	// Swaps is the set of swaps in the report, in the order they were
+	// initiated.
+	Swaps []*SwapCost
+}
+
+// MsatCosts returns the total cost attributed to each channel in the report
+// in millisatoshis.
+func (r *Report) MsatCosts() map[string]lnwire.MilliSatoshi {
+	costs := make(map[string]lnwire.MilliSatoshi, len(r.Channels))
+	for chanPoint, cost := range r.Channels {
+		costs[chanPoint] = lnwire.NewMSatFromSatoshis(cost.Total())
+	}
+
+	return costs
+}
+
+// DecayedMsatCosts returns the cost attributed to each channel in the report
+// in millisatoshis, with each swap's cost weighted by its age so that it can
+// be compared with revenue that is weighted by the same half-life. Each swap
+// is weighted by 0.5^(age/halfLife), and swaps initiated after the current
+// time are not weighted. Unattributed swaps are not included.
+func (r *Report) DecayedMsatCosts(now time.Time,
+	halfLife time.Duration) map[string]lnwire.MilliSatoshi {
+
+	costs := make(map[string]lnwire.MilliSatoshi, len(r.Channels))
+	for _, swap := range r.Swaps {
+		if len(swap.Channels) == 0 {
+			continue
+		}
+
+		total := lnwire.NewMSatFromSatoshis(
+			swap.ServerCost + swap.OnChainCost + swap.OffChainCost,
+		)
+
+		if age := now.Sub(swap.Initiated); age > 0 {
+			weight := math.Pow(0.5, float64(age)/float64(halfLife))
+			total = lnwire.MilliSatoshi(float64(total) * weight)
+		}
+
+		// Split the decayed cost evenly between the swap's channels,
+		// adding any remainder to the first channel as we do for
+		// undecayed costs.
+		n := lnwire.MilliSatoshi(len(swap.Channels))
+		share := total / n
+		for i, chanPoint := range swap.Channels {
+			costs[chanPoint] += share
+			if i == 0 {
+				costs[chanPoint] += total - share*n
+			}
+		}
+	}
+
+	return costs
+}
+
+// Config contains the functions required to produce a liquidity cost
+// report.
+type Config struct {
+	// ListSwaps returns all of the swaps that we have made.
+	ListSwaps func() ([]*Swap, error)
+
+	// ListChannels returns all of our open channels.
+	ListChannels func() ([]*lnrpc.Channel, error)
+
+	// ClosedChannels returns all of our closed channels.
+	ClosedChannels func() ([]*lnrpc.ChannelCloseSummary, error)
+
+	// HeightAt returns the block height at the time provided, which is
+	// used to find the channels that we had open with a peer when a Loop
+	// In swap was initiated.
+	HeightAt func(time.Time) (uint32, error)
+
+	// StartTime is the start of the period that swaps are included for.
+	// If it is zero, swaps are included from the start of our swap
+	// history.
+	StartTime time.Time
+
+	// EndTime is the end of the period that swaps are included for. If it
+	// is zero, swaps are included until the present.
+	EndTime time.Time
+}
+
+// GetReport produces a report of the cost of the swaps initiated over the
+// period in our config.
+func GetReport(cfg *Config) (*Report, error) {
+	swaps, err := cfg.ListSwaps()
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := cfg.ListChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	closedChannels, err := cfg.ClosedChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	// Map our short channel ids to outpoints so that we can find the
+	// channels that Loop Out swaps used, and our peers to all of the
+	// channels we have had with them so that we can find the channels
+	// that Loop In swaps used.
+	channelIDs := make(map[uint64]string)
+	peerChannels := make(map[string][]*peerChannel)
+	for _, channel := range channels {
+		channelIDs[channel.ChanId] = channel.ChannelPoint
+		peerChannels[channel.RemotePubkey] = append(
+			peerChannels[channel.RemotePubkey], &peerChannel{
+				chanPoint: channel.ChannelPoint,
+				chanID:    channel.ChanId,
+			},
+		)
+	}
+
+	for _, channel := range closedChannels {
+		channelIDs[channel.ChanId] = channel.ChannelPoint
+		peerChannels[channel.RemotePubkey] = append(
+			peerChannels[channel.RemotePubkey], &peerChannel{
+				chanPoint:   channel.ChannelPoint,
+				chanID:      channel.ChanId,
+				closeHeight: channel.CloseHeight,
+			},
+		)
+	}
+
+	report := &Report{
+		Channels: make(map[string]*Cost),
+	}
+
+	for _, swap := range filterSwaps(swaps, cfg.StartTime, cfg.EndTime) {
+		var swapChannels []string
+		switch swap.Type {
+		case LoopOut:
+			for _, id := range swap.OutgoingChannels {
+				chanPoint, ok := channelIDs[id]
+				if !ok {
+					log.Debugf("Swap: %v used unknown "+
+						"channel: %v", swap.ID,
+						lnwire.NewShortChanIDFromInt(id))
+
+					continue
+				}
+
+				swapChannels = append(swapChannels, chanPoint)
+			}
+
+		case LoopIn:
+			if swap.LastHop == "" {
+				break
+			}
+
+			height, err := cfg.HeightAt(swap.Initiated)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, channel := range peerChannels[swap.LastHop] {
+				if channel.openAt(height) {
+					swapChannels = append(
+						swapChannels, channel.chanPoint,
+					)
+				}
+			}
+		}
+
+		report.addSwap(swap, swapChannels)
+	}
+
+	return report, nil
+}
+
+// peerChannel is a channel that we have had open with a peer.
+type peerChannel struct {
+	// chanPoint is the outpoint of the channel's funding transaction.
+	chanPoint string
+
+	// chanID is the short channel id of the channel, which contains the
+	// height that it was opened at.
+	chanID uint64
+
+	// closeHeight is the height that the channel was closed at, which is
+	// zero if the channel is still open.
+	closeHeight uint32
+}
+
+// openAt returns a boolean indicating whether the channel was open at the
+// height provided.
+func (p *peerChannel) openAt(height uint32) bool {
+	openHeight := lnwire.NewShortChanIDFromInt(p.chanID).BlockHeight
+	if openHeight > height {
+		return false
+	}
+
+	return p.closeHeight == 0 || p.closeHeight > height
+}
+
+// filterSwaps returns the swaps that were initiated within the period
+// provided, inclusive of start and exclusive of end, sorted by initiation
+// time. Zero start or end times do not restrict the period.
+func filterSwaps(swaps []*Swap, start, end time.Time) []*Swap {
+	var filtered []*Swap
+	for _, swap := range swaps {
+		if !start.IsZero() && swap.Initiated.Before(start) {
+			continue
+		}
+
+		if !end.IsZero() && !swap.Initiated.Before(end) {
+			continue
+		}
+
+		filtered = append(filtered, swap)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Initiated.Before(filtered[j].Initiated)
+	})
+
+	return filtered
+}
+
+// addSwap adds a swap to the report, splitting its cost evenly between the
+// channels provided. If no channels are provided, the swap's cost is
+// unattributed.
+func (r *Report) addSwap(swap *Swap, channels []string) {
+	cost := &Cost{
+		Swaps:    1,
+		Amount:   swap.Amount,
+		Server:   swap.ServerCost,
+		OnChain:  swap.OnChainCost,
+		OffChain: swap.OffChainCost,
+	}
+
+	r.Total.add(cost)
+	r.Swaps = append(r.Swaps, &SwapCost{
+		Swap:     swap,
+		Channels: channels,
+	})
+
+	if len(channels) == 0 {
+		r.Unattributed.add(cost)
+		return
+	}
+
+	for i, share := range splitCost(cost, len(channels)) {
+		channelCost, ok := r.Channels[channels[i]]
+		if !ok {
+			channelCost = &Cost{}
+			r.Channels[channels[i]] = channelCost
+		}
+
+		channelCost.add(share)
+	}
+}
+
+// splitCost splits a cost into n shares. Any remainder left by dividing
+// amounts evenly is added to the first share so that the total cost is
+// preserved. Each share counts the swap.
+func splitCost(cost *Cost, n int) []*Cost {
+	split := func(amt btcutil.Amount) (btcutil.Amount, btcutil.Amount) {
+		share := amt / btcutil.Amount(n)
+		return share, amt - share*btcutil.Amount(n)
+	}
+
+	amount, amountRem := split(cost.Amount)
+	server, serverRem := split(cost.Server)
+	onChain, onChainRem := split(cost.OnChain)
+	offChain, offChainRem := split(cost.OffChain)
+
+	shares := make([]*Cost, n)
+	for i := range shares {
+		shares[i] = &Cost{
+			Swaps:    cost.Swaps,
+			Amount:   amount,
+			Server:   server,
+			OnChain:  onChain,
+			OffChain: offChain,
+		}
+	}
+
+	shares[0].Amount += amountRem
+	shares[0].Server += serverRem
+	shares[0].OnChain += onChainRem
+	shares[0].OffChain += offChainRem
+
+	return shares
+}
@@ -0,0 +1,381 @@
+package liquidity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestGetReport tests attribution of swap costs to the channels that they
+// rebalanced.
+func TestGetReport(t *testing.T) {
+	var (
+		testErr = errors.New("intentional test error")
+
+		chan1 = &lnrpc.Channel{
+			ChannelPoint: "a:1",
+			ChanId:       1,
+			RemotePubkey: "peer1",
+		}
+
+		chan2 = &lnrpc.Channel{
+			ChannelPoint: "a:2",
+			ChanId:       2,
+			RemotePubkey: "peer2",
+		}
+
+		closed = &lnrpc.ChannelCloseSummary{
+			ChannelPoint: "b:1",
+			ChanId:       3,
+			RemotePubkey: "peer1",
+		}
+	)
+
+	// loopOut is a swap that rebalanced our first channel.
+	loopOut := &Swap{
+		ID:               "out",
+		Type:             LoopOut,
+		Initiated:        time.Unix(100, 0),
+		Amount:           100000,
+		ServerCost:       100,
+		OnChainCost:      50,
+		OffChainCost:     10,
+		OutgoingChannels: []uint64{chan1.ChanId},
+	}
+
+	// loopOutSplit is a swap that rebalanced our second channel and a
+	// channel that has since closed.
+	loopOutSplit := &Swap{
+		ID:               "split",
+		Type:             LoopOut,
+		Initiated:        time.Unix(200, 0),
+		Amount:           100001,
+		ServerCost:       101,
+		OnChainCost:      51,
+		OffChainCost:     11,
+		OutgoingChannels: []uint64{chan2.ChanId, closed.ChanId},
+	}
+
+	// loopIn is a swap that used our second peer as its last hop.
+	loopIn := &Swap{
+		ID:          "in",
+		Type:        LoopIn,
+		Initiated:   time.Unix(300, 0),
+		Amount:      50000,
+		ServerCost:  40,
+		OnChainCost: 30,
+		LastHop:     "peer2",
+	}
+
+	// unattributed is a swap which did not specify any channels.
+	unattributed := &Swap{
+		ID:          "any",
+		Type:        LoopOut,
+		Initiated:   time.Unix(400, 0),
+		Amount:      20000,
+		ServerCost:  20,
+		OnChainCost: 20,
+	}
+
+	allSwaps := []*Swap{unattributed, loopIn, loopOutSplit, loopOut}
+
+	tests := []struct {
+		name        string
+		swaps       []*Swap
+		swapsErr    error
+		start       time.Time
+		end         time.Time
+		expected    *Report
+		expectedErr error
+	}{
+		{
+			name:        "list swaps fails",
+			swapsErr:    testErr,
+			expectedErr: testErr,
+		},
+		{
+			name:  "all swaps",
+			swaps: allSwaps,
+			expected: &Report{
+				Total: Cost{
+					Swaps:    4,
+					Amount:   270001,
+					Server:   261,
+					OnChain:  151,
+					OffChain: 21,
+				},
+				Unattributed: Cost{
+					Swaps:   1,
+					Amount:  20000,
+					Server:  20,
+					OnChain: 20,
+				},
+				Channels: map[string]*Cost{
+					chan1.ChannelPoint: {
+						Swaps:    1,
+						Amount:   100000,
+						Server:   100,
+						OnChain:  50,
+						OffChain: 10,
+					},
+					chan2.ChannelPoint: {
+						Swaps:    2,
+						Amount:   100001,
+						Server:   91,
+						OnChain:  56,
+						OffChain: 6,
+					},
+					closed.ChannelPoint: {
+						Swaps:    1,
+						Amount:   50000,
+						Server:   50,
+						OnChain:  25,
+						OffChain: 5,
+					},
+				},
+				Swaps: []*SwapCost{
+					{
+						Swap: loopOut,
+						Channels: []string{
+							chan1.ChannelPoint,
+						},
+					},
+					{
+						Swap: loopOutSplit,
+						Channels: []string{
+							chan2.ChannelPoint,
+							closed.ChannelPoint,
+						},
+					},
+					{
+						Swap: loopIn,
+						Channels: []string{
+							chan2.ChannelPoint,
+						},
+					},
+					{
+						Swap: unattributed,
+					},
+				},
+			},
+		},
+		{
+			name:  "period filtered",
+			swaps: allSwaps,
+			start: time.Unix(250, 0),
+			end:   time.Unix(350, 0),
+			expected: &Report{
+				Total: Cost{
+					Swaps:   1,
+					Amount:  50000,
+					Server:  40,
+					OnChain: 30,
+				},
+				Channels: map[string]*Cost{
+					chan2.ChannelPoint: {
+						Swaps:   1,
+						Amount:  50000,
+						Server:  40,
+						OnChain: 30,
+					},
+				},
+				Swaps: []*SwapCost{
+					{
+						Swap: loopIn,
+						Channels: []string{
+							chan2.ChannelPoint,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := GetReport(&Config{
+				ListSwaps: func() ([]*Swap, error) {
+					return test.swaps, test.swapsErr
+				},
+				ListChannels: func() ([]*lnrpc.Channel, error) {
+					return []*lnrpc.Channel{
+						chan1, chan2,
+					}, nil
+				},
+				ClosedChannels: func() (
+					[]*lnrpc.ChannelCloseSummary, error) {
+
+					return []*lnrpc.ChannelCloseSummary{
+						closed,
+					}, nil
+				},
+				HeightAt:  testHeightAt,
+				StartTime: test.start,
+				EndTime:   test.end,
+			})
+			if err != test.expectedErr {
+				t.Fatalf("expected: %v, got: %v",
+					test.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(test.expected, report) {
+				t.Fatalf("expected: %+v, got: %+v",
+					test.expected, report)
+			}
+		})
+	}
+}
+
+// testHeightAt maps each second since the unix epoch to a block height, so
+// that test swaps' times can be compared to channel heights directly.
+func testHeightAt(t time.Time) (uint32, error) {
+	return uint32(t.Unix()), nil
+}
+
+// TestGetReportLoopInClosed tests that Loop In swaps are attributed to the
+// channels that we had open with their last hop when they were initiated,
+// including channels that have since closed.
+func TestGetReportLoopInClosed(t *testing.T) {
+	// newChanID returns a short channel id for a channel opened at the
+	// height provided.
+	newChanID := func(height, index uint32) uint64 {
+		return lnwire.ShortChannelID{
+			BlockHeight: height,
+			TxIndex:     index,
+		}.ToUint64()
+	}
+
+	var (
+		// opened is a channel that was opened after our first swap.
+		opened = &lnrpc.Channel{
+			ChannelPoint: "a:1",
+			ChanId:       newChanID(250, 1),
+			RemotePubkey: "peer1",
+		}
+
+		// closedEarly is a channel that closed between our swaps.
+		closedEarly = &lnrpc.ChannelCloseSummary{
+			ChannelPoint: "b:1",
+			ChanId:       newChanID(50, 2),
+			CloseHeight:  200,
+			RemotePubkey: "peer1",
+		}
+
+		// closedLate is a channel that closed after both of our
+		// swaps.
+		closedLate = &lnrpc.ChannelCloseSummary{
+			ChannelPoint: "b:2",
+			ChanId:       newChanID(50, 3),
+			CloseHeight:  400,
+			RemotePubkey: "peer1",
+		}
+
+		first = &Swap{
+			ID:        "first",
+			Type:      LoopIn,
+			Initiated: time.Unix(100, 0),
+			LastHop:   "peer1",
+		}
+
+		second = &Swap{
+			ID:        "second",
+			Type:      LoopIn,
+			Initiated: time.Unix(300, 0),
+			LastHop:   "peer1",
+		}
+	)
+
+	report, err := GetReport(&Config{
+		ListSwaps: func() ([]*Swap, error) {
+			return []*Swap{first, second}, nil
+		},
+		ListChannels: func() ([]*lnrpc.Channel, error) {
+			return []*lnrpc.Channel{opened}, nil
+		},
+		ClosedChannels: func() ([]*lnrpc.ChannelCloseSummary, error) {
+			return []*lnrpc.ChannelCloseSummary{
+				closedEarly, closedLate,
+			}, nil
+		},
+		HeightAt: testHeightAt,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []*SwapCost{
+		{
+			Swap: first,
+			Channels: []string{
+				closedEarly.ChannelPoint,
+				closedLate.ChannelPoint,
+			},
+		},
+		{
+			Swap: second,
+			Channels: []string{
+				opened.ChannelPoint, closedLate.ChannelPoint,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, report.Swaps) {
+		t.Fatalf("expected: %+v, got: %+v", expected, report.Swaps)
+	}
+}
+
+// TestDecayedMsatCosts tests weighting of swap costs by their age.
+func TestDecayedMsatCosts(t *testing.T) {
+	var (
+		now      = time.Unix(10000, 0)
+		halfLife = time.Hour
+	)
+
+	report := &Report{
+		Swaps: []*SwapCost{
+			{
+				// A current swap is not decayed.
+				Swap: &Swap{
+					Initiated:  now,
+					ServerCost: 2,
+				},
+				Channels: []string{"a:1"},
+			},
+			{
+				// A swap that is one half-life old is worth
+				// half of its cost, split between channels.
+				Swap: &Swap{
+					Initiated:   now.Add(halfLife * -1),
+					ServerCost:  2,
+					OnChainCost: 1,
+				},
+				Channels: []string{"a:1", "a:2"},
+			},
+			{
+				// Unattributed swaps are not included.
+				Swap: &Swap{
+					Initiated:  now,
+					ServerCost: 10,
+				},
+			},
+		},
+	}
+
+	expected := map[string]lnwire.MilliSatoshi{
+		"a:1": 2750,
+		"a:2": 750,
+	}
+
+	costs := report.DecayedMsatCosts(now, halfLife)
+	if !reflect.DeepEqual(expected, costs) {
+		t.Fatalf("expected: %v, got: %v", expected, costs)
+	}
+}
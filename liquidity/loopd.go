@@ -0,0 +1,111 @@
+package liquidity
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/golang/protobuf/proto"
+	"github.com/lightninglabs/loop/looprpc"
+	"google.golang.org/grpc"
+)
+
+// listSwapsMethod is the full name of loopd's ListSwaps rpc. The version of
+// looprpc that we depend on predates this rpc, so we call it directly with
+// message types that mirror loopd's.
+const listSwapsMethod = "/looprpc.SwapClient/ListSwaps"
+
+// listSwapsRequest mirrors loopd's ListSwapsRequest message. We do not set
+// any filters, so that all swaps are returned.
+type listSwapsRequest struct{}
+
+func (m *listSwapsRequest) Reset()         { *m = listSwapsRequest{} }
+func (m *listSwapsRequest) String() string { return proto.CompactTextString(m) }
+func (*listSwapsRequest) ProtoMessage()    {}
+
+// listSwapsResponse mirrors loopd's ListSwapsResponse message.
+type listSwapsResponse struct {
+	Swaps []*swapStatus `protobuf:"bytes,1,rep,name=swaps,proto3"`
+}
+
+func (m *listSwapsResponse) Reset()         { *m = listSwapsResponse{} }
+func (m *listSwapsResponse) String() string { return proto.CompactTextString(m) }
+func (*listSwapsResponse) ProtoMessage()    {}
+
+// swapStatus mirrors the fields of loopd's SwapStatus message that we use.
+// Fields one to ten are shared with the looprpc version that we depend on,
+// the last hop and outgoing channel set fields were added to loopd later.
+type swapStatus struct {
+	Amt             int64             `protobuf:"varint,1,opt,name=amt,proto3"`
+	Id              string            `protobuf:"bytes,2,opt,name=id,proto3"`
+	Type            looprpc.SwapType  `protobuf:"varint,3,opt,name=type,proto3,enum=looprpc.SwapType"`
+	State           looprpc.SwapState `protobuf:"varint,4,opt,name=state,proto3,enum=looprpc.SwapState"`
+	InitiationTime  int64             `protobuf:"varint,5,opt,name=initiation_time,json=initiationTime,proto3"`
+	CostServer      int64             `protobuf:"varint,8,opt,name=cost_server,json=costServer,proto3"`
+	CostOnchain     int64             `protobuf:"varint,9,opt,name=cost_onchain,json=costOnchain,proto3"`
+	CostOffchain    int64             `protobuf:"varint,10,opt,name=cost_offchain,json=costOffchain,proto3"`
+	LastHop         []byte            `protobuf:"bytes,16,opt,name=last_hop,json=lastHop,proto3"`
+	OutgoingChanSet []uint64          `protobuf:"varint,17,rep,packed,name=outgoing_chan_set,json=outgoingChanSet,proto3"`
+}
+
+func (m *swapStatus) Reset()         { *m = swapStatus{} }
+func (m *swapStatus) String() string { return proto.CompactTextString(m) }
+func (*swapStatus) ProtoMessage()    {}
+
+// ListSwaps returns a function which lists all of the swaps that a loopd
+// instance has made over the connection provided.
+func ListSwaps(conn *grpc.ClientConn,
+	timeout time.Duration) func() ([]*Swap, error) {
+
+	return func() ([]*Swap, error) {
+		ctx, cancel := context.WithTimeout(
+			context.Background(), timeout,
+		)
+		defer cancel()
+
+		resp := &listSwapsResponse{}
+		err := conn.Invoke(
+			ctx, listSwapsMethod, &listSwapsRequest{}, resp,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		swaps := make([]*Swap, 0, len(resp.Swaps))
+		for _, status := range resp.Swaps {
+			swaps = append(swaps, parseSwap(status))
+		}
+
+		return swaps, nil
+	}
+}
+
+// parseSwap converts a loopd swap status to a swap.
+func parseSwap(status *swapStatus) *Swap {
+	swap := &Swap{
+		ID:    status.Id,
+		State: status.State.String(),
+		// Loopd reports initiation time in nanoseconds.
+		Initiated:        time.Unix(0, status.InitiationTime),
+		Amount:           btcutil.Amount(status.Amt),
+		ServerCost:       btcutil.Amount(status.CostServer),
+		OnChainCost:      btcutil.Amount(status.CostOnchain),
+		OffChainCost:     btcutil.Amount(status.CostOffchain),
+		OutgoingChannels: status.OutgoingChanSet,
+	}
+
+	switch status.Type {
+	case looprpc.SwapType_LOOP_IN:
+		swap.Type = LoopIn
+
+	default:
+		swap.Type = LoopOut
+	}
+
+	if len(status.LastHop) != 0 {
+		swap.LastHop = hex.EncodeToString(status.LastHop)
+	}
+
+	return swap
+}
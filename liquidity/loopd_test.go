@@ -0,0 +1,159 @@
+package liquidity
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lightninglabs/loop/looprpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// TestSwapStatusCompatible tests that the swap status fields we share with
+// looprpc are decoded from its encoding.
+func TestSwapStatusCompatible(t *testing.T) {
+	status := &looprpc.SwapStatus{
+		Amt:            100000,
+		Id:             "abcd",
+		Type:           looprpc.SwapType_LOOP_IN,
+		State:          looprpc.SwapState_SUCCESS,
+		InitiationTime: 1000,
+		LastUpdateTime: 2000,
+		HtlcAddress:    "address",
+		CostServer:     100,
+		CostOnchain:    50,
+		CostOffchain:   10,
+	}
+
+	b, err := proto.Marshal(status)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded := &swapStatus{}
+	if err := proto.Unmarshal(b, decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &swapStatus{
+		Amt:            100000,
+		Id:             "abcd",
+		Type:           looprpc.SwapType_LOOP_IN,
+		State:          looprpc.SwapState_SUCCESS,
+		InitiationTime: 1000,
+		CostServer:     100,
+		CostOnchain:    50,
+		CostOffchain:   10,
+	}
+
+	if !proto.Equal(expected, decoded) {
+		t.Fatalf("expected: %v, got: %v", expected, decoded)
+	}
+}
+
+// TestListSwaps tests listing of swaps from a loopd server.
+func TestListSwaps(t *testing.T) {
+	statuses := []*swapStatus{
+		{
+			Amt:             100000,
+			Id:              "out",
+			Type:            looprpc.SwapType_LOOP_OUT,
+			State:           looprpc.SwapState_SUCCESS,
+			InitiationTime:  int64(time.Second * 100),
+			CostServer:      100,
+			CostOnchain:     50,
+			CostOffchain:    10,
+			OutgoingChanSet: []uint64{1, 2},
+		},
+		{
+			Amt:            50000,
+			Id:             "in",
+			Type:           looprpc.SwapType_LOOP_IN,
+			State:          looprpc.SwapState_FAILED,
+			InitiationTime: int64(time.Second * 200),
+			CostOnchain:    30,
+			LastHop:        []byte{0x02, 0xab},
+		},
+	}
+
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "looprpc.SwapClient",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "ListSwaps",
+				Handler: func(_ interface{},
+					_ context.Context,
+					dec func(interface{}) error,
+					_ grpc.UnaryServerInterceptor) (
+					interface{}, error) {
+
+					req := &listSwapsRequest{}
+					if err := dec(req); err != nil {
+						return nil, err
+					}
+
+					return &listSwapsResponse{
+						Swaps: statuses,
+					}, nil
+				},
+			},
+		},
+	}, struct{}{})
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.Dial(
+		"bufnet", grpc.WithInsecure(),
+		grpc.WithDialer(func(string, time.Duration) (net.Conn,
+			error) {
+
+			return listener.Dial()
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	swaps, err := ListSwaps(conn, time.Second*5)()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []*Swap{
+		{
+			ID:               "out",
+			Type:             LoopOut,
+			State:            "SUCCESS",
+			Initiated:        time.Unix(100, 0),
+			Amount:           100000,
+			ServerCost:       100,
+			OnChainCost:      50,
+			OffChainCost:     10,
+			OutgoingChannels: []uint64{1, 2},
+		},
+		{
+			ID:          "in",
+			Type:        LoopIn,
+			State:       "FAILED",
+			Initiated:   time.Unix(200, 0),
+			Amount:      50000,
+			OnChainCost: 30,
+			LastHop:     "02ab",
+		},
+	}
+
+	if !reflect.DeepEqual(expected, swaps) {
+		t.Fatalf("expected: %+v, got: %+v", expected, swaps)
+	}
+}
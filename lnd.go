@@ -0,0 +1,129 @@
+package faraday
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/macaroon.v2"
+)
+
+var (
+	// defaultLndDir is the default directory for lnd's files.
+	defaultLndDir = btcutil.AppDataDir("lnd", false)
+
+	// defaultLndTLSCert is the default path to lnd's tls cert.
+	defaultLndTLSCert = filepath.Join(defaultLndDir, "tls.cert")
+
+	// maxLndMsgRecvSize is the largest message we will receive from lnd.
+	// We set this to 200MiB so that large forwarding history pages can be
+	// read.
+	maxLndMsgRecvSize = grpc.MaxCallRecvMsgSize(1 * 1024 * 1024 * 200)
+)
+
+// defaultLndMacaroonDir returns the default directory for lnd's macaroons on
+// the network provided.
+func defaultLndMacaroonDir(network string) string {
+	return filepath.Join(
+		defaultLndDir, "data", "chain", "bitcoin", network,
+	)
+}
+
+// dialLnd creates a new connection to a lnd node, reading its tls cert and
+// macaroon from disk. Files are read each time we connect so that a
+// regenerated cert is picked up when we reconnect. It returns a client and a
+// function which closes the connection.
+func dialLnd(node *nodeConfig, network string) (lnrpc.LightningClient,
+	func(), error) {
+
+	tlsPath := node.tlsCertPath
+	if tlsPath == "" {
+		tlsPath = defaultLndTLSCert
+	}
+
+	creds, err := credentials.NewClientTLSFromFile(tlsPath, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read tls cert: %v", err)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithContextDialer(lndDialer),
+		grpc.WithDefaultCallOptions(maxLndMsgRecvSize),
+	}
+
+	macCreds, err := macaroonCredentials(node, network)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if macCreds != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(macCreds))
+	}
+
+	conn, err := grpc.Dial(node.rpcServer, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not connect to lnd: %v", err)
+	}
+
+	closeConn := func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf("could not close lnd connection: %v", err)
+		}
+	}
+
+	return lnrpc.NewLightningClient(conn), closeConn, nil
+}
+
+// macaroonCredentials reads the macaroon that a node is configured with and
+// returns credentials which attach it to our requests. Nil credentials are
+// returned if the node is configured to connect without macaroons. A missing
+// macaroon is an error otherwise, so that we do not silently connect without
+// credentials.
+func macaroonCredentials(node *nodeConfig,
+	network string) (credentials.PerRPCCredentials, error) {
+
+	if node.noMacaroons {
+		return nil, nil
+	}
+
+	macDir := node.macaroonDir
+	if macDir == "" {
+		macDir = defaultLndMacaroonDir(network)
+	}
+
+	macPath := filepath.Join(macDir, node.macaroonFile)
+	macBytes, err := ioutil.ReadFile(macPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read macaroon: %v", err)
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return nil, fmt.Errorf("could not decode macaroon: %v", err)
+	}
+
+	return macaroons.NewMacaroonCredential(mac), nil
+}
+
+// lndDialer dials lnd's rpc server, which may be listening on a unix socket
+// or a tcp address.
+func lndDialer(ctx context.Context, addr string) (net.Conn, error) {
+	parsedAddr, err := lncfg.ParseAddressString(
+		addr, defaultRPCPort, net.ResolveTCPAddr,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	d := net.Dialer{}
+	return d.DialContext(ctx, parsedAddr.Network(), parsedAddr.String())
+}
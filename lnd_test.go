@@ -0,0 +1,57 @@
+package faraday
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// TestMacaroonCredentials tests that a missing macaroon is an error unless
+// macaroons are disabled.
+func TestMacaroonCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "faraday")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name      string
+		node      *nodeConfig
+		expectErr bool
+	}{
+		{
+			name: "missing macaroon",
+			node: &nodeConfig{
+				macaroonDir:  dir,
+				macaroonFile: defaultMacaroon,
+			},
+			expectErr: true,
+		},
+		{
+			name: "macaroons disabled",
+			node: &nodeConfig{
+				macaroonDir:  dir,
+				macaroonFile: defaultMacaroon,
+				noMacaroons:  true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			creds, err := macaroonCredentials(test.node, "mainnet")
+			if (err != nil) != test.expectErr {
+				t.Fatalf("expected error: %v, got: %v",
+					test.expectErr, err)
+			}
+
+			if creds != nil {
+				t.Fatalf("expected no credentials, got: %v",
+					creds)
+			}
+		})
+	}
+}
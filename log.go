@@ -2,10 +2,22 @@ package faraday
 
 import (
 	"github.com/btcsuite/btclog"
+	"github.com/lightninglabs/faraday/alert"
+	"github.com/lightninglabs/faraday/backtest"
+	"github.com/lightninglabs/faraday/closer"
+	"github.com/lightninglabs/faraday/dashboard"
 	"github.com/lightninglabs/faraday/dataset"
+	"github.com/lightninglabs/faraday/fiat"
+	"github.com/lightninglabs/faraday/flowgraph"
 	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/lightninglabs/faraday/liquidity"
+	"github.com/lightninglabs/faraday/offline"
 	"github.com/lightninglabs/faraday/recommend"
 	"github.com/lightninglabs/faraday/revenue"
+	"github.com/lightninglabs/faraday/schedule"
+	"github.com/lightninglabs/faraday/subscribe"
+	"github.com/lightninglabs/faraday/supervisor"
+	"github.com/lightninglabs/faraday/uptime"
 	"github.com/lightningnetwork/lnd/build"
 )
 
@@ -28,6 +40,18 @@ func init() {
 	addSubLogger(dataset.Subsystem, dataset.UseLogger)
 	addSubLogger(frdrpc.Subsystem, frdrpc.UseLogger)
 	addSubLogger(revenue.Subsystem, revenue.UseLogger)
+	addSubLogger(closer.Subsystem, closer.UseLogger)
+	addSubLogger(backtest.Subsystem, backtest.UseLogger)
+	addSubLogger(uptime.Subsystem, uptime.UseLogger)
+	addSubLogger(subscribe.Subsystem, subscribe.UseLogger)
+	addSubLogger(offline.Subsystem, offline.UseLogger)
+	addSubLogger(supervisor.Subsystem, supervisor.UseLogger)
+	addSubLogger(fiat.Subsystem, fiat.UseLogger)
+	addSubLogger(liquidity.Subsystem, liquidity.UseLogger)
+	addSubLogger(alert.Subsystem, alert.UseLogger)
+	addSubLogger(schedule.Subsystem, schedule.UseLogger)
+	addSubLogger(dashboard.Subsystem, dashboard.UseLogger)
+	addSubLogger(flowgraph.Subsystem, flowgraph.UseLogger)
 }
 
 // UseLogger uses a specified Logger to output package logging info.
@@ -0,0 +1,93 @@
+package faraday
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// defaultLoopTimeout is the amount of time we allow loopd to respond
+	// to each request.
+	defaultLoopTimeout = time.Second * 30
+
+	defaultLoopTLSCert  = "tls.cert"
+	defaultLoopMacaroon = "loop.macaroon"
+)
+
+// defaultLoopDir is the default directory for loop's files.
+var defaultLoopDir = btcutil.AppDataDir("loop", false)
+
+// dialLoop creates a connection to the loopd instance that manages a node's
+// liquidity. Older versions of loopd do not serve TLS or require macaroons,
+// so if the default cert or macaroon do not exist they are not used. Paths
+// that are set explicitly must exist.
+func dialLoop(node *nodeConfig, network string) (*grpc.ClientConn, error) {
+	networkDir := filepath.Join(defaultLoopDir, network)
+
+	tlsPath, explicitTLS := node.loopTLSCertPath, true
+	if tlsPath == "" {
+		tlsPath = filepath.Join(networkDir, defaultLoopTLSCert)
+		explicitTLS = false
+	}
+
+	var opts []grpc.DialOption
+	_, err := os.Stat(tlsPath)
+	switch {
+	case err == nil:
+		creds, err := credentials.NewClientTLSFromFile(tlsPath, "")
+		if err != nil {
+			return nil, fmt.Errorf("could not read loop tls "+
+				"cert: %v", err)
+		}
+
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+
+	case os.IsNotExist(err) && !explicitTLS:
+		log.Infof("No loop tls cert found at: %v, connecting "+
+			"without tls", tlsPath)
+
+		opts = append(opts, grpc.WithInsecure())
+
+	default:
+		return nil, fmt.Errorf("could not read loop tls cert: %v", err)
+	}
+
+	macPath, explicitMac := node.loopMacaroonPath, true
+	if macPath == "" {
+		macPath = filepath.Join(networkDir, defaultLoopMacaroon)
+		explicitMac = false
+	}
+
+	macBytes, err := ioutil.ReadFile(macPath)
+	switch {
+	case err == nil:
+		mac := &macaroon.Macaroon{}
+		if err := mac.UnmarshalBinary(macBytes); err != nil {
+			return nil, fmt.Errorf("could not decode loop "+
+				"macaroon: %v", err)
+		}
+
+		opts = append(opts, grpc.WithPerRPCCredentials(
+			macaroons.NewMacaroonCredential(mac),
+		))
+
+	case !os.IsNotExist(err) || explicitMac:
+		return nil, fmt.Errorf("could not read loop macaroon: %v", err)
+	}
+
+	conn, err := grpc.Dial(node.loopServer, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to loopd: %v", err)
+	}
+
+	return conn, nil
+}
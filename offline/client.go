@@ -0,0 +1,117 @@
+package offline
+
+import (
+	"context"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc"
+)
+
+// defaultMaxEvents is the number of forwarding events that lnd returns if a
+// request does not specify a maximum.
+const defaultMaxEvents = 100
+
+// client is a lnd client which serves a snapshot. It only implements the
+// calls that can be answered from the snapshot's exports, all other calls
+// fail with ErrOffline.
+type client struct {
+	unimplementedClient
+
+	snapshot *Snapshot
+}
+
+// A compile time check to ensure that client implements lnd's client
+// interface.
+var _ lnrpc.LightningClient = (*client)(nil)
+
+// GetInfo returns the node's info at the time of export.
+func (c *client) GetInfo(_ context.Context, _ *lnrpc.GetInfoRequest,
+	_ ...grpc.CallOption) (*lnrpc.GetInfoResponse, error) {
+
+	return c.snapshot.Info, nil
+}
+
+// ListChannels returns the channels that were open at the time of export,
+// filtered as requested.
+func (c *client) ListChannels(_ context.Context,
+	req *lnrpc.ListChannelsRequest, _ ...grpc.CallOption) (
+	*lnrpc.ListChannelsResponse, error) {
+
+	resp := &lnrpc.ListChannelsResponse{}
+	for _, channel := range c.snapshot.Channels {
+		switch {
+		case req.ActiveOnly && !channel.Active:
+			continue
+
+		case req.InactiveOnly && channel.Active:
+			continue
+
+		case req.PublicOnly && channel.Private:
+			continue
+
+		case req.PrivateOnly && !channel.Private:
+			continue
+		}
+
+		resp.Channels = append(resp.Channels, channel)
+	}
+
+	return resp, nil
+}
+
+// ClosedChannels returns the channels that had been closed at the time of
+// export.
+func (c *client) ClosedChannels(_ context.Context,
+	_ *lnrpc.ClosedChannelsRequest, _ ...grpc.CallOption) (
+	*lnrpc.ClosedChannelsResponse, error) {
+
+	return &lnrpc.ClosedChannelsResponse{
+		Channels: c.snapshot.ClosedChannels,
+	}, nil
+}
+
+// ForwardingHistory returns a page of the snapshot's forwarding log. Like
+// lnd, events with timestamps in the inclusive range [start time, end time]
+// are considered and the index offset is the number of events in that range
+// to skip. If no end time is set, all forwards after the start time are
+// considered, because the snapshot does not contain any forwards after it
+// was exported.
+func (c *client) ForwardingHistory(_ context.Context,
+	req *lnrpc.ForwardingHistoryRequest, _ ...grpc.CallOption) (
+	*lnrpc.ForwardingHistoryResponse, error) {
+
+	maxEvents := req.NumMaxEvents
+	if maxEvents == 0 {
+		maxEvents = defaultMaxEvents
+	}
+
+	var (
+		events []*lnrpc.ForwardingEvent
+		skip   = req.IndexOffset
+	)
+	for _, fwd := range c.snapshot.Forwards {
+		if fwd.Timestamp < req.StartTime {
+			continue
+		}
+
+		if req.EndTime != 0 && fwd.Timestamp > req.EndTime {
+			break
+		}
+
+		if uint32(len(events)) >= maxEvents {
+			break
+		}
+
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		events = append(events, fwd)
+	}
+
+	return &lnrpc.ForwardingHistoryResponse{
+		ForwardingEvents: events,
+		LastOffsetIndex:  req.IndexOffset + uint32(len(events)),
+	}, nil
+}
@@ -0,0 +1,256 @@
+// Package offline provides a data source for faraday which reads a snapshot
+// of a node from JSON files exported with lncli, rather than querying a live
+// lnd node. This allows reports and recommendations to be produced for nodes
+// that faraday cannot connect to.
+//
+// The following lncli commands are used to export a snapshot:
+//
+//	lncli getinfo > getinfo.json
+//	lncli listchannels > listchannels.json
+//	lncli closedchannels > closedchannels.json
+//	lncli fwdinghistory --start_time=1 --max_events=50000 > fwdinghistory.json
+//
+// Note that fwdinghistory only returns the last day of forwards by default,
+// so a start time must be set to export the full forwarding log. Larger logs
+// can be exported in multiple pages using --index_offset, and saved as
+// separate files with the fwdinghistory prefix.
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// getInfoFile is the name of the getinfo export in a snapshot
+	// directory.
+	getInfoFile = "getinfo.json"
+
+	// listChannelsFile is the name of the listchannels export in a
+	// snapshot directory.
+	listChannelsFile = "listchannels.json"
+
+	// closedChannelsFile is the name of the closedchannels export in a
+	// snapshot directory.
+	closedChannelsFile = "closedchannels.json"
+
+	// fwdingHistoryPattern matches the fwdinghistory exports in a snapshot
+	// directory.
+	fwdingHistoryPattern = "fwdinghistory*.json"
+)
+
+var (
+	// ErrOffline is returned for calls which require a live lnd node,
+	// such as closing channels. It has an unimplemented status code so
+	// that it is reported as such to rpc clients.
+	ErrOffline = status.Error(
+		codes.Unimplemented, "not available in offline mode",
+	)
+
+	// ErrNoForwardingHistory is returned when a snapshot directory does
+	// not contain any forwarding history exports.
+	ErrNoForwardingHistory = errors.New("no fwdinghistory files found")
+)
+
+// Files contains the paths to the files that make up a snapshot.
+type Files struct {
+	// GetInfo is the path to the output of lncli getinfo.
+	GetInfo string
+
+	// ListChannels is the path to the output of lncli listchannels.
+	ListChannels string
+
+	// ClosedChannels is the path to the output of lncli closedchannels.
+	ClosedChannels string
+
+	// ForwardingHistory is the set of paths to the output of lncli
+	// fwdinghistory. Multiple files may be provided if the forwarding log
+	// was exported in pages.
+	ForwardingHistory []string
+}
+
+// FilesFromDir returns the snapshot files in a directory, which are expected
+// to be named getinfo.json, listchannels.json, closedchannels.json and
+// fwdinghistory*.json.
+func FilesFromDir(dir string) (*Files, error) {
+	fwdingHistory, err := filepath.Glob(
+		filepath.Join(dir, fwdingHistoryPattern),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fwdingHistory) == 0 {
+		return nil, ErrNoForwardingHistory
+	}
+
+	// Sort our forwarding history files so that pages are read in a
+	// consistent order.
+	sort.Strings(fwdingHistory)
+
+	return &Files{
+		GetInfo:           filepath.Join(dir, getInfoFile),
+		ListChannels:      filepath.Join(dir, listChannelsFile),
+		ClosedChannels:    filepath.Join(dir, closedChannelsFile),
+		ForwardingHistory: fwdingHistory,
+	}, nil
+}
+
+// Snapshot is the state of a node, read from exported files.
+type Snapshot struct {
+	// Info is the node's info at the time of export.
+	Info *lnrpc.GetInfoResponse
+
+	// Channels is the set of channels that were open at the time of
+	// export.
+	Channels []*lnrpc.Channel
+
+	// ClosedChannels is the set of channels that had been closed at the
+	// time of export.
+	ClosedChannels []*lnrpc.ChannelCloseSummary
+
+	// Forwards is the node's forwarding log, sorted by timestamp.
+	Forwards []*lnrpc.ForwardingEvent
+}
+
+// Load reads a snapshot from the files provided.
+func Load(files *Files) (*Snapshot, error) {
+	info := &lnrpc.GetInfoResponse{}
+	if err := readFile(files.GetInfo, info); err != nil {
+		return nil, err
+	}
+
+	channels := &lnrpc.ListChannelsResponse{}
+	if err := readFile(files.ListChannels, channels); err != nil {
+		return nil, err
+	}
+
+	closed := &lnrpc.ClosedChannelsResponse{}
+	if err := readFile(files.ClosedChannels, closed); err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		Info:           info,
+		Channels:       channels.Channels,
+		ClosedChannels: closed.Channels,
+	}
+
+	for _, path := range files.ForwardingHistory {
+		resp := &lnrpc.ForwardingHistoryResponse{}
+		if err := readFile(path, resp); err != nil {
+			return nil, err
+		}
+
+		snapshot.Forwards = append(
+			snapshot.Forwards, resp.ForwardingEvents...,
+		)
+	}
+
+	// Pages may overlap or be provided out of order, so we sort our
+	// forwards and remove duplicates.
+	snapshot.Forwards = dedupForwards(snapshot.Forwards)
+
+	log.Debugf("Loaded snapshot with %v open channels, %v closed "+
+		"channels and %v forwards", len(snapshot.Channels),
+		len(snapshot.ClosedChannels), len(snapshot.Forwards))
+
+	return snapshot, nil
+}
+
+// readFile reads a jsonpb encoded message from the path provided. Unknown
+// fields are allowed so that exports from newer versions of lncli can be
+// read.
+func readFile(path string, msg proto.Message) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	unmarshaler := &jsonpb.Unmarshaler{
+		AllowUnknownFields: true,
+	}
+
+	if err := unmarshaler.Unmarshal(f, msg); err != nil {
+		return fmt.Errorf("could not read %v: %v", path, err)
+	}
+
+	return nil
+}
+
+// dedupForwards sorts forwards by timestamp and removes exact duplicates.
+func dedupForwards(forwards []*lnrpc.ForwardingEvent) []*lnrpc.ForwardingEvent {
+	sort.SliceStable(forwards, func(i, j int) bool {
+		return forwards[i].Timestamp < forwards[j].Timestamp
+	})
+
+	deduped := make([]*lnrpc.ForwardingEvent, 0, len(forwards))
+	for i, fwd := range forwards {
+		// Duplicates will have the same timestamp, so we only need
+		// to check the events since the last change in timestamp.
+		var duplicate bool
+		for j := i - 1; j >= 0; j-- {
+			if forwards[j].Timestamp != fwd.Timestamp {
+				break
+			}
+
+			if proto.Equal(forwards[j], fwd) {
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			deduped = append(deduped, fwd)
+		}
+	}
+
+	return deduped
+}
+
+// Now returns the time at which the snapshot was taken, so that reports on
+// the snapshot can be produced as of that time. Exports do not record the
+// time that they were made, so we use the latest of the best block's
+// timestamp and the timestamp of our last forward. If neither is available,
+// the current time is returned.
+func (s *Snapshot) Now() time.Time {
+	var latest int64
+	if s.Info != nil {
+		latest = s.Info.BestHeaderTimestamp
+	}
+
+	// Our forwards are sorted by timestamp, so we only need to check the
+	// last one.
+	if len(s.Forwards) != 0 {
+		last := int64(s.Forwards[len(s.Forwards)-1].Timestamp)
+		if last > latest {
+			latest = last
+		}
+	}
+
+	if latest == 0 {
+		return time.Now()
+	}
+
+	return time.Unix(latest, 0)
+}
+
+// Client returns a lnd client which serves the snapshot, so that faraday's
+// rpc server can run against it. Calls that require a live node, such as
+// closing channels or subscribing to channel events, fail with ErrOffline.
+func (s *Snapshot) Client() lnrpc.LightningClient {
+	return &client{
+		snapshot: s,
+	}
+}
@@ -0,0 +1,263 @@
+package offline
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The following exports are formatted as lncli outputs them, with 64 bit
+// integers as strings and default values included. The first forward is
+// present in both forwarding history pages.
+const (
+	testGetInfo = `{
+    "version": "0.8.0-beta",
+    "identity_pubkey": "02aa",
+    "block_height": 1000,
+    "synced_to_chain": true,
+    "some_newer_field": true
+}`
+
+	testListChannels = `{
+    "channels": [
+        {
+            "active": true,
+            "remote_pubkey": "02bb",
+            "channel_point": "a:0",
+            "chan_id": "989560464998400",
+            "capacity": "100000",
+            "lifetime": "1000",
+            "uptime": "900"
+        }
+    ]
+}`
+
+	testClosedChannels = `{
+    "channels": [
+        {
+            "channel_point": "b:0",
+            "chan_id": "879609302220800",
+            "capacity": "100000"
+        }
+    ]
+}`
+
+	testFwdingHistory1 = `{
+    "forwarding_events": [
+        {
+            "timestamp": "100",
+            "chan_id_in": "879609302220800",
+            "chan_id_out": "989560464998400",
+            "amt_in_msat": "2000",
+            "amt_out_msat": "1000"
+        }
+    ],
+    "last_offset_index": 1
+}`
+
+	testFwdingHistory2 = `{
+    "forwarding_events": [
+        {
+            "timestamp": "100",
+            "chan_id_in": "879609302220800",
+            "chan_id_out": "989560464998400",
+            "amt_in_msat": "2000",
+            "amt_out_msat": "1000"
+        },
+        {
+            "timestamp": "200",
+            "chan_id_in": "989560464998400",
+            "chan_id_out": "879609302220800",
+            "amt_in_msat": "5000",
+            "amt_out_msat": "4000"
+        }
+    ],
+    "last_offset_index": 2
+}`
+)
+
+// writeTestSnapshot writes our test exports to a temporary directory, and
+// returns the directory and a cleanup function.
+func writeTestSnapshot(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "offline")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+
+	files := map[string]string{
+		getInfoFile:           testGetInfo,
+		listChannelsFile:      testListChannels,
+		closedChannelsFile:    testClosedChannels,
+		"fwdinghistory1.json": testFwdingHistory1,
+		"fwdinghistory2.json": testFwdingHistory2,
+	}
+
+	for name, contents := range files {
+		err := ioutil.WriteFile(
+			filepath.Join(dir, name), []byte(contents), 0600,
+		)
+		if err != nil {
+			t.Fatalf("could not write %v: %v", name, err)
+		}
+	}
+
+	return dir, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+// TestSnapshot tests loading a snapshot from lncli exports and serving it
+// with our offline client.
+func TestSnapshot(t *testing.T) {
+	dir, cleanup := writeTestSnapshot(t)
+	defer cleanup()
+
+	files, err := FilesFromDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	snapshot, err := Load(files)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if snapshot.Info.BlockHeight != 1000 {
+		t.Fatalf("expected height 1000, got: %v",
+			snapshot.Info.BlockHeight)
+	}
+
+	// Our duplicated forward should only be included once.
+	if len(snapshot.Forwards) != 2 {
+		t.Fatalf("expected 2 forwards, got: %v",
+			len(snapshot.Forwards))
+	}
+
+	// Our export does not include a best block timestamp, so the
+	// snapshot's time should be that of our last forward.
+	if snapshot.Now().Unix() != 200 {
+		t.Fatalf("expected snapshot time 200, got: %v",
+			snapshot.Now().Unix())
+	}
+
+	// Check that our client serves the snapshot.
+	client := snapshot.Client()
+	ctx := context.Background()
+
+	info, err := client.GetInfo(ctx, &lnrpc.GetInfoRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info.IdentityPubkey != "02aa" {
+		t.Fatalf("unexpected pubkey: %v", info.IdentityPubkey)
+	}
+
+	channels, err := client.ListChannels(
+		ctx, &lnrpc.ListChannelsRequest{},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(channels.Channels) != 1 {
+		t.Fatalf("expected 1 channel, got: %v", len(channels.Channels))
+	}
+
+	// Our only channel is public, so it should be filtered out of a
+	// request for private channels.
+	channels, err = client.ListChannels(
+		ctx, &lnrpc.ListChannelsRequest{PrivateOnly: true},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(channels.Channels) != 0 {
+		t.Fatalf("expected no channels, got: %v",
+			len(channels.Channels))
+	}
+
+	closed, err := client.ClosedChannels(
+		ctx, &lnrpc.ClosedChannelsRequest{},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(closed.Channels) != 1 {
+		t.Fatalf("expected 1 closed channel, got: %v",
+			len(closed.Channels))
+	}
+
+	// Query for forwards over a period which only covers our second
+	// forward.
+	fwds, err := client.ForwardingHistory(
+		ctx, &lnrpc.ForwardingHistoryRequest{
+			StartTime: 150,
+			EndTime:   250,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(fwds.ForwardingEvents) != 1 ||
+		fwds.ForwardingEvents[0].Timestamp != 200 {
+
+		t.Fatalf("unexpected forwards: %v", fwds.ForwardingEvents)
+	}
+
+	// Query for our forwards without an end time, one event at a time,
+	// skipping our first forward.
+	fwds, err = client.ForwardingHistory(
+		ctx, &lnrpc.ForwardingHistoryRequest{
+			IndexOffset:  1,
+			NumMaxEvents: 1,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(fwds.ForwardingEvents) != 1 ||
+		fwds.ForwardingEvents[0].Timestamp != 200 ||
+		fwds.LastOffsetIndex != 2 {
+
+		t.Fatalf("unexpected forwards: %v, offset: %v",
+			fwds.ForwardingEvents, fwds.LastOffsetIndex)
+	}
+
+	// Calls which cannot be served from a snapshot should fail with an
+	// unimplemented error rather than panicking.
+	_, err = client.CloseChannel(ctx, &lnrpc.CloseChannelRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected unimplemented, got: %v", err)
+	}
+
+	_, err = client.DescribeGraph(ctx, &lnrpc.ChannelGraphRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected unimplemented, got: %v", err)
+	}
+}
+
+// TestFilesFromDirNoHistory tests that a directory without forwarding
+// history fails.
+func TestFilesFromDirNoHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "offline")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := FilesFromDir(dir); err != ErrNoForwardingHistory {
+		t.Fatalf("expected: %v, got: %v", ErrNoForwardingHistory,
+			err)
+	}
+}
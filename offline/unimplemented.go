@@ -0,0 +1,429 @@
+package offline
+
+import (
+	"context"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc"
+)
+
+// unimplementedClient implements every lnd rpc by returning ErrOffline, so
+// that our snapshot client only needs to implement the calls that it can
+// serve from a snapshot. Embedding this type rather than a nil interface
+// ensures that calls we do not serve fail gracefully rather than panicking.
+type unimplementedClient struct{}
+
+// A compile time check to ensure that unimplementedClient implements lnd's
+// client interface.
+var _ lnrpc.LightningClient = (*unimplementedClient)(nil)
+
+// WalletBalance is not available offline.
+func (u unimplementedClient) WalletBalance(_ context.Context,
+	_ *lnrpc.WalletBalanceRequest, _ ...grpc.CallOption) (
+	*lnrpc.WalletBalanceResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// ChannelBalance is not available offline.
+func (u unimplementedClient) ChannelBalance(_ context.Context,
+	_ *lnrpc.ChannelBalanceRequest, _ ...grpc.CallOption) (
+	*lnrpc.ChannelBalanceResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// GetTransactions is not available offline.
+func (u unimplementedClient) GetTransactions(_ context.Context,
+	_ *lnrpc.GetTransactionsRequest, _ ...grpc.CallOption) (
+	*lnrpc.TransactionDetails, error) {
+
+	return nil, ErrOffline
+}
+
+// EstimateFee is not available offline.
+func (u unimplementedClient) EstimateFee(_ context.Context,
+	_ *lnrpc.EstimateFeeRequest, _ ...grpc.CallOption) (
+	*lnrpc.EstimateFeeResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// SendCoins is not available offline.
+func (u unimplementedClient) SendCoins(_ context.Context,
+	_ *lnrpc.SendCoinsRequest, _ ...grpc.CallOption) (
+	*lnrpc.SendCoinsResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// ListUnspent is not available offline.
+func (u unimplementedClient) ListUnspent(_ context.Context,
+	_ *lnrpc.ListUnspentRequest, _ ...grpc.CallOption) (
+	*lnrpc.ListUnspentResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// SubscribeTransactions is not available offline.
+func (u unimplementedClient) SubscribeTransactions(_ context.Context,
+	_ *lnrpc.GetTransactionsRequest, _ ...grpc.CallOption) (
+	lnrpc.Lightning_SubscribeTransactionsClient, error) {
+
+	return nil, ErrOffline
+}
+
+// SendMany is not available offline.
+func (u unimplementedClient) SendMany(_ context.Context,
+	_ *lnrpc.SendManyRequest, _ ...grpc.CallOption) (
+	*lnrpc.SendManyResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// NewAddress is not available offline.
+func (u unimplementedClient) NewAddress(_ context.Context,
+	_ *lnrpc.NewAddressRequest, _ ...grpc.CallOption) (
+	*lnrpc.NewAddressResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// SignMessage is not available offline.
+func (u unimplementedClient) SignMessage(_ context.Context,
+	_ *lnrpc.SignMessageRequest, _ ...grpc.CallOption) (
+	*lnrpc.SignMessageResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// VerifyMessage is not available offline.
+func (u unimplementedClient) VerifyMessage(_ context.Context,
+	_ *lnrpc.VerifyMessageRequest, _ ...grpc.CallOption) (
+	*lnrpc.VerifyMessageResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// ConnectPeer is not available offline.
+func (u unimplementedClient) ConnectPeer(_ context.Context,
+	_ *lnrpc.ConnectPeerRequest, _ ...grpc.CallOption) (
+	*lnrpc.ConnectPeerResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// DisconnectPeer is not available offline.
+func (u unimplementedClient) DisconnectPeer(_ context.Context,
+	_ *lnrpc.DisconnectPeerRequest, _ ...grpc.CallOption) (
+	*lnrpc.DisconnectPeerResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// ListPeers is not available offline.
+func (u unimplementedClient) ListPeers(_ context.Context,
+	_ *lnrpc.ListPeersRequest, _ ...grpc.CallOption) (
+	*lnrpc.ListPeersResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// GetInfo is not available offline.
+func (u unimplementedClient) GetInfo(_ context.Context,
+	_ *lnrpc.GetInfoRequest, _ ...grpc.CallOption) (
+	*lnrpc.GetInfoResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// PendingChannels is not available offline.
+func (u unimplementedClient) PendingChannels(_ context.Context,
+	_ *lnrpc.PendingChannelsRequest, _ ...grpc.CallOption) (
+	*lnrpc.PendingChannelsResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// ListChannels is not available offline.
+func (u unimplementedClient) ListChannels(_ context.Context,
+	_ *lnrpc.ListChannelsRequest, _ ...grpc.CallOption) (
+	*lnrpc.ListChannelsResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// SubscribeChannelEvents is not available offline.
+func (u unimplementedClient) SubscribeChannelEvents(_ context.Context,
+	_ *lnrpc.ChannelEventSubscription, _ ...grpc.CallOption) (
+	lnrpc.Lightning_SubscribeChannelEventsClient, error) {
+
+	return nil, ErrOffline
+}
+
+// ClosedChannels is not available offline.
+func (u unimplementedClient) ClosedChannels(_ context.Context,
+	_ *lnrpc.ClosedChannelsRequest, _ ...grpc.CallOption) (
+	*lnrpc.ClosedChannelsResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// OpenChannelSync is not available offline.
+func (u unimplementedClient) OpenChannelSync(_ context.Context,
+	_ *lnrpc.OpenChannelRequest, _ ...grpc.CallOption) (
+	*lnrpc.ChannelPoint, error) {
+
+	return nil, ErrOffline
+}
+
+// OpenChannel is not available offline.
+func (u unimplementedClient) OpenChannel(_ context.Context,
+	_ *lnrpc.OpenChannelRequest, _ ...grpc.CallOption) (
+	lnrpc.Lightning_OpenChannelClient, error) {
+
+	return nil, ErrOffline
+}
+
+// ChannelAcceptor is not available offline.
+func (u unimplementedClient) ChannelAcceptor(_ context.Context,
+	_ ...grpc.CallOption) (lnrpc.Lightning_ChannelAcceptorClient, error) {
+
+	return nil, ErrOffline
+}
+
+// CloseChannel is not available offline.
+func (u unimplementedClient) CloseChannel(_ context.Context,
+	_ *lnrpc.CloseChannelRequest, _ ...grpc.CallOption) (
+	lnrpc.Lightning_CloseChannelClient, error) {
+
+	return nil, ErrOffline
+}
+
+// AbandonChannel is not available offline.
+func (u unimplementedClient) AbandonChannel(_ context.Context,
+	_ *lnrpc.AbandonChannelRequest, _ ...grpc.CallOption) (
+	*lnrpc.AbandonChannelResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// SendPayment is not available offline.
+func (u unimplementedClient) SendPayment(_ context.Context,
+	_ ...grpc.CallOption) (lnrpc.Lightning_SendPaymentClient, error) {
+
+	return nil, ErrOffline
+}
+
+// SendPaymentSync is not available offline.
+func (u unimplementedClient) SendPaymentSync(_ context.Context,
+	_ *lnrpc.SendRequest, _ ...grpc.CallOption) (
+	*lnrpc.SendResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// SendToRoute is not available offline.
+func (u unimplementedClient) SendToRoute(_ context.Context,
+	_ ...grpc.CallOption) (lnrpc.Lightning_SendToRouteClient, error) {
+
+	return nil, ErrOffline
+}
+
+// SendToRouteSync is not available offline.
+func (u unimplementedClient) SendToRouteSync(_ context.Context,
+	_ *lnrpc.SendToRouteRequest, _ ...grpc.CallOption) (
+	*lnrpc.SendResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// AddInvoice is not available offline.
+func (u unimplementedClient) AddInvoice(_ context.Context,
+	_ *lnrpc.Invoice, _ ...grpc.CallOption) (
+	*lnrpc.AddInvoiceResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// ListInvoices is not available offline.
+func (u unimplementedClient) ListInvoices(_ context.Context,
+	_ *lnrpc.ListInvoiceRequest, _ ...grpc.CallOption) (
+	*lnrpc.ListInvoiceResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// LookupInvoice is not available offline.
+func (u unimplementedClient) LookupInvoice(_ context.Context,
+	_ *lnrpc.PaymentHash, _ ...grpc.CallOption) (*lnrpc.Invoice, error) {
+
+	return nil, ErrOffline
+}
+
+// SubscribeInvoices is not available offline.
+func (u unimplementedClient) SubscribeInvoices(_ context.Context,
+	_ *lnrpc.InvoiceSubscription, _ ...grpc.CallOption) (
+	lnrpc.Lightning_SubscribeInvoicesClient, error) {
+
+	return nil, ErrOffline
+}
+
+// DecodePayReq is not available offline.
+func (u unimplementedClient) DecodePayReq(_ context.Context,
+	_ *lnrpc.PayReqString, _ ...grpc.CallOption) (*lnrpc.PayReq, error) {
+
+	return nil, ErrOffline
+}
+
+// ListPayments is not available offline.
+func (u unimplementedClient) ListPayments(_ context.Context,
+	_ *lnrpc.ListPaymentsRequest, _ ...grpc.CallOption) (
+	*lnrpc.ListPaymentsResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// DeleteAllPayments is not available offline.
+func (u unimplementedClient) DeleteAllPayments(_ context.Context,
+	_ *lnrpc.DeleteAllPaymentsRequest, _ ...grpc.CallOption) (
+	*lnrpc.DeleteAllPaymentsResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// DescribeGraph is not available offline.
+func (u unimplementedClient) DescribeGraph(_ context.Context,
+	_ *lnrpc.ChannelGraphRequest, _ ...grpc.CallOption) (
+	*lnrpc.ChannelGraph, error) {
+
+	return nil, ErrOffline
+}
+
+// GetChanInfo is not available offline.
+func (u unimplementedClient) GetChanInfo(_ context.Context,
+	_ *lnrpc.ChanInfoRequest, _ ...grpc.CallOption) (
+	*lnrpc.ChannelEdge, error) {
+
+	return nil, ErrOffline
+}
+
+// GetNodeInfo is not available offline.
+func (u unimplementedClient) GetNodeInfo(_ context.Context,
+	_ *lnrpc.NodeInfoRequest, _ ...grpc.CallOption) (
+	*lnrpc.NodeInfo, error) {
+
+	return nil, ErrOffline
+}
+
+// QueryRoutes is not available offline.
+func (u unimplementedClient) QueryRoutes(_ context.Context,
+	_ *lnrpc.QueryRoutesRequest, _ ...grpc.CallOption) (
+	*lnrpc.QueryRoutesResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// GetNetworkInfo is not available offline.
+func (u unimplementedClient) GetNetworkInfo(_ context.Context,
+	_ *lnrpc.NetworkInfoRequest, _ ...grpc.CallOption) (
+	*lnrpc.NetworkInfo, error) {
+
+	return nil, ErrOffline
+}
+
+// StopDaemon is not available offline.
+func (u unimplementedClient) StopDaemon(_ context.Context,
+	_ *lnrpc.StopRequest, _ ...grpc.CallOption) (
+	*lnrpc.StopResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// SubscribeChannelGraph is not available offline.
+func (u unimplementedClient) SubscribeChannelGraph(_ context.Context,
+	_ *lnrpc.GraphTopologySubscription, _ ...grpc.CallOption) (
+	lnrpc.Lightning_SubscribeChannelGraphClient, error) {
+
+	return nil, ErrOffline
+}
+
+// DebugLevel is not available offline.
+func (u unimplementedClient) DebugLevel(_ context.Context,
+	_ *lnrpc.DebugLevelRequest, _ ...grpc.CallOption) (
+	*lnrpc.DebugLevelResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// FeeReport is not available offline.
+func (u unimplementedClient) FeeReport(_ context.Context,
+	_ *lnrpc.FeeReportRequest, _ ...grpc.CallOption) (
+	*lnrpc.FeeReportResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// UpdateChannelPolicy is not available offline.
+func (u unimplementedClient) UpdateChannelPolicy(_ context.Context,
+	_ *lnrpc.PolicyUpdateRequest, _ ...grpc.CallOption) (
+	*lnrpc.PolicyUpdateResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// ForwardingHistory is not available offline.
+func (u unimplementedClient) ForwardingHistory(_ context.Context,
+	_ *lnrpc.ForwardingHistoryRequest, _ ...grpc.CallOption) (
+	*lnrpc.ForwardingHistoryResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// ExportChannelBackup is not available offline.
+func (u unimplementedClient) ExportChannelBackup(_ context.Context,
+	_ *lnrpc.ExportChannelBackupRequest, _ ...grpc.CallOption) (
+	*lnrpc.ChannelBackup, error) {
+
+	return nil, ErrOffline
+}
+
+// ExportAllChannelBackups is not available offline.
+func (u unimplementedClient) ExportAllChannelBackups(_ context.Context,
+	_ *lnrpc.ChanBackupExportRequest, _ ...grpc.CallOption) (
+	*lnrpc.ChanBackupSnapshot, error) {
+
+	return nil, ErrOffline
+}
+
+// VerifyChanBackup is not available offline.
+func (u unimplementedClient) VerifyChanBackup(_ context.Context,
+	_ *lnrpc.ChanBackupSnapshot, _ ...grpc.CallOption) (
+	*lnrpc.VerifyChanBackupResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// RestoreChannelBackups is not available offline.
+func (u unimplementedClient) RestoreChannelBackups(_ context.Context,
+	_ *lnrpc.RestoreChanBackupRequest, _ ...grpc.CallOption) (
+	*lnrpc.RestoreBackupResponse, error) {
+
+	return nil, ErrOffline
+}
+
+// SubscribeChannelBackups is not available offline.
+func (u unimplementedClient) SubscribeChannelBackups(_ context.Context,
+	_ *lnrpc.ChannelBackupSubscription, _ ...grpc.CallOption) (
+	lnrpc.Lightning_SubscribeChannelBackupsClient, error) {
+
+	return nil, ErrOffline
+}
+
+// BakeMacaroon is not available offline.
+func (u unimplementedClient) BakeMacaroon(_ context.Context,
+	_ *lnrpc.BakeMacaroonRequest, _ ...grpc.CallOption) (
+	*lnrpc.BakeMacaroonResponse, error) {
+
+	return nil, ErrOffline
+}
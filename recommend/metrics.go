@@ -0,0 +1,392 @@
+package recommend
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/lightninglabs/faraday/dataset"
+	"github.com/lightninglabs/faraday/insights"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// ErrUnknownMetric is returned when recommendations are requested for
+	// a metric that has not been registered.
+	ErrUnknownMetric = errors.New("unknown metric")
+
+	// ErrDuplicateMetric is returned when a metric is registered with a
+	// name that is already in use.
+	ErrDuplicateMetric = errors.New("metric already registered")
+
+	// ErrInvalidMetric is returned when a metric without a name or value
+	// function is registered.
+	ErrInvalidMetric = errors.New("metric name and value function " +
+		"required")
+)
+
+// Names of the metrics that are registered by default.
+const (
+	// UptimeMetric bases recommendations on the uptime of the channel's
+	// remote peer.
+	UptimeMetric = "uptime"
+
+	// RevenueMetric bases recommendations on the revenue that the channel
+	// has generated per block that our capital has been committed for.
+	RevenueMetric = "revenue"
+
+	// IncomingVolume bases recommendations on the incoming volume that the
+	// channel has processed, scaled by funding transaction confirmations.
+	IncomingVolume = "incoming_volume"
+
+	// OutgoingVolume bases recommendations on the outgoing volume that the
+	// channel has processed, scaled by funding transaction confirmations.
+	OutgoingVolume = "outgoing_volume"
+
+	// Volume bases recommendations on the total volume that the
+	// channel has processed, scaled by funding transaction confirmations.
+	Volume = "volume"
+
+	// NetValueMetric bases recommendations on the revenue that the
+	// channel has generated less the opportunity cost of the capital
+	// committed to it, scaled by funding transaction confirmations.
+	NetValueMetric = "net_value"
+
+	// BalanceMetric bases recommendations on the ratio of time that the
+	// channel's balance was not one-sided to the time that its balance
+	// has been sampled for, so that channels which have been stuck with
+	// all of their balance on one side have low values.
+	BalanceMetric = "balance"
+
+	// RevenuePerCapacityMetric bases recommendations on the revenue that
+	// the channel has generated per satoshi of capacity.
+	RevenuePerCapacityMetric = "revenue_per_capacity"
+)
+
+// Scaling describes how a metric's value is scaled so that channels of
+// different ages and sizes can be compared.
+type Scaling int
+
+const (
+	// ScaleRaw uses a metric's value as is.
+	ScaleRaw Scaling = iota
+
+	// ScalePerConfirmation divides a metric's value by the number of
+	// confirmations that the channel's funding transaction has.
+	ScalePerConfirmation
+
+	// ScalePerCapacity divides a metric's value by the channel's capacity
+	// in satoshis.
+	ScalePerCapacity
+)
+
+// String returns the name of a scaling mode.
+func (s Scaling) String() string {
+	switch s {
+	case ScaleRaw:
+		return "raw"
+
+	case ScalePerConfirmation:
+		return "per_confirmation"
+
+	case ScalePerCapacity:
+		return "per_capacity"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Metric is a data point that close recommendations can be based on.
+type Metric struct {
+	// Name is the unique name that the metric is requested with.
+	Name string
+
+	// Description describes the metric.
+	Description string
+
+	// Unit is the unit of the metric's value once it has been scaled.
+	Unit string
+
+	// Scaling is the way that the metric's value is scaled.
+	Scaling Scaling
+
+	// Value returns the metric's unscaled value for a channel. It is
+	// passed the config that recommendations were requested with, so that
+	// metrics can use its parameters.
+	Value func(channel *insights.ChannelInfo,
+		cfg *CloseRecommendationConfig) float64
+}
+
+// dataset produces a dataset of the metric's scaled values for a set of
+// channels.
+func (m *Metric) dataset(cfg *CloseRecommendationConfig,
+	channels []*insights.ChannelInfo) dataset.Dataset {
+
+	getValue := func(channel *insights.ChannelInfo) float64 {
+		return m.Value(channel, cfg)
+	}
+
+	switch m.Scaling {
+	case ScalePerConfirmation:
+		return getConfirmationScaledDataset(getValue, channels)
+
+	case ScalePerCapacity:
+		return getCapacityScaledDataset(getValue, channels)
+
+	default:
+		return getRawDataset(getValue, channels)
+	}
+}
+
+var (
+	// metrics is the set of registered metrics, keyed by name.
+	metrics = make(map[string]*Metric)
+
+	// metricsMtx guards access to our set of registered metrics.
+	metricsMtx sync.RWMutex
+)
+
+// RegisterMetric adds a metric to the set of metrics that recommendations can
+// be based on. It fails if a metric with the same name is already
+// registered.
+func RegisterMetric(metric *Metric) error {
+	if metric.Name == "" || metric.Value == nil {
+		return ErrInvalidMetric
+	}
+
+	metricsMtx.Lock()
+	defer metricsMtx.Unlock()
+
+	if _, ok := metrics[metric.Name]; ok {
+		return fmt.Errorf("%w: %v", ErrDuplicateMetric, metric.Name)
+	}
+
+	metrics[metric.Name] = metric
+
+	return nil
+}
+
+// GetMetric returns the registered metric with the name provided.
+func GetMetric(name string) (*Metric, error) {
+	if name == "" {
+		return nil, ErrNoMetric
+	}
+
+	metricsMtx.RLock()
+	defer metricsMtx.RUnlock()
+
+	metric, ok := metrics[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownMetric, name)
+	}
+
+	return metric, nil
+}
+
+// Metrics returns all registered metrics, sorted by name.
+func Metrics() []*Metric {
+	metricsMtx.RLock()
+	defer metricsMtx.RUnlock()
+
+	all := make([]*Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		all = append(all, metric)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Name < all[j].Name
+	})
+
+	return all
+}
+
+// init registers our default set of metrics.
+func init() {
+	defaultMetrics := []*Metric{
+		{
+			Name: UptimeMetric,
+			Description: "ratio of the channel peer's uptime to " +
+				"the period it has been monitored for",
+			Unit:    "ratio",
+			Scaling: ScaleRaw,
+			Value:   withoutConfig(uptimeValue),
+		},
+		{
+			Name: RevenueMetric,
+			Description: "fees earned per block that the " +
+				"channel's capital has been committed for",
+			Unit:    "msat/block",
+			Scaling: ScalePerConfirmation,
+			Value:   withoutConfig(revenueValue),
+		},
+		{
+			Name: IncomingVolume,
+			Description: "incoming volume per block that the " +
+				"channel's capital has been committed for",
+			Unit:    "msat/block",
+			Scaling: ScalePerConfirmation,
+			Value:   withoutConfig(incomingVolumeValue),
+		},
+		{
+			Name: OutgoingVolume,
+			Description: "outgoing volume per block that the " +
+				"channel's capital has been committed for",
+			Unit:    "msat/block",
+			Scaling: ScalePerConfirmation,
+			Value:   withoutConfig(outgoingVolumeValue),
+		},
+		{
+			Name: Volume,
+			Description: "total volume per block that the " +
+				"channel's capital has been committed for",
+			Unit:    "msat/block",
+			Scaling: ScalePerConfirmation,
+			Value:   withoutConfig(totalVolumeValue),
+		},
+		{
+			Name: NetValueMetric,
+			Description: "fees earned less liquidity costs and " +
+				"the opportunity cost of the channel's " +
+				"capital, per block that it has been " +
+				"committed for",
+			Unit:    "msat/block",
+			Scaling: ScalePerConfirmation,
+			Value: func(channel *insights.ChannelInfo,
+				cfg *CloseRecommendationConfig) float64 {
+
+				return netValue(cfg.OpportunityCostRate)(
+					channel,
+				)
+			},
+		},
+		{
+			Name: BalanceMetric,
+			Description: "ratio of time that the channel's " +
+				"balance was not one-sided to the period " +
+				"its balance has been sampled for",
+			Unit:    "ratio",
+			Scaling: ScaleRaw,
+			Value:   withoutConfig(balancedValue),
+		},
+		{
+			Name: RevenuePerCapacityMetric,
+			Description: "fees earned per satoshi of the " +
+				"channel's capacity",
+			Unit:    "msat/sat",
+			Scaling: ScalePerCapacity,
+			Value:   withoutConfig(revenueValue),
+		},
+	}
+
+	for _, metric := range defaultMetrics {
+		if err := RegisterMetric(metric); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// withoutConfig wraps a value function which does not depend on the
+// recommendation config so that it can be used as a metric's value.
+func withoutConfig(getValue func(*insights.ChannelInfo) float64) func(
+	*insights.ChannelInfo, *CloseRecommendationConfig) float64 {
+
+	return func(channel *insights.ChannelInfo,
+		_ *CloseRecommendationConfig) float64 {
+
+		return getValue(channel)
+	}
+}
+
+// getRawDataset returns a dataset of unscaled values.
+func getRawDataset(getValue func(*insights.ChannelInfo) float64,
+	eligibleChannels []*insights.ChannelInfo) dataset.Dataset {
+
+	var channels = make(map[string]float64, len(eligibleChannels))
+
+	for _, channel := range eligibleChannels {
+		channels[channel.ChannelPoint] = getValue(channel)
+	}
+
+	return dataset.New(channels)
+}
+
+// getCapacityScaledDataset returns a dataset that scales a value by the
+// channel's capacity in satoshis. Channels with no capacity information are
+// given a value of zero.
+func getCapacityScaledDataset(getValue func(*insights.ChannelInfo) float64,
+	eligibleChannels []*insights.ChannelInfo) dataset.Dataset {
+
+	var channels = make(map[string]float64, len(eligibleChannels))
+
+	for _, channel := range eligibleChannels {
+		if channel.Capacity == 0 {
+			channels[channel.ChannelPoint] = 0
+			continue
+		}
+
+		channels[channel.ChannelPoint] = getValue(channel) /
+			float64(channel.Capacity)
+	}
+
+	return dataset.New(channels)
+}
+
+// uptimeValue gets the ratio of a channel's peer's uptime to the time it has
+// been monitored for.
+func uptimeValue(channel *insights.ChannelInfo) float64 {
+	return float64(channel.Uptime) / float64(channel.MonitoredFor)
+}
+
+// balancedValue gets the ratio of time that a channel's balance was not
+// one-sided. Channels that have no balance history are given a ratio of 1,
+// because we have no evidence that they have been one-sided.
+func balancedValue(channel *insights.ChannelInfo) float64 {
+	if channel.BalanceMonitored == 0 {
+		return 1
+	}
+
+	return 1 - float64(channel.OneSided)/float64(channel.BalanceMonitored)
+}
+
+// revenueValue gets total revenue for a channel.
+func revenueValue(channel *insights.ChannelInfo) float64 {
+	return float64(channel.FeesEarned)
+}
+
+// incomingVolumeValue gets total incoming volume for a channel.
+func incomingVolumeValue(channel *insights.ChannelInfo) float64 {
+	return float64(channel.VolumeIncoming)
+}
+
+// outgoingVolumeValue gets total outgoing volume for a channel.
+func outgoingVolumeValue(channel *insights.ChannelInfo) float64 {
+	return float64(channel.VolumeOutgoing)
+}
+
+// totalVolumeValue gets total volume for a channel.
+func totalVolumeValue(channel *insights.ChannelInfo) float64 {
+	return float64(channel.VolumeIncoming + channel.VolumeOutgoing)
+}
+
+// OpportunityCost returns the opportunity cost in millisatoshis of the
+// capital committed to a channel, given an annual rate of return. The cost is
+// charged over the same blocks, with the same weighting, as the channel's
+// revenue, so that it can be compared with the fees that the channel earned.
+func OpportunityCost(channel *insights.ChannelInfo, rate float64) float64 {
+	capacity := float64(lnwire.NewMSatFromSatoshis(channel.Capacity))
+
+	return capacity * rate * channel.ScalingBlocks() / blocksPerYear
+}
+
+// netValue returns a function which gets the fee revenue for a channel less
+// the cost of rebalancing it and the opportunity cost of its capital at the
+// annual rate provided.
+func netValue(rate float64) perConfirmationValue {
+	return func(channel *insights.ChannelInfo) float64 {
+		return float64(channel.FeesEarned) -
+			float64(channel.LiquidityCost) -
+			OpportunityCost(channel, rate)
+	}
+}
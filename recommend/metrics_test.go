@@ -0,0 +1,177 @@
+package recommend
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/faraday/insights"
+)
+
+// TestRegisterMetric tests registration and lookup of metrics.
+func TestRegisterMetric(t *testing.T) {
+	value := withoutConfig(revenueValue)
+
+	tests := []struct {
+		name        string
+		metric      *Metric
+		expectedErr error
+	}{
+		{
+			name:        "no name",
+			metric:      &Metric{Value: value},
+			expectedErr: ErrInvalidMetric,
+		},
+		{
+			name:        "no value",
+			metric:      &Metric{Name: "test_no_value"},
+			expectedErr: ErrInvalidMetric,
+		},
+		{
+			name:        "duplicate",
+			metric:      &Metric{Name: UptimeMetric, Value: value},
+			expectedErr: ErrDuplicateMetric,
+		},
+		{
+			name: "registered",
+			metric: &Metric{
+				Name:  "test_registered",
+				Value: value,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			err := RegisterMetric(test.metric)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected: %v, got: %v",
+					test.expectedErr, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			metric, err := GetMetric(test.metric.Name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if metric != test.metric {
+				t.Fatalf("expected registered metric to be " +
+					"returned")
+			}
+		})
+	}
+
+	if _, err := GetMetric("unknown"); !errors.Is(err, ErrUnknownMetric) {
+		t.Fatalf("expected: %v, got: %v", ErrUnknownMetric, err)
+	}
+
+	if _, err := GetMetric(""); err != ErrNoMetric {
+		t.Fatalf("expected: %v, got: %v", ErrNoMetric, err)
+	}
+
+	all := Metrics()
+	if !sort.SliceIsSorted(all, func(i, j int) bool {
+		return all[i].Name < all[j].Name
+	}) {
+		t.Fatalf("expected metrics sorted by name")
+	}
+}
+
+// TestMetricDatasets tests scaling of the values produced by our default
+// metrics.
+func TestMetricDatasets(t *testing.T) {
+	channels := []*insights.ChannelInfo{
+		{
+			ChannelPoint:  "a:0",
+			FeesEarned:    1000,
+			Confirmations: 10,
+			Capacity:      btcutil.Amount(500),
+		},
+		{
+			ChannelPoint:     "a:1",
+			FeesEarned:       1000,
+			Confirmations:    100,
+			BalanceMonitored: time.Hour,
+			OneSided:         time.Minute * 15,
+		},
+		{
+			ChannelPoint:     "a:2",
+			Confirmations:    1,
+			Capacity:         btcutil.Amount(1000),
+			BalanceMonitored: time.Hour,
+			OneSided:         time.Hour,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		metric   string
+		expected map[string]float64
+	}{
+		{
+			name:   "per confirmation",
+			metric: RevenueMetric,
+			expected: map[string]float64{
+				"a:0": 100,
+				"a:1": 10,
+				"a:2": 0,
+			},
+		},
+		{
+			name:   "per capacity",
+			metric: RevenuePerCapacityMetric,
+			expected: map[string]float64{
+				"a:0": 2,
+				"a:1": 0,
+				"a:2": 0,
+			},
+		},
+		{
+			name:   "raw",
+			metric: BalanceMetric,
+			expected: map[string]float64{
+				"a:0": 1,
+				"a:1": 0.75,
+				"a:2": 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			metric, err := GetMetric(test.metric)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			data := metric.dataset(
+				&CloseRecommendationConfig{}, channels,
+			)
+			if len(data) != len(test.expected) {
+				t.Fatalf("expected: %v values, got: %v",
+					len(test.expected), len(data))
+			}
+
+			for chanPoint, value := range test.expected {
+				if data.Value(chanPoint) != value {
+					t.Fatalf("expected: %v to have value "+
+						"%v, got %v", chanPoint, value,
+						data.Value(chanPoint))
+				}
+			}
+		})
+	}
+}
@@ -3,12 +3,17 @@
 // channels that have been monitored for the configurable minimum monitored
 // time will be considered for closing.
 //
-// Channels will be assessed based on the following data points:
+// Channels are assessed based on a metric, chosen from a registry of metrics
+// which can be extended with RegisterMetric. The following metrics are
+// registered by default:
 // - Uptime ratio
 // - Fee revenue per block capital has been committed for
 // - Incoming volume per block capital has been committed for
 // - Outgoing volume per block capital has been committed for
 // - Total volume per block capital has been committed for
+// - Net value (fee revenue less the opportunity cost of capital) per block
+// - Ratio of time that the channel's balance was not one-sided
+// - Fee revenue per satoshi of capacity
 //
 // Channels that are outliers within the set of channels that are eligible for
 // close recommendation will be recommended for closure.
@@ -33,39 +38,20 @@ var (
 	ErrNoMetric = errors.New("metric required for close " +
 		"recommendations")
 
+	// ErrNegativeCostRate is returned when a negative opportunity cost
+	// rate is provided.
+	ErrNegativeCostRate = errors.New("opportunity cost rate must be " +
+		"non-negative")
+
 	// DefaultOutlierMultiplier is the default value used in close
 	// recommendations based on outliers when there is no user provided
 	// value.
 	DefaultOutlierMultiplier float64 = 3
 )
 
-// Metric is an enum which indicate what data point our recommendations should
-// be based on.
-type Metric int
-
-const (
-	invalidMetric Metric = iota
-
-	// UptimeMetric bases recommendations on the uptime of the channel's
-	// remote peer.
-	UptimeMetric
-
-	// RevenueMetric bases recommendations on the revenue that the channel
-	// has generated per block that our capital has been committed for.
-	RevenueMetric
-
-	// IncomingVolume bases recommendations on the incoming volume that the
-	// channel has processed, scaled by funding transaction confirmations.
-	IncomingVolume
-
-	// IncomingVolume bases recommendations on the incoming volume that the
-	// channel has processed, scaled by funding transaction confirmations.
-	OutgoingVolume
-
-	// Volume bases recommendations on the total volume that the
-	// channel has processed, scaled by funding transaction confirmations.
-	Volume
-)
+// blocksPerYear is the approximate number of blocks mined in a year, used to
+// convert an annual opportunity cost rate to a rate per block.
+const blocksPerYear = 6 * 24 * 365
 
 // CloseRecommendationConfig provides the functions and parameters required to
 // provide close recommendations. This struct holds fields which are common to
@@ -75,21 +61,30 @@ type CloseRecommendationConfig struct {
 	// for our current set of channels.
 	ChannelInsights func() ([]*insights.ChannelInfo, error)
 
-	// Metric defines the metric that we will use to provide close
-	// recommendations. Calls will fail if no value is provided.
-	Metric Metric
+	// Metric is the name of the registered metric that we will use to
+	// provide close recommendations. Calls will fail if no value is
+	// provided.
+	Metric string
 
 	// MinimumMonitored is the minimum amount of time that a channel must
 	// have been monitored for before it is considered for closing.
 	MinimumMonitored time.Duration
+
+	// OpportunityCostRate is the annual rate of return that we could
+	// earn on our capital elsewhere, expressed as a fraction (eg, 0.05
+	// for 5%). It is used to calculate the opportunity cost of the
+	// capital committed to a channel for the net value metric.
+	OpportunityCostRate float64
 }
 
 // Recommendation provides the value that a close recommendation was
 // based on, and a boolean indicating whether we recommend closing the
-// channel.
+// channel. Recommendations based on rules do not have a value, and instead
+// record the name of the rule that matched the channel.
 type Recommendation struct {
 	Value          float64
 	RecommendClose bool
+	Rule           string
 }
 
 // Report contains a set of close recommendations and information about the
@@ -105,6 +100,12 @@ type Report struct {
 	// Recommendations is a map of chanel outpoints to a bool which
 	// indicates whether we should close the channel.
 	Recommendations map[string]Recommendation
+
+	// OutlierBounds contains the quartiles and outlier bounds that outlier
+	// recommendations were based on. It is nil for other recommendations,
+	// and for outlier recommendations where there were too few channels
+	// to calculate quartiles.
+	OutlierBounds *dataset.Bounds
 }
 
 // OutlierRecommendations returns recommendations based on whether a value is a
@@ -115,11 +116,24 @@ type Report struct {
 func OutlierRecommendations(cfg *CloseRecommendationConfig,
 	outlierMultiplier float64) (*Report, error) {
 
-	getRecs := func(dataset dataset.Dataset) (map[string]Recommendation, error) {
-		return getOutlierRecs(dataset, outlierMultiplier, false)
+	var bounds *dataset.Bounds
+	getRecs := func(data dataset.Dataset) (map[string]Recommendation, error) {
+		var err error
+		bounds, err = data.OutlierBounds(outlierMultiplier)
+		if err != nil {
+			return nil, err
+		}
+
+		return getOutlierRecs(data, outlierMultiplier, false)
 	}
 
-	return closeRecommendations(cfg, getRecs)
+	report, err := closeRecommendations(cfg, getRecs)
+	if err != nil {
+		return nil, err
+	}
+	report.OutlierBounds = bounds
+
+	return report, nil
 }
 
 // ThresholdRecommendations returns a recommendations based on whether a value is
@@ -147,6 +161,15 @@ func closeRecommendations(cfg *CloseRecommendationConfig,
 		return nil, errZeroMinMonitored
 	}
 
+	if cfg.OpportunityCostRate < 0 {
+		return nil, ErrNegativeCostRate
+	}
+
+	metric, err := GetMetric(cfg.Metric)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get the set of insights for our currently open channels.
 	channels, err := cfg.ChannelInsights()
 	if err != nil {
@@ -161,30 +184,7 @@ func closeRecommendations(cfg *CloseRecommendationConfig,
 		ConsideredChannels: len(filtered),
 	}
 
-	var data dataset.Dataset
-	switch cfg.Metric {
-	case UptimeMetric:
-		data = getUptimeDataset(filtered)
-
-	case RevenueMetric:
-		data = getConfirmationScaledDataset(revenueValue, filtered)
-
-	case IncomingVolume:
-		data = getConfirmationScaledDataset(
-			incomingVolumeValue, filtered,
-		)
-
-	case OutgoingVolume:
-		data = getConfirmationScaledDataset(
-			outgoingVolumeValue, filtered,
-		)
-
-	case Volume:
-		data = getConfirmationScaledDataset(totalVolumeValue, filtered)
-
-	default:
-		return nil, ErrNoMetric
-	}
+	data := metric.dataset(cfg, filtered)
 
 	// Get close recommendations based on outliers.
 	report.Recommendations, err = getRecommendations(data)
@@ -297,31 +297,10 @@ func filterChannels(channelInsights []*insights.ChannelInfo,
 	return filteredChannels
 }
 
-// getUptimeDataset takes a set of channels that are eligible for close and
-// produces an uptime dataset.
-func getUptimeDataset(
-	eligibleChannels []*insights.ChannelInfo) dataset.Dataset {
-
-	// Create a map which will hold channel point string label to uptime
-	// ratio.
-	var channels = make(map[string]float64, len(eligibleChannels))
-
-	for _, channel := range eligibleChannels {
-		// Calculate the uptime ratio for the channel and add it
-		// to the channel -> uptime map.
-		uptimeRatio := float64(channel.Uptime) /
-			float64(channel.MonitoredFor)
-
-		channels[channel.ChannelPoint] = uptimeRatio
-	}
-
-	// Create a dataset for the uptime values we have collected.
-	return dataset.New(channels)
-}
-
 // getConfirmationScaledDataset returns a dataset that scales a value by the
-// number of confirmations its funding transaction has. It takes a function
-// which gets the relevant value from the channel insight as input.
+// number of confirmations its funding transaction has, limited to the number
+// of blocks that its revenue was calculated over. It takes a function which
+// gets the relevant value from the channel insight as input.
 func getConfirmationScaledDataset(getValue perConfirmationValue,
 	eligibleChannels []*insights.ChannelInfo) dataset.Dataset {
 
@@ -334,8 +313,7 @@ func getConfirmationScaledDataset(getValue perConfirmationValue,
 		// dealing with open (ie confirmed) channels, so we can
 		// get the value and scale it by our confirmation total.
 		valuePerConfirmation :=
-			getValue(channel) /
-				float64(channel.Confirmations)
+			getValue(channel) / channel.ScalingBlocks()
 
 		channels[channel.ChannelPoint] = valuePerConfirmation
 	}
@@ -346,23 +324,3 @@ func getConfirmationScaledDataset(getValue perConfirmationValue,
 // perConfirmationValue is a function which gets a value from a channel insight
 // that needs to be scaled by its number of confirmations.
 type perConfirmationValue func(channel *insights.ChannelInfo) float64
-
-// revenueValue gets total revenue for a channel.
-func revenueValue(channel *insights.ChannelInfo) float64 {
-	return float64(channel.FeesEarned)
-}
-
-// incomingVolumeValue gets total incoming volume for a channel.
-func incomingVolumeValue(channel *insights.ChannelInfo) float64 {
-	return float64(channel.VolumeIncoming)
-}
-
-// outgoingVolumeValue gets total outgoing volume for a channel.
-func outgoingVolumeValue(channel *insights.ChannelInfo) float64 {
-	return float64(channel.VolumeOutgoing)
-}
-
-// totalVolumeValue gets total volume for a channel.
-func totalVolumeValue(channel *insights.ChannelInfo) float64 {
-	return float64(channel.VolumeIncoming + channel.VolumeOutgoing)
-}
@@ -2,11 +2,16 @@ package recommend
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/lightninglabs/faraday/dataset"
 	"github.com/lightninglabs/faraday/insights"
+	"github.com/lightninglabs/faraday/revenue"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
 )
 
 // TestCloseRecommendations tests CloseRecommendations for error cases where
@@ -20,7 +25,7 @@ func TestCloseRecommendations(t *testing.T) {
 	tests := []struct {
 		name         string
 		upperOutlier bool
-		metric       Metric
+		metric       string
 		ChanInsights func() ([]*insights.ChannelInfo, error)
 		MinMonitored time.Duration
 		expectedErr  error
@@ -36,15 +41,25 @@ func TestCloseRecommendations(t *testing.T) {
 			expectedErr:  nil,
 		},
 		{
-			name:         "channel insights fails",
+			name:         "no metric",
 			upperOutlier: false,
-			metric:       invalidMetric,
+			metric:       "",
 			ChanInsights: func() ([]*insights.ChannelInfo, error) {
 				return nil, nil
 			},
 			MinMonitored: time.Hour,
 			expectedErr:  ErrNoMetric,
 		},
+		{
+			name:         "unknown metric",
+			upperOutlier: false,
+			metric:       "unknown",
+			ChanInsights: func() ([]*insights.ChannelInfo, error) {
+				return nil, nil
+			},
+			MinMonitored: time.Hour,
+			expectedErr:  ErrUnknownMetric,
+		},
 		{
 			name:   "channel insights fails",
 			metric: UptimeMetric,
@@ -113,7 +128,7 @@ func TestCloseRecommendations(t *testing.T) {
 				recFunc,
 			)
 
-			if err != test.expectedErr {
+			if !errors.Is(err, test.expectedErr) {
 				t.Fatalf("expected: %v, got: %v",
 					test.expectedErr, err)
 			}
@@ -280,6 +295,72 @@ func TestOutlierRecommendations(t *testing.T) {
 	}
 }
 
+// TestOutlierBounds tests that outlier recommendations report the bounds
+// that they were based on.
+func TestOutlierBounds(t *testing.T) {
+	var channels []*insights.ChannelInfo
+	for i, uptime := range []time.Duration{10, 10, 9, 8, 2} {
+		channels = append(channels, &insights.ChannelInfo{
+			ChannelPoint: fmt.Sprintf("a:%v", i),
+			MonitoredFor: 10,
+			Uptime:       uptime,
+		})
+	}
+
+	tests := []struct {
+		name     string
+		channels []*insights.ChannelInfo
+		expected *dataset.Bounds
+	}{
+		{
+			name:     "too few channels",
+			channels: channels[:2],
+			expected: nil,
+		},
+		{
+			name:     "bounds calculated",
+			channels: channels,
+			expected: &dataset.Bounds{
+				LowerQuartile: 0.5,
+				UpperQuartile: 1,
+				LowerOutlier:  -0.25,
+				UpperOutlier:  1.75,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := OutlierRecommendations(
+				&CloseRecommendationConfig{
+					ChannelInsights: func() (
+						[]*insights.ChannelInfo, error) {
+
+						return test.channels, nil
+					},
+					Metric:           UptimeMetric,
+					MinimumMonitored: 1,
+				}, 1.5,
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(
+				report.OutlierBounds, test.expected,
+			) {
+
+				t.Fatalf("expected: %+v, got: %+v",
+					test.expected, report.OutlierBounds)
+			}
+		})
+	}
+}
+
 // TestThresholdRecommendations tests getting of recommendations above and
 // below a threshold.
 func TestThresholdRecommendations(t *testing.T) {
@@ -442,6 +523,70 @@ func TestFilterChannels(t *testing.T) {
 	}
 }
 
+// TestLookbackScaling tests that when revenue is calculated over a lookback
+// period, channels with identical revenue over the period have the same per
+// confirmation values regardless of how long they have been open for, and
+// channels that are younger than the period are scaled by their age.
+func TestLookbackScaling(t *testing.T) {
+	const height = 20000
+
+	// newChannel returns a channel that was opened at the height
+	// provided.
+	newChannel := func(chanPoint string, openHeight uint32) *lnrpc.Channel {
+		return &lnrpc.Channel{
+			ChannelPoint: chanPoint,
+			ChanId: lnwire.ShortChannelID{
+				BlockHeight: openHeight,
+			}.ToUint64(),
+		}
+	}
+
+	// Each of our channels forwarded the same amount to a closed channel
+	// within our lookback period.
+	fees := revenue.Revenue{
+		AmountIncoming: 10000,
+		FeesIncoming:   1440,
+	}
+
+	channels, err := insights.GetChannels(&insights.Config{
+		OpenChannels: func() ([]*lnrpc.Channel, error) {
+			return []*lnrpc.Channel{
+				newChannel("old:0", height-10000),
+				newChannel("young:0", height-499),
+				newChannel("new:0", height-71),
+			}, nil
+		},
+		CurrentHeight: func() (uint32, error) {
+			return height, nil
+		},
+		RevenueReport: &revenue.Report{
+			ChannelPairs: map[string]map[string]revenue.Revenue{
+				"old:0":   {"closed:0": fees},
+				"young:0": {"closed:0": fees},
+				"new:0":   {"closed:0": fees},
+			},
+		},
+		Lookback: time.Hour * 24,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Our lookback period is 144 blocks, so our old and young channels
+	// are both scaled by 144 blocks, and our new channel is scaled by its
+	// 72 confirmations.
+	expected := dataset.Dataset{
+		"old:0":   5,
+		"young:0": 5,
+		"new:0":   10,
+	}
+
+	values := getConfirmationScaledDataset(revenueValue, channels)
+	if !reflect.DeepEqual(expected, values) {
+		t.Fatalf("expected: %v, got: %v", expected, values)
+	}
+}
+
 // TestGetConfirmationScaledDataset tests scaling of data by the number of
 // confirmations that a channel has.
 func TestGetConfirmationScaledDataset(t *testing.T) {
@@ -521,6 +666,28 @@ func TestGetConfirmationScaledDataset(t *testing.T) {
 				"a:0": 1,
 			},
 		},
+		{
+			name:     "net value",
+			getValue: netValue(0.1),
+			insights: []*insights.ChannelInfo{
+				{
+					ChannelPoint:  "a:0",
+					FeesEarned:    300,
+					Capacity:      blocksPerYear,
+					Confirmations: 2,
+				},
+				{
+					ChannelPoint:  "a:1",
+					FeesEarned:    100,
+					Capacity:      blocksPerYear,
+					Confirmations: 2,
+				},
+			},
+			expectedValues: map[string]float64{
+				"a:0": 50,
+				"a:1": -50,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -545,3 +712,123 @@ func TestGetConfirmationScaledDataset(t *testing.T) {
 		})
 	}
 }
+
+// TestNetValueThreshold tests that channels which have earned less in fees
+// than the opportunity cost of their capital are recommended for close by a
+// net value threshold of zero, and that the opportunity cost is charged over
+// the same blocks as the channel's revenue.
+func TestNetValueThreshold(t *testing.T) {
+	channels := []*insights.ChannelInfo{
+		{
+			ChannelPoint:  "a:0",
+			MonitoredFor:  time.Hour,
+			FeesEarned:    300,
+			Capacity:      blocksPerYear,
+			Confirmations: 2,
+		},
+		{
+			ChannelPoint:  "a:1",
+			MonitoredFor:  time.Hour,
+			FeesEarned:    100,
+			Capacity:      blocksPerYear,
+			Confirmations: 2,
+		},
+		{
+			ChannelPoint:  "a:2",
+			MonitoredFor:  time.Hour,
+			FeesEarned:    300,
+			LiquidityCost: 200,
+			Capacity:      blocksPerYear,
+			Confirmations: 2,
+		},
+		// A channel that has been open for much longer, but only had
+		// its revenue calculated over the last two blocks.
+		{
+			ChannelPoint:  "a:3",
+			MonitoredFor:  time.Hour,
+			FeesEarned:    300,
+			Capacity:      blocksPerYear,
+			Confirmations: 2000,
+			RevenueBlocks: 2,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		rate         float64
+		expectedRecs map[string]Recommendation
+		expectedErr  error
+	}{
+		{
+			name: "no opportunity cost",
+			rate: 0,
+			expectedRecs: map[string]Recommendation{
+				"a:0": {Value: 150, RecommendClose: false},
+				"a:1": {Value: 50, RecommendClose: false},
+				"a:2": {Value: 50, RecommendClose: false},
+				"a:3": {Value: 150, RecommendClose: false},
+			},
+		},
+		{
+			name: "negative net value",
+			rate: 0.1,
+			expectedRecs: map[string]Recommendation{
+				"a:0": {Value: 50, RecommendClose: false},
+				"a:1": {Value: -50, RecommendClose: true},
+				"a:2": {Value: -50, RecommendClose: true},
+				"a:3": {Value: 50, RecommendClose: false},
+			},
+		},
+		{
+			name:        "negative rate",
+			rate:        -0.1,
+			expectedErr: ErrNegativeCostRate,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := ThresholdRecommendations(
+				&CloseRecommendationConfig{
+					ChannelInsights: func() (
+						[]*insights.ChannelInfo, error) {
+
+						return channels, nil
+					},
+					Metric:              NetValueMetric,
+					MinimumMonitored:    time.Hour,
+					OpportunityCostRate: test.rate,
+				}, 0,
+			)
+			if err != test.expectedErr {
+				t.Fatalf("expected: %v, got: %v",
+					test.expectedErr, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if len(report.Recommendations) !=
+				len(test.expectedRecs) {
+
+				t.Fatalf("expected: %v recommendations, "+
+					"got: %v", len(test.expectedRecs),
+					len(report.Recommendations))
+			}
+
+			for channel, expected := range test.expectedRecs {
+				rec := report.Recommendations[channel]
+				if rec != expected {
+					t.Fatalf("expected: %v for channel: "+
+						"%v, got: %v", expected, channel,
+						rec)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package recommend
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lightninglabs/faraday/insights"
+	"github.com/lightninglabs/faraday/rules"
+)
+
+// ErrNoRules is returned when rule based recommendations are requested
+// without any rules.
+var ErrNoRules = errors.New("at least one rule required for rule " +
+	"recommendations")
+
+// ruleVariable is a variable that close recommendation rules can use, which
+// is obtained from a channel's insights.
+type ruleVariable struct {
+	varType rules.Type
+	value   func(channel *insights.ChannelInfo,
+		cfg *CloseRecommendationConfig) rules.Value
+}
+
+// ruleVariables is the set of variables that close recommendation rules can
+// use.
+var ruleVariables = map[string]ruleVariable{
+	"uptime_ratio": perChannel(uptimeValue),
+	"uptime": {
+		varType: rules.Duration,
+		value: func(c *insights.ChannelInfo,
+			_ *CloseRecommendationConfig) rules.Value {
+
+			return rules.DurationValue(c.Uptime)
+		},
+	},
+	"monitored": {
+		varType: rules.Duration,
+		value: func(c *insights.ChannelInfo,
+			_ *CloseRecommendationConfig) rules.Value {
+
+			return rules.DurationValue(c.MonitoredFor)
+		},
+	},
+	"fees":            perChannel(revenueValue),
+	"fees_per_conf":   perConf(revenueValue),
+	"volume_incoming": perChannel(incomingVolumeValue),
+	"volume_outgoing": perChannel(outgoingVolumeValue),
+	"volume":          perChannel(totalVolumeValue),
+	"volume_per_conf": perConf(totalVolumeValue),
+	"liquidity_cost": perChannel(func(c *insights.ChannelInfo) float64 {
+		return float64(c.LiquidityCost)
+	}),
+	"net_fees": perChannel(func(c *insights.ChannelInfo) float64 {
+		return float64(c.NetFees)
+	}),
+	"net_value_per_conf": {
+		varType: rules.Number,
+		value: func(c *insights.ChannelInfo,
+			cfg *CloseRecommendationConfig) rules.Value {
+
+			getValue := netValue(cfg.OpportunityCostRate)
+
+			return rules.NumberValue(
+				getValue(c) / c.ScalingBlocks(),
+			)
+		},
+	},
+	"confirmations": perChannel(func(c *insights.ChannelInfo) float64 {
+		return float64(c.Confirmations)
+	}),
+	"capacity": perChannel(func(c *insights.ChannelInfo) float64 {
+		return float64(c.Capacity)
+	}),
+	"local_balance": perChannel(func(c *insights.ChannelInfo) float64 {
+		return float64(c.LocalBalance)
+	}),
+	"remote_balance": perChannel(func(c *insights.ChannelInfo) float64 {
+		return float64(c.RemoteBalance)
+	}),
+	"pending_incoming": perChannel(func(c *insights.ChannelInfo) float64 {
+		return float64(c.PendingIncoming)
+	}),
+	"pending_outgoing": perChannel(func(c *insights.ChannelInfo) float64 {
+		return float64(c.PendingOutgoing)
+	}),
+	"balance_ratio": perChannel(func(c *insights.ChannelInfo) float64 {
+		return c.BalanceRatio
+	}),
+	"one_sided": {
+		varType: rules.Duration,
+		value: func(c *insights.ChannelInfo,
+			_ *CloseRecommendationConfig) rules.Value {
+
+			return rules.DurationValue(c.OneSided)
+		},
+	},
+	"one_sided_ratio": perChannel(func(c *insights.ChannelInfo) float64 {
+		if c.BalanceMonitored == 0 {
+			return 0
+		}
+
+		return float64(c.OneSided) / float64(c.BalanceMonitored)
+	}),
+	"private": {
+		varType: rules.Bool,
+		value: func(c *insights.ChannelInfo,
+			_ *CloseRecommendationConfig) rules.Value {
+
+			return rules.BoolValue(c.Private)
+		},
+	},
+	"internal": {
+		varType: rules.Bool,
+		value: func(c *insights.ChannelInfo,
+			_ *CloseRecommendationConfig) rules.Value {
+
+			return rules.BoolValue(c.Internal)
+		},
+	},
+}
+
+// perChannel creates a number variable from a channel value.
+func perChannel(getValue func(*insights.ChannelInfo) float64) ruleVariable {
+	return ruleVariable{
+		varType: rules.Number,
+		value: func(c *insights.ChannelInfo,
+			_ *CloseRecommendationConfig) rules.Value {
+
+			return rules.NumberValue(getValue(c))
+		},
+	}
+}
+
+// perConf creates a number variable from a channel value which is scaled by
+// the number of confirmations the channel's funding transaction has, limited
+// to the number of blocks that its revenue was calculated over.
+func perConf(getValue perConfirmationValue) ruleVariable {
+	return ruleVariable{
+		varType: rules.Number,
+		value: func(c *insights.ChannelInfo,
+			_ *CloseRecommendationConfig) rules.Value {
+
+			return rules.NumberValue(
+				getValue(c) / c.ScalingBlocks(),
+			)
+		},
+	}
+}
+
+// RuleVariables returns the names and types of the variables that close
+// recommendation rules can use.
+func RuleVariables() map[string]rules.Type {
+	variables := make(map[string]rules.Type, len(ruleVariables))
+	for name, variable := range ruleVariables {
+		variables[name] = variable.varType
+	}
+
+	return variables
+}
+
+// ParseRules parses a set of rules expressed as name:expression, checking
+// them against the variables that close recommendation rules can use. Rules
+// that are not named are named by their position in the set provided.
+func ParseRules(ruleStrs []string) ([]*rules.Rule, error) {
+	variables := RuleVariables()
+
+	parsed := make([]*rules.Rule, 0, len(ruleStrs))
+	for i, ruleStr := range ruleStrs {
+		rule, err := rules.ParseNamed(
+			ruleStr, fmt.Sprintf("rule-%v", i+1), variables,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, rule)
+	}
+
+	return parsed, nil
+}
+
+// RuleRecommendations returns recommendations based on a set of rules. Each
+// channel that is eligible for close is checked against the rules in order,
+// and is recommended for close if any of them match. The first rule that
+// matches a channel is recorded in its recommendation. The metric in the
+// config provided is not used.
+func RuleRecommendations(cfg *CloseRecommendationConfig,
+	closeRules []*rules.Rule) (*Report, error) {
+
+	if len(closeRules) == 0 {
+		return nil, ErrNoRules
+	}
+
+	// Check that the minimum wait time is non-zero.
+	if cfg.MinimumMonitored == 0 {
+		return nil, errZeroMinMonitored
+	}
+
+	if cfg.OpportunityCostRate < 0 {
+		return nil, ErrNegativeCostRate
+	}
+
+	channels, err := cfg.ChannelInsights()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := filterChannels(channels, cfg.MinimumMonitored)
+
+	report := &Report{
+		TotalChannels:      len(channels),
+		ConsideredChannels: len(filtered),
+		Recommendations: make(
+			map[string]Recommendation, len(filtered),
+		),
+	}
+
+	for _, channel := range filtered {
+		values := make(map[string]rules.Value, len(ruleVariables))
+		for name, variable := range ruleVariables {
+			values[name] = variable.value(channel, cfg)
+		}
+
+		var rec Recommendation
+		for _, rule := range closeRules {
+			match, err := rule.Match(values)
+			if err != nil {
+				return nil, err
+			}
+
+			if match {
+				rec.RecommendClose = true
+				rec.Rule = rule.Name
+				break
+			}
+		}
+
+		report.Recommendations[channel.ChannelPoint] = rec
+	}
+
+	return report, nil
+}
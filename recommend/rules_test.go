@@ -0,0 +1,109 @@
+package recommend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/faraday/insights"
+	"github.com/lightninglabs/faraday/rules"
+)
+
+// TestRuleRecommendations tests that channels are recommended for close by
+// the first rule that matches them.
+func TestRuleRecommendations(t *testing.T) {
+	channels := []*insights.ChannelInfo{
+		{
+			ChannelPoint:  "a:0",
+			MonitoredFor:  time.Hour * 24 * 90,
+			Uptime:        time.Hour * 24 * 45,
+			FeesEarned:    10,
+			Confirmations: 2,
+		},
+		{
+			ChannelPoint:  "a:1",
+			MonitoredFor:  time.Hour * 24 * 90,
+			Uptime:        time.Hour * 24 * 90,
+			FeesEarned:    10,
+			Confirmations: 2,
+		},
+		{
+			ChannelPoint:  "a:2",
+			MonitoredFor:  time.Hour * 24 * 90,
+			Uptime:        time.Hour * 24 * 90,
+			FeesEarned:    100,
+			Confirmations: 2,
+		},
+		{
+			ChannelPoint:  "a:3",
+			MonitoredFor:  time.Hour,
+			Confirmations: 2,
+		},
+	}
+
+	closeRules, err := ParseRules([]string{
+		"flaky: uptime_ratio < 0.8 && fees_per_conf < 10 && " +
+			"monitored > 60d",
+		"fees_per_conf < 10",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := RuleRecommendations(&CloseRecommendationConfig{
+		ChannelInsights: func() ([]*insights.ChannelInfo, error) {
+			return channels, nil
+		},
+		MinimumMonitored: time.Hour * 24,
+	}, closeRules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.TotalChannels != 4 || report.ConsideredChannels != 3 {
+		t.Fatalf("expected 3/4 channels considered, got: %v/%v",
+			report.ConsideredChannels, report.TotalChannels)
+	}
+
+	expected := map[string]Recommendation{
+		"a:0": {RecommendClose: true, Rule: "flaky"},
+		"a:1": {RecommendClose: true, Rule: "rule-2"},
+		"a:2": {RecommendClose: false},
+	}
+
+	if len(report.Recommendations) != len(expected) {
+		t.Fatalf("expected: %v recommendations, got: %v",
+			len(expected), len(report.Recommendations))
+	}
+
+	for chanPoint, rec := range expected {
+		if report.Recommendations[chanPoint] != rec {
+			t.Fatalf("expected: %v for %v, got: %v", rec,
+				chanPoint, report.Recommendations[chanPoint])
+		}
+	}
+
+	// Recommendations without rules should fail.
+	_, err = RuleRecommendations(&CloseRecommendationConfig{
+		MinimumMonitored: time.Hour,
+	}, []*rules.Rule{})
+	if err != ErrNoRules {
+		t.Fatalf("expected: %v, got: %v", ErrNoRules, err)
+	}
+}
+
+// TestRuleVariables tests that every rule variable can be evaluated for a
+// channel, and produces a value of the type that it is declared with.
+func TestRuleVariables(t *testing.T) {
+	channel := &insights.ChannelInfo{
+		MonitoredFor:  time.Hour,
+		Confirmations: 1,
+	}
+
+	for name, variable := range ruleVariables {
+		value := variable.value(channel, &CloseRecommendationConfig{})
+		if value.Type != variable.varType {
+			t.Fatalf("%v: expected type %v, got: %v", name,
+				variable.varType, value.Type)
+		}
+	}
+}
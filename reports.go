@@ -0,0 +1,539 @@
+package faraday
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/lightninglabs/faraday/recommend"
+	"github.com/lightninglabs/faraday/schedule"
+)
+
+// The types of report that can be scheduled.
+const (
+	revenueReport   = "revenue"
+	insightsReport  = "insights"
+	outliersReport  = "outliers"
+	thresholdReport = "threshold"
+	rulesReport     = "rules"
+)
+
+// The formats that scheduled reports can be written in.
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// defaultReportLookback is the period that scheduled revenue reports cover
+// if their lookback is not set.
+const defaultReportLookback = time.Hour * 24 * 7
+
+// reportNameRegex matches the names that scheduled reports may have, which
+// are used to name their directories and files.
+var reportNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// reportSpec is a scheduled report, as it is expressed in our reports file.
+type reportSpec struct {
+	// Name is the name of the report, which is used to name its files.
+	Name string `json:"name"`
+
+	// Schedule is the cron-like schedule that the report runs on.
+	Schedule string `json:"schedule"`
+
+	// Report is the type of report.
+	Report string `json:"report"`
+
+	// Format is the format that the report is written in, json by
+	// default.
+	Format string `json:"format"`
+
+	// Keep is the number of runs of the report to keep. If it is not set,
+	// our configured default is used.
+	Keep int `json:"keep"`
+
+	// Lookback is the period, counting back from each run, that revenue
+	// reports cover.
+	Lookback string `json:"lookback"`
+
+	// Request contains the saved parameters for the report, expressed as
+	// the json form of its rpc request.
+	Request json.RawMessage `json:"request"`
+}
+
+// scheduledReport is a report that is generated on a schedule.
+type scheduledReport struct {
+	name     string
+	schedule *schedule.Schedule
+	report   string
+	format   string
+	keep     int
+	lookback time.Duration
+
+	// request is the rpc request that the report is generated with.
+	request proto.Message
+}
+
+// readReports reads a set of scheduled reports from the json file provided.
+// Reports that do not set the number of runs they keep default to the value
+// provided.
+func readReports(path string, keep int) ([]*scheduledReport, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var specs []*reportSpec
+	if err := json.Unmarshal(contents, &specs); err != nil {
+		return nil, fmt.Errorf("invalid reports file: %v", err)
+	}
+
+	names := make(map[string]bool, len(specs))
+	reports := make([]*scheduledReport, 0, len(specs))
+	for _, spec := range specs {
+		report, err := parseReport(spec, keep)
+		if err != nil {
+			return nil, fmt.Errorf("report %v: %v", spec.Name, err)
+		}
+
+		if names[report.name] {
+			return nil, fmt.Errorf("report name: %v used more "+
+				"than once", report.name)
+		}
+		names[report.name] = true
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// parseReport validates a report spec and parses its schedule and request.
+func parseReport(spec *reportSpec, keep int) (*scheduledReport, error) {
+	if !reportNameRegex.MatchString(spec.Name) {
+		return nil, fmt.Errorf("name must only contain letters, " +
+			"numbers, - and _")
+	}
+
+	reportSchedule, err := schedule.Parse(spec.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &scheduledReport{
+		name:     spec.Name,
+		schedule: reportSchedule,
+		report:   spec.Report,
+		format:   spec.Format,
+		keep:     spec.Keep,
+	}
+
+	if report.format == "" {
+		report.format = formatJSON
+	}
+
+	if report.format != formatJSON && report.format != formatCSV {
+		return nil, fmt.Errorf("unknown format: %v", report.format)
+	}
+
+	if report.keep == 0 {
+		report.keep = keep
+	}
+
+	if report.keep < 0 {
+		return nil, fmt.Errorf("keep must not be negative")
+	}
+
+	switch report.report {
+	case revenueReport:
+		report.request = &frdrpc.RevenueReportRequest{}
+
+		report.lookback = defaultReportLookback
+		if spec.Lookback != "" {
+			report.lookback, err = time.ParseDuration(spec.Lookback)
+			if err != nil {
+				return nil, fmt.Errorf("invalid lookback: %v",
+					err)
+			}
+		}
+
+		if report.lookback <= 0 {
+			return nil, fmt.Errorf("lookback must be positive")
+		}
+
+	case insightsReport:
+		report.request = &frdrpc.ChannelInsightsRequest{}
+
+	case outliersReport:
+		report.request = &frdrpc.OutlierRecommendationsRequest{}
+
+	case thresholdReport:
+		report.request = &frdrpc.ThresholdRecommendationsRequest{}
+
+	case rulesReport:
+		report.request = &frdrpc.RuleRecommendationsRequest{}
+
+	default:
+		return nil, fmt.Errorf("unknown report: %v", report.report)
+	}
+
+	if spec.Lookback != "" && report.report != revenueReport {
+		return nil, fmt.Errorf("lookback is only used by revenue " +
+			"reports, set lookback_seconds in the request instead")
+	}
+
+	if len(spec.Request) != 0 {
+		err := jsonpb.Unmarshal(
+			bytes.NewReader(spec.Request), report.request,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request: %v", err)
+		}
+	}
+
+	// Check the parameters that would otherwise only fail when the report
+	// runs.
+	switch req := report.request.(type) {
+	case *frdrpc.OutlierRecommendationsRequest:
+		err = checkMetric(req.RecRequest)
+
+	case *frdrpc.ThresholdRecommendationsRequest:
+		err = checkMetric(req.RecRequest)
+
+	case *frdrpc.RuleRecommendationsRequest:
+		_, err = recommend.ParseRules(req.Rules)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// checkMetric checks that a recommendation request names a registered
+// metric.
+func checkMetric(req *frdrpc.CloseRecommendationRequest) error {
+	if req.GetMetricName() == "" {
+		return fmt.Errorf("request must set rec_request.metric_name")
+	}
+
+	_, err := recommend.GetMetric(req.GetMetricName())
+	return err
+}
+
+// startReports starts a scheduler which generates our scheduled reports
+// with our rpc server's handlers. It returns a function which stops the
+// scheduler.
+func startReports(config *config, server *frdrpc.RPCServer) func() {
+	jobs := make([]*schedule.Job, 0, len(config.reports))
+	for _, report := range config.reports {
+		report := report
+
+		jobs = append(jobs, &schedule.Job{
+			Name:      report.name,
+			Schedule:  report.schedule,
+			Extension: report.format,
+			Keep:      report.keep,
+			Run: func(now time.Time) ([]byte, error) {
+				resp, err := runReport(
+					config, server, report, now,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				return formatReport(resp, report.format)
+			},
+		})
+	}
+
+	scheduler := schedule.NewScheduler(&schedule.Config{
+		Jobs: jobs,
+		Dir:  config.ReportDir,
+		Now:  time.Now,
+	})
+	scheduler.Start()
+
+	return scheduler.Stop
+}
+
+// runReport generates a scheduled report at the time provided. Revenue
+// reports cover the report's lookback period before this time, and
+// recommendation requests that do not set a minimum monitored period use
+// the period in our config.
+func runReport(config *config, server *frdrpc.RPCServer,
+	report *scheduledReport, now time.Time) (proto.Message, error) {
+
+	ctx := context.Background()
+
+	// Copy our saved request so that the defaults we set do not alter
+	// it.
+	request := proto.Clone(report.request)
+	minMonitored := int64(config.MinimumMonitored.Seconds())
+
+	switch req := request.(type) {
+	case *frdrpc.RevenueReportRequest:
+		req.StartTime = uint64(now.Add(report.lookback * -1).Unix())
+		req.EndTime = uint64(now.Unix())
+
+		return server.RevenueReport(ctx, req)
+
+	case *frdrpc.ChannelInsightsRequest:
+		return server.ChannelInsights(ctx, req)
+
+	case *frdrpc.OutlierRecommendationsRequest:
+		req.RecRequest = withMinimumMonitored(
+			req.RecRequest, minMonitored,
+		)
+		return server.OutlierRecommendations(ctx, req)
+
+	case *frdrpc.ThresholdRecommendationsRequest:
+		req.RecRequest = withMinimumMonitored(
+			req.RecRequest, minMonitored,
+		)
+		return server.ThresholdRecommendations(ctx, req)
+
+	case *frdrpc.RuleRecommendationsRequest:
+		req.RecRequest = withMinimumMonitored(
+			req.RecRequest, minMonitored,
+		)
+		return server.RuleRecommendations(ctx, req)
+
+	default:
+		return nil, fmt.Errorf("unknown report: %v", report.report)
+	}
+}
+
+// withMinimumMonitored returns a recommendation request with its minimum
+// monitored period set to the value provided if it is not set.
+func withMinimumMonitored(req *frdrpc.CloseRecommendationRequest,
+	minMonitored int64) *frdrpc.CloseRecommendationRequest {
+
+	if req == nil {
+		req = &frdrpc.CloseRecommendationRequest{}
+	}
+
+	if req.MinimumMonitored == 0 {
+		req.MinimumMonitored = minMonitored
+	}
+
+	return req
+}
+
+// formatReport formats a report's rpc response in the format provided. Json
+// reports are formatted in the same way as frcli's output, so that scheduled
+// reports can be used with the same tools.
+func formatReport(resp proto.Message, format string) ([]byte, error) {
+	if format == formatJSON {
+		marshaler := &jsonpb.Marshaler{
+			OrigName:     true,
+			EmitDefaults: true,
+			Indent:       "    ",
+		}
+
+		jsonStr, err := marshaler.MarshalToString(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(jsonStr + "\n"), nil
+	}
+
+	var records [][]string
+	switch r := resp.(type) {
+	case *frdrpc.RevenueReportResponse:
+		records = revenueRecords(r)
+
+	case *frdrpc.ChannelInsightsResponse:
+		records = insightsRecords(r)
+
+	case *frdrpc.CloseRecommendationsResponse:
+		records = recommendationRecords(r)
+
+	default:
+		return nil, fmt.Errorf("cannot format %T as csv", resp)
+	}
+
+	var buf bytes.Buffer
+	if err := csv.NewWriter(&buf).WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// revenueRecords returns csv records for a revenue report, with a row for
+// each pair of channels. Fiat columns are only included if the report was
+// valued in fiat.
+func revenueRecords(resp *frdrpc.RevenueReportResponse) [][]string {
+	fiat := resp.FiatCurrency != ""
+
+	header := []string{
+		"target_channel", "pair_channel", "amount_incoming_msat",
+		"fees_incoming_msat", "amount_outgoing_msat",
+		"fees_outgoing_msat",
+	}
+	if fiat {
+		header = append(
+			header, "fiat_currency", "fees_incoming_fiat",
+			"fees_outgoing_fiat",
+		)
+	}
+
+	records := [][]string{header}
+	for _, report := range resp.Reports {
+		pairs := make([]string, 0, len(report.PairReports))
+		for pair := range report.PairReports {
+			pairs = append(pairs, pair)
+		}
+		sort.Strings(pairs)
+
+		for _, pair := range pairs {
+			p := report.PairReports[pair]
+
+			record := []string{
+				report.TargetChannel, pair,
+				formatInt(p.AmountIncomingMsat),
+				formatInt(p.FeesIncomingMsat),
+				formatInt(p.AmountOutgoingMsat),
+				formatInt(p.FeesOutgoingMsat),
+			}
+			if fiat {
+				record = append(
+					record, resp.FiatCurrency,
+					formatFloat(p.FeesIncomingFiat),
+					formatFloat(p.FeesOutgoingFiat),
+				)
+			}
+
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+// insightsRecords returns csv records for channel insights, with a row for
+// each channel.
+func insightsRecords(resp *frdrpc.ChannelInsightsResponse) [][]string {
+	records := [][]string{{
+		"chan_point", "monitored_seconds", "uptime_seconds",
+		"volume_incoming_msat", "volume_outgoing_msat",
+		"fees_earned_msat", "confirmations", "private", "internal",
+		"capacity_sat", "local_balance_sat", "remote_balance_sat",
+		"pending_incoming_sat", "pending_outgoing_sat",
+		"balance_ratio", "balance_monitored_seconds",
+		"one_sided_seconds", "liquidity_cost_msat", "net_fees_msat",
+	}}
+	records[0] = append(records[0], forwardStatsHeader("incoming")...)
+	records[0] = append(records[0], forwardStatsHeader("outgoing")...)
+
+	for _, i := range resp.ChannelInsights {
+		record := []string{
+			i.ChanPoint,
+			formatUint(i.MonitoredSeconds),
+			formatUint(i.UptimeSeconds),
+			formatInt(i.VolumeIncomingMsat),
+			formatInt(i.VolumeOutgoingMsat),
+			formatInt(i.FeesEarnedMsat),
+			formatUint(uint64(i.Confirmations)),
+			strconv.FormatBool(i.Private),
+			strconv.FormatBool(i.Internal),
+			formatInt(i.CapacitySat),
+			formatInt(i.LocalBalanceSat),
+			formatInt(i.RemoteBalanceSat),
+			formatInt(i.PendingIncomingSat),
+			formatInt(i.PendingOutgoingSat),
+			formatFloat32(i.BalanceRatio),
+			formatUint(i.BalanceMonitoredSeconds),
+			formatUint(i.OneSidedSeconds),
+			formatInt(i.LiquidityCostMsat),
+			formatInt(i.NetFeesMsat),
+		}
+		record = append(
+			record, forwardStatsRecord(i.IncomingForwards)...,
+		)
+		record = append(
+			record, forwardStatsRecord(i.OutgoingForwards)...,
+		)
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// forwardStatsHeader returns the csv column names for forward stats in the
+// direction provided.
+func forwardStatsHeader(direction string) []string {
+	columns := []string{
+		"forwards", "min_size_msat", "median_size_msat",
+		"p90_size_msat", "max_size_msat", "fee_rate_ppm",
+	}
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = direction + "_" + column
+	}
+
+	return header
+}
+
+// forwardStatsRecord returns the csv values for a set of forward stats, which
+// are zero if there were no forwards.
+func forwardStatsRecord(stats *frdrpc.ForwardStats) []string {
+	return []string{
+		formatUint(stats.GetCount()),
+		formatInt(stats.GetMinSizeMsat()),
+		formatInt(stats.GetMedianSizeMsat()),
+		formatInt(stats.GetP90SizeMsat()),
+		formatInt(stats.GetMaxSizeMsat()),
+		formatFloat(stats.GetFeeRatePpm()),
+	}
+}
+
+// recommendationRecords returns csv records for close recommendations, with
+// a row for each channel.
+func recommendationRecords(
+	resp *frdrpc.CloseRecommendationsResponse) [][]string {
+
+	records := [][]string{{
+		"chan_point", "value", "recommend_close", "rule",
+	}}
+
+	for _, rec := range resp.Recommendations {
+		records = append(records, []string{
+			rec.ChanPoint,
+			formatFloat32(rec.Value),
+			strconv.FormatBool(rec.RecommendClose),
+			rec.Rule,
+		})
+	}
+
+	return records
+}
+
+func formatInt(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
+
+func formatUint(value uint64) string {
+	return strconv.FormatUint(value, 10)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatFloat32(value float32) string {
+	return strconv.FormatFloat(float64(value), 'f', -1, 32)
+}
@@ -0,0 +1,264 @@
+package faraday
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lightninglabs/faraday/frdrpc"
+)
+
+// TestReadReports tests reading and validation of scheduled reports.
+func TestReadReports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "faraday")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	thresholdRequest := &frdrpc.ThresholdRecommendationsRequest{
+		RecRequest: &frdrpc.CloseRecommendationRequest{
+			MetricName: "uptime",
+		},
+		ThresholdValue: 0.8,
+	}
+
+	tests := []struct {
+		name      string
+		contents  string
+		expectErr bool
+		check     func(t *testing.T, reports []*scheduledReport)
+	}{
+		{
+			name: "revenue and threshold reports",
+			contents: `[
+{"name": "weekly_revenue", "schedule": "0 9 * * 1",
+ "report": "revenue", "format": "csv", "lookback": "168h"},
+{"name": "uptime", "schedule": "@daily", "report": "threshold",
+ "keep": 3, "request": {"rec_request": {"metric_name": "uptime"},
+ "threshold_value": 0.8}}
+]`,
+			check: func(t *testing.T, reports []*scheduledReport) {
+				if len(reports) != 2 {
+					t.Fatalf("expected 2 reports, got: %v",
+						len(reports))
+				}
+
+				revenue := reports[0]
+				if revenue.format != formatCSV ||
+					revenue.keep != 5 ||
+					revenue.lookback != time.Hour*168 {
+
+					t.Fatalf("unexpected revenue report: "+
+						"%+v", revenue)
+				}
+
+				threshold := reports[1]
+				if threshold.format != formatJSON ||
+					threshold.keep != 3 ||
+					!proto.Equal(
+						threshold.request,
+						thresholdRequest,
+					) {
+
+					t.Fatalf("unexpected threshold "+
+						"report: %+v", threshold)
+				}
+			},
+		},
+		{
+			name: "duplicate names",
+			contents: `[
+{"name": "insights", "schedule": "@daily", "report": "insights"},
+{"name": "insights", "schedule": "@weekly", "report": "insights"}
+]`,
+			expectErr: true,
+		},
+		{
+			name: "invalid name",
+			contents: `[
+{"name": "../insights", "schedule": "@daily", "report": "insights"}
+]`,
+			expectErr: true,
+		},
+		{
+			name: "invalid schedule",
+			contents: `[
+{"name": "insights", "schedule": "daily", "report": "insights"}
+]`,
+			expectErr: true,
+		},
+		{
+			name: "unknown report",
+			contents: `[
+{"name": "fees", "schedule": "@daily", "report": "fees"}
+]`,
+			expectErr: true,
+		},
+		{
+			name: "unknown format",
+			contents: `[
+{"name": "insights", "schedule": "@daily", "report": "insights",
+ "format": "xml"}
+]`,
+			expectErr: true,
+		},
+		{
+			name: "lookback for insights",
+			contents: `[
+{"name": "insights", "schedule": "@daily", "report": "insights",
+ "lookback": "24h"}
+]`,
+			expectErr: true,
+		},
+		{
+			name: "unknown request field",
+			contents: `[
+{"name": "insights", "schedule": "@daily", "report": "insights",
+ "request": {"lookback": 10}}
+]`,
+			expectErr: true,
+		},
+		{
+			name: "outliers without metric",
+			contents: `[
+{"name": "outliers", "schedule": "@daily", "report": "outliers"}
+]`,
+			expectErr: true,
+		},
+		{
+			name: "invalid rule",
+			contents: `[
+{"name": "rules", "schedule": "@daily", "report": "rules",
+ "request": {"rules": ["uptime_ratio <"]}}
+]`,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, "reports.json")
+			err := ioutil.WriteFile(
+				path, []byte(test.contents), 0600,
+			)
+			if err != nil {
+				t.Fatalf("could not write reports: %v", err)
+			}
+
+			reports, err := readReports(path, 5)
+			if test.expectErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			test.check(t, reports)
+		})
+	}
+}
+
+// TestFormatReport tests formatting of reports as csv.
+func TestFormatReport(t *testing.T) {
+	pairs := map[string]*frdrpc.PairReport{
+		"c:1": {
+			AmountIncomingMsat: 3000,
+			FeesIncomingMsat:   3,
+		},
+		"b:1": {
+			AmountOutgoingMsat: 2000,
+			FeesOutgoingMsat:   2,
+		},
+	}
+
+	fiatPairs := map[string]*frdrpc.PairReport{
+		"b:1": {
+			AmountOutgoingMsat: 2000,
+			FeesOutgoingMsat:   2,
+			FeesOutgoingFiat:   0.5,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		resp     proto.Message
+		expected string
+	}{
+		{
+			name: "revenue",
+			resp: &frdrpc.RevenueReportResponse{
+				Reports: []*frdrpc.RevenueReport{
+					{
+						TargetChannel: "a:1",
+						PairReports:   pairs,
+					},
+				},
+			},
+			expected: "target_channel,pair_channel," +
+				"amount_incoming_msat,fees_incoming_msat," +
+				"amount_outgoing_msat,fees_outgoing_msat\n" +
+				"a:1,b:1,0,0,2000,2\n" +
+				"a:1,c:1,3000,3,0,0\n",
+		},
+		{
+			name: "revenue in fiat",
+			resp: &frdrpc.RevenueReportResponse{
+				Reports: []*frdrpc.RevenueReport{
+					{
+						TargetChannel: "a:1",
+						PairReports:   fiatPairs,
+					},
+				},
+				FiatCurrency: "USD",
+			},
+			expected: "target_channel,pair_channel," +
+				"amount_incoming_msat,fees_incoming_msat," +
+				"amount_outgoing_msat,fees_outgoing_msat," +
+				"fiat_currency,fees_incoming_fiat," +
+				"fees_outgoing_fiat\n" +
+				"a:1,b:1,0,0,2000,2,USD,0,0.5\n",
+		},
+		{
+			name: "recommendations",
+			resp: &frdrpc.CloseRecommendationsResponse{
+				Recommendations: []*frdrpc.Recommendation{
+					{
+						ChanPoint:      "a:1",
+						Value:          0.1,
+						RecommendClose: true,
+						Rule:           "flaky",
+					},
+				},
+			},
+			expected: "chan_point,value,recommend_close,rule\n" +
+				"a:1,0.1,true,flaky\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			output, err := formatReport(test.resp, formatCSV)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(output) != test.expected {
+				t.Fatalf("expected:\n%v\ngot:\n%v",
+					test.expected, string(output))
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package revenue
+
+import (
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// Period contains the totals for the forwards that our node made over a
+// period of time.
+type Period struct {
+	// Start is the start of the period, inclusive.
+	Start time.Time
+
+	// End is the end of the period, exclusive.
+	End time.Time
+
+	// Forwards is the number of forwards made over the period.
+	Forwards int
+
+	// Fees is the total fees in msat earned over the period.
+	Fees lnwire.MilliSatoshi
+
+	// Volume is the total amount in msat forwarded over the period,
+	// counted as the amount that left our node.
+	Volume lnwire.MilliSatoshi
+}
+
+// getPeriods splits the events provided into consecutive periods of the
+// length provided, starting from start and ending at end. The final period is
+// shortened if the range does not divide into a whole number of periods.
+// Events at the end time are included in the final period, because lnd's
+// forwarding history queries include their end time. Events outside of the
+// range are not included.
+func getPeriods(events []revenueEvent, start, end time.Time,
+	length time.Duration) []*Period {
+
+	var periods []*Period
+	for periodStart := start; periodStart.Before(end); {
+		periodEnd := periodStart.Add(length)
+		if periodEnd.After(end) {
+			periodEnd = end
+		}
+
+		periods = append(periods, &Period{
+			Start: periodStart,
+			End:   periodEnd,
+		})
+
+		periodStart = periodEnd
+	}
+
+	if len(periods) == 0 {
+		return nil
+	}
+
+	for _, event := range events {
+		if event.timestamp.Before(start) || event.timestamp.After(end) {
+			continue
+		}
+
+		index := int(event.timestamp.Sub(start) / length)
+		if index >= len(periods) {
+			index = len(periods) - 1
+		}
+
+		period := periods[index]
+		period.Forwards++
+		period.Fees += event.incomingAmt - event.outgoingAmt
+		period.Volume += event.outgoingAmt
+	}
+
+	return periods
+}
@@ -0,0 +1,106 @@
+package revenue
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestGetPeriods tests splitting of events into periods.
+func TestGetPeriods(t *testing.T) {
+	var (
+		start  = time.Unix(1000, 0)
+		length = time.Hour
+	)
+
+	// newEvent creates a revenue event with the timestamp provided.
+	newEvent := func(timestamp time.Time, in,
+		out lnwire.MilliSatoshi) revenueEvent {
+
+		return revenueEvent{
+			incomingChannel: "a:1",
+			outgoingChannel: "a:2",
+			incomingAmt:     in,
+			outgoingAmt:     out,
+			timestamp:       timestamp,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		events   []revenueEvent
+		end      time.Time
+		expected []*Period
+	}{
+		{
+			name: "empty range",
+			events: []revenueEvent{
+				newEvent(start, 1000, 800),
+			},
+			end: start,
+		},
+		{
+			name: "events split into periods",
+			events: []revenueEvent{
+				newEvent(start.Add(time.Hour*-1), 1000, 800),
+				newEvent(start, 1000, 800),
+				newEvent(start.Add(time.Minute), 500, 400),
+				newEvent(start.Add(length), 300, 200),
+			},
+			end: start.Add(length * 2),
+			expected: []*Period{
+				{
+					Start:    start,
+					End:      start.Add(length),
+					Forwards: 2,
+					Fees:     300,
+					Volume:   1200,
+				},
+				{
+					Start:    start.Add(length),
+					End:      start.Add(length * 2),
+					Forwards: 1,
+					Fees:     100,
+					Volume:   200,
+				},
+			},
+		},
+		{
+			name: "partial final period includes end",
+			events: []revenueEvent{
+				newEvent(start.Add(length*3/2), 300, 200),
+				newEvent(start.Add(length*3), 300, 200),
+			},
+			end: start.Add(length * 3 / 2),
+			expected: []*Period{
+				{
+					Start: start,
+					End:   start.Add(length),
+				},
+				{
+					Start:    start.Add(length),
+					End:      start.Add(length * 3 / 2),
+					Forwards: 1,
+					Fees:     100,
+					Volume:   200,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			periods := getPeriods(test.events, start, test.end, length)
+			if !reflect.DeepEqual(periods, test.expected) {
+				t.Fatalf("expected: %v, got: %v",
+					test.expected, periods)
+			}
+		})
+	}
+}
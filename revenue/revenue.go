@@ -1,6 +1,11 @@
 package revenue
 
 import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/faraday/fiat"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
@@ -27,6 +32,45 @@ type Config struct {
 	// that the report is generated for.
 	ForwardingHistory func(offset, maxEvents uint32) (
 		[]*lnrpc.ForwardingEvent, uint32, error)
+
+	// DecayHalfLife is an optional half-life which is used to weight
+	// forwarding events by their age, so that recent forwards contribute
+	// more to the report than older ones. An event that is one half-life
+	// old contributes half of its volume and fees to the report. If this
+	// value is zero, all events are weighted equally.
+	DecayHalfLife time.Duration
+
+	// Now returns the current time, which is used to calculate the age of
+	// forwarding events when a decay half-life is set. If it is nil,
+	// time.Now is used.
+	Now func() time.Time
+
+	// FiatPrice is an optional function which returns the price of one
+	// bitcoin in a fiat currency at a point in time. If it is set, the
+	// fees earned by each forward are valued at the price when the
+	// forward happened, and fiat totals are included in the report.
+	FiatPrice func(timestamp time.Time) (float64, error)
+
+	// GetChanInfo is an optional function which looks up the outpoint of
+	// a channel in the graph. It is used to resolve the short channel ids
+	// of forwards that are not found in our open or closed channels, such
+	// as channels that lnd has forgotten. If it is not set, or a channel
+	// cannot be found, forwards over the channel are reported as
+	// unattributed.
+	GetChanInfo func(chanID lnwire.ShortChannelID) (string, error)
+
+	// PeriodLength is an optional length of time that the report's
+	// forwards are split into, from PeriodStart until PeriodEnd, so that
+	// the report includes totals for each period. Period totals are not
+	// weighted by a decay half-life. If it is zero, the report does not
+	// include periods.
+	PeriodLength time.Duration
+
+	// PeriodStart is the start of the first period in the report.
+	PeriodStart time.Time
+
+	// PeriodEnd is the end of the last period in the report.
+	PeriodEnd time.Time
 }
 
 // GetRevenueReport produces a revenue report over the period specified.
@@ -58,19 +102,124 @@ func GetRevenueReport(cfg *Config) (*Report, error) {
 			closedChannel.ChannelPoint
 	}
 
-	events, err := getEvents(channelIDs, cfg.ForwardingHistory)
+	resolver := &channelResolver{
+		channelIDs:  channelIDs,
+		unresolved:  make(map[lnwire.ShortChannelID]bool),
+		getChanInfo: cfg.GetChanInfo,
+	}
+
+	events, err := getEvents(resolver, cfg.ForwardingHistory)
 	if err != nil {
 		return nil, err
 	}
 
-	return getReport(events), nil
+	// Calculate stats on the individual forwards for each channel before
+	// we apply any decay, so that they reflect the actual size of each
+	// forward.
+	channelStats := getChannelStats(events)
+
+	// Split our events into periods before we apply any decay, so that
+	// each period reflects the forwards that our node actually made.
+	var periods []*Period
+	if cfg.PeriodLength != 0 {
+		periods = getPeriods(
+			events, cfg.PeriodStart, cfg.PeriodEnd,
+			cfg.PeriodLength,
+		)
+	}
+
+	// If we have a decay half-life, we weight each event by its age
+	// before we produce our report.
+	if cfg.DecayHalfLife != 0 {
+		now := time.Now
+		if cfg.Now != nil {
+			now = cfg.Now
+		}
+
+		events = decayEvents(events, now(), cfg.DecayHalfLife)
+	}
+
+	// If we need to value our fees in fiat, we look up the price at the
+	// time of each event.
+	if cfg.FiatPrice != nil {
+		events, err = priceEvents(events, cfg.FiatPrice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := getReport(events)
+	report.ChannelStats = channelStats
+	report.Periods = periods
+
+	return report, nil
+}
+
+// priceEvents returns a copy of the set of events provided with the fiat
+// price at the time of each event set.
+func priceEvents(events []revenueEvent,
+	fiatPrice func(time.Time) (float64, error)) ([]revenueEvent, error) {
+
+	priced := make([]revenueEvent, len(events))
+	for i, event := range events {
+		price, err := fiatPrice(event.timestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		priced[i] = event
+		priced[i].fiatPrice = price
+	}
+
+	return priced, nil
+}
+
+// channelResolver resolves the short channel ids of forwards to channel
+// outpoints.
+type channelResolver struct {
+	// channelIDs maps the short channel ids of our open and closed
+	// channels, and any channels that we have since looked up, to their
+	// outpoints.
+	channelIDs map[lnwire.ShortChannelID]string
+
+	// unresolved is the set of short channel ids that we have already
+	// failed to look up, so that we do not look them up repeatedly.
+	unresolved map[lnwire.ShortChannelID]bool
+
+	// getChanInfo is an optional function which looks up a channel's
+	// outpoint in the graph.
+	getChanInfo func(chanID lnwire.ShortChannelID) (string, error)
+}
+
+// resolve returns the outpoint for a short channel id, and a boolean which
+// indicates whether it could be found.
+func (c *channelResolver) resolve(chanID lnwire.ShortChannelID) (string,
+	bool) {
+
+	if outpoint, ok := c.channelIDs[chanID]; ok {
+		return outpoint, true
+	}
+
+	if c.getChanInfo == nil || c.unresolved[chanID] {
+		return "", false
+	}
+
+	outpoint, err := c.getChanInfo(chanID)
+	if err != nil {
+		log.Debugf("could not look up channel %v: %v", chanID, err)
+
+		c.unresolved[chanID] = true
+		return "", false
+	}
+
+	c.channelIDs[chanID] = outpoint
+	return outpoint, true
 }
 
 // getEvents gets calls the paginated query function until it has all the
-// forwarding events for the period provided. It takes a map of shortChannelIDs
-// to outpoints which is used to convert forwarding events short ids to
-// outpoint strings.
-func getEvents(channelIDs map[lnwire.ShortChannelID]string,
+// forwarding events for the period provided. It takes a resolver which is
+// used to convert forwarding events short ids to outpoint strings.
+func getEvents(resolver *channelResolver,
 	query eventsQuery) ([]revenueEvent, error) {
 
 	var (
@@ -84,10 +233,11 @@ func getEvents(channelIDs map[lnwire.ShortChannelID]string,
 			return nil, err
 		}
 
-		// Get the event's channel outpoints from out known list of maps
-		// and create a revenue event. Return an error if the short
-		// channel id's outpoint cannot be found, because we expect all
-		// known short channel ids to be provided.
+		// Get the event's channel outpoints from our resolver and
+		// create a revenue event. If either of the short channel ids
+		// cannot be resolved, we still include the event, recording
+		// the ids that were not found so that the unresolved side is
+		// reported as unattributed rather than silently dropped.
 		for _, fwd := range fwdEvents {
 			shortChanIn := lnwire.NewShortChanIDFromInt(
 				fwd.ChanIdIn,
@@ -96,35 +246,44 @@ func getEvents(channelIDs map[lnwire.ShortChannelID]string,
 				fwd.ChanIdOut,
 			)
 
-			incoming, ok := channelIDs[shortChanIn]
-			if !ok {
-				log.Errorf("cannot find channel incoming "+
-					"outpoint for forward: %v(%v msat) "+
-					"-> %v(%v msat)", shortChanIn,
-					fwd.AmtInMsat, shortChanOut,
-					fwd.AmtOutMsat)
-
-				continue
+			event := revenueEvent{
+				incomingAmt: lnwire.MilliSatoshi(fwd.AmtInMsat),
+				outgoingAmt: lnwire.MilliSatoshi(
+					fwd.AmtOutMsat,
+				),
+				timestamp: time.Unix(
+					int64(fwd.Timestamp), 0,
+				),
+			}
 
+			var ok bool
+			event.incomingChannel, ok = resolver.resolve(
+				shortChanIn,
+			)
+			if !ok {
+				event.unresolved = append(
+					event.unresolved, shortChanIn,
+				)
 			}
 
-			outgoing, ok := channelIDs[shortChanOut]
+			event.outgoingChannel, ok = resolver.resolve(
+				shortChanOut,
+			)
 			if !ok {
-				log.Errorf("cannot find channel outgoing "+
-					"outpoint for forward: %v(%v msat) "+
-					"-> %v(%v msat)", shortChanIn,
-					fwd.AmtInMsat, shortChanOut,
-					fwd.AmtOutMsat)
+				event.unresolved = append(
+					event.unresolved, shortChanOut,
+				)
+			}
 
-				continue
+			if len(event.unresolved) != 0 {
+				log.Warnf("cannot find channel outpoints "+
+					"%v for forward: %v(%v msat) -> "+
+					"%v(%v msat)", event.unresolved,
+					shortChanIn, fwd.AmtInMsat,
+					shortChanOut, fwd.AmtOutMsat)
 			}
 
-			events = append(events, revenueEvent{
-				incomingChannel: incoming,
-				outgoingChannel: outgoing,
-				incomingAmt:     lnwire.MilliSatoshi(fwd.AmtInMsat),
-				outgoingAmt:     lnwire.MilliSatoshi(fwd.AmtOutMsat),
-			})
+			events = append(events, event)
 		}
 
 		// If we have less than the maximum number of events, we do not
@@ -147,6 +306,98 @@ type Report struct {
 	// ChannelPairs contains a map of the string representation of a channel's
 	// outpoint to a map of pair channels with which it has generated revenue.
 	ChannelPairs map[string]map[string]Revenue
+
+	// TotalFees is the total amount in msat of fees earned by all of the
+	// forwards in the report, counting each forward once.
+	TotalFees lnwire.MilliSatoshi
+
+	// TotalFeesFiat is the total fiat value of fees earned by all of the
+	// forwards in the report. It is only set if fees were valued in fiat.
+	TotalFeesFiat float64
+
+	// ChannelStats maps the string representation of a channel's outpoint
+	// to statistics about the individual forwards that it was part of.
+	// These statistics are not weighted by a decay half-life.
+	ChannelStats map[string]*ChannelStats
+
+	// Unattributed contains the forwards in the report that could not be
+	// attributed to channels. These forwards are included in the report's
+	// totals, but not in ChannelPairs.
+	Unattributed Unattributed
+
+	// Periods contains the totals for each period that the report was
+	// split into, in chronological order. It is only set if a period
+	// length was configured.
+	Periods []*Period
+}
+
+// Unattributed describes the forwards that could not be fully attributed to
+// channels because the short channel id of their incoming or outgoing
+// channel could not be resolved to an outpoint. This happens for forwards
+// over channels that lnd no longer knows about. Forwards over channels that
+// are identified by an alias rather than their confirmed short channel id
+// are also unattributed, because we do not map aliases to channels. If only
+// one side of a forward is unresolved, only that side is unattributed and the
+// other side is attributed to its channel as usual.
+type Unattributed struct {
+	// Forwards is the number of forwards with at least one side that
+	// could not be attributed.
+	Forwards int
+
+	// AmountIncoming is the amount in msat that arrived at our node over
+	// channels that could not be resolved.
+	AmountIncoming lnwire.MilliSatoshi
+
+	// AmountOutgoing is the amount in msat that left our node over
+	// channels that could not be resolved.
+	AmountOutgoing lnwire.MilliSatoshi
+
+	// Fees is the amount in msat of fees earned by forwards with at least
+	// one side that could not be attributed.
+	Fees lnwire.MilliSatoshi
+
+	// FeesFiat is the fiat value of the fees earned by unattributed
+	// forwards. It is only set if fees were valued in fiat.
+	FeesFiat float64
+
+	// ChannelIDs is the sorted set of short channel ids that could not be
+	// resolved.
+	ChannelIDs []lnwire.ShortChannelID
+}
+
+// add adds an unattributed forward to our totals.
+func (u *Unattributed) add(event revenueEvent, fees lnwire.MilliSatoshi,
+	fiatFees float64) {
+
+	u.Forwards++
+	u.Fees += fees
+	u.FeesFiat += fiatFees
+
+	if event.incomingChannel == "" {
+		u.AmountIncoming += event.incomingAmt
+	}
+
+	if event.outgoingChannel == "" {
+		u.AmountOutgoing += event.outgoingAmt
+	}
+
+	for _, chanID := range event.unresolved {
+		if !u.hasChannel(chanID) {
+			u.ChannelIDs = append(u.ChannelIDs, chanID)
+		}
+	}
+}
+
+// hasChannel returns a boolean indicating whether a short channel id is in
+// our set of unresolved channels.
+func (u *Unattributed) hasChannel(chanID lnwire.ShortChannelID) bool {
+	for _, id := range u.ChannelIDs {
+		if id == chanID {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Revenue describes the volume of forwards that a channel has been a part of
@@ -170,6 +421,16 @@ type Revenue struct {
 	// FeesIncoming is the amount in msat of fees that we attribute to the
 	// channel for its role as the incoming channel in forwards.
 	FeesIncoming lnwire.MilliSatoshi
+
+	// FeesOutgoingFiat is the fiat value of the outgoing fees, with each
+	// forward's fees valued at the price when it happened. It is only set
+	// if fees were valued in fiat.
+	FeesOutgoingFiat float64
+
+	// FeesIncomingFiat is the fiat value of the incoming fees, with each
+	// forward's fees valued at the price when it happened. It is only set
+	// if fees were valued in fiat.
+	FeesIncomingFiat float64
 }
 
 // getRevenue gets a revenue record for a given target channel and its
@@ -205,13 +466,16 @@ func (r Report) setRevenue(targetChan, pairChan string,
 // the outgoing channel, adds the incoming volume and fees and updates the
 // Revenue Report.
 func (r Report) addIncoming(incomingChannel,
-	outgoingChannel string, amount, fees lnwire.MilliSatoshi) {
+	outgoingChannel string, amount, fees lnwire.MilliSatoshi,
+	fiatFees float64) {
+
 	revenue := r.getRevenue(incomingChannel, outgoingChannel)
 
 	// Add the fees and revenue that have been earned to the existing revenue
 	// record.
 	revenue.AmountIncoming += amount
 	revenue.FeesIncoming += fees
+	revenue.FeesIncomingFiat += fiatFees
 
 	// Set the new revenue record in the revenue report.
 	r.setRevenue(incomingChannel, outgoingChannel, revenue)
@@ -221,13 +485,16 @@ func (r Report) addIncoming(incomingChannel,
 // the incoming channel, adds the outgoing volume and fees and updates the
 // Revenue Report.
 func (r Report) addOutgoing(outgoingChannel,
-	incomingChannel string, amount, fees lnwire.MilliSatoshi) {
+	incomingChannel string, amount, fees lnwire.MilliSatoshi,
+	fiatFees float64) {
+
 	revenue := r.getRevenue(outgoingChannel, incomingChannel)
 
 	// Add the fees and revenue that have been earned to the existing revenue
 	// record.
 	revenue.AmountOutgoing += amount
 	revenue.FeesOutgoing += fees
+	revenue.FeesOutgoingFiat += fiatFees
 
 	// Set the new revenue record in the revenue report.
 	r.setRevenue(outgoingChannel, incomingChannel, revenue)
@@ -240,6 +507,47 @@ type revenueEvent struct {
 	outgoingChannel string
 	incomingAmt     lnwire.MilliSatoshi
 	outgoingAmt     lnwire.MilliSatoshi
+	timestamp       time.Time
+
+	// fiatPrice is the price of one bitcoin in fiat at the time of the
+	// event, which is zero if the event has not been valued in fiat.
+	fiatPrice float64
+
+	// unresolved contains the short channel ids of the event's channels
+	// that could not be resolved to outpoints. If it is non-empty, the
+	// event is reported as unattributed.
+	unresolved []lnwire.ShortChannelID
+}
+
+// decayEvents returns a copy of the set of events provided with their amounts
+// weighted by age. Each event is weighted by 0.5^(age/halfLife), so that an
+// event that is one half-life old is worth half of its original amount.
+// Events with timestamps after the current time are not weighted.
+func decayEvents(events []revenueEvent, now time.Time,
+	halfLife time.Duration) []revenueEvent {
+
+	decayed := make([]revenueEvent, len(events))
+	for i, event := range events {
+		decayed[i] = event
+
+		age := now.Sub(event.timestamp)
+		if age <= 0 {
+			continue
+		}
+
+		weight := math.Pow(0.5, float64(age)/float64(halfLife))
+
+		// We scale both amounts by the same weight, so the fee for
+		// the event is scaled by the same weight.
+		decayed[i].incomingAmt = lnwire.MilliSatoshi(
+			float64(event.incomingAmt) * weight,
+		)
+		decayed[i].outgoingAmt = lnwire.MilliSatoshi(
+			float64(event.outgoingAmt) * weight,
+		)
+	}
+
+	return decayed
 }
 
 // getReport creates a revenue report for the set of events provided. It
@@ -257,14 +565,53 @@ func getReport(events []revenueEvent) *Report {
 		// Calculate fees earned by the incoming channel in this event.
 		fees := lnwire.MilliSatoshi(float64(fee))
 
+		// Value the fees at the fiat price at the time of the event.
+		// This will be zero if the event has not been priced.
+		fiatFees := fiat.MsatToFiat(fees, event.fiatPrice)
+
+		report.TotalFees += fees
+		report.TotalFeesFiat += fiatFees
+
+		// If we could not resolve one of the event's channels, we add
+		// the unresolved side to our unattributed forwards. If the
+		// other side was resolved, we still attribute it to its
+		// channel, paired with the short channel id that we could not
+		// resolve.
+		if len(event.unresolved) != 0 {
+			report.Unattributed.add(event, fees, fiatFees)
+			pair := event.unresolved[0].String()
+
+			switch {
+			case event.incomingChannel != "":
+				report.addIncoming(
+					event.incomingChannel, pair,
+					event.incomingAmt, fees, fiatFees,
+				)
+
+			case event.outgoingChannel != "":
+				report.addOutgoing(
+					event.outgoingChannel, pair,
+					event.outgoingAmt, fees, fiatFees,
+				)
+			}
+
+			continue
+		}
+
 		// Update the revenue record for the incoming channel.
 		report.addIncoming(event.incomingChannel, event.outgoingChannel,
-			event.incomingAmt, fees)
+			event.incomingAmt, fees, fiatFees)
 
 		// Update the revenue record for the downstream channel.
 		report.addOutgoing(event.outgoingChannel, event.incomingChannel,
-			event.outgoingAmt, fees)
+			event.outgoingAmt, fees, fiatFees)
 	}
 
+	// Sort our unresolved channels so that our report is deterministic.
+	channelIDs := report.Unattributed.ChannelIDs
+	sort.Slice(channelIDs, func(i, j int) bool {
+		return channelIDs[i].ToUint64() < channelIDs[j].ToUint64()
+	})
+
 	return report
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -11,6 +12,8 @@ import (
 
 // TestGetRevenueReport tests querying for a revenue report.
 func TestGetRevenueReport(t *testing.T) {
+	chanID := lnwire.NewShortChanIDFromInt
+
 	var (
 		// testErr is an error returned by the mock to simulate rpc
 		// failures.
@@ -27,6 +30,49 @@ func TestGetRevenueReport(t *testing.T) {
 		}
 	)
 
+	// chanStats contains the forward stats for a single forward of 150
+	// msat from chan1 to chan2, which paid 50 msat in fees.
+	chanStats := map[string]*ChannelStats{
+		chan1.ChannelPoint: {
+			Incoming: ForwardStats{
+				Count:      1,
+				MinSize:    150,
+				MedianSize: 150,
+				P90Size:    150,
+				MaxSize:    150,
+				FeeRatePPM: 500000,
+			},
+		},
+		chan2.ChannelPoint: {
+			Outgoing: ForwardStats{
+				Count:      1,
+				MinSize:    100,
+				MedianSize: 100,
+				P90Size:    100,
+				MaxSize:    100,
+				FeeRatePPM: 500000,
+			},
+		},
+	}
+
+	// partialStats contains the forward stats for chan1 and chan2 when
+	// chan1 also forwarded 11 msat to a channel that could not be
+	// resolved, which paid 1 msat in fees. The unresolved side of the
+	// forward is not included.
+	partialStats := map[string]*ChannelStats{
+		chan1.ChannelPoint: {
+			Incoming: ForwardStats{
+				Count:      2,
+				MinSize:    11,
+				MedianSize: 81,
+				P90Size:    136,
+				MaxSize:    150,
+				FeeRatePPM: float64(51) * 1e6 / 110,
+			},
+		},
+		chan2.ChannelPoint: chanStats[chan2.ChannelPoint],
+	}
+
 	tests := []struct {
 		name           string
 		listChanErr    error
@@ -35,6 +81,13 @@ func TestGetRevenueReport(t *testing.T) {
 		openChannels   []*lnrpc.Channel
 		closedChannels []*lnrpc.ChannelCloseSummary
 		fwdHistory     []*lnrpc.ForwardingEvent
+
+		// getChanInfo maps short channel ids to the outpoints that
+		// our mocked graph lookup finds. If it is nil, graph lookups
+		// are not used.
+		getChanInfo map[uint64]string
+
+		fiatPriceErr   error
 		expectedReport *Report
 		expectErr      error
 	}{
@@ -57,12 +110,76 @@ func TestGetRevenueReport(t *testing.T) {
 			name: "cannot find channel",
 			fwdHistory: []*lnrpc.ForwardingEvent{
 				{
-					ChanIdIn: 123,
+					ChanIdIn:   123,
+					ChanIdOut:  321,
+					AmtOutMsat: 100,
+					AmtInMsat:  150,
 				},
 			},
 			expectErr: nil,
 			expectedReport: &Report{
 				ChannelPairs: make(map[string]map[string]Revenue),
+				TotalFees:    50,
+				ChannelStats: make(map[string]*ChannelStats),
+				Unattributed: Unattributed{
+					Forwards:       1,
+					AmountIncoming: 150,
+					AmountOutgoing: 100,
+					Fees:           50,
+					ChannelIDs: []lnwire.ShortChannelID{
+						chanID(123), chanID(321),
+					},
+				},
+			},
+		},
+		{
+			name:         "channel found in graph",
+			openChannels: []*lnrpc.Channel{chan1},
+			getChanInfo: map[uint64]string{
+				chan2.ChanId: chan2.ChannelPoint,
+			},
+			fwdHistory: []*lnrpc.ForwardingEvent{
+				{
+					ChanIdIn:   chan1.ChanId,
+					ChanIdOut:  chan2.ChanId,
+					AmtOutMsat: 100,
+					AmtInMsat:  150,
+				},
+				{
+					ChanIdIn:   chan1.ChanId,
+					ChanIdOut:  999,
+					AmtOutMsat: 10,
+					AmtInMsat:  11,
+				},
+			},
+			expectedReport: &Report{
+				ChannelPairs: map[string]map[string]Revenue{
+					chan1.ChannelPoint: {
+						chan2.ChannelPoint: Revenue{
+							AmountIncoming: 150,
+							FeesIncoming:   50,
+						},
+						chanID(999).String(): Revenue{
+							AmountIncoming: 11,
+							FeesIncoming:   1,
+						},
+					},
+					chan2.ChannelPoint: {
+						chan1.ChannelPoint: Revenue{
+							AmountOutgoing: 100,
+							FeesOutgoing:   50,
+						}},
+				},
+				TotalFees:    51,
+				ChannelStats: partialStats,
+				Unattributed: Unattributed{
+					Forwards:       1,
+					AmountOutgoing: 10,
+					Fees:           1,
+					ChannelIDs: []lnwire.ShortChannelID{
+						chanID(999),
+					},
+				},
 			},
 		},
 		{
@@ -96,9 +213,26 @@ func TestGetRevenueReport(t *testing.T) {
 							FeesIncoming:   0,
 							FeesOutgoing:   50,
 						}},
-				}},
+				},
+				TotalFees:    50,
+				ChannelStats: chanStats,
+			},
 			expectErr: nil,
 		},
+		{
+			name:         "fiat price fails",
+			openChannels: []*lnrpc.Channel{chan1, chan2},
+			fwdHistory: []*lnrpc.ForwardingEvent{
+				{
+					ChanIdIn:   chan1.ChanId,
+					ChanIdOut:  chan2.ChanId,
+					AmtOutMsat: 100,
+					AmtInMsat:  150,
+				},
+			},
+			fiatPriceErr: testErr,
+			expectErr:    testErr,
+		},
 	}
 
 	for _, test := range tests {
@@ -121,6 +255,27 @@ func TestGetRevenueReport(t *testing.T) {
 				},
 			}
 
+			if test.getChanInfo != nil {
+				graph := test.getChanInfo
+				cfg.GetChanInfo = func(
+					id lnwire.ShortChannelID) (string,
+					error) {
+
+					outpoint, ok := graph[id.ToUint64()]
+					if !ok {
+						return "", testErr
+					}
+
+					return outpoint, nil
+				}
+			}
+
+			if test.fiatPriceErr != nil {
+				cfg.FiatPrice = func(time.Time) (float64, error) {
+					return 0, test.fiatPriceErr
+				}
+			}
+
 			report, err := GetRevenueReport(cfg)
 			if test.expectErr != err {
 				t.Fatalf("expected: %v, got: %v",
@@ -148,6 +303,10 @@ func TestGetEvents(t *testing.T) {
 		{}: "a:1",
 	}
 
+	// channelIDNotFound is an empty map, used for tests where we want
+	// channels to not be found.
+	channelIDNotFound := make(map[lnwire.ShortChannelID]string)
+
 	tests := []struct {
 		name string
 
@@ -162,9 +321,17 @@ func TestGetEvents(t *testing.T) {
 
 		channelMap map[lnwire.ShortChannelID]string
 
+		// getChanInfo is an optional graph lookup for channels that
+		// are not in our channel map.
+		getChanInfo func(lnwire.ShortChannelID) (string, error)
+
 		// expectedEvents is the number of events we expect to be
 		// accumulated.
 		expectedEvents int
+
+		// expectedUnresolved is the number of events we expect to
+		// have channels that could not be resolved.
+		expectedUnresolved int
 	}{
 		{
 			name:           "no events",
@@ -185,10 +352,22 @@ func TestGetEvents(t *testing.T) {
 			expectedEvents: int(maxQueryEvents) + int(maxQueryEvents)/2,
 		},
 		{
-			name:           "can't lookup channel skips event",
+			name:               "can't lookup channel",
+			queryResponses:     []uint32{maxQueryEvents / 2},
+			channelMap:         channelIDNotFound,
+			expectedEvents:     int(maxQueryEvents / 2),
+			expectedUnresolved: int(maxQueryEvents / 2),
+		},
+		{
+			name:           "channel found in graph",
 			queryResponses: []uint32{maxQueryEvents / 2},
-			channelMap:     make(map[lnwire.ShortChannelID]string),
-			expectedEvents: 0,
+			channelMap:     channelIDNotFound,
+			getChanInfo: func(lnwire.ShortChannelID) (string,
+				error) {
+
+				return "a:1", nil
+			},
+			expectedEvents: int(maxQueryEvents / 2),
 		},
 	}
 
@@ -221,7 +400,22 @@ func TestGetEvents(t *testing.T) {
 				return events, offset, nil
 			}
 
-			events, err := getEvents(test.channelMap, query)
+			// Copy our channel map, because our resolver adds
+			// the channels that it looks up to it.
+			channelIDs := make(map[lnwire.ShortChannelID]string)
+			for id, outpoint := range test.channelMap {
+				channelIDs[id] = outpoint
+			}
+
+			resolver := &channelResolver{
+				channelIDs: channelIDs,
+				unresolved: make(
+					map[lnwire.ShortChannelID]bool,
+				),
+				getChanInfo: test.getChanInfo,
+			}
+
+			events, err := getEvents(resolver, query)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -232,6 +426,19 @@ func TestGetEvents(t *testing.T) {
 				t.Fatalf("Expected %v events, got: %v",
 					test.expectedEvents, len(events))
 			}
+
+			var unresolved int
+			for _, event := range events {
+				if len(event.unresolved) != 0 {
+					unresolved++
+				}
+			}
+
+			if unresolved != test.expectedUnresolved {
+				t.Fatalf("Expected %v unresolved events, "+
+					"got: %v", test.expectedUnresolved,
+					unresolved)
+			}
 		})
 	}
 }
@@ -315,6 +522,46 @@ func TestGetReport(t *testing.T) {
 						},
 					},
 				},
+				TotalFees: 710,
+			},
+		},
+		{
+			name: "fiat valued forwards",
+			events: []revenueEvent{
+				{
+					incomingChannel: channel1,
+					outgoingChannel: channel2,
+					incomingAmt:     3e8,
+					outgoingAmt:     2e8,
+					fiatPrice:       10000,
+				},
+				{
+					incomingChannel: channel1,
+					outgoingChannel: channel2,
+					incomingAmt:     3e8,
+					outgoingAmt:     2e8,
+					fiatPrice:       20000,
+				},
+			},
+			expectedReport: &Report{
+				ChannelPairs: map[string]map[string]Revenue{
+					channel1: {
+						channel2: {
+							AmountIncoming:   6e8,
+							FeesIncoming:     2e8,
+							FeesIncomingFiat: 30,
+						},
+					},
+					channel2: {
+						channel1: {
+							AmountOutgoing:   4e8,
+							FeesOutgoing:     2e8,
+							FeesOutgoingFiat: 30,
+						},
+					},
+				},
+				TotalFees:     2e8,
+				TotalFeesFiat: 30,
 			},
 		},
 	}
@@ -332,3 +579,79 @@ func TestGetReport(t *testing.T) {
 		})
 	}
 }
+
+// TestDecayEvents tests weighting of revenue events by their age.
+func TestDecayEvents(t *testing.T) {
+	var (
+		now      = time.Unix(100000, 0)
+		halfLife = time.Hour
+	)
+
+	// newEvent creates a revenue event with the timestamp provided.
+	newEvent := func(timestamp time.Time, in,
+		out lnwire.MilliSatoshi) revenueEvent {
+
+		return revenueEvent{
+			incomingChannel: "a:1",
+			outgoingChannel: "a:2",
+			incomingAmt:     in,
+			outgoingAmt:     out,
+			timestamp:       timestamp,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		events         []revenueEvent
+		expectedEvents []revenueEvent
+	}{
+		{
+			name:           "no events",
+			events:         []revenueEvent{},
+			expectedEvents: []revenueEvent{},
+		},
+		{
+			name: "current event not decayed",
+			events: []revenueEvent{
+				newEvent(now, 1000, 800),
+			},
+			expectedEvents: []revenueEvent{
+				newEvent(now, 1000, 800),
+			},
+		},
+		{
+			name: "future event not decayed",
+			events: []revenueEvent{
+				newEvent(now.Add(time.Hour), 1000, 800),
+			},
+			expectedEvents: []revenueEvent{
+				newEvent(now.Add(time.Hour), 1000, 800),
+			},
+		},
+		{
+			name: "one and two half lives",
+			events: []revenueEvent{
+				newEvent(now.Add(halfLife*-1), 1000, 800),
+				newEvent(now.Add(halfLife*-2), 1000, 800),
+			},
+			expectedEvents: []revenueEvent{
+				newEvent(now.Add(halfLife*-1), 500, 400),
+				newEvent(now.Add(halfLife*-2), 250, 200),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			events := decayEvents(test.events, now, halfLife)
+			if !reflect.DeepEqual(events, test.expectedEvents) {
+				t.Fatalf("expected: %v, got: %v",
+					test.expectedEvents, events)
+			}
+		})
+	}
+}
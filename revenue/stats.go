@@ -0,0 +1,161 @@
+package revenue
+
+import (
+	"math"
+	"sort"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// ChannelStats contains statistics about the individual forwards that a
+// channel was part of, split by direction.
+type ChannelStats struct {
+	// Incoming describes the forwards that arrived at our node via the
+	// channel.
+	Incoming ForwardStats
+
+	// Outgoing describes the forwards that left our node via the channel.
+	Outgoing ForwardStats
+}
+
+// ForwardStats describes the distribution of the sizes of a set of forwards
+// and the effective fee rate that they paid. This allows us to distinguish
+// between channels that earn fees from a few large forwards and those that
+// earn from many small ones. Forward sizes are the amount that moved over
+// the channel, so incoming sizes include the fee paid to our node.
+type ForwardStats struct {
+	// Count is the number of forwards.
+	Count int
+
+	// MinSize is the amount of the smallest forward.
+	MinSize lnwire.MilliSatoshi
+
+	// MedianSize is the median forward amount.
+	MedianSize lnwire.MilliSatoshi
+
+	// P90Size is the 90th percentile forward amount.
+	P90Size lnwire.MilliSatoshi
+
+	// MaxSize is the amount of the largest forward.
+	MaxSize lnwire.MilliSatoshi
+
+	// FeeRatePPM is the effective fee rate of the forwards, expressed as
+	// the fees earned in parts per million of the amount forwarded onwards
+	// by our node. This is comparable with the proportional fee rates set
+	// in channel policies.
+	FeeRatePPM float64
+}
+
+// forwardSamples accumulates the forwards in one direction of a channel.
+type forwardSamples struct {
+	sizes     []lnwire.MilliSatoshi
+	forwarded lnwire.MilliSatoshi
+	fees      lnwire.MilliSatoshi
+}
+
+// add adds a forward to our set of samples.
+func (f *forwardSamples) add(size, forwarded, fees lnwire.MilliSatoshi) {
+	f.sizes = append(f.sizes, size)
+	f.forwarded += forwarded
+	f.fees += fees
+}
+
+// stats returns the forward stats for our set of samples.
+func (f *forwardSamples) stats() ForwardStats {
+	if len(f.sizes) == 0 {
+		return ForwardStats{}
+	}
+
+	sort.Slice(f.sizes, func(i, j int) bool {
+		return f.sizes[i] < f.sizes[j]
+	})
+
+	stats := ForwardStats{
+		Count:      len(f.sizes),
+		MinSize:    f.sizes[0],
+		MedianSize: percentile(f.sizes, 0.5),
+		P90Size:    percentile(f.sizes, 0.9),
+		MaxSize:    f.sizes[len(f.sizes)-1],
+	}
+
+	if f.forwarded != 0 {
+		stats.FeeRatePPM = float64(f.fees) * 1e6 / float64(f.forwarded)
+	}
+
+	return stats
+}
+
+// percentile returns the value at the percentile provided, expressed in
+// [0;1], of a sorted, non-empty set of values. If the percentile falls
+// between two values, it is interpolated between them, so that the 50th
+// percentile of an even number of values is the mean of the middle two.
+func percentile(sorted []lnwire.MilliSatoshi,
+	p float64) lnwire.MilliSatoshi {
+
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	weight := rank - float64(lower)
+	value := float64(sorted[lower])*(1-weight) +
+		float64(sorted[upper])*weight
+
+	return lnwire.MilliSatoshi(math.Round(value))
+}
+
+// getChannelStats calculates forward stats for each channel that was part of
+// the set of events provided. If one side of an event could not be resolved
+// to a channel, only the side that was resolved is included.
+func getChannelStats(events []revenueEvent) map[string]*ChannelStats {
+	incoming := make(map[string]*forwardSamples)
+	outgoing := make(map[string]*forwardSamples)
+
+	getSamples := func(samples map[string]*forwardSamples,
+		channel string) *forwardSamples {
+
+		s, ok := samples[channel]
+		if !ok {
+			s = &forwardSamples{}
+			samples[channel] = s
+		}
+
+		return s
+	}
+
+	for _, event := range events {
+		fees := event.incomingAmt - event.outgoingAmt
+
+		if event.incomingChannel != "" {
+			getSamples(incoming, event.incomingChannel).add(
+				event.incomingAmt, event.outgoingAmt, fees,
+			)
+		}
+
+		if event.outgoingChannel != "" {
+			getSamples(outgoing, event.outgoingChannel).add(
+				event.outgoingAmt, event.outgoingAmt, fees,
+			)
+		}
+	}
+
+	stats := make(map[string]*ChannelStats)
+	getStats := func(channel string) *ChannelStats {
+		s, ok := stats[channel]
+		if !ok {
+			s = &ChannelStats{}
+			stats[channel] = s
+		}
+
+		return s
+	}
+
+	for channel, samples := range incoming {
+		getStats(channel).Incoming = samples.stats()
+	}
+
+	for channel, samples := range outgoing {
+		getStats(channel).Outgoing = samples.stats()
+	}
+
+	return stats
+}
@@ -0,0 +1,140 @@
+package revenue
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestPercentile tests interpolation of percentiles from sorted values.
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []lnwire.MilliSatoshi
+		percentile float64
+		expected   lnwire.MilliSatoshi
+	}{
+		{
+			name:       "single value",
+			values:     []lnwire.MilliSatoshi{10},
+			percentile: 0.9,
+			expected:   10,
+		},
+		{
+			name:       "odd median",
+			values:     []lnwire.MilliSatoshi{1, 5, 100},
+			percentile: 0.5,
+			expected:   5,
+		},
+		{
+			name:       "even median",
+			values:     []lnwire.MilliSatoshi{1, 5, 7, 100},
+			percentile: 0.5,
+			expected:   6,
+		},
+		{
+			name: "interpolated p90",
+			values: []lnwire.MilliSatoshi{
+				0, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100,
+				200,
+			},
+			percentile: 0.9,
+			expected:   99,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			value := percentile(test.values, test.percentile)
+			if value != test.expected {
+				t.Fatalf("expected: %v, got: %v",
+					test.expected, value)
+			}
+		})
+	}
+}
+
+// TestGetChannelStats tests calculation of forward stats for each channel.
+func TestGetChannelStats(t *testing.T) {
+	var (
+		channel1 = "a:1"
+		channel2 = "a:2"
+		channel3 = "a:3"
+	)
+
+	events := []revenueEvent{
+		{
+			incomingChannel: channel1,
+			outgoingChannel: channel2,
+			incomingAmt:     1010,
+			outgoingAmt:     1000,
+		},
+		{
+			incomingChannel: channel1,
+			outgoingChannel: channel3,
+			incomingAmt:     202,
+			outgoingAmt:     200,
+		},
+		{
+			incomingChannel: channel1,
+			outgoingChannel: channel2,
+			incomingAmt:     5030,
+			outgoingAmt:     5000,
+		},
+		{
+			// A partially resolved event should only be included
+			// in the stats of the channel that was resolved.
+			incomingChannel: channel1,
+			incomingAmt:     3040,
+			outgoingAmt:     3000,
+			unresolved: []lnwire.ShortChannelID{
+				lnwire.NewShortChanIDFromInt(1),
+			},
+		},
+	}
+
+	expected := map[string]*ChannelStats{
+		channel1: {
+			Incoming: ForwardStats{
+				Count:      4,
+				MinSize:    202,
+				MedianSize: 2025,
+				P90Size:    4433,
+				MaxSize:    5030,
+				FeeRatePPM: 82 * 1e6 / 9200,
+			},
+		},
+		channel2: {
+			Outgoing: ForwardStats{
+				Count:      2,
+				MinSize:    1000,
+				MedianSize: 3000,
+				P90Size:    4600,
+				MaxSize:    5000,
+				FeeRatePPM: 40 * 1e6 / 6000,
+			},
+		},
+		channel3: {
+			Outgoing: ForwardStats{
+				Count:      1,
+				MinSize:    200,
+				MedianSize: 200,
+				P90Size:    200,
+				MaxSize:    200,
+				FeeRatePPM: 10000,
+			},
+		},
+	}
+
+	stats := getChannelStats(events)
+	if !reflect.DeepEqual(stats, expected) {
+		for channel, s := range stats {
+			t.Logf("%v: %+v", channel, s)
+		}
+
+		t.Fatalf("unexpected channel stats")
+	}
+}
@@ -0,0 +1,212 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// tokenKind is the kind of a token in a rule expression.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenDuration
+	tokenLParen
+	tokenRParen
+	tokenNot
+	tokenMinus
+	tokenAnd
+	tokenOr
+	tokenLess
+	tokenLessEqual
+	tokenGreater
+	tokenGreaterEqual
+	tokenEqual
+	tokenNotEqual
+)
+
+// token is a single token in a rule expression.
+type token struct {
+	kind tokenKind
+
+	// text is the text of the token as it appears in the expression.
+	text string
+
+	// pos is the 0-indexed position of the token in the expression.
+	pos int
+
+	// value is the value of number and duration tokens. Durations are
+	// expressed in nanoseconds.
+	value float64
+}
+
+// operators maps the text of our operators to their token kinds. Two
+// character operators are matched before single character ones.
+var operators = map[string]tokenKind{
+	"&&": tokenAnd,
+	"||": tokenOr,
+	"<=": tokenLessEqual,
+	">=": tokenGreaterEqual,
+	"==": tokenEqual,
+	"!=": tokenNotEqual,
+	"<":  tokenLess,
+	">":  tokenGreater,
+	"!":  tokenNot,
+	"-":  tokenMinus,
+	"(":  tokenLParen,
+	")":  tokenRParen,
+}
+
+// durationUnits maps the units that may follow a number to express a
+// duration to their length.
+var durationUnits = map[byte]time.Duration{
+	's': time.Second,
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': time.Hour * 24,
+	'w': time.Hour * 24 * 7,
+}
+
+// lex splits the parser's expression into tokens.
+func (p *parser) lex() error {
+	expr := p.expression
+
+	for i := 0; i < len(expr); {
+		c := expr[i]
+
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+
+		case isIdentStart(c):
+			start := i
+			for i < len(expr) && isIdentChar(expr[i]) {
+				i++
+			}
+
+			p.tokens = append(p.tokens, token{
+				kind: tokenIdent,
+				text: expr[start:i],
+				pos:  start,
+			})
+
+		case isDigit(c) || c == '.':
+			tok, err := p.lexNumber(i)
+			if err != nil {
+				return err
+			}
+
+			p.tokens = append(p.tokens, tok)
+			i += len(tok.text)
+
+		default:
+			kind, text, ok := matchOperator(expr[i:])
+			if !ok {
+				return p.errorf(i, "unexpected character %q",
+					c)
+			}
+
+			p.tokens = append(p.tokens, token{
+				kind: kind,
+				text: text,
+				pos:  i,
+			})
+			i += len(text)
+		}
+	}
+
+	p.tokens = append(p.tokens, token{
+		kind: tokenEOF,
+		pos:  len(expr),
+	})
+
+	return nil
+}
+
+// lexNumber reads a number, optionally followed by a duration unit, from
+// the position provided.
+func (p *parser) lexNumber(start int) (token, error) {
+	expr := p.expression
+
+	i := start
+	for i < len(expr) && (isDigit(expr[i]) || expr[i] == '.') {
+		i++
+	}
+
+	number, err := strconv.ParseFloat(expr[start:i], 64)
+	if err != nil {
+		return token{}, p.errorf(start, "invalid number %q",
+			expr[start:i])
+	}
+
+	if i == len(expr) || !isIdentChar(expr[i]) {
+		return token{
+			kind:  tokenNumber,
+			text:  expr[start:i],
+			pos:   start,
+			value: number,
+		}, nil
+	}
+
+	// If the number is followed by a letter, it must be a single
+	// duration unit.
+	unit, ok := durationUnits[expr[i]]
+	if !ok || (i+1 < len(expr) && isIdentChar(expr[i+1])) {
+		end := i
+		for end < len(expr) && isIdentChar(expr[end]) {
+			end++
+		}
+
+		return token{}, p.errorf(i, "unknown duration unit %q, "+
+			"expected one of s, m, h, d or w", expr[i:end])
+	}
+
+	return token{
+		kind:  tokenDuration,
+		text:  expr[start : i+1],
+		pos:   start,
+		value: number * float64(unit),
+	}, nil
+}
+
+// matchOperator returns the operator at the start of the string provided,
+// if there is one.
+func matchOperator(s string) (tokenKind, string, bool) {
+	if len(s) >= 2 {
+		if kind, ok := operators[s[:2]]; ok {
+			return kind, s[:2], true
+		}
+	}
+
+	kind, ok := operators[s[:1]]
+	return kind, s[:1], ok
+}
+
+// errorf returns an error at the position provided.
+func (p *parser) errorf(pos int, format string, args ...interface{}) error {
+	return &Error{
+		Expression: p.expression,
+		Column:     pos + 1,
+		Msg:        fmt.Sprintf(format, args...),
+	}
+}
+
+// isDigit returns a boolean indicating whether a character is a digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentStart returns a boolean indicating whether a character may start a
+// variable name.
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isIdentChar returns a boolean indicating whether a character may be part of
+// a variable name.
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
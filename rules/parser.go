@@ -0,0 +1,463 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxExpressionLength is the maximum length of a rule expression, in
+	// bytes.
+	maxExpressionLength = 1024
+
+	// maxDepth is the maximum number of brackets, negations and minus
+	// signs that may be nested in a rule expression. Our parser is
+	// recursive, so we limit nesting so that deeply nested expressions
+	// cannot exhaust the stack.
+	maxDepth = 32
+)
+
+// parser parses a rule expression into a tree of type checked nodes, using
+// the following grammar, from lowest to highest precedence:
+//
+//	or         = and { "||" and }
+//	and        = not { "&&" not }
+//	not        = "!" not | comparison
+//	comparison = operand [ ( "<" | "<=" | ">" | ">=" | "==" | "!=" ) operand ]
+//	operand    = "-" operand | number | duration | "true" | "false" |
+//	             variable | "(" or ")"
+type parser struct {
+	expression string
+	variables  map[string]Type
+
+	tokens []token
+	next   int
+
+	// depth is the number of nested brackets, negations and minus signs
+	// that we are currently parsing within.
+	depth int
+}
+
+// parse parses the parser's tokens into an expression, failing if any tokens
+// are left over.
+func (p *parser) parse() (node, error) {
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok.pos, "unexpected %q", tok.text)
+	}
+
+	return expr, nil
+}
+
+// peek returns the next token without consuming it.
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+// advance consumes and returns the next token.
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+
+	return tok
+}
+
+// enter increases the nesting depth of our parser for the token provided,
+// failing if the maximum depth is exceeded. Callers must call exit once they
+// have parsed the nested expression.
+func (p *parser) enter(tok token) error {
+	p.depth++
+	if p.depth > maxDepth {
+		return p.errorf(tok.pos, "expression nested more than %v "+
+			"levels deep", maxDepth)
+	}
+
+	return nil
+}
+
+// exit decreases the nesting depth of our parser.
+func (p *parser) exit() {
+	p.depth--
+}
+
+// parseOr parses a set of expressions joined by ||.
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOr {
+		op := p.advance()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left, err = p.logical(op, left, right)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return left, nil
+}
+
+// parseAnd parses a set of expressions joined by &&.
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenAnd {
+		op := p.advance()
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left, err = p.logical(op, left, right)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return left, nil
+}
+
+// parseNot parses an optionally negated comparison.
+func (p *parser) parseNot() (node, error) {
+	if p.peek().kind != tokenNot {
+		return p.parseComparison()
+	}
+
+	op := p.advance()
+	if err := p.enter(op); err != nil {
+		return nil, err
+	}
+	defer p.exit()
+
+	operand, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	if operand.typ() != Bool {
+		return nil, p.errorf(op.pos, "cannot apply ! to %v",
+			operand.typ())
+	}
+
+	return &notNode{operand: operand}, nil
+}
+
+// parseComparison parses an operand, optionally compared to another.
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.peek()
+	switch op.kind {
+	case tokenLess, tokenLessEqual, tokenGreater, tokenGreaterEqual,
+		tokenEqual, tokenNotEqual:
+
+	default:
+		return left, nil
+	}
+	p.advance()
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if left.typ() != right.typ() {
+		return nil, p.errorf(op.pos, "cannot compare %v with %v",
+			left.typ(), right.typ())
+	}
+
+	ordered := op.kind != tokenEqual && op.kind != tokenNotEqual
+	if ordered && left.typ() == Bool {
+		return nil, p.errorf(op.pos, "cannot use %v with bool",
+			op.text)
+	}
+
+	return &compareNode{
+		op:    op.kind,
+		left:  left,
+		right: right,
+	}, nil
+}
+
+// parseOperand parses a single value, which may be a bracketed expression.
+func (p *parser) parseOperand() (node, error) {
+	tok := p.advance()
+
+	switch tok.kind {
+	case tokenNumber:
+		return &literalNode{value: NumberValue(tok.value)}, nil
+
+	case tokenDuration:
+		return &literalNode{
+			value: Value{Type: Duration, number: tok.value},
+		}, nil
+
+	case tokenMinus:
+		if err := p.enter(tok); err != nil {
+			return nil, err
+		}
+		defer p.exit()
+
+		operand, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		if operand.typ() == Bool {
+			return nil, p.errorf(tok.pos, "cannot negate bool")
+		}
+
+		return &negateNode{operand: operand}, nil
+
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return &literalNode{value: BoolValue(true)}, nil
+
+		case "false":
+			return &literalNode{value: BoolValue(false)}, nil
+		}
+
+		varType, ok := p.variables[tok.text]
+		if !ok {
+			return nil, p.errorf(tok.pos, "unknown variable %q, "+
+				"expected one of: %v", tok.text,
+				p.variableNames())
+		}
+
+		return &variableNode{name: tok.text, varType: varType}, nil
+
+	case tokenLParen:
+		if err := p.enter(tok); err != nil {
+			return nil, err
+		}
+		defer p.exit()
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		closing := p.advance()
+		if closing.kind != tokenRParen {
+			return nil, p.errorf(closing.pos, "expected ) to "+
+				"close ( at column %v", tok.pos+1)
+		}
+
+		return expr, nil
+
+	case tokenEOF:
+		return nil, p.errorf(tok.pos, "unexpected end of expression")
+
+	default:
+		return nil, p.errorf(tok.pos, "unexpected %q", tok.text)
+	}
+}
+
+// logical creates a node which joins two boolean expressions with the
+// operator provided.
+func (p *parser) logical(op token, left, right node) (node, error) {
+	if left.typ() != Bool || right.typ() != Bool {
+		return nil, p.errorf(op.pos, "cannot use %v with %v and %v",
+			op.text, left.typ(), right.typ())
+	}
+
+	return &logicalNode{
+		and:   op.kind == tokenAnd,
+		left:  left,
+		right: right,
+	}, nil
+}
+
+// variableNames returns a sorted, comma separated list of the variables that
+// the parser accepts.
+func (p *parser) variableNames() string {
+	names := make([]string, 0, len(p.variables))
+	for name := range p.variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// node is a type checked node in a parsed expression.
+type node interface {
+	// typ returns the type of the value that the node produces.
+	typ() Type
+
+	// eval evaluates the node with the variable values provided.
+	eval(values map[string]Value) (Value, error)
+}
+
+// literalNode is a constant value.
+type literalNode struct {
+	value Value
+}
+
+func (n *literalNode) typ() Type {
+	return n.value.Type
+}
+
+func (n *literalNode) eval(map[string]Value) (Value, error) {
+	return n.value, nil
+}
+
+// variableNode is a named variable.
+type variableNode struct {
+	name    string
+	varType Type
+}
+
+func (n *variableNode) typ() Type {
+	return n.varType
+}
+
+func (n *variableNode) eval(values map[string]Value) (Value, error) {
+	value, ok := values[n.name]
+	if !ok {
+		return Value{}, fmt.Errorf("no value for variable %v", n.name)
+	}
+
+	if value.Type != n.varType {
+		return Value{}, fmt.Errorf("variable %v has type %v, expected "+
+			"%v", n.name, value.Type, n.varType)
+	}
+
+	return value, nil
+}
+
+// negateNode negates a number or duration.
+type negateNode struct {
+	operand node
+}
+
+func (n *negateNode) typ() Type {
+	return n.operand.typ()
+}
+
+func (n *negateNode) eval(values map[string]Value) (Value, error) {
+	value, err := n.operand.eval(values)
+	if err != nil {
+		return Value{}, err
+	}
+
+	value.number = -value.number
+
+	return value, nil
+}
+
+// notNode inverts a boolean.
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) typ() Type {
+	return Bool
+}
+
+func (n *notNode) eval(values map[string]Value) (Value, error) {
+	value, err := n.operand.eval(values)
+	if err != nil {
+		return Value{}, err
+	}
+
+	return BoolValue(!value.boolean), nil
+}
+
+// logicalNode joins two booleans with && or ||. The right side is only
+// evaluated if it is required.
+type logicalNode struct {
+	and         bool
+	left, right node
+}
+
+func (n *logicalNode) typ() Type {
+	return Bool
+}
+
+func (n *logicalNode) eval(values map[string]Value) (Value, error) {
+	left, err := n.left.eval(values)
+	if err != nil {
+		return Value{}, err
+	}
+
+	if left.boolean != n.and {
+		return left, nil
+	}
+
+	return n.right.eval(values)
+}
+
+// compareNode compares two values of the same type.
+type compareNode struct {
+	op          tokenKind
+	left, right node
+}
+
+func (n *compareNode) typ() Type {
+	return Bool
+}
+
+func (n *compareNode) eval(values map[string]Value) (Value, error) {
+	left, err := n.left.eval(values)
+	if err != nil {
+		return Value{}, err
+	}
+
+	right, err := n.right.eval(values)
+	if err != nil {
+		return Value{}, err
+	}
+
+	// Booleans can only be compared for equality, which is enforced when
+	// the expression is parsed.
+	if left.Type == Bool {
+		equal := left.boolean == right.boolean
+		return BoolValue(equal == (n.op == tokenEqual)), nil
+	}
+
+	var result bool
+	switch n.op {
+	case tokenLess:
+		result = left.number < right.number
+
+	case tokenLessEqual:
+		result = left.number <= right.number
+
+	case tokenGreater:
+		result = left.number > right.number
+
+	case tokenGreaterEqual:
+		result = left.number >= right.number
+
+	case tokenEqual:
+		result = left.number == right.number
+
+	case tokenNotEqual:
+		result = left.number != right.number
+	}
+
+	return BoolValue(result), nil
+}
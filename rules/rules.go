@@ -0,0 +1,206 @@
+// Package rules provides a small expression language which is used to write
+// policies that are checked against a set of named, typed variables. Rules
+// are boolean expressions such as:
+//
+//	uptime_ratio < 0.8 && fees_per_conf < 10 && monitored > 60d
+//
+// The language supports three types:
+//   - Numbers, written as decimals (eg, 10 or 0.8)
+//   - Durations, written as a number followed by a unit of s, m, h, d or w
+//     (eg, 30m or 60d)
+//   - Booleans, written as true or false
+//
+// Values can be compared with <, <=, >, >=, == and !=, and boolean
+// expressions can be combined with &&, || and !, and grouped with brackets.
+// Numbers and durations can be negated with a leading -. Rules are type
+// checked when they are parsed, so that rules which compare values of
+// different types, or reference unknown variables, are rejected before they
+// are used. Expressions are limited in length and in how deeply they may be
+// nested.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrEmptyRule is returned when a rule with no expression is parsed.
+	ErrEmptyRule = errors.New("rule expression required")
+
+	// ErrNotBoolean is returned when a rule's expression does not produce
+	// a boolean value.
+	ErrNotBoolean = errors.New("rule expression must produce a boolean")
+)
+
+// Type is the type of a value in a rule expression.
+type Type int
+
+const (
+	invalidType Type = iota
+
+	// Number is a floating point number.
+	Number
+
+	// Duration is a period of time.
+	Duration
+
+	// Bool is a boolean.
+	Bool
+)
+
+// String returns the name of a type.
+func (t Type) String() string {
+	switch t {
+	case Number:
+		return "number"
+
+	case Duration:
+		return "duration"
+
+	case Bool:
+		return "bool"
+
+	default:
+		return "invalid"
+	}
+}
+
+// Value is a typed value that a variable takes when a rule is evaluated.
+type Value struct {
+	// Type is the type of the value.
+	Type Type
+
+	// number holds the value of numbers, and durations expressed in
+	// nanoseconds, so that both can be compared the same way.
+	number float64
+
+	// boolean holds the value of booleans.
+	boolean bool
+}
+
+// NumberValue returns a number value.
+func NumberValue(n float64) Value {
+	return Value{Type: Number, number: n}
+}
+
+// DurationValue returns a duration value.
+func DurationValue(d time.Duration) Value {
+	return Value{Type: Duration, number: float64(d)}
+}
+
+// BoolValue returns a boolean value.
+func BoolValue(b bool) Value {
+	return Value{Type: Bool, boolean: b}
+}
+
+// Error is an error in a rule expression, which includes the position in the
+// expression that the error occurred at.
+type Error struct {
+	// Expression is the expression that could not be parsed.
+	Expression string
+
+	// Column is the 1-indexed column in the expression that the error
+	// occurred at.
+	Column int
+
+	// Msg describes the error.
+	Msg string
+}
+
+// Error returns the error's message along with the expression and a marker
+// pointing at the column the error occurred at.
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %v: %v\n\t%v\n\t%v^", e.Column, e.Msg,
+		e.Expression, strings.Repeat(" ", e.Column-1))
+}
+
+// Rule is a named expression which has been parsed and type checked.
+type Rule struct {
+	// Name is the name of the rule, which is used to report which rule a
+	// value matched.
+	Name string
+
+	// Expression is the rule's expression as it was written.
+	Expression string
+
+	// expr is the parsed expression.
+	expr node
+}
+
+// Parse parses and type checks a rule expression against the set of
+// variables provided. The rule returned always produces a boolean.
+func Parse(name, expression string, variables map[string]Type) (*Rule,
+	error) {
+
+	if strings.TrimSpace(expression) == "" {
+		return nil, ErrEmptyRule
+	}
+
+	p := &parser{
+		expression: expression,
+		variables:  variables,
+	}
+
+	if len(expression) > maxExpressionLength {
+		return nil, p.errorf(maxExpressionLength, "expression "+
+			"longer than %v characters", maxExpressionLength)
+	}
+
+	if err := p.lex(); err != nil {
+		return nil, err
+	}
+
+	expr, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if expr.typ() != Bool {
+		return nil, fmt.Errorf("%w, got %v", ErrNotBoolean, expr.typ())
+	}
+
+	return &Rule{
+		Name:       name,
+		Expression: expression,
+		expr:       expr,
+	}, nil
+}
+
+// ParseNamed parses a rule expressed as name:expression. If no name is
+// provided, the default name is used.
+func ParseNamed(rule, defaultName string,
+	variables map[string]Type) (*Rule, error) {
+
+	name, expression := defaultName, rule
+	if i := strings.Index(rule, ":"); i != -1 {
+		name = strings.TrimSpace(rule[:i])
+		expression = rule[i+1:]
+	}
+
+	parsed, err := Parse(name, expression, variables)
+	if err != nil {
+		return nil, fmt.Errorf("rule %v: %w", name, err)
+	}
+
+	return parsed, nil
+}
+
+// String returns the rule in the form name:expression.
+func (r *Rule) String() string {
+	return fmt.Sprintf("%v:%v", r.Name, r.Expression)
+}
+
+// Match evaluates the rule with the variable values provided and returns a
+// boolean indicating whether the rule matched. An error is returned if a
+// variable that the rule uses is not provided, or has the wrong type.
+func (r *Rule) Match(values map[string]Value) (bool, error) {
+	value, err := r.expr.eval(values)
+	if err != nil {
+		return false, fmt.Errorf("rule %v: %w", r.Name, err)
+	}
+
+	return value.boolean, nil
+}
@@ -0,0 +1,255 @@
+package rules
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testVariables is the set of variables that our test rules are checked
+// against.
+var testVariables = map[string]Type{
+	"uptime_ratio":  Number,
+	"fees_per_conf": Number,
+	"monitored":     Duration,
+	"private":       Bool,
+}
+
+// testValues is a set of values for our test variables.
+var testValues = map[string]Value{
+	"uptime_ratio":  NumberValue(0.5),
+	"fees_per_conf": NumberValue(5),
+	"monitored":     DurationValue(time.Hour * 24 * 90),
+	"private":       BoolValue(false),
+}
+
+// TestRules tests parsing and evaluation of valid rules.
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		match      bool
+	}{
+		{
+			name:       "all conditions match",
+			expression: "uptime_ratio < 0.8 && fees_per_conf < 10 && monitored > 60d",
+			match:      true,
+		},
+		{
+			name:       "one condition fails",
+			expression: "uptime_ratio < 0.8 && fees_per_conf < 1",
+			match:      false,
+		},
+		{
+			name:       "or",
+			expression: "uptime_ratio > 0.8 || fees_per_conf <= 5",
+			match:      true,
+		},
+		{
+			name:       "and binds tighter than or",
+			expression: "uptime_ratio > 0.8 && private || monitored >= 12w",
+			match:      true,
+		},
+		{
+			name:       "brackets",
+			expression: "uptime_ratio > 0.8 && (private || monitored >= 12w)",
+			match:      false,
+		},
+		{
+			name: "maximum nesting",
+			expression: strings.Repeat("(", maxDepth) + "private" +
+				strings.Repeat(")", maxDepth),
+			match: false,
+		},
+		{
+			name:       "not",
+			expression: "!private && !(uptime_ratio == 1)",
+			match:      true,
+		},
+		{
+			name:       "boolean equality",
+			expression: "private == false",
+			match:      true,
+		},
+		{
+			name:       "negative number",
+			expression: "fees_per_conf > -1",
+			match:      true,
+		},
+		{
+			name:       "duration units",
+			expression: "monitored == 90d && monitored == 7776000s",
+			match:      true,
+		},
+		{
+			name:       "literal",
+			expression: "true",
+			match:      true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := Parse("test", test.expression, testVariables)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			match, err := rule.Match(testValues)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if match != test.match {
+				t.Fatalf("expected match: %v, got: %v",
+					test.match, match)
+			}
+		})
+	}
+}
+
+// TestRuleErrors tests that invalid rules fail with errors at the position
+// of the problem.
+func TestRuleErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		column     int
+	}{
+		{
+			name:       "unknown variable",
+			expression: "uptime_ratio < 0.8 && fes_per_conf < 10",
+			column:     23,
+		},
+		{
+			name:       "type mismatch",
+			expression: "monitored > 60",
+			column:     11,
+		},
+		{
+			name:       "unknown unit",
+			expression: "monitored > 60y",
+			column:     15,
+		},
+		{
+			name:       "ordered bool comparison",
+			expression: "private < true",
+			column:     9,
+		},
+		{
+			name:       "logical operator on number",
+			expression: "uptime_ratio && private",
+			column:     14,
+		},
+		{
+			name:       "unclosed bracket",
+			expression: "(private || uptime_ratio < 1",
+			column:     29,
+		},
+		{
+			name:       "unexpected character",
+			expression: "private & true",
+			column:     9,
+		},
+		{
+			name:       "trailing token",
+			expression: "uptime_ratio < 1 1",
+			column:     18,
+		},
+		{
+			name:       "missing operand",
+			expression: "uptime_ratio <",
+			column:     15,
+		},
+		{
+			name: "nested too deeply",
+			expression: strings.Repeat("(", maxDepth+1) +
+				"private" + strings.Repeat(")", maxDepth+1),
+			column: maxDepth + 1,
+		},
+		{
+			name: "negated too deeply",
+			expression: strings.Repeat("!", maxDepth+1) +
+				"private",
+			column: maxDepth + 1,
+		},
+		{
+			name: "expression too long",
+			expression: "private" + strings.Repeat(
+				" || private", maxExpressionLength/10,
+			),
+			column: maxExpressionLength + 1,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse("test", test.expression, testVariables)
+
+			var ruleErr *Error
+			if !errors.As(err, &ruleErr) {
+				t.Fatalf("expected rule error, got: %v", err)
+			}
+
+			if ruleErr.Column != test.column {
+				t.Fatalf("expected error at column: %v, got: "+
+					"%v", test.column, ruleErr)
+			}
+		})
+	}
+}
+
+// TestParseNamed tests parsing of named rules and errors for rules which do
+// not produce booleans.
+func TestParseNamed(t *testing.T) {
+	rule, err := ParseNamed("idle: fees_per_conf < 1", "default",
+		testVariables)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rule.Name != "idle" {
+		t.Fatalf("expected name idle, got: %v", rule.Name)
+	}
+
+	rule, err = ParseNamed("private", "default", testVariables)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rule.Name != "default" {
+		t.Fatalf("expected name default, got: %v", rule.Name)
+	}
+
+	_, err = ParseNamed("value: fees_per_conf", "default", testVariables)
+	if !errors.Is(err, ErrNotBoolean) {
+		t.Fatalf("expected: %v, got: %v", ErrNotBoolean, err)
+	}
+
+	_, err = ParseNamed("empty:", "default", testVariables)
+	if !errors.Is(err, ErrEmptyRule) {
+		t.Fatalf("expected: %v, got: %v", ErrEmptyRule, err)
+	}
+}
+
+// TestMatchMissingValue tests that evaluating a rule without a value for one
+// of its variables fails.
+func TestMatchMissingValue(t *testing.T) {
+	rule, err := Parse("test", "private", testVariables)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := rule.Match(map[string]Value{}); err == nil {
+		t.Fatalf("expected error for missing value")
+	}
+}
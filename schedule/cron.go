@@ -0,0 +1,267 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule is returned when a schedule cannot be parsed.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// maxSearchDays is the number of days that we search for the next time that
+// a schedule runs. It covers a leap day, so that schedules which only run on
+// the 29th of February are found.
+const maxSearchDays = 366 * 5
+
+// descriptors maps the shorthand schedules that we support to their five
+// field equivalents.
+var descriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+// field is a set of values that a schedule field matches, with a bit set for
+// each value.
+type field uint64
+
+// matches returns a boolean indicating whether the field matches a value.
+func (f field) matches(value int) bool {
+	return f&(1<<uint(value)) != 0
+}
+
+// Schedule is a cron-like schedule, which runs at each minute that matches
+// its minute, hour, day of month, month and day of week fields.
+type Schedule struct {
+	expr string
+
+	minute     field
+	hour       field
+	dayOfMonth field
+	month      field
+	dayOfWeek  field
+
+	// anyDayOfMonth and anyDayOfWeek are true if the day of month or day
+	// of week fields are *. If both fields are restricted, a day matches
+	// if it matches either of them, following cron.
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// String returns the expression that the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Parse parses a schedule expressed as five space separated fields:
+//
+//	minute (0-59) hour (0-23) day-of-month (1-31) month (1-12)
+//	day-of-week (0-7, where both 0 and 7 are Sunday)
+//
+// Each field is either *, a value, a range a-b or a comma separated list of
+// these, and values may be stepped with /n, eg */15 or 1-5/2. The shorthand
+// schedules @hourly, @daily, @midnight, @weekly, @monthly, @yearly and
+// @annually are also supported.
+func Parse(expr string) (*Schedule, error) {
+	value := expr
+	if descriptor, ok := descriptors[expr]; ok {
+		value = descriptor
+	}
+
+	parts := strings.Fields(value)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("%w: %v should have 5 fields",
+			ErrInvalidSchedule, expr)
+	}
+
+	schedule := &Schedule{
+		expr:          expr,
+		anyDayOfMonth: parts[2] == "*",
+		anyDayOfWeek:  parts[4] == "*",
+	}
+
+	fields := []struct {
+		target   *field
+		min, max int
+	}{
+		{&schedule.minute, 0, 59},
+		{&schedule.hour, 0, 23},
+		{&schedule.dayOfMonth, 1, 31},
+		{&schedule.month, 1, 12},
+		{&schedule.dayOfWeek, 0, 7},
+	}
+
+	for i, f := range fields {
+		parsed, err := parseField(parts[i], f.min, f.max)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v: %v", ErrInvalidSchedule,
+				expr, err)
+		}
+
+		*f.target = parsed
+	}
+
+	// Sunday can be expressed as 7, so we fold it into 0.
+	if schedule.dayOfWeek.matches(7) {
+		schedule.dayOfWeek |= 1
+	}
+
+	// Schedules such as the 31st of February are valid fields, but never
+	// run, so we reject them.
+	if schedule.Next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return nil, fmt.Errorf("%w: %v never runs", ErrInvalidSchedule,
+			expr)
+	}
+
+	return schedule, nil
+}
+
+// parseField parses a comma separated list of values, ranges and steps which
+// fall within the bounds provided.
+func parseField(value string, min, max int) (field, error) {
+	var parsed field
+	for _, part := range strings.Split(value, ",") {
+		rangePart := part
+		step := 1
+
+		if i := strings.Index(part, "/"); i != -1 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step: %v", part)
+			}
+
+			rangePart = part[:i]
+		}
+
+		start, end := min, max
+		switch {
+		case rangePart == "*":
+
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+
+			var err error
+			start, err = parseValue(bounds[0], min, max)
+			if err != nil {
+				return 0, err
+			}
+
+			end, err = parseValue(bounds[1], min, max)
+			if err != nil {
+				return 0, err
+			}
+
+			if end < start {
+				return 0, fmt.Errorf("invalid range: %v",
+					rangePart)
+			}
+
+		default:
+			var err error
+			start, err = parseValue(rangePart, min, max)
+			if err != nil {
+				return 0, err
+			}
+
+			// A single value only covers itself, unless it is
+			// stepped, in which case it runs to the maximum.
+			if step == 1 {
+				end = start
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			parsed |= 1 << uint(i)
+		}
+	}
+
+	return parsed, nil
+}
+
+// parseValue parses a single value and checks that it is within the bounds
+// provided.
+func parseValue(value string, min, max int) (int, error) {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value: %v", value)
+	}
+
+	if parsed < min || parsed > max {
+		return 0, fmt.Errorf("value: %v not in [%v;%v]", parsed, min,
+			max)
+	}
+
+	return parsed, nil
+}
+
+// matchesDay returns a boolean indicating whether the schedule runs on the
+// day of the time provided.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	if !s.month.matches(int(t.Month())) {
+		return false
+	}
+
+	dayOfMonth := s.dayOfMonth.matches(t.Day())
+	dayOfWeek := s.dayOfWeek.matches(int(t.Weekday()))
+
+	if !s.anyDayOfMonth && !s.anyDayOfWeek {
+		return dayOfMonth || dayOfWeek
+	}
+
+	return dayOfMonth && dayOfWeek
+}
+
+// Next returns the first time after the time provided that the schedule
+// runs, in the location of the time provided. If the schedule does not run
+// within the next five years, the zero time is returned.
+func (s *Schedule) Next(after time.Time) time.Time {
+	start := after.Truncate(time.Minute).Add(time.Minute)
+	loc := start.Location()
+
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0,
+		loc)
+
+	for i := 0; i < maxSearchDays; i++ {
+		if s.matchesDay(day) {
+			for hour := 0; hour < 24; hour++ {
+				if !s.hour.matches(hour) {
+					continue
+				}
+
+				for minute := 0; minute < 60; minute++ {
+					if !s.minute.matches(minute) {
+						continue
+					}
+
+					next := time.Date(
+						day.Year(), day.Month(),
+						day.Day(), hour, minute, 0, 0,
+						loc,
+					)
+
+					// Skip times before our start on
+					// the first day, and times that
+					// daylight savings moved back.
+					if next.Before(start) {
+						continue
+					}
+
+					return next
+				}
+			}
+		}
+
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0,
+			0, loc)
+	}
+
+	return time.Time{}
+}
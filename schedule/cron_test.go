@@ -0,0 +1,150 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestParse tests parsing of invalid schedules.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		err  error
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+		},
+		{
+			name: "lists, ranges and steps",
+			expr: "0,30 9-17/2 1-7 */3 1-5",
+		},
+		{
+			name: "descriptor",
+			expr: "@weekly",
+		},
+		{
+			name: "too few fields",
+			expr: "0 9 * *",
+			err:  ErrInvalidSchedule,
+		},
+		{
+			name: "unknown descriptor",
+			expr: "@fortnightly",
+			err:  ErrInvalidSchedule,
+		},
+		{
+			name: "value out of range",
+			expr: "60 * * * *",
+			err:  ErrInvalidSchedule,
+		},
+		{
+			name: "reversed range",
+			expr: "* 17-9 * * *",
+			err:  ErrInvalidSchedule,
+		},
+		{
+			name: "zero step",
+			expr: "*/0 * * * *",
+			err:  ErrInvalidSchedule,
+		},
+		{
+			name: "not a number",
+			expr: "* * * jan *",
+			err:  ErrInvalidSchedule,
+		},
+		{
+			name: "never runs",
+			expr: "0 0 31 2 *",
+			err:  ErrInvalidSchedule,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(test.expr)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected: %v, got: %v", test.err, err)
+			}
+		})
+	}
+}
+
+// TestNext tests finding the next time that a schedule runs.
+func TestNext(t *testing.T) {
+	// Thursday the 2nd of January 2020, at 10:30:15.
+	after := time.Date(2020, 1, 2, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected time.Time
+	}{
+		{
+			name:     "every minute",
+			expr:     "* * * * *",
+			expected: time.Date(2020, 1, 2, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			name:     "later today",
+			expr:     "0 12 * * *",
+			expected: time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "earlier today runs tomorrow",
+			expr:     "0 9 * * *",
+			expected: time.Date(2020, 1, 3, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "step",
+			expr:     "*/20 * * * *",
+			expected: time.Date(2020, 1, 2, 10, 40, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekly on monday",
+			expr:     "0 9 * * 1",
+			expected: time.Date(2020, 1, 6, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "sunday as 7",
+			expr:     "0 0 * * 7",
+			expected: time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "monthly descriptor",
+			expr:     "@monthly",
+			expected: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month or day of week",
+			expr:     "0 0 15 * 6",
+			expected: time.Date(2020, 1, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "leap day",
+			expr:     "0 0 29 2 *",
+			expected: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := Parse(test.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			next := schedule.Next(after)
+			if !next.Equal(test.expected) {
+				t.Fatalf("expected: %v, got: %v",
+					test.expected, next)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+// Package schedule generates reports on cron-like schedules and writes them
+// to disk. Each run of a job is written to a file named with the time that
+// it ran, in a directory for the job, so that a job's history is laid out
+// as:
+//
+//	<dir>/<job>/<job>_20200102T150405Z.<extension>
+//
+// Only a set number of each job's most recent files are kept, and older
+// files are removed after each run.
+package schedule
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// checkInterval is the interval at which we check whether jobs are due to
+// run. It is shorter than a minute so that jobs run close to the minute that
+// they are scheduled for.
+const checkInterval = time.Second * 15
+
+// timestampFormat is the format of the timestamps in the names of the files
+// that jobs write. It sorts lexicographically, so that file names sort in
+// the order that they were written.
+const timestampFormat = "20060102T150405Z"
+
+// Job is a job which is run on a schedule, and whose output is written to
+// file.
+type Job struct {
+	// Name is the name of the job, which is used to name its directory
+	// and files. It must be unique.
+	Name string
+
+	// Schedule is the schedule that the job runs on.
+	Schedule *Schedule
+
+	// Extension is the extension of the files that the job writes.
+	Extension string
+
+	// Keep is the number of the job's most recent files to keep. If it is
+	// zero, all files are kept.
+	Keep int
+
+	// Run runs the job at the time provided and returns its output.
+	Run func(now time.Time) ([]byte, error)
+}
+
+// Config provides the jobs that the scheduler runs.
+type Config struct {
+	// Jobs is the set of jobs that we run.
+	Jobs []*Job
+
+	// Dir is the directory that job output is written to.
+	Dir string
+
+	// Now returns the current time.
+	Now func() time.Time
+}
+
+// Scheduler runs a set of jobs on their schedules.
+type Scheduler struct {
+	cfg *Config
+
+	// next maps the name of each job to the next time it is due. It is
+	// only accessed by the scheduler's goroutine once started.
+	next map[string]time.Time
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates a new scheduler. Note that the scheduler returned
+// does not run jobs until it is started using Start().
+func NewScheduler(cfg *Config) *Scheduler {
+	return &Scheduler{
+		cfg:  cfg,
+		next: make(map[string]time.Time),
+		quit: make(chan struct{}),
+	}
+}
+
+// Start starts running our jobs on their schedules.
+func (s *Scheduler) Start() {
+	now := s.cfg.Now()
+	for _, job := range s.cfg.Jobs {
+		s.next[job.Name] = job.Schedule.Next(now)
+
+		log.Infof("Scheduled report: %v (%v), next run: %v", job.Name,
+			job.Schedule, s.next[job.Name])
+	}
+
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop stops the scheduler and waits for it to exit.
+func (s *Scheduler) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+// run runs the jobs that are due at each check interval until the scheduler
+// is stopped.
+func (s *Scheduler) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runDue(s.cfg.Now())
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// runDue runs each job that is due at the time provided, and sets the next
+// time that it is due. It returns the paths of the files that were written.
+func (s *Scheduler) runDue(now time.Time) []string {
+	var written []string
+	for _, job := range s.cfg.Jobs {
+		if now.Before(s.next[job.Name]) {
+			continue
+		}
+
+		// Jobs that fail are not retried until their next scheduled
+		// run, because they are likely to fail again immediately.
+		s.next[job.Name] = job.Schedule.Next(now)
+
+		path, err := s.runJob(job, now)
+		if err != nil {
+			log.Errorf("could not run report: %v: %v", job.Name,
+				err)
+
+			continue
+		}
+
+		log.Infof("Wrote report: %v, next run: %v", path,
+			s.next[job.Name])
+
+		written = append(written, path)
+	}
+
+	return written
+}
+
+// runJob runs a job, writes its output to a new file in its directory and
+// removes any files that exceed the number of files that it keeps. It
+// returns the path of the file written.
+func (s *Scheduler) runJob(job *Job, now time.Time) (string, error) {
+	output, err := job.Run(now)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(s.cfg.Dir, job.Name)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%v_%v.%v", job.Name,
+		now.UTC().Format(timestampFormat), job.Extension))
+
+	// Write to a temporary file and then rename it, so that readers never
+	// see a partially written report.
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, output, 0600); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return "", err
+	}
+
+	if err := prune(dir, job); err != nil {
+		return "", fmt.Errorf("could not remove old reports: %v", err)
+	}
+
+	return path, nil
+}
+
+// prune removes the oldest files that a job has written in the directory
+// provided, so that only the number of files that the job keeps remain.
+func prune(dir string, job *Job) error {
+	if job.Keep == 0 {
+		return nil
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	prefix := job.Name + "_"
+	suffix := "." + job.Extension
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) ||
+			!strings.HasSuffix(name, suffix) {
+
+			continue
+		}
+
+		files = append(files, name)
+	}
+
+	if len(files) <= job.Keep {
+		return nil
+	}
+
+	// Our file names include their timestamp, so sorting them orders them
+	// from oldest to newest.
+	sort.Strings(files)
+
+	for _, name := range files[:len(files)-job.Keep] {
+		log.Debugf("Removing old report: %v", name)
+
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
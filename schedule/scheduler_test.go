@@ -0,0 +1,144 @@
+package schedule
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestRunDue tests running jobs when they are due, and removal of old files.
+func TestRunDue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "schedule")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	hourly, err := Parse("@hourly")
+	if err != nil {
+		t.Fatalf("could not parse schedule: %v", err)
+	}
+
+	start := time.Date(2020, 1, 2, 10, 30, 0, 0, time.UTC)
+
+	// runErr is the error that our failing job returns.
+	runErr := errors.New("report failed")
+
+	scheduler := NewScheduler(&Config{
+		Jobs: []*Job{
+			{
+				Name:      "revenue",
+				Schedule:  hourly,
+				Extension: "csv",
+				Keep:      2,
+				Run: func(now time.Time) ([]byte, error) {
+					return []byte(now.String()), nil
+				},
+			},
+			{
+				Name:      "failing",
+				Schedule:  hourly,
+				Extension: "json",
+				Run: func(_ time.Time) ([]byte, error) {
+					return nil, runErr
+				},
+			},
+		},
+		Dir: dir,
+		Now: func() time.Time {
+			return start
+		},
+	})
+
+	// Set our next run times as Start does, without starting our
+	// goroutine.
+	for _, job := range scheduler.cfg.Jobs {
+		scheduler.next[job.Name] = job.Schedule.Next(start)
+	}
+
+	revenueFile := func(timestamp string) string {
+		return filepath.Join(
+			dir, "revenue", "revenue_"+timestamp+".csv",
+		)
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+
+		// expectedWritten is the set of files written by this run.
+		expectedWritten []string
+
+		// expectedFiles is the set of report files on disk after this
+		// run.
+		expectedFiles []string
+	}{
+		{
+			name:          "not due",
+			now:           start.Add(time.Minute * 10),
+			expectedFiles: nil,
+		},
+		{
+			name: "first run",
+			now:  time.Date(2020, 1, 2, 11, 0, 0, 0, time.UTC),
+			expectedWritten: []string{
+				revenueFile("20200102T110000Z"),
+			},
+			expectedFiles: []string{
+				revenueFile("20200102T110000Z"),
+			},
+		},
+		{
+			name: "already run",
+			now:  time.Date(2020, 1, 2, 11, 0, 15, 0, time.UTC),
+			expectedFiles: []string{
+				revenueFile("20200102T110000Z"),
+			},
+		},
+		{
+			name: "second run",
+			now:  time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC),
+			expectedWritten: []string{
+				revenueFile("20200102T120000Z"),
+			},
+			expectedFiles: []string{
+				revenueFile("20200102T110000Z"),
+				revenueFile("20200102T120000Z"),
+			},
+		},
+		{
+			name: "late run removes oldest",
+			now:  time.Date(2020, 1, 2, 15, 0, 30, 0, time.UTC),
+			expectedWritten: []string{
+				revenueFile("20200102T150030Z"),
+			},
+			expectedFiles: []string{
+				revenueFile("20200102T120000Z"),
+				revenueFile("20200102T150030Z"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		written := scheduler.runDue(test.now)
+		if !reflect.DeepEqual(written, test.expectedWritten) {
+			t.Fatalf("%v: expected written: %v, got: %v",
+				test.name, test.expectedWritten, written)
+		}
+
+		files, err := filepath.Glob(filepath.Join(dir, "*", "*"))
+		if err != nil {
+			t.Fatalf("%v: could not list files: %v", test.name,
+				err)
+		}
+
+		if !reflect.DeepEqual(files, test.expectedFiles) {
+			t.Fatalf("%v: expected files: %v, got: %v",
+				test.name, test.expectedFiles, files)
+		}
+	}
+}
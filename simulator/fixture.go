@@ -0,0 +1,196 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/lightninglabs/faraday/fakelnd"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// ourPubkey is the public key used for our simulated node.
+const ourPubkey = "020000000000000000000000000000000000000000000000000000000000000000"
+
+// Fixture contains the lnd responses for a simulated node. It can be saved
+// to disk and loaded later so that a simulation can be replayed.
+type Fixture struct {
+	// Info is our node's info.
+	Info *lnrpc.GetInfoResponse `json:"info"`
+
+	// Channels is the set of channels that are open at the end of the
+	// simulation.
+	Channels []*lnrpc.Channel `json:"channels"`
+
+	// ClosedChannels is the set of channels that were closed during the
+	// simulation.
+	ClosedChannels []*lnrpc.ChannelCloseSummary `json:"closed_channels"`
+
+	// Forwards is our node's forwarding log, sorted by timestamp.
+	Forwards []*lnrpc.ForwardingEvent `json:"forwards"`
+
+	// Peers is the set of peers that are online at the end of the
+	// simulation.
+	Peers []*lnrpc.Peer `json:"peers"`
+
+	// Nodes is the set of nodes in our graph.
+	Nodes []*lnrpc.LightningNode `json:"nodes"`
+
+	// Edges is the set of public channels in our graph.
+	Edges []*lnrpc.ChannelEdge `json:"edges"`
+}
+
+// fixture produces a fixture for the simulation's state at the end time
+// provided.
+func (s *simulation) fixture(end time.Time) *Fixture {
+	height := s.heightAt(end)
+
+	fixture := &Fixture{
+		Info: &lnrpc.GetInfoResponse{
+			IdentityPubkey:      ourPubkey,
+			Alias:               "simulated",
+			BlockHeight:         height,
+			SyncedToChain:       true,
+			BestHeaderTimestamp: end.Unix(),
+		},
+		Forwards: s.forwards,
+		Nodes: []*lnrpc.LightningNode{
+			{
+				PubKey: ourPubkey,
+				Alias:  "simulated",
+			},
+		},
+	}
+
+	for i, c := range s.channels {
+		capacity := int64(c.Capacity)
+		localBalance := int64(c.localBalance.ToSatoshis())
+
+		fixture.Nodes = append(fixture.Nodes, &lnrpc.LightningNode{
+			PubKey: c.remotePubkey,
+			Alias:  peerAlias(i),
+		})
+
+		// Channels that have not opened yet by the end of the
+		// simulation are not included.
+		if c.openAt.After(end) {
+			continue
+		}
+
+		if !c.closeAt.IsZero() && !c.closeAt.After(end) {
+			closed := &lnrpc.ChannelCloseSummary{
+				ChannelPoint:   c.chanPoint,
+				ChanId:         c.chanID,
+				RemotePubkey:   c.remotePubkey,
+				Capacity:       capacity,
+				CloseHeight:    s.heightAt(c.closeAt),
+				SettledBalance: localBalance,
+				CloseType:      lnrpc.ChannelCloseSummary_COOPERATIVE_CLOSE,
+			}
+
+			fixture.ClosedChannels = append(
+				fixture.ClosedChannels, closed,
+			)
+
+			continue
+		}
+
+		fixture.Channels = append(fixture.Channels, &lnrpc.Channel{
+			Active:        c.online,
+			RemotePubkey:  c.remotePubkey,
+			ChannelPoint:  c.chanPoint,
+			ChanId:        c.chanID,
+			Capacity:      capacity,
+			LocalBalance:  localBalance,
+			RemoteBalance: int64(c.remoteBalance.ToSatoshis()),
+			Private:       c.Private,
+			Lifetime:      int64(c.monitored.Seconds()),
+			Uptime:        int64(c.uptime.Seconds()),
+		})
+
+		if c.online {
+			fixture.Info.NumActiveChannels++
+			fixture.Peers = append(fixture.Peers, &lnrpc.Peer{
+				PubKey: c.remotePubkey,
+			})
+		} else {
+			fixture.Info.NumInactiveChannels++
+		}
+
+		if c.Private {
+			continue
+		}
+
+		fixture.Edges = append(fixture.Edges, &lnrpc.ChannelEdge{
+			ChannelId: c.chanID,
+			ChanPoint: c.chanPoint,
+			Node1Pub:  ourPubkey,
+			Node2Pub:  c.remotePubkey,
+			Capacity:  capacity,
+			Node1Policy: &lnrpc.RoutingPolicy{
+				FeeBaseMsat:      int64(c.BaseFee),
+				FeeRateMilliMsat: int64(c.FeeRatePPM),
+			},
+		})
+	}
+
+	return fixture
+}
+
+// peerAlias returns the alias for the peer of the channel at the index
+// provided.
+func peerAlias(index int) string {
+	return fmt.Sprintf("peer-%v", index)
+}
+
+// Client returns a fake lnd client which serves the fixture.
+func (f *Fixture) Client() *fakelnd.Client {
+	client := fakelnd.NewClient()
+	client.SetInfo(f.Info)
+	client.SetChannels(f.Channels...)
+	client.SetClosedChannels(f.ClosedChannels...)
+	client.AddForwards(f.Forwards...)
+	client.SetPeers(f.Peers...)
+
+	for _, node := range f.Nodes {
+		client.AddNode(node)
+	}
+
+	for _, edge := range f.Edges {
+		client.AddEdge(edge)
+	}
+
+	return client
+}
+
+// Save writes the fixture to the path provided as JSON.
+func (f *Fixture) Save(path string) error {
+	bytes, err := json.MarshalIndent(f, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, bytes, 0600)
+}
+
+// LoadFixture reads a fixture from the path provided.
+func LoadFixture(path string) (*Fixture, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := &Fixture{}
+	if err := json.Unmarshal(bytes, fixture); err != nil {
+		return nil, err
+	}
+
+	// Replace a missing info with an empty one so that the fixture's
+	// client can always serve it.
+	if fixture.Info == nil {
+		fixture.Info = &lnrpc.GetInfoResponse{}
+	}
+
+	return fixture, nil
+}
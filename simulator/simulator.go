@@ -0,0 +1,520 @@
+// Package simulator generates synthetic routing node data so that faraday's
+// recommendations can be tried out without access to a production node. A
+// simulation opens a set of channels with configurable capacities, peer
+// uptime patterns and flow distributions, then steps through simulated time
+// producing forwarding events.
+//
+// Peer uptime is modelled as a two state (online/offline) Markov chain per
+// channel, parameterised by the long run fraction of time the peer is online
+// and the mean length of its outages. Forwards arrive as a Poisson process at
+// a configurable daily rate. Each forward picks an incoming and outgoing
+// channel from the channels that are open and online, weighted by each
+// channel's incoming and outgoing flow weights, and is only completed if the
+// channels' balances allow it. Completed forwards shift balance between the
+// channels, so that channels which mostly forward in one direction become
+// depleted over time, as they do on a real node.
+//
+// The output of a simulation is a Fixture, which contains the lnd responses
+// required to replay the simulated node through the fake lnd client.
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// DefaultStep is the default simulation time step.
+	DefaultStep = time.Hour
+
+	// DefaultBlockInterval is the default time between simulated blocks.
+	DefaultBlockInterval = time.Minute * 10
+
+	// DefaultStartHeight is the default block height at which simulations
+	// start.
+	DefaultStartHeight = 600000
+
+	// day is the period that forwarding rates are expressed over.
+	day = time.Hour * 24
+)
+
+var (
+	// ErrNoChannels is returned when a simulation is configured without
+	// any channels.
+	ErrNoChannels = errors.New("at least one channel required")
+
+	// ErrInvalidDuration is returned when a simulation has a zero
+	// duration, or a step that is zero or longer than its duration.
+	ErrInvalidDuration = errors.New("simulation duration must be " +
+		"non-zero and longer than its step")
+
+	// ErrInvalidForwardRange is returned when the minimum forward amount
+	// is zero or greater than the maximum forward amount.
+	ErrInvalidForwardRange = errors.New("minimum forward amount must be " +
+		"non-zero and less than or equal to the maximum")
+)
+
+// ChannelSpec describes a simulated channel.
+type ChannelSpec struct {
+	// Capacity is the capacity of the channel.
+	Capacity btcutil.Amount
+
+	// LocalRatio is the fraction of the channel's capacity that is on our
+	// side of the channel when it is opened.
+	LocalRatio float64
+
+	// UptimeRatio is the long run fraction of time that the channel's
+	// peer is online.
+	UptimeRatio float64
+
+	// MeanOffline is the mean duration of the peer's outages. If it is
+	// zero, outages last for a single simulation step on average.
+	MeanOffline time.Duration
+
+	// InWeight is the channel's relative share of forwards arriving at
+	// our node.
+	InWeight float64
+
+	// OutWeight is the channel's relative share of forwards leaving our
+	// node.
+	OutWeight float64
+
+	// BaseFee is the base fee that we charge to forward over the channel.
+	BaseFee lnwire.MilliSatoshi
+
+	// FeeRatePPM is the proportional fee, in parts per million, that we
+	// charge to forward over the channel.
+	FeeRatePPM uint32
+
+	// Private indicates whether the channel is private.
+	Private bool
+
+	// OpenAfter is the time after the start of the simulation that the
+	// channel is opened.
+	OpenAfter time.Duration
+
+	// CloseAfter is the time after the start of the simulation that the
+	// channel is closed. If it is zero, the channel remains open.
+	CloseAfter time.Duration
+}
+
+// Config describes a simulation.
+type Config struct {
+	// Channels is the set of channels our simulated node has.
+	Channels []*ChannelSpec
+
+	// Start is the time that the simulation starts.
+	Start time.Time
+
+	// Duration is the length of time that is simulated.
+	Duration time.Duration
+
+	// Step is the simulation time step, which sets the granularity at
+	// which peer uptime changes. If it is zero, DefaultStep is used.
+	Step time.Duration
+
+	// ForwardsPerDay is the mean number of forwards that are attempted
+	// through our node per day.
+	ForwardsPerDay float64
+
+	// MinForward is the smallest amount we forward.
+	MinForward lnwire.MilliSatoshi
+
+	// MaxForward is the largest amount we forward. Forward amounts are
+	// log-uniformly distributed between our minimum and maximum, so that
+	// small forwards are more common than large ones.
+	MaxForward lnwire.MilliSatoshi
+
+	// StartHeight is the block height at the start of the simulation. If
+	// it is zero, DefaultStartHeight is used.
+	StartHeight uint32
+
+	// BlockInterval is the time between simulated blocks. If it is zero,
+	// DefaultBlockInterval is used.
+	BlockInterval time.Duration
+
+	// Seed seeds the simulation's source of randomness, so that the same
+	// config and seed always produce the same node.
+	Seed int64
+}
+
+// Summary provides totals for a simulation.
+type Summary struct {
+	// Forwards is the number of forwards that were completed.
+	Forwards int `json:"forwards"`
+
+	// FailedForwards is the number of forwards that could not be
+	// completed because one of the channels did not have sufficient
+	// balance.
+	FailedForwards int `json:"failed_forwards"`
+
+	// FeesMsat is the total fees earned by completed forwards.
+	FeesMsat int64 `json:"fees_msat"`
+}
+
+// channel holds the state of a simulated channel.
+type channel struct {
+	*ChannelSpec
+
+	chanPoint    string
+	chanID       uint64
+	remotePubkey string
+
+	openAt  time.Time
+	closeAt time.Time
+
+	localBalance  lnwire.MilliSatoshi
+	remoteBalance lnwire.MilliSatoshi
+
+	online    bool
+	monitored time.Duration
+	uptime    time.Duration
+}
+
+// isOpen returns a boolean indicating whether the channel is open at the
+// time provided.
+func (c *channel) isOpen(t time.Time) bool {
+	if t.Before(c.openAt) {
+		return false
+	}
+
+	return c.closeAt.IsZero() || t.Before(c.closeAt)
+}
+
+// simulation holds the state of a running simulation.
+type simulation struct {
+	cfg      *Config
+	rand     *rand.Rand
+	channels []*channel
+	forwards []*lnrpc.ForwardingEvent
+	summary  *Summary
+}
+
+// Simulate runs the simulation described by the config provided, and returns
+// the resulting node as a fixture.
+func Simulate(cfg *Config) (*Fixture, *Summary, error) {
+	if err := validateConfig(cfg); err != nil {
+		return nil, nil, err
+	}
+
+	sim := &simulation{
+		cfg:     cfg,
+		rand:    rand.New(rand.NewSource(cfg.Seed)),
+		summary: &Summary{},
+	}
+
+	for i, spec := range cfg.Channels {
+		sim.channels = append(sim.channels, sim.newChannel(i, spec))
+	}
+
+	end := cfg.Start.Add(cfg.Duration)
+	for t := cfg.Start; t.Before(end); t = t.Add(cfg.Step) {
+		sim.step(t)
+	}
+
+	// Forwards within a step are generated at random times, so we sort
+	// them to match the ordering of lnd's forwarding log.
+	sort.SliceStable(sim.forwards, func(i, j int) bool {
+		return sim.forwards[i].Timestamp < sim.forwards[j].Timestamp
+	})
+
+	return sim.fixture(end), sim.summary, nil
+}
+
+// validateConfig checks a simulation config and sets default values.
+func validateConfig(cfg *Config) error {
+	if len(cfg.Channels) == 0 {
+		return ErrNoChannels
+	}
+
+	if cfg.Step == 0 {
+		cfg.Step = DefaultStep
+	}
+
+	if cfg.Duration == 0 || cfg.Step > cfg.Duration {
+		return ErrInvalidDuration
+	}
+
+	if cfg.MinForward == 0 || cfg.MinForward > cfg.MaxForward {
+		return ErrInvalidForwardRange
+	}
+
+	if cfg.StartHeight == 0 {
+		cfg.StartHeight = DefaultStartHeight
+	}
+
+	if cfg.BlockInterval == 0 {
+		cfg.BlockInterval = DefaultBlockInterval
+	}
+
+	for i, spec := range cfg.Channels {
+		if spec.Capacity <= 0 {
+			return fmt.Errorf("channel %v: capacity must be "+
+				"positive", i)
+		}
+
+		if spec.LocalRatio < 0 || spec.LocalRatio > 1 {
+			return fmt.Errorf("channel %v: local ratio must be "+
+				"in [0, 1]", i)
+		}
+
+		if spec.UptimeRatio < 0 || spec.UptimeRatio > 1 {
+			return fmt.Errorf("channel %v: uptime ratio must be "+
+				"in [0, 1]", i)
+		}
+
+		if spec.InWeight < 0 || spec.OutWeight < 0 {
+			return fmt.Errorf("channel %v: flow weights must not "+
+				"be negative", i)
+		}
+
+		if spec.CloseAfter != 0 && spec.CloseAfter <= spec.OpenAfter {
+			return fmt.Errorf("channel %v: channel must close "+
+				"after it opens", i)
+		}
+	}
+
+	return nil
+}
+
+// heightAt returns the simulated block height at the time provided.
+func (s *simulation) heightAt(t time.Time) uint32 {
+	blocks := t.Sub(s.cfg.Start) / s.cfg.BlockInterval
+	return s.cfg.StartHeight + uint32(blocks)
+}
+
+// newChannel creates the state for a simulated channel. Channel points and
+// peer public keys are derived from the channel's index so that they are
+// stable across runs.
+func (s *simulation) newChannel(index int, spec *ChannelSpec) *channel {
+	openAt := s.cfg.Start.Add(spec.OpenAfter)
+
+	var closeAt time.Time
+	if spec.CloseAfter != 0 {
+		closeAt = s.cfg.Start.Add(spec.CloseAfter)
+	}
+
+	capacity := lnwire.NewMSatFromSatoshis(spec.Capacity)
+	local := lnwire.MilliSatoshi(float64(capacity) * spec.LocalRatio)
+
+	return &channel{
+		ChannelSpec: spec,
+		chanPoint:   fmt.Sprintf("%064x:0", index+1),
+		chanID: lnwire.ShortChannelID{
+			BlockHeight: s.heightAt(openAt),
+			TxIndex:     uint32(index + 1),
+		}.ToUint64(),
+		remotePubkey:  fmt.Sprintf("02%064x", index+1),
+		openAt:        openAt,
+		closeAt:       closeAt,
+		localBalance:  local,
+		remoteBalance: capacity - local,
+		online:        s.rand.Float64() < spec.UptimeRatio,
+	}
+}
+
+// step advances the simulation by one time step from the time provided.
+func (s *simulation) step(t time.Time) {
+	for _, c := range s.channels {
+		if !c.isOpen(t) {
+			continue
+		}
+
+		s.updateUptime(c)
+
+		c.monitored += s.cfg.Step
+		if c.online {
+			c.uptime += s.cfg.Step
+		}
+	}
+
+	rate := s.cfg.ForwardsPerDay * float64(s.cfg.Step) / float64(day)
+	attempts := poisson(s.rand, rate)
+
+	for i := 0; i < attempts; i++ {
+		offset := time.Duration(s.rand.Int63n(int64(s.cfg.Step)))
+		ts := t.Add(offset)
+
+		s.forward(s.available(ts), ts)
+	}
+}
+
+// available returns the channels that are open at the time provided and
+// whose peers are online. Channels may open or close part way through a
+// step, so this is checked for each forward's own timestamp.
+func (s *simulation) available(t time.Time) []*channel {
+	var available []*channel
+	for _, c := range s.channels {
+		if c.isOpen(t) && c.online {
+			available = append(available, c)
+		}
+	}
+
+	return available
+}
+
+// updateUptime transitions a channel's peer between online and offline. The
+// transition probabilities are chosen so that the peer's outages last for
+// its mean offline period on average, and it is online for its uptime ratio
+// of the time in the long run.
+func (s *simulation) updateUptime(c *channel) {
+	switch {
+	case c.UptimeRatio >= 1:
+		c.online = true
+		return
+
+	case c.UptimeRatio <= 0:
+		c.online = false
+		return
+	}
+
+	meanOffline := c.MeanOffline
+	if meanOffline < s.cfg.Step {
+		meanOffline = s.cfg.Step
+	}
+
+	toOnline := float64(s.cfg.Step) / float64(meanOffline)
+	toOffline := math.Min(
+		toOnline*(1-c.UptimeRatio)/c.UptimeRatio, 1,
+	)
+
+	if c.online {
+		c.online = s.rand.Float64() >= toOffline
+	} else {
+		c.online = s.rand.Float64() < toOnline
+	}
+}
+
+// forward attempts a forward between two of the online channels provided.
+func (s *simulation) forward(online []*channel, ts time.Time) {
+	incoming := pick(s.rand, online, nil, func(c *channel) float64 {
+		return c.InWeight
+	})
+	if incoming == nil {
+		return
+	}
+
+	outgoing := pick(s.rand, online, incoming, func(c *channel) float64 {
+		return c.OutWeight
+	})
+	if outgoing == nil {
+		return
+	}
+
+	amtOut := s.forwardAmount()
+	fee := outgoing.BaseFee + amtOut*lnwire.MilliSatoshi(
+		outgoing.FeeRatePPM,
+	)/1000000
+	amtIn := amtOut + fee
+
+	// The forward can only succeed if our peer on the incoming channel
+	// can pay us, and we can pay our peer on the outgoing channel.
+	if incoming.remoteBalance < amtIn || outgoing.localBalance < amtOut {
+		s.summary.FailedForwards++
+		return
+	}
+
+	incoming.remoteBalance -= amtIn
+	incoming.localBalance += amtIn
+	outgoing.localBalance -= amtOut
+	outgoing.remoteBalance += amtOut
+
+	s.forwards = append(s.forwards, &lnrpc.ForwardingEvent{
+		Timestamp:  uint64(ts.Unix()),
+		ChanIdIn:   incoming.chanID,
+		ChanIdOut:  outgoing.chanID,
+		AmtIn:      uint64(amtIn.ToSatoshis()),
+		AmtOut:     uint64(amtOut.ToSatoshis()),
+		Fee:        uint64(fee.ToSatoshis()),
+		FeeMsat:    uint64(fee),
+		AmtInMsat:  uint64(amtIn),
+		AmtOutMsat: uint64(amtOut),
+	})
+
+	s.summary.Forwards++
+	s.summary.FeesMsat += int64(fee)
+}
+
+// forwardAmount returns a log-uniformly distributed forward amount between
+// our minimum and maximum forward.
+func (s *simulation) forwardAmount() lnwire.MilliSatoshi {
+	min := math.Log(float64(s.cfg.MinForward))
+	max := math.Log(float64(s.cfg.MaxForward))
+
+	return lnwire.MilliSatoshi(math.Exp(min + s.rand.Float64()*(max-min)))
+}
+
+// pick chooses a channel from the set provided, excluding the channel
+// provided, with probability proportional to the weight function. It returns
+// nil if no channel has a non-zero weight.
+func pick(r *rand.Rand, channels []*channel, exclude *channel,
+	weight func(*channel) float64) *channel {
+
+	var total float64
+	for _, c := range channels {
+		if c != exclude {
+			total += weight(c)
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	target := r.Float64() * total
+	for _, c := range channels {
+		if c == exclude {
+			continue
+		}
+
+		target -= weight(c)
+		if target < 0 {
+			return c
+		}
+	}
+
+	// Floating point rounding may leave us with a small remainder, in
+	// which case we return the last channel with a non-zero weight.
+	for i := len(channels) - 1; i >= 0; i-- {
+		if channels[i] != exclude && weight(channels[i]) > 0 {
+			return channels[i]
+		}
+	}
+
+	return nil
+}
+
+// poisson returns a poisson distributed value with the mean provided. For
+// large means, we use a normal approximation.
+func poisson(r *rand.Rand, mean float64) int {
+	if mean <= 0 {
+		return 0
+	}
+
+	if mean > 30 {
+		value := math.Round(mean + r.NormFloat64()*math.Sqrt(mean))
+		return int(math.Max(value, 0))
+	}
+
+	var (
+		limit = math.Exp(-mean)
+		p     = 1.0
+		k     = 0
+	)
+
+	for {
+		p *= r.Float64()
+		if p <= limit {
+			return k
+		}
+		k++
+	}
+}
@@ -0,0 +1,326 @@
+package simulator
+
+import (
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// newTestConfig returns a simulation config with an always online channel,
+// a flaky channel and a channel that closes half way through the simulation.
+func newTestConfig() *Config {
+	return &Config{
+		Channels: []*ChannelSpec{
+			{
+				Capacity:    1000000,
+				LocalRatio:  0.5,
+				UptimeRatio: 1,
+				InWeight:    1,
+				OutWeight:   1,
+				FeeRatePPM:  1000,
+			},
+			{
+				Capacity:    2000000,
+				LocalRatio:  1,
+				UptimeRatio: 0.5,
+				MeanOffline: time.Hour * 6,
+				InWeight:    1,
+				OutWeight:   2,
+				BaseFee:     1000,
+			},
+			{
+				Capacity:    1000000,
+				UptimeRatio: 1,
+				InWeight:    2,
+				CloseAfter:  time.Hour * 24 * 15,
+			},
+		},
+		Start:          time.Unix(1500000000, 0),
+		Duration:       time.Hour * 24 * 30,
+		ForwardsPerDay: 50,
+		MinForward:     1000000,
+		MaxForward:     100000000,
+		Seed:           1,
+	}
+}
+
+// TestSimulate tests that simulations are deterministic for a seed, and that
+// the node they produce is consistent with the config.
+func TestSimulate(t *testing.T) {
+	fixture, summary, err := Simulate(newTestConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Running the same config again should produce the same node.
+	fixture2, summary2, err := Simulate(newTestConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(fixture, fixture2) {
+		t.Fatalf("expected simulation to be deterministic")
+	}
+
+	if *summary != *summary2 {
+		t.Fatalf("expected summaries to match: %v vs %v", summary,
+			summary2)
+	}
+
+	if summary.Forwards != len(fixture.Forwards) {
+		t.Fatalf("expected %v forwards, got: %v", summary.Forwards,
+			len(fixture.Forwards))
+	}
+
+	if summary.Forwards == 0 {
+		t.Fatalf("expected forwards")
+	}
+
+	if len(fixture.Channels) != 2 || len(fixture.ClosedChannels) != 1 {
+		t.Fatalf("expected 2 open and 1 closed channel, got %v and %v",
+			len(fixture.Channels), len(fixture.ClosedChannels))
+	}
+
+	var (
+		closeTime = newTestConfig().Start.Add(time.Hour * 24 * 15)
+		closedID  = fixture.ClosedChannels[0].ChanId
+		lastTime  uint64
+		totalFees int64
+	)
+
+	for _, fwd := range fixture.Forwards {
+		if fwd.Timestamp < lastTime {
+			t.Fatalf("forwards not sorted by timestamp")
+		}
+		lastTime = fwd.Timestamp
+
+		if fwd.ChanIdIn == fwd.ChanIdOut {
+			t.Fatalf("forward in and out of the same channel")
+		}
+
+		if fwd.AmtInMsat-fwd.AmtOutMsat != fwd.FeeMsat {
+			t.Fatalf("fee does not match forward amounts")
+		}
+		totalFees += int64(fwd.FeeMsat)
+
+		// Our closed channel should not forward after it closes, and
+		// has no outgoing weight, so should never be used to send.
+		if fwd.ChanIdOut == closedID {
+			t.Fatalf("forward out over channel with no weight")
+		}
+
+		if fwd.ChanIdIn == closedID &&
+			int64(fwd.Timestamp) >= closeTime.Unix() {
+
+			t.Fatalf("forward over closed channel")
+		}
+	}
+
+	if totalFees != summary.FeesMsat {
+		t.Fatalf("expected fees: %v, got: %v", summary.FeesMsat,
+			totalFees)
+	}
+
+	// Each channel's balances should sum to its capacity, within the
+	// rounding of msat to sat.
+	for _, c := range fixture.Channels {
+		total := c.LocalBalance + c.RemoteBalance
+		if total < c.Capacity-1 || total > c.Capacity {
+			t.Fatalf("channel %v balance: %v does not match "+
+				"capacity: %v", c.ChannelPoint, total,
+				c.Capacity)
+		}
+	}
+
+	// Our first channel is always online, and the second is online
+	// roughly half the time.
+	if fixture.Channels[0].Uptime != fixture.Channels[0].Lifetime {
+		t.Fatalf("expected always online channel to have full uptime")
+	}
+
+	ratio := float64(fixture.Channels[1].Uptime) /
+		float64(fixture.Channels[1].Lifetime)
+	if ratio < 0.3 || ratio > 0.7 {
+		t.Fatalf("expected uptime ratio close to 0.5, got: %v", ratio)
+	}
+}
+
+// TestForwardsWithinLifetime tests that channels which open or close part way
+// through a step only forward while they are open.
+func TestForwardsWithinLifetime(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Step = time.Hour * 24
+	cfg.Channels[1].OpenAfter = time.Hour * 12
+	cfg.Channels[2].CloseAfter = time.Hour * 36
+
+	fixture, _, err := Simulate(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var (
+		openTime  = cfg.Start.Add(cfg.Channels[1].OpenAfter).Unix()
+		closeTime = cfg.Start.Add(cfg.Channels[2].CloseAfter).Unix()
+		openedID  = fixture.Channels[1].ChanId
+		closedID  = fixture.ClosedChannels[0].ChanId
+	)
+
+	for _, fwd := range fixture.Forwards {
+		ts := int64(fwd.Timestamp)
+
+		if (fwd.ChanIdIn == openedID || fwd.ChanIdOut == openedID) &&
+			ts < openTime {
+
+			t.Fatalf("forward at %v before channel opened at %v",
+				ts, openTime)
+		}
+
+		if fwd.ChanIdIn == closedID && ts >= closeTime {
+			t.Fatalf("forward at %v after channel closed at %v",
+				ts, closeTime)
+		}
+	}
+}
+
+// TestValidateConfig tests validation of simulation configs.
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(cfg *Config)
+		expectErr bool
+	}{
+		{
+			name:   "valid",
+			mutate: func(_ *Config) {},
+		},
+		{
+			name: "no channels",
+			mutate: func(cfg *Config) {
+				cfg.Channels = nil
+			},
+			expectErr: true,
+		},
+		{
+			name: "step longer than duration",
+			mutate: func(cfg *Config) {
+				cfg.Step = cfg.Duration * 2
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid forward range",
+			mutate: func(cfg *Config) {
+				cfg.MinForward = cfg.MaxForward + 1
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid uptime",
+			mutate: func(cfg *Config) {
+				cfg.Channels[0].UptimeRatio = 2
+			},
+			expectErr: true,
+		},
+		{
+			name: "close before open",
+			mutate: func(cfg *Config) {
+				cfg.Channels[0].OpenAfter = time.Hour
+				cfg.Channels[0].CloseAfter = time.Minute
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			test.mutate(cfg)
+
+			err := validateConfig(cfg)
+			if test.expectErr != (err != nil) {
+				t.Fatalf("expected error: %v, got: %v",
+					test.expectErr, err)
+			}
+		})
+	}
+}
+
+// TestFixtureReplay tests that a saved fixture can be loaded and replayed
+// through the fake lnd client.
+func TestFixtureReplay(t *testing.T) {
+	fixture, _, err := Simulate(newTestConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dir, err := ioutil.TempDir("", "simulator")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "fixture.json")
+	if err := fixture.Save(path); err != nil {
+		t.Fatalf("could not save fixture: %v", err)
+	}
+
+	loaded, err := LoadFixture(path)
+	if err != nil {
+		t.Fatalf("could not load fixture: %v", err)
+	}
+
+	if !reflect.DeepEqual(fixture, loaded) {
+		t.Fatalf("loaded fixture does not match saved fixture")
+	}
+
+	if len(loaded.Client().CloseRequests()) != 0 {
+		t.Fatalf("expected no close requests")
+	}
+}
+
+// TestPoisson tests that our poisson values have the expected mean for small
+// and large means.
+func TestPoisson(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, mean := range []float64{0.5, 5, 100} {
+		var total int
+		for i := 0; i < 10000; i++ {
+			total += poisson(r, mean)
+		}
+
+		actual := float64(total) / 10000
+		if math.Abs(actual-mean) > mean*0.05 {
+			t.Fatalf("expected mean: %v, got: %v", mean, actual)
+		}
+	}
+}
+
+// TestForwardAmount tests that forward amounts lie within the configured
+// range.
+func TestForwardAmount(t *testing.T) {
+	sim := &simulation{
+		cfg: &Config{
+			MinForward: lnwire.NewMSatFromSatoshis(btcutil.Amount(1)),
+			MaxForward: lnwire.NewMSatFromSatoshis(btcutil.Amount(10)),
+		},
+		rand: rand.New(rand.NewSource(1)),
+	}
+
+	for i := 0; i < 1000; i++ {
+		amt := sim.forwardAmount()
+		if amt < sim.cfg.MinForward || amt > sim.cfg.MaxForward {
+			t.Fatalf("amount: %v outside of range", amt)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+// Package subscribe triggers updates for streaming subscriptions when events
+// that may change their results occur in lnd. Updates are triggered by
+// channel events (opens, closes and peers going online or offline), by new
+// forwards, and optionally at a fixed interval.
+//
+// lnd does not provide a subscription for forwarding events, so its
+// forwarding log is polled for new forwards at a configurable interval. Each
+// poll only queries for forwards since the previous poll, so the full
+// forwarding log is not read unless an update is triggered.
+//
+// Triggers that occur while an update is in progress are coalesced so that
+// bursts of events only result in a single update. Updates may be rate
+// limited with a minimum interval between event triggered updates, because
+// each update may read our full forwarding history.
+package subscribe
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// Reason describes the reason an update was triggered.
+type Reason int
+
+const (
+	// ReasonStart indicates that the update was triggered because the
+	// subscription started.
+	ReasonStart Reason = iota
+
+	// ReasonInterval indicates that the update was triggered because
+	// the subscription's update interval elapsed.
+	ReasonInterval
+
+	// ReasonChannelEvent indicates that the update was triggered by a
+	// channel open, close or a peer going online or offline.
+	ReasonChannelEvent
+
+	// ReasonForward indicates that the update was triggered by a new
+	// forward.
+	ReasonForward
+)
+
+// String returns the string representation of a reason.
+func (r Reason) String() string {
+	switch r {
+	case ReasonStart:
+		return "start"
+
+	case ReasonInterval:
+		return "interval"
+
+	case ReasonChannelEvent:
+		return "channel event"
+
+	case ReasonForward:
+		return "forward"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Config provides the functions and parameters required to trigger updates.
+type Config struct {
+	// ChannelEvents subscribes to lnd's channel events. It returns a
+	// function which blocks until the next event is received. The
+	// subscription should be cancelled when the context provided is
+	// cancelled. If this function is nil, channel events will not trigger
+	// updates.
+	ChannelEvents func(ctx context.Context) (
+		func() (*lnrpc.ChannelEventUpdate, error), error)
+
+	// HasForwards returns a boolean indicating whether any forwards have
+	// occurred since the time provided. If this function is nil, forwards
+	// will not trigger updates.
+	HasForwards func(since time.Time) (bool, error)
+
+	// ForwardPollInterval is the interval at which we poll for new
+	// forwards.
+	ForwardPollInterval time.Duration
+
+	// Interval is an optional interval at which updates are triggered
+	// regardless of whether any events have occurred. If it is zero, no
+	// interval updates are triggered. Interval updates are not rate
+	// limited, so callers should not use an interval that is shorter than
+	// MinUpdateInterval.
+	Interval time.Duration
+
+	// MinUpdateInterval is the minimum amount of time between an update
+	// and an update triggered by a channel event or forward. Events that
+	// occur within this period are coalesced into a single update at the
+	// end of the period. If it is zero, updates are not rate limited.
+	MinUpdateInterval time.Duration
+
+	// Now returns the current time.
+	Now func() time.Time
+}
+
+// Run calls the update function provided when the subscription starts, and
+// then each time an update is triggered. It blocks until the context provided
+// is cancelled, or the update function returns an error. The error that
+// caused the subscription to exit is returned.
+func Run(ctx context.Context, cfg *Config,
+	update func(reason Reason) error) error {
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// triggers is buffered so that we can coalesce triggers that occur
+	// while an update is in progress.
+	triggers := make(chan Reason, 1)
+	trigger := func(reason Reason) {
+		select {
+		case triggers <- reason:
+		default:
+		}
+	}
+
+	if cfg.ChannelEvents != nil {
+		recv, err := cfg.ChannelEvents(ctx)
+		if err != nil {
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			watchChannelEvents(ctx, recv, trigger)
+		}()
+	}
+
+	if cfg.HasForwards != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pollForwards(ctx, cfg, trigger)
+		}()
+	}
+
+	// If we have an update interval, create a ticker. Otherwise, we leave
+	// our tick channel nil so that it never fires.
+	var tick <-chan time.Time
+	if cfg.Interval != 0 {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	reason := ReasonStart
+	for {
+		log.Debugf("subscription update triggered by: %v", reason)
+
+		if err := update(reason); err != nil {
+			return err
+		}
+		lastUpdate := cfg.Now()
+
+		select {
+		case reason = <-triggers:
+			err := rateLimit(ctx, cfg, lastUpdate, triggers)
+			if err != nil {
+				return err
+			}
+
+		case <-tick:
+			reason = ReasonInterval
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// rateLimit waits until our minimum update interval has elapsed since the
+// last update, and then drops any triggers that occurred while we were
+// waiting, since they are covered by the update that we are about to make.
+func rateLimit(ctx context.Context, cfg *Config, lastUpdate time.Time,
+	triggers chan Reason) error {
+
+	wait := cfg.MinUpdateInterval - cfg.Now().Sub(lastUpdate)
+	if wait <= 0 {
+		return nil
+	}
+
+	log.Tracef("delaying subscription update by: %v", wait)
+
+	select {
+	case <-time.After(wait):
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-triggers:
+	default:
+	}
+
+	return nil
+}
+
+// watchChannelEvents triggers an update for each channel event received until
+// the subscription fails. If the subscription fails, we log the error and
+// continue to trigger updates for our other events.
+func watchChannelEvents(ctx context.Context,
+	recv func() (*lnrpc.ChannelEventUpdate, error), trigger func(Reason)) {
+
+	for {
+		event, err := recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Errorf("channel event subscription "+
+					"failed: %v", err)
+			}
+
+			return
+		}
+
+		log.Tracef("received channel event: %v", event.Type)
+		trigger(ReasonChannelEvent)
+	}
+}
+
+// pollForwards polls for new forwards at our poll interval, and triggers an
+// update if any new forwards are found.
+func pollForwards(ctx context.Context, cfg *Config, trigger func(Reason)) {
+	ticker := time.NewTicker(cfg.ForwardPollInterval)
+	defer ticker.Stop()
+
+	since := cfg.Now()
+	for {
+		select {
+		case <-ticker.C:
+
+		case <-ctx.Done():
+			return
+		}
+
+		// Take the current time before we query so that forwards
+		// that occur during our query are picked up by the next one.
+		now := cfg.Now()
+
+		found, err := cfg.HasForwards(since)
+		if err != nil {
+			log.Errorf("could not poll for forwards: %v", err)
+			continue
+		}
+
+		since = now
+
+		if found {
+			trigger(ReasonForward)
+		}
+	}
+}
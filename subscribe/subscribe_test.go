@@ -0,0 +1,201 @@
+package subscribe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// defaultTimeout is the amount of time we wait for updates in tests.
+const defaultTimeout = time.Second * 5
+
+// TestRun tests that updates are triggered on start, by channel events and by
+// new forwards, and that the subscription exits when its context is
+// cancelled.
+func TestRun(t *testing.T) {
+	var (
+		channelEvents = make(chan *lnrpc.ChannelEventUpdate)
+		forwards      = make(chan bool, 1)
+		updates       = make(chan Reason)
+		errChan       = make(chan error, 1)
+	)
+
+	cfg := &Config{
+		ChannelEvents: func(ctx context.Context) (
+			func() (*lnrpc.ChannelEventUpdate, error), error) {
+
+			return func() (*lnrpc.ChannelEventUpdate, error) {
+				select {
+				case event := <-channelEvents:
+					return event, nil
+
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}, nil
+		},
+		HasForwards: func(_ time.Time) (bool, error) {
+			select {
+			case found := <-forwards:
+				return found, nil
+
+			default:
+				return false, nil
+			}
+		},
+		ForwardPollInterval: time.Millisecond,
+		Now:                 time.Now,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		errChan <- Run(ctx, cfg, func(reason Reason) error {
+			updates <- reason
+			return nil
+		})
+	}()
+
+	// expectUpdate waits for an update with the reason provided.
+	expectUpdate := func(expected Reason) {
+		select {
+		case reason := <-updates:
+			if reason != expected {
+				t.Fatalf("expected: %v, got: %v", expected,
+					reason)
+			}
+
+		case <-time.After(defaultTimeout):
+			t.Fatalf("timeout waiting for update: %v", expected)
+		}
+	}
+
+	expectUpdate(ReasonStart)
+
+	channelEvents <- &lnrpc.ChannelEventUpdate{}
+	expectUpdate(ReasonChannelEvent)
+
+	forwards <- true
+	expectUpdate(ReasonForward)
+
+	cancel()
+
+	select {
+	case err := <-errChan:
+		if err != context.Canceled {
+			t.Fatalf("expected: %v, got: %v", context.Canceled,
+				err)
+		}
+
+	case <-time.After(defaultTimeout):
+		t.Fatalf("timeout waiting for exit")
+	}
+}
+
+// TestRunUpdateError tests that the subscription exits with the error
+// returned by its update function, and that interval updates are triggered.
+func TestRunUpdateError(t *testing.T) {
+	var (
+		testErr = errors.New("error thrown by mock")
+		updates []Reason
+	)
+
+	cfg := &Config{
+		Interval: time.Millisecond,
+		Now:      time.Now,
+	}
+
+	err := Run(context.Background(), cfg, func(reason Reason) error {
+		updates = append(updates, reason)
+
+		if len(updates) == 2 {
+			return testErr
+		}
+
+		return nil
+	})
+	if err != testErr {
+		t.Fatalf("expected: %v, got: %v", testErr, err)
+	}
+
+	expected := []Reason{ReasonStart, ReasonInterval}
+	for i, reason := range expected {
+		if updates[i] != reason {
+			t.Fatalf("expected: %v, got: %v", reason, updates[i])
+		}
+	}
+}
+
+// TestRunRateLimit tests that event triggered updates are delayed until our
+// minimum update interval has elapsed, and that events which occur during
+// that period are coalesced into a single update.
+func TestRunRateLimit(t *testing.T) {
+	var (
+		channelEvents = make(chan *lnrpc.ChannelEventUpdate)
+		updates       = make(chan time.Time)
+		minInterval   = time.Millisecond * 100
+	)
+
+	cfg := &Config{
+		ChannelEvents: func(ctx context.Context) (
+			func() (*lnrpc.ChannelEventUpdate, error), error) {
+
+			return func() (*lnrpc.ChannelEventUpdate, error) {
+				select {
+				case event := <-channelEvents:
+					return event, nil
+
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}, nil
+		},
+		MinUpdateInterval: minInterval,
+		Now:               time.Now,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = Run(ctx, cfg, func(_ Reason) error {
+			updates <- time.Now()
+			return nil
+		})
+	}()
+
+	var start time.Time
+	select {
+	case start = <-updates:
+	case <-time.After(defaultTimeout):
+		t.Fatalf("timeout waiting for start update")
+	}
+
+	// Send a burst of events, which should result in a single update once
+	// our minimum interval has elapsed.
+	for i := 0; i < 3; i++ {
+		channelEvents <- &lnrpc.ChannelEventUpdate{}
+	}
+
+	select {
+	case updated := <-updates:
+		if elapsed := updated.Sub(start); elapsed < minInterval {
+			t.Fatalf("update after: %v, expected at least: %v",
+				elapsed, minInterval)
+		}
+
+	case <-time.After(defaultTimeout):
+		t.Fatalf("timeout waiting for event update")
+	}
+
+	select {
+	case <-updates:
+		t.Fatalf("expected events to be coalesced")
+
+	case <-time.After(minInterval * 2):
+	}
+}
@@ -0,0 +1,174 @@
+package supervisor
+
+import (
+	"context"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc"
+)
+
+// client is a lnd client which makes calls over the supervisor's current
+// connection, failing with ErrLndUnavailable if lnd is not ready. It only
+// implements the calls that faraday makes, the embedded interface is nil so
+// any other calls will panic.
+type client struct {
+	lnrpc.LightningClient
+
+	s *Supervisor
+}
+
+// Client returns a lnd client which makes calls over our current connection
+// to lnd.
+func (s *Supervisor) Client() lnrpc.LightningClient {
+	return &client{s: s}
+}
+
+// GetInfo returns lnd's info.
+func (c *client) GetInfo(ctx context.Context, req *lnrpc.GetInfoRequest,
+	opts ...grpc.CallOption) (*lnrpc.GetInfoResponse, error) {
+
+	lnd, err := c.s.readyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lnd.GetInfo(ctx, req, opts...)
+	return resp, c.s.callErr(err)
+}
+
+// ListChannels returns lnd's open channels.
+func (c *client) ListChannels(ctx context.Context,
+	req *lnrpc.ListChannelsRequest, opts ...grpc.CallOption) (
+	*lnrpc.ListChannelsResponse, error) {
+
+	lnd, err := c.s.readyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lnd.ListChannels(ctx, req, opts...)
+	return resp, c.s.callErr(err)
+}
+
+// ClosedChannels returns lnd's closed channels.
+func (c *client) ClosedChannels(ctx context.Context,
+	req *lnrpc.ClosedChannelsRequest, opts ...grpc.CallOption) (
+	*lnrpc.ClosedChannelsResponse, error) {
+
+	lnd, err := c.s.readyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lnd.ClosedChannels(ctx, req, opts...)
+	return resp, c.s.callErr(err)
+}
+
+// PendingChannels returns lnd's pending channels.
+func (c *client) PendingChannels(ctx context.Context,
+	req *lnrpc.PendingChannelsRequest, opts ...grpc.CallOption) (
+	*lnrpc.PendingChannelsResponse, error) {
+
+	lnd, err := c.s.readyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lnd.PendingChannels(ctx, req, opts...)
+	return resp, c.s.callErr(err)
+}
+
+// ForwardingHistory returns a page of lnd's forwarding log.
+func (c *client) ForwardingHistory(ctx context.Context,
+	req *lnrpc.ForwardingHistoryRequest, opts ...grpc.CallOption) (
+	*lnrpc.ForwardingHistoryResponse, error) {
+
+	lnd, err := c.s.readyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lnd.ForwardingHistory(ctx, req, opts...)
+	return resp, c.s.callErr(err)
+}
+
+// ListPeers returns lnd's connected peers.
+func (c *client) ListPeers(ctx context.Context, req *lnrpc.ListPeersRequest,
+	opts ...grpc.CallOption) (*lnrpc.ListPeersResponse, error) {
+
+	lnd, err := c.s.readyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lnd.ListPeers(ctx, req, opts...)
+	return resp, c.s.callErr(err)
+}
+
+// GetChanInfo returns a channel from lnd's graph.
+func (c *client) GetChanInfo(ctx context.Context, req *lnrpc.ChanInfoRequest,
+	opts ...grpc.CallOption) (*lnrpc.ChannelEdge, error) {
+
+	lnd, err := c.s.readyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lnd.GetChanInfo(ctx, req, opts...)
+	return resp, c.s.callErr(err)
+}
+
+// GetNodeInfo returns a node from lnd's graph.
+func (c *client) GetNodeInfo(ctx context.Context, req *lnrpc.NodeInfoRequest,
+	opts ...grpc.CallOption) (*lnrpc.NodeInfo, error) {
+
+	lnd, err := c.s.readyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lnd.GetNodeInfo(ctx, req, opts...)
+	return resp, c.s.callErr(err)
+}
+
+// DescribeGraph returns lnd's graph.
+func (c *client) DescribeGraph(ctx context.Context,
+	req *lnrpc.ChannelGraphRequest, opts ...grpc.CallOption) (
+	*lnrpc.ChannelGraph, error) {
+
+	lnd, err := c.s.readyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lnd.DescribeGraph(ctx, req, opts...)
+	return resp, c.s.callErr(err)
+}
+
+// CloseChannel closes a channel with lnd.
+func (c *client) CloseChannel(ctx context.Context,
+	req *lnrpc.CloseChannelRequest, opts ...grpc.CallOption) (
+	lnrpc.Lightning_CloseChannelClient, error) {
+
+	lnd, err := c.s.readyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := lnd.CloseChannel(ctx, req, opts...)
+	return stream, c.s.callErr(err)
+}
+
+// SubscribeChannelEvents subscribes to lnd's channel events.
+func (c *client) SubscribeChannelEvents(ctx context.Context,
+	req *lnrpc.ChannelEventSubscription, opts ...grpc.CallOption) (
+	lnrpc.Lightning_SubscribeChannelEventsClient, error) {
+
+	lnd, err := c.s.readyClient()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := lnd.SubscribeChannelEvents(ctx, req, opts...)
+	return stream, c.s.callErr(err)
+}
@@ -0,0 +1,361 @@
+// Package supervisor manages faraday's connection to lnd. It checks lnd's
+// state with GetInfo at a regular interval, waits for lnd to be unlocked and
+// synced to chain, and reconnects with backoff when lnd cannot be reached.
+//
+// Calls are made through a client which fails with ErrLndUnavailable while
+// lnd is not ready, so that callers receive a clear error rather than a
+// transport error when lnd is down.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrLndUnavailable is returned for calls made while lnd is not ready.
+var ErrLndUnavailable = errors.New("lnd unavailable")
+
+// State is the state of our connection to lnd.
+type State int
+
+const (
+	// StateConnecting indicates that we have not yet checked lnd's state.
+	StateConnecting State = iota
+
+	// StateDisconnected indicates that we could not reach lnd, and are
+	// reconnecting.
+	StateDisconnected
+
+	// StateWaitingUnlock indicates that lnd is running, but its wallet is
+	// locked.
+	StateWaitingUnlock
+
+	// StateWaitingSync indicates that lnd is unlocked, but is not yet
+	// synced to chain.
+	StateWaitingSync
+
+	// StateReady indicates that lnd is unlocked and synced, and calls can
+	// be made.
+	StateReady
+)
+
+// String returns a string representation of a state.
+func (s State) String() string {
+	switch s {
+	case StateConnecting:
+		return "connecting"
+
+	case StateDisconnected:
+		return "disconnected"
+
+	case StateWaitingUnlock:
+		return "waiting for wallet unlock"
+
+	case StateWaitingSync:
+		return "waiting for chain sync"
+
+	case StateReady:
+		return "ready"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Config provides the functions and parameters required to supervise a lnd
+// connection.
+type Config struct {
+	// Name is the name of the node that we are connecting to, used for
+	// logging.
+	Name string
+
+	// Connect creates a new connection to lnd, returning a client and a
+	// function which closes the connection.
+	Connect func() (lnrpc.LightningClient, func(), error)
+
+	// CheckInterval is the interval at which we check lnd's state while
+	// we are connected.
+	CheckInterval time.Duration
+
+	// CheckTimeout is the amount of time we allow for each check.
+	CheckTimeout time.Duration
+
+	// MinBackoff is the time we wait before our first reconnect attempt
+	// after lnd cannot be reached. It is doubled for each failed attempt.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum time we wait between reconnect attempts.
+	MaxBackoff time.Duration
+
+	// Now returns the current time.
+	Now func() time.Time
+}
+
+// Status describes the state of our connection to lnd.
+type Status struct {
+	// State is the current state of our connection.
+	State State
+
+	// Since is the time that we entered our current state.
+	Since time.Time
+
+	// LastCheck is the time that we last checked lnd's state.
+	LastCheck time.Time
+
+	// Err is the error that caused us to enter our current state, if
+	// any.
+	Err error
+
+	// ConnectAttempts is the number of times we have connected to lnd.
+	ConnectAttempts int
+
+	// Info is the response to the last successful GetInfo call, which is
+	// nil if lnd has never been reached.
+	Info *lnrpc.GetInfoResponse
+}
+
+// Supervisor maintains a connection to lnd.
+type Supervisor struct {
+	cfg *Config
+
+	// mu protects our client and status.
+	mu        sync.RWMutex
+	client    lnrpc.LightningClient
+	closeConn func()
+	status    Status
+
+	// recheck is used to trigger an immediate check of lnd's state.
+	recheck chan struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewSupervisor creates a new supervisor. Note that the supervisor returned
+// does not connect to lnd until it is started using Start().
+func NewSupervisor(cfg *Config) *Supervisor {
+	return &Supervisor{
+		cfg: cfg,
+		status: Status{
+			State: StateConnecting,
+			Since: cfg.Now(),
+		},
+		recheck: make(chan struct{}, 1),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Start starts supervising our lnd connection.
+func (s *Supervisor) Start() {
+	log.Infof("Starting lnd supervisor for node: %v, checking every: %v",
+		s.cfg.Name, s.cfg.CheckInterval)
+
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop stops the supervisor, waits for it to exit and closes our connection
+// to lnd.
+func (s *Supervisor) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+
+	s.disconnect()
+}
+
+// Status returns the current status of our lnd connection.
+func (s *Supervisor) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.status
+}
+
+// run checks lnd's state on startup, and then at our check interval while
+// lnd can be reached, or with backoff while it cannot.
+func (s *Supervisor) run() {
+	defer s.wg.Done()
+
+	backoff := s.cfg.MinBackoff
+
+	for {
+		wait := s.cfg.CheckInterval
+		if s.check() {
+			backoff = s.cfg.MinBackoff
+		} else {
+			wait = backoff
+
+			backoff *= 2
+			if backoff > s.cfg.MaxBackoff {
+				backoff = s.cfg.MaxBackoff
+			}
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-timer.C:
+
+		case <-s.recheck:
+			timer.Stop()
+
+		case <-s.quit:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// check connects to lnd if we are not connected, and updates our state based
+// on lnd's response to GetInfo. It returns false if lnd could not be
+// reached.
+func (s *Supervisor) check() bool {
+	s.mu.RLock()
+	client := s.client
+	s.mu.RUnlock()
+
+	if client == nil {
+		var err error
+		client, err = s.connect()
+		if err != nil {
+			s.setState(StateDisconnected, err, nil)
+			return false
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), s.cfg.CheckTimeout,
+	)
+	defer cancel()
+
+	info, err := client.GetInfo(ctx, &lnrpc.GetInfoRequest{})
+	switch {
+	// If lnd's wallet is locked, only its wallet unlocker service is
+	// running, so calls to the lightning service are unimplemented.
+	case status.Code(err) == codes.Unimplemented:
+		s.setState(StateWaitingUnlock, nil, nil)
+
+	// For any other error, we assume that lnd cannot be reached and
+	// create a new connection on our next attempt.
+	case err != nil:
+		s.disconnect()
+		s.setState(StateDisconnected, err, nil)
+
+		return false
+
+	case !info.SyncedToChain:
+		s.setState(StateWaitingSync, nil, info)
+
+	default:
+		s.setState(StateReady, nil, info)
+	}
+
+	return true
+}
+
+// connect creates a new connection to lnd.
+func (s *Supervisor) connect() (lnrpc.LightningClient, error) {
+	s.mu.Lock()
+	s.status.ConnectAttempts++
+	s.mu.Unlock()
+
+	client, closeConn, err := s.cfg.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.client = client
+	s.closeConn = closeConn
+	s.mu.Unlock()
+
+	return client, nil
+}
+
+// disconnect closes our current connection to lnd, if we have one.
+func (s *Supervisor) disconnect() {
+	s.mu.Lock()
+	closeConn := s.closeConn
+	s.client = nil
+	s.closeConn = nil
+	s.mu.Unlock()
+
+	if closeConn != nil {
+		closeConn()
+	}
+}
+
+// setState records the outcome of a check, logging if our state changed.
+func (s *Supervisor) setState(state State, err error,
+	info *lnrpc.GetInfoResponse) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.cfg.Now()
+	s.status.LastCheck = now
+	s.status.Err = err
+
+	if info != nil {
+		s.status.Info = info
+	}
+
+	if s.status.State == state {
+		return
+	}
+
+	if err != nil {
+		log.Warnf("Node %v: lnd %v: %v", s.cfg.Name, state, err)
+	} else {
+		log.Infof("Node %v: lnd %v", s.cfg.Name, state)
+	}
+
+	s.status.State = state
+	s.status.Since = now
+}
+
+// triggerCheck requests an immediate check of lnd's state, if one is not
+// already pending.
+func (s *Supervisor) triggerCheck() {
+	select {
+	case s.recheck <- struct{}{}:
+	default:
+	}
+}
+
+// readyClient returns our lnd client if lnd is ready, or an error describing
+// its state if it is not.
+func (s *Supervisor) readyClient() (lnrpc.LightningClient, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.status.State == StateReady && s.client != nil {
+		return s.client, nil
+	}
+
+	if s.status.Err != nil {
+		return nil, fmt.Errorf("%w: %v: %v", ErrLndUnavailable,
+			s.status.State, s.status.Err)
+	}
+
+	return nil, fmt.Errorf("%w: %v", ErrLndUnavailable, s.status.State)
+}
+
+// callErr checks the error returned by a call to lnd. If lnd could not be
+// reached, we trigger a check of its state and return an unavailable error.
+func (s *Supervisor) callErr(err error) error {
+	if status.Code(err) != codes.Unavailable {
+		return err
+	}
+
+	s.triggerCheck()
+
+	return fmt.Errorf("%w: %v", ErrLndUnavailable, err)
+}
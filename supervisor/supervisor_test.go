@@ -0,0 +1,149 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/faraday/fakelnd"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// waitForState waits for the supervisor to reach the state provided.
+func waitForState(t *testing.T, s *Supervisor, state State) Status {
+	t.Helper()
+
+	deadline := time.After(time.Second * 5)
+	for {
+		current := s.Status()
+		if current.State == state {
+			return current
+		}
+
+		select {
+		case <-deadline:
+			t.Fatalf("expected state: %v, got: %v (%v)", state,
+				current.State, current.Err)
+
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+// TestSupervisor tests that the supervisor tracks lnd as it is unlocked,
+// syncs, becomes unreachable and recovers, and that calls fail with a clear
+// error while lnd is not ready.
+func TestSupervisor(t *testing.T) {
+	lnd := fakelnd.NewClient()
+	lnd.SetError("GetInfo", status.Error(codes.Unimplemented, "locked"))
+
+	connects := make(chan struct{}, 100)
+	s := NewSupervisor(&Config{
+		Name: "test",
+		Connect: func() (lnrpc.LightningClient, func(), error) {
+			connects <- struct{}{}
+			return lnd, func() {}, nil
+		},
+		CheckInterval: time.Millisecond * 10,
+		CheckTimeout:  time.Second,
+		MinBackoff:    time.Millisecond * 5,
+		MaxBackoff:    time.Millisecond * 20,
+		Now:           time.Now,
+	})
+	s.Start()
+	defer s.Stop()
+
+	client := s.Client()
+	ctx := context.Background()
+
+	// While lnd is locked, calls should fail with an unavailable error.
+	waitForState(t, s, StateWaitingUnlock)
+
+	_, err := client.ListChannels(ctx, &lnrpc.ListChannelsRequest{})
+	if !errors.Is(err, ErrLndUnavailable) {
+		t.Fatalf("expected: %v, got: %v", ErrLndUnavailable, err)
+	}
+
+	// Once lnd is unlocked, we wait for it to sync.
+	lnd.SetInfo(&lnrpc.GetInfoResponse{})
+	lnd.SetError("GetInfo", nil)
+	waitForState(t, s, StateWaitingSync)
+
+	lnd.SetInfo(&lnrpc.GetInfoResponse{SyncedToChain: true})
+	waitForState(t, s, StateReady)
+
+	if _, err := client.ListChannels(
+		ctx, &lnrpc.ListChannelsRequest{},
+	); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Calls that fail because lnd cannot be reached should return an
+	// unavailable error.
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	lnd.SetError("ListChannels", unavailable)
+
+	_, err = client.ListChannels(ctx, &lnrpc.ListChannelsRequest{})
+	if !errors.Is(err, ErrLndUnavailable) {
+		t.Fatalf("expected: %v, got: %v", ErrLndUnavailable, err)
+	}
+
+	// When lnd cannot be reached, we should disconnect and reconnect.
+	lnd.SetError("GetInfo", unavailable)
+	waitForState(t, s, StateDisconnected)
+
+	lnd.SetError("GetInfo", nil)
+	lnd.SetError("ListChannels", nil)
+	current := waitForState(t, s, StateReady)
+
+	if current.ConnectAttempts < 2 {
+		t.Fatalf("expected reconnect, got %v connect attempts",
+			current.ConnectAttempts)
+	}
+
+	if len(connects) != current.ConnectAttempts {
+		t.Fatalf("expected %v connects, got: %v",
+			current.ConnectAttempts, len(connects))
+	}
+}
+
+// TestConnectFailure tests that failures to connect are retried.
+func TestConnectFailure(t *testing.T) {
+	lnd := fakelnd.NewClient()
+	lnd.SetInfo(&lnrpc.GetInfoResponse{SyncedToChain: true})
+
+	var attempts int
+	s := NewSupervisor(&Config{
+		Name: "test",
+		Connect: func() (lnrpc.LightningClient, func(), error) {
+			attempts++
+			if attempts < 3 {
+				return nil, nil, errors.New("no cert")
+			}
+
+			return lnd, func() {}, nil
+		},
+		CheckInterval: time.Millisecond * 10,
+		CheckTimeout:  time.Second,
+		MinBackoff:    time.Millisecond,
+		MaxBackoff:    time.Millisecond * 2,
+		Now:           time.Now,
+	})
+
+	// Check our state before we start.
+	if s.Status().State != StateConnecting {
+		t.Fatalf("expected connecting state")
+	}
+
+	s.Start()
+	defer s.Stop()
+
+	current := waitForState(t, s, StateReady)
+	if current.ConnectAttempts != 3 {
+		t.Fatalf("expected 3 connect attempts, got: %v",
+			current.ConnectAttempts)
+	}
+}
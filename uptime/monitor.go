@@ -0,0 +1,153 @@
+// Package uptime tracks the online status of our channels' peers, and
+// persists it so that uptime history is not lost when lnd restarts. lnd only
+// tracks peer uptime in memory, so its lifetime and uptime values reset each
+// time it is restarted.
+//
+// Peer status is sampled by polling lnd at a regular interval. Each channel's
+// history is recorded separately so that uptime is only tracked for the
+// period that we have had a channel with the peer. Each sample also records
+// whether the channel's balance is one-sided, so that channels which hold
+// capital that cannot be used in one direction can be identified.
+package uptime
+
+import (
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/faraday/insights"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// DefaultOneSidedThreshold is the default proportion of a channel's balance
+// that may be on the less funded side for its balance to be considered
+// one-sided.
+const DefaultOneSidedThreshold = 0.05
+
+// MonitorConfig provides the functions and parameters required to monitor
+// peer uptime.
+type MonitorConfig struct {
+	// OpenChannels is a function which returns all of our currently open,
+	// public and private channels.
+	OpenChannels func() ([]*lnrpc.Channel, error)
+
+	// OnlinePeers is a function which returns the pubkeys of all of the
+	// peers that we are currently connected to.
+	OnlinePeers func() ([]string, error)
+
+	// Store is the store that peer status is recorded in.
+	Store *Store
+
+	// PollInterval is the interval at which we sample peer status.
+	PollInterval time.Duration
+
+	// OneSidedThreshold is the proportion of a channel's balance that may
+	// be on the less funded side for its balance to be considered
+	// one-sided.
+	OneSidedThreshold float64
+
+	// Now returns the current time.
+	Now func() time.Time
+}
+
+// Monitor periodically samples the online status of our channels' peers and
+// records it in a store.
+type Monitor struct {
+	cfg *MonitorConfig
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewMonitor creates a new uptime monitor. Note that the monitor returned is
+// not running, and should be started using Start().
+func NewMonitor(cfg *MonitorConfig) *Monitor {
+	return &Monitor{
+		cfg:  cfg,
+		quit: make(chan struct{}),
+	}
+}
+
+// Start starts sampling peer status.
+func (m *Monitor) Start() {
+	log.Infof("Starting uptime monitor, polling every: %v",
+		m.cfg.PollInterval)
+
+	m.wg.Add(1)
+	go m.run()
+}
+
+// Stop stops the monitor and waits for it to exit.
+func (m *Monitor) Stop() {
+	close(m.quit)
+	m.wg.Wait()
+}
+
+// run samples peer status once on startup, and then at each poll interval
+// until the monitor is stopped.
+func (m *Monitor) run() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// We log errors rather than exiting, because lnd may be
+		// temporarily unavailable.
+		if err := m.sample(); err != nil {
+			log.Errorf("could not sample peer uptime: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// sample records the current online status of each of our channels' peers,
+// and whether each channel's balance is one-sided.
+func (m *Monitor) sample() error {
+	channels, err := m.cfg.OpenChannels()
+	if err != nil {
+		return err
+	}
+
+	peers, err := m.cfg.OnlinePeers()
+	if err != nil {
+		return err
+	}
+
+	online := make(map[string]bool, len(peers))
+	for _, peer := range peers {
+		online[peer] = true
+	}
+
+	observations := make([]*Observation, 0, len(channels))
+	for _, channel := range channels {
+		ratio := insights.BalanceRatio(
+			btcutil.Amount(channel.LocalBalance),
+			btcutil.Amount(channel.RemoteBalance),
+		)
+
+		observations = append(observations, &Observation{
+			ChannelPoint: channel.ChannelPoint,
+			Online:       online[channel.RemotePubkey],
+			OneSided: insights.IsOneSided(
+				ratio, m.cfg.OneSidedThreshold,
+			),
+		})
+	}
+
+	err = m.cfg.Store.RecordObservations(observations, m.cfg.Now())
+	if err != nil {
+		return err
+	}
+
+	log.Tracef("recorded uptime for %v channels, %v peers online",
+		len(channels), len(peers))
+
+	return nil
+}
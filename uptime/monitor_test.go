@@ -0,0 +1,109 @@
+package uptime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// TestMonitorSample tests that each sample records the status of our
+// channels' peers.
+func TestMonitorSample(t *testing.T) {
+	store, cleanup := newTestStore(t, time.Hour)
+	defer cleanup()
+
+	var (
+		now = time.Unix(100000, 0)
+
+		channels = []*lnrpc.Channel{
+			{
+				ChannelPoint:  "a:1",
+				RemotePubkey:  "online",
+				LocalBalance:  500,
+				RemoteBalance: 500,
+			},
+			{
+				ChannelPoint:  "a:2",
+				RemotePubkey:  "offline",
+				LocalBalance:  990,
+				RemoteBalance: 10,
+			},
+		}
+	)
+
+	monitor := NewMonitor(&MonitorConfig{
+		OpenChannels: func() ([]*lnrpc.Channel, error) {
+			return channels, nil
+		},
+		OnlinePeers: func() ([]string, error) {
+			return []string{"online"}, nil
+		},
+		Store:             store,
+		OneSidedThreshold: DefaultOneSidedThreshold,
+		Now: func() time.Time {
+			return now
+		},
+	})
+
+	// Take two samples, a minute apart.
+	if err := monitor.sample(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := monitor.sample(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		chanPoint string
+		uptime    time.Duration
+		oneSided  time.Duration
+	}{
+		{
+			chanPoint: "a:1",
+			uptime:    time.Minute,
+			oneSided:  0,
+		},
+		{
+			chanPoint: "a:2",
+			uptime:    0,
+			oneSided:  time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		monitored, uptime, err := store.ChannelUptime(test.chanPoint)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if monitored != time.Minute {
+			t.Fatalf("%v: expected monitored: %v, got: %v",
+				test.chanPoint, time.Minute, monitored)
+		}
+
+		if uptime != test.uptime {
+			t.Fatalf("%v: expected uptime: %v, got: %v",
+				test.chanPoint, test.uptime, uptime)
+		}
+
+		monitored, oneSided, err := store.ChannelBalance(
+			test.chanPoint,
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if monitored != time.Minute {
+			t.Fatalf("%v: expected balance monitored: %v, got: %v",
+				test.chanPoint, time.Minute, monitored)
+		}
+
+		if oneSided != test.oneSided {
+			t.Fatalf("%v: expected one-sided: %v, got: %v",
+				test.chanPoint, test.oneSided, oneSided)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package uptime
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+)
+
+const (
+	// dbFilePermission is the permission that the uptime database file is
+	// created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the amount of time we wait to obtain a lock on the
+	// database file before failing.
+	dbTimeout = time.Second * 5
+
+	// runLength is the length of a serialized run value: an 8 byte end
+	// timestamp followed by a single byte status flag.
+	runLength = 9
+)
+
+var (
+	// uptimeBucket is the top level bucket in our database, which holds a
+	// sub-bucket of runs for each channel.
+	uptimeBucket = []byte("channel-uptime")
+
+	// balanceBucket is the top level bucket which holds a sub-bucket of
+	// runs for each channel recording whether the channel's balance was
+	// one-sided.
+	balanceBucket = []byte("channel-balance")
+
+	// errInvalidRun is returned when a run stored in the database cannot
+	// be deserialized.
+	errInvalidRun = errors.New("invalid uptime run")
+)
+
+// Store persists the online status of our channels' peers, and whether our
+// channels' balances were one-sided, over time. Each channel's history is
+// stored as a set of runs, where each run is a period during which the
+// status was observed to be the same. Periods where
+// we were not observing the peer are not included in any run, so that time
+// that faraday was not running is not counted as downtime.
+//
+// Runs are keyed by their start time, and store their end time and status.
+type Store struct {
+	db *bolt.DB
+
+	// maxGap is the maximum amount of time between two observations of a
+	// peer for us to consider it monitored for the time between them.
+	maxGap time.Duration
+}
+
+// NewStore opens the uptime database at the path provided, creating it if it
+// does not exist. It takes the maximum amount of time that may elapse between
+// observations of a peer for the period between them to be considered
+// monitored.
+func NewStore(path string, maxGap time.Duration) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(path, dbFilePermission, &bolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(uptimeBucket)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateBucketIfNotExists(balanceBucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{
+		db:     db,
+		maxGap: maxGap,
+	}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Observation is a single observation of a channel, taken when we poll lnd.
+type Observation struct {
+	// ChannelPoint is the outpoint of the channel observed.
+	ChannelPoint string
+
+	// Online indicates whether the channel's peer was online.
+	Online bool
+
+	// OneSided indicates whether the channel's balance was one-sided.
+	OneSided bool
+}
+
+// RecordObservations records a set of observations taken at the time
+// provided, recording each channel's peer status and balance in the same way
+// as RecordStatus and RecordBalance. All of the observations are written in
+// a single transaction, so that a poll is either recorded in full or not at
+// all.
+func (s *Store) RecordObservations(observations []*Observation,
+	ts time.Time) error {
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, observation := range observations {
+			err := s.recordRun(
+				tx, uptimeBucket, observation.ChannelPoint,
+				observation.Online, ts,
+			)
+			if err != nil {
+				return err
+			}
+
+			err = s.recordRun(
+				tx, balanceBucket, observation.ChannelPoint,
+				observation.OneSided, ts,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// RecordStatus records an observation of the online status of a channel's
+// peer at the time provided. If the last observation for the channel was
+// within our maximum gap, the period between the observations is considered
+// monitored, and attributed to the status observed now. Otherwise, a new run
+// is started at the time provided.
+func (s *Store) RecordStatus(chanPoint string, online bool,
+	ts time.Time) error {
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return s.recordRun(tx, uptimeBucket, chanPoint, online, ts)
+	})
+}
+
+// RecordBalance records an observation of whether a channel's balance was
+// one-sided at the time provided. Observations are recorded in the same way
+// as peer status.
+func (s *Store) RecordBalance(chanPoint string, oneSided bool,
+	ts time.Time) error {
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return s.recordRun(tx, balanceBucket, chanPoint, oneSided, ts)
+	})
+}
+
+// ChannelUptime returns the total amount of time that a channel's peer has
+// been monitored for, and the amount of time it was online for. If we have
+// no records for the channel, zero durations are returned.
+func (s *Store) ChannelUptime(chanPoint string) (time.Duration,
+	time.Duration, error) {
+
+	return s.channelRuns(uptimeBucket, chanPoint)
+}
+
+// PeerOffline returns the amount of time that a channel's peer has been
+// offline for in our most recent run of observations. If the peer was online
+// when we last observed it, or we have no records for the channel, zero is
+// returned.
+func (s *Store) PeerOffline(chanPoint string) (time.Duration, error) {
+	var offline time.Duration
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		channel := tx.Bucket(uptimeBucket).Bucket([]byte(chanPoint))
+		if channel == nil {
+			return nil
+		}
+
+		lastKey, lastValue := channel.Cursor().Last()
+		if lastKey == nil {
+			return nil
+		}
+
+		lastEnd, online, err := deserializeRun(lastValue)
+		if err != nil {
+			return err
+		}
+
+		if !online {
+			offline = lastEnd.Sub(deserializeTime(lastKey))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return offline, nil
+}
+
+// ChannelBalance returns the total amount of time that a channel's balance
+// has been monitored for, and the amount of time it was one-sided for. If we
+// have no records for the channel, zero durations are returned.
+func (s *Store) ChannelBalance(chanPoint string) (time.Duration,
+	time.Duration, error) {
+
+	return s.channelRuns(balanceBucket, chanPoint)
+}
+
+// recordRun records an observation of a channel's status in the top level
+// bucket provided, using the transaction provided.
+func (s *Store) recordRun(tx *bolt.Tx, bucket []byte, chanPoint string,
+	status bool, ts time.Time) error {
+
+	channel, err := tx.Bucket(bucket).CreateBucketIfNotExists(
+		[]byte(chanPoint),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Get the most recent run for the channel, if there is one.
+	lastKey, lastValue := channel.Cursor().Last()
+
+	// If there are no runs for this channel, we start a new run at the
+	// time provided.
+	if lastKey == nil {
+		return putRun(channel, ts, ts, status)
+	}
+
+	lastStart := deserializeTime(lastKey)
+	lastEnd, lastStatus, err := deserializeRun(lastValue)
+	if err != nil {
+		return err
+	}
+
+	// Ignore observations that are older than our current run, which
+	// could happen if the system clock has moved backwards.
+	if ts.Before(lastEnd) {
+		log.Debugf("ignoring out of order observation for channel: %v",
+			chanPoint)
+
+		return nil
+	}
+
+	// If we have not observed the channel for longer than our maximum
+	// gap, we start a new run.
+	if ts.Sub(lastEnd) > s.maxGap {
+		return putRun(channel, ts, ts, status)
+	}
+
+	// If the status has not changed, we just extend the current run to
+	// the time provided.
+	if lastStatus == status {
+		return putRun(channel, lastStart, ts, status)
+	}
+
+	// Otherwise, the status changed some time since our last observation,
+	// so we start a new run from the end of our last one.
+	return putRun(channel, lastEnd, ts, status)
+}
+
+// channelRuns returns the total amount of time covered by a channel's runs in
+// the top level bucket provided, and the amount of that time that its status
+// was true.
+func (s *Store) channelRuns(bucket []byte, chanPoint string) (time.Duration,
+	time.Duration, error) {
+
+	var monitored, active time.Duration
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		channel := tx.Bucket(bucket).Bucket([]byte(chanPoint))
+		if channel == nil {
+			return nil
+		}
+
+		return channel.ForEach(func(k, v []byte) error {
+			start := deserializeTime(k)
+			end, status, err := deserializeRun(v)
+			if err != nil {
+				return err
+			}
+
+			period := end.Sub(start)
+			monitored += period
+			if status {
+				active += period
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return monitored, active, nil
+}
+
+// putRun writes a run to the bucket provided.
+func putRun(bucket *bolt.Bucket, start, end time.Time, status bool) error {
+	value := make([]byte, runLength)
+	binary.BigEndian.PutUint64(value[:8], uint64(end.UnixNano()))
+	if status {
+		value[8] = 1
+	}
+
+	return bucket.Put(serializeTime(start), value)
+}
+
+// deserializeRun reads the end time and status of a run.
+func deserializeRun(value []byte) (time.Time, bool, error) {
+	if len(value) != runLength {
+		return time.Time{}, false, errInvalidRun
+	}
+
+	return deserializeTime(value[:8]), value[8] == 1, nil
+}
+
+// serializeTime serializes a time as big endian unix nanoseconds, so that
+// keys are sorted by time.
+func serializeTime(t time.Time) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(t.UnixNano()))
+	return b
+}
+
+// deserializeTime reads a time that was serialized with serializeTime.
+func deserializeTime(b []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b)))
+}
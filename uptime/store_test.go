@@ -0,0 +1,213 @@
+package uptime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestStore creates a store in a temporary directory, and returns a
+// cleanup function which closes it and removes the directory.
+func newTestStore(t *testing.T, maxGap time.Duration) (*Store, func()) {
+	dir, err := ioutil.TempDir("", "uptime")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %v", err)
+	}
+
+	store, err := NewStore(filepath.Join(dir, "uptime.db"), maxGap)
+	if err != nil {
+		t.Fatalf("could not create store: %v", err)
+	}
+
+	return store, func() {
+		if err := store.Close(); err != nil {
+			t.Fatalf("could not close store: %v", err)
+		}
+
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatalf("could not remove temp dir: %v", err)
+		}
+	}
+}
+
+// observation is a single observation of a peer's status.
+type observation struct {
+	online bool
+	offset time.Duration
+}
+
+// TestStore tests recording of peer status and calculation of uptime.
+func TestStore(t *testing.T) {
+	var (
+		start  = time.Unix(100000, 0)
+		maxGap = time.Minute * 5
+	)
+
+	tests := []struct {
+		name              string
+		observations      []observation
+		expectedMonitored time.Duration
+		expectedUptime    time.Duration
+		expectedOffline   time.Duration
+	}{
+		{
+			name: "no observations",
+		},
+		{
+			name: "single observation",
+			observations: []observation{
+				{online: true, offset: 0},
+			},
+		},
+		{
+			name: "online run",
+			observations: []observation{
+				{online: true, offset: 0},
+				{online: true, offset: time.Minute},
+				{online: true, offset: time.Minute * 2},
+			},
+			expectedMonitored: time.Minute * 2,
+			expectedUptime:    time.Minute * 2,
+		},
+		{
+			name: "status changes",
+			observations: []observation{
+				{online: true, offset: 0},
+				{online: true, offset: time.Minute},
+				{online: false, offset: time.Minute * 2},
+				{online: false, offset: time.Minute * 3},
+				{online: true, offset: time.Minute * 4},
+			},
+			expectedMonitored: time.Minute * 4,
+			expectedUptime:    time.Minute * 2,
+		},
+		{
+			name: "gap not monitored",
+			observations: []observation{
+				{online: true, offset: 0},
+				{online: true, offset: time.Minute},
+				{online: true, offset: time.Hour},
+				{online: false, offset: time.Hour + time.Minute},
+			},
+			expectedMonitored: time.Minute * 2,
+			expectedUptime:    time.Minute,
+			expectedOffline:   time.Minute,
+		},
+		{
+			name: "offline run",
+			observations: []observation{
+				{online: true, offset: 0},
+				{online: false, offset: time.Minute},
+				{online: false, offset: time.Minute * 5},
+			},
+			expectedMonitored: time.Minute * 5,
+			expectedOffline:   time.Minute * 5,
+		},
+		{
+			name: "out of order observation ignored",
+			observations: []observation{
+				{online: true, offset: time.Minute},
+				{online: false, offset: 0},
+				{online: true, offset: time.Minute * 2},
+			},
+			expectedMonitored: time.Minute,
+			expectedUptime:    time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanup := newTestStore(t, maxGap)
+			defer cleanup()
+
+			for _, o := range test.observations {
+				err := store.RecordStatus(
+					"a:1", o.online, start.Add(o.offset),
+				)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			monitored, uptime, err := store.ChannelUptime("a:1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// We have not recorded any balance observations, so we
+			// expect no balance history.
+			balanceMonitored, _, err := store.ChannelBalance("a:1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if balanceMonitored != 0 {
+				t.Fatalf("expected no balance history, got: %v",
+					balanceMonitored)
+			}
+
+			if monitored != test.expectedMonitored {
+				t.Fatalf("expected monitored: %v, got: %v",
+					test.expectedMonitored, monitored)
+			}
+
+			if uptime != test.expectedUptime {
+				t.Fatalf("expected uptime: %v, got: %v",
+					test.expectedUptime, uptime)
+			}
+
+			offline, err := store.PeerOffline("a:1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if offline != test.expectedOffline {
+				t.Fatalf("expected offline: %v, got: %v",
+					test.expectedOffline, offline)
+			}
+		})
+	}
+}
+
+// TestRecordObservations tests that a set of observations is recorded in a
+// single transaction, so that none of them are recorded if one fails.
+func TestRecordObservations(t *testing.T) {
+	store, cleanup := newTestStore(t, time.Hour)
+	defer cleanup()
+
+	ts := time.Unix(100000, 0)
+
+	err := store.RecordObservations([]*Observation{
+		{ChannelPoint: "a:1", Online: true},
+	}, ts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// An empty channel point cannot be used as a bucket name, so the
+	// second observation in this set fails.
+	err = store.RecordObservations([]*Observation{
+		{ChannelPoint: "a:1", Online: true},
+		{ChannelPoint: ""},
+	}, ts.Add(time.Minute))
+	if err == nil {
+		t.Fatalf("expected error for empty channel point")
+	}
+
+	// If our first channel's second observation had been recorded, it
+	// would have been monitored for a minute.
+	monitored, _, err := store.ChannelUptime("a:1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if monitored != 0 {
+		t.Fatalf("expected no monitored time, got: %v", monitored)
+	}
+}
@@ -32,3 +32,14 @@ func GetOutPointFromString(chanStr string) (*wire.OutPoint, error) {
 		Index: uint32(index),
 	}, nil
 }
+
+// TxidString returns the string representation of the raw txid bytes
+// provided.
+func TxidString(txid []byte) (string, error) {
+	hash, err := chainhash.NewHash(txid)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.String(), nil
+}
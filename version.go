@@ -32,8 +32,16 @@ const (
 )
 
 // Version returns the application version as a properly formed string per the
-// semantic versioning 2.0.0 spec (http://semver.org/).
+// semantic versioning 2.0.0 spec (http://semver.org/), followed by the commit
+// hash of the build.
 func Version() string {
+	// Append commit hash of current build to version.
+	return fmt.Sprintf("%s commit=%s", SemanticVersion(), Commit)
+}
+
+// SemanticVersion returns the application version as a properly formed string
+// per the semantic versioning 2.0.0 spec (http://semver.org/).
+func SemanticVersion() string {
 	// Start with the major, minor, and patch versions.
 	version := fmt.Sprintf("%d.%d.%d", appMajor, appMinor, appPatch)
 
@@ -46,12 +54,38 @@ func Version() string {
 		version = fmt.Sprintf("%s-%s", version, preRelease)
 	}
 
-	// Append commit hash of current build to version.
-	version = fmt.Sprintf("%s commit=%s", version, Commit)
-
 	return version
 }
 
+// CheckCompatible checks whether the semantic version provided is compatible
+// with our version. Versions are compatible if they have the same major
+// version, and while the major version is zero, the same minor version.
+func CheckCompatible(version string) error {
+	major, minor, err := parseVersion(version)
+	if err != nil {
+		return err
+	}
+
+	if major != appMajor || (major == 0 && minor != appMinor) {
+		return fmt.Errorf("version %v is not compatible with %v",
+			version, SemanticVersion())
+	}
+
+	return nil
+}
+
+// parseVersion returns the major and minor versions of a semantic version
+// string, ignoring its patch, pre-release and build metadata.
+func parseVersion(version string) (uint, uint, error) {
+	var major, minor, patch uint
+	_, err := fmt.Sscanf(version, "%d.%d.%d", &major, &minor, &patch)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid version: %v", version)
+	}
+
+	return major, minor, nil
+}
+
 // normalizeVerString returns the passed string stripped of all characters which
 // are not valid according to the semantic versioning guidelines for pre-release
 // version and build metadata strings.  In particular they MUST only contain
@@ -0,0 +1,55 @@
+package faraday
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestCheckCompatible tests checking whether versions are compatible with
+// our version.
+func TestCheckCompatible(t *testing.T) {
+	tests := []struct {
+		version    string
+		compatible bool
+	}{
+		{
+			version:    SemanticVersion(),
+			compatible: true,
+		},
+		{
+			version: fmt.Sprintf("%d.%d.%d", appMajor, appMinor,
+				appPatch+1),
+			compatible: true,
+		},
+		{
+			version: fmt.Sprintf("%d.%d.0-beta", appMajor,
+				appMinor),
+			compatible: true,
+		},
+		{
+			version: fmt.Sprintf("%d.%d.0", appMajor,
+				appMinor+1),
+			compatible: false,
+		},
+		{
+			version:    fmt.Sprintf("%d.0.0", appMajor+1),
+			compatible: false,
+		},
+		{
+			version:    "unknown",
+			compatible: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.version, func(t *testing.T) {
+			err := CheckCompatible(test.version)
+			if test.compatible != (err == nil) {
+				t.Fatalf("expected compatible: %v, got: %v",
+					test.compatible, err)
+			}
+		})
+	}
+}